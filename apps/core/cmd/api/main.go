@@ -39,22 +39,89 @@ func main() {
 	wsHub := services.NewWebSocketHub()
 
 	// Initialize services
-	userService := services.NewUserService(store)
 	githubService := services.NewGitHubService()
-	accountService := services.NewAccountService(store, githubService)
+	oauthService := services.NewGitHubOAuthService(store, cfg.GitHub.ClientID, cfg.GitHub.ClientSecret, cfg.Server.PublicURL)
+	oauthService.Start()
+	defer oauthService.Shutdown()
+	gitProviders := services.NewGitProviders(githubService)
+	accountService := services.NewAccountService(store, gitProviders, oauthService)
 	analyzerService := services.NewRepositoryAnalyzerService(githubService)
-	authService := services.NewAuthService(userService, store, cfg.Auth.JWTSecret)
+
+	appPrivateKey, err := cfg.GitHub.AppPrivateKeyPEM()
+	if err != nil {
+		log.Fatal("❌ Failed to read GitHub App private key:", err)
+	}
+	githubAppService, err := services.NewGitHubAppService(githubService, cfg.GitHub.AppID, cfg.GitHub.AppSlug, appPrivateKey)
+	if err != nil {
+		log.Fatal("❌ Failed to initialize GitHub App:", err)
+	}
+	if githubAppService.IsConfigured() {
+		log.Println("✅ GitHub App authentication enabled")
+	}
+	if cfg.TLS.Enabled {
+		log.Printf("✅ HTTPS enabled for deployed apps (resolver: %s)", cfg.TLS.CertResolver)
+	}
+	installationService := services.NewInstallationService(store, githubAppService)
 
 	// Initialize build service (needs store and account store)
-	buildService := services.NewBuildService(store, store, wsHub)
+	tlsOptions := services.TLSOptions{
+		Enabled:          cfg.TLS.Enabled,
+		CertResolver:     cfg.TLS.CertResolver,
+		DynamicConfigDir: cfg.TLS.DynamicConfigDir,
+	}
+	buildService := services.NewBuildService(store, store, githubService, githubAppService, gitProviders, tlsOptions, wsHub)
 	defer buildService.Shutdown()
 
+	// Deleted projects are torn down in the background, so users are deleted through it too
+	teardownService := services.NewTeardownService(store, gitProviders, githubAppService, tlsOptions)
+	teardownService.Start()
+	defer teardownService.Shutdown()
+	userService := services.NewUserService(store, teardownService)
+	authService := services.NewAuthService(userService, store, cfg.Auth.JWTSecret)
+
+	// Domains are verified against the system resolver
+	domainService := services.NewDomainService(store, buildService, nil, cfg.Server.BaseDomain)
+
 	// Initialize project service with build service
-	projectService := services.NewProjectService(store, buildService)
+	projectService := services.NewProjectService(store, buildService, githubAppService, gitProviders, domainService, teardownService, cfg.Server.PublicURL)
+	previewService := services.NewPreviewService(store, githubService, githubAppService, buildService, cfg.Server.PublicURL, cfg.GitHub.WebhookSecret)
+	logService := services.NewLogService(store)
+
+	dockerClient, err := services.NewDockerClient(cfg.Docker.Host)
+	if err != nil {
+		log.Fatal("❌ Failed to initialize Docker client:", err)
+	}
+	metricsService := services.NewMetricsService(store, dockerClient, services.MetricsOptions{
+		Enabled:         cfg.Metrics.Enabled,
+		MinuteRetention: time.Duration(cfg.Metrics.MinuteRetentionHours) * time.Hour,
+		HourRetention:   time.Duration(cfg.Metrics.RetentionDays) * 24 * time.Hour,
+	})
+	metricsService.Start()
+	defer metricsService.Shutdown()
+	reconcilerService := services.NewReconcilerService(store, dockerClient, wsHub)
+	reconcilerService.Start()
+	defer reconcilerService.Shutdown()
+	gcService := services.NewGCService(store, dockerClient, services.GCOptions{
+		Enabled:         cfg.GC.Enabled,
+		Interval:        time.Duration(cfg.GC.IntervalHours) * time.Hour,
+		KeepImages:      cfg.GC.KeepImages,
+		BuildCacheLimit: int64(cfg.GC.BuildCacheLimitGB) * 1_000_000_000,
+	})
+	gcService.Start()
+	defer gcService.Shutdown()
+	cronService := services.NewCronService(store)
+	cronService.Start()
+	defer cronService.Shutdown()
+	addonService := services.NewAddonService(store, buildService)
+	addonService.Start()
+	defer addonService.Shutdown()
+	backupService := services.NewBackupService(store)
+	backupService.Start()
+	defer backupService.Shutdown()
 
 	log.Println("✅ Services initialized")
 
-	app := GetApp(store, userService, accountService, projectService, authService, analyzerService, wsHub, cfg)
+	app := GetApp(store, userService, accountService, installationService, projectService, domainService, previewService, logService, metricsService, reconcilerService, teardownService, gcService, cronService, addonService, backupService, authService, analyzerService, wsHub, cfg)
 
 	go func() {
 		port := ":" + cfg.Server.Port
@@ -80,8 +147,16 @@ func main() {
 	case sig := <-shutdown:
 		log.Printf("🔄 Shutting down server due to signal: %v", sig)
 
-		// Shutdown build service first
+		// Shutdown background workers first
 		buildService.Shutdown()
+		oauthService.Shutdown()
+		metricsService.Shutdown()
+		reconcilerService.Shutdown()
+		teardownService.Shutdown()
+		gcService.Shutdown()
+		cronService.Shutdown()
+		addonService.Shutdown()
+		backupService.Shutdown()
 
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
@@ -96,7 +171,7 @@ func main() {
 	}
 }
 
-func GetApp(store store.Store, userService *services.UserService, accountService *services.AccountService, projectService *services.ProjectService, authService *services.AuthService, analyzerService *services.RepositoryAnalyzerService, wsHub *services.WebSocketHub, cfg *config.Config) *fiber.App {
+func GetApp(store store.Store, userService *services.UserService, accountService *services.AccountService, installationService *services.InstallationService, projectService *services.ProjectService, domainService *services.DomainService, previewService *services.PreviewService, logService *services.LogService, metricsService *services.MetricsService, reconcilerService *services.ReconcilerService, teardownService *services.TeardownService, gcService *services.GCService, cronService *services.CronService, addonService *services.AddonService, backupService *services.BackupService, authService *services.AuthService, analyzerService *services.RepositoryAnalyzerService, wsHub *services.WebSocketHub, cfg *config.Config) *fiber.App {
 	app := fiber.New(fiber.Config{
 		AppName:      "Kova",
 		ServerHeader: "Kova",
@@ -189,27 +264,120 @@ func GetApp(store store.Store, userService *services.UserService, accountService
 					"GET /users - List users (requires auth)",
 					"GET /users/:id - Get user (requires auth)",
 					"PUT /users/:id - Update user (requires auth)",
-					"DELETE /users/:id - Delete user (requires auth)",
+					"DELETE /users/:id?remove_volumes=true - Delete user and queue the teardown of their projects (requires auth)",
 					"POST /users/:id/change-password - Change password (requires auth)",
 					"GET /users/search?q=query - Search users (requires auth)",
 				},
 				"accounts": {
 					"GET /users/:id/accounts - Get user's GitHub accounts (requires auth)",
 					"POST /users/:id/accounts - Link new GitHub account (requires auth)",
-					"GET /users/:id/accounts/:accountId/repositories - Get repositories (requires auth)",
+					"GET /users/:id/accounts/:accountId/repositories - Get repositories, all=true for every page (requires auth)",
+					"GET /users/:id/accounts/:accountId/repositories/:owner/:repo/branches - Get repository branches (requires auth)",
+					"GET /users/:id/accounts/:accountId/repositories/:owner/:repo/tags - Get repository tags (requires auth)",
+					"GET /users/:id/accounts/oauth/github - Start GitHub OAuth web flow (requires auth)",
+					"POST /users/:id/accounts/device - Start GitHub device flow (requires auth)",
+					"POST /users/:id/accounts/device/poll - Poll GitHub device flow (requires auth)",
+					"GET /oauth/github/callback - GitHub OAuth callback (public, state verified)",
+				},
+				"installations": {
+					"GET /users/:id/installations - Get linked GitHub App installations (requires auth)",
+					"POST /users/:id/installations - Link GitHub App installation with the state from its install URL (requires auth)",
+					"GET /users/:id/installations/new - Get GitHub App install URL (requires auth)",
+					"GET /users/:id/installations/repositories - Get repositories across installations (requires auth)",
+					"GET /users/:id/installations/:installationId/repositories - Get installation repositories (requires auth)",
+					"DELETE /users/:id/installations/:installationId - Unlink installation (requires auth)",
 				},
 				"projects": {
 					"GET /users/:id/projects - Get user's projects (requires auth)",
 					"POST /users/:id/projects - Create new project (requires auth)",
 					"GET /users/:id/projects/:projectId - Get project (requires auth)",
 					"PUT /users/:id/projects/:projectId - Update project (requires auth)",
-					"DELETE /users/:id/projects/:projectId - Delete project (requires auth)",
+					"DELETE /users/:id/projects/:projectId?remove_volumes=true - Delete project and queue its teardown (requires auth)",
 					"PUT /users/:id/projects/:projectId/archive - Archive project (requires auth)",
 					"PUT /users/:id/projects/:projectId/activate - Activate project (requires auth)",
+					"POST /users/:id/projects/:projectId/deploy - Queue a deployment, optionally of another image tag (requires auth)",
+					"GET /users/:id/projects/:projectId/deploy-key - Get SSH deploy key (requires auth)",
+					"POST /users/:id/projects/:projectId/deploy-key - Regenerate SSH deploy key (requires auth)",
+					"PUT /users/:id/projects/:projectId/tls - Update HTTPS settings and custom certificate (requires auth)",
+					"DELETE /users/:id/projects/:projectId/tls/certificate - Remove custom certificate (requires auth)",
+					"PUT /users/:id/projects/:projectId/routing - Update basic auth, IP allowlist, headers, rate limit and redirects (requires auth)",
+					"PUT /users/:id/projects/:projectId/processes - Set process types, or fall back to the repository's Procfile (requires auth)",
+					"PUT /users/:id/projects/:projectId/volumes - Set the named volumes mounted into every process (requires auth)",
+					"PUT /users/:id/projects/:projectId/static-site - Serve the build output from the shared static file server (requires auth)",
+					"PUT /users/:id/projects/:projectId/image - Update the image and registry credentials of an image project (requires auth)",
+					"POST /users/:id/projects/:projectId/deploy-hook - Regenerate the CI deploy hook token (requires auth)",
+					"DELETE /users/:id/projects/:projectId/deploy-hook - Disable the CI deploy hook (requires auth)",
+					"POST /users/:id/projects/:projectId/stop - Scale the app down to zero replicas (requires auth)",
+					"POST /users/:id/projects/:projectId/start - Start a stopped app (requires auth)",
+					"POST /users/:id/projects/:projectId/restart - Restart the app's containers (requires auth)",
+					"GET /users/:id/projects/:projectId/timeline?limit=&offset= - Get the deployment timeline (requires auth)",
 					"GET /users/:id/projects/search?q=query - Search projects (requires auth)",
 					"GET /users/:id/projects/active - Get active projects (requires auth)",
 					"WS /users/:id/projects/:projectId/ws - WebSocket deployment updates (requires auth)",
 				},
+				"domains": {
+					"GET /users/:id/projects/:projectId/domains - List project domains (requires auth)",
+					"POST /users/:id/projects/:projectId/domains - Add a domain pending DNS verification (requires auth)",
+					"POST /users/:id/projects/:projectId/domains/:domainId/verify - Verify domain ownership (requires auth)",
+					"DELETE /users/:id/projects/:projectId/domains/:domainId - Remove a domain (requires auth)",
+				},
+				"logs": {
+					"GET /users/:id/projects/:projectId/logs?follow=&since=&tail=&replica=&process= - Stream runtime logs as server-sent events (requires auth)",
+				},
+				"metrics": {
+					"GET /users/:id/projects/:projectId/metrics?range=1h|6h|24h|7d|30d - CPU, memory, network and restart history (requires auth)",
+				},
+				"teardowns": {
+					"GET /users/:id/teardowns - Latest teardowns of deleted projects (requires auth)",
+					"GET /users/:id/teardowns/:teardownId - Teardown progress by step (requires auth)",
+				},
+				"runtime": {
+					"GET /users/:id/projects/:projectId/runtime - Runtime status and replica counts (requires auth)",
+					"GET /system/orphaned-stacks - Stacks left running without a project (requires admin)",
+				},
+				"gc": {
+					"GET /system/gc - Garbage collection status and last report (requires admin)",
+					"POST /system/gc?dry_run=true - Remove old images and BuildKit cache, or report what would be removed (requires admin)",
+				},
+				"cron_jobs": {
+					"GET /users/:id/projects/:projectId/cron-jobs - List cron jobs and when they next run (requires auth)",
+					"POST /users/:id/projects/:projectId/cron-jobs - Add a cron job (requires auth)",
+					"PUT /users/:id/projects/:projectId/cron-jobs/:jobId - Update a cron job (requires auth)",
+					"DELETE /users/:id/projects/:projectId/cron-jobs/:jobId - Delete a cron job and its history (requires auth)",
+					"POST /users/:id/projects/:projectId/cron-jobs/:jobId/run - Run a cron job now (requires auth)",
+					"GET /users/:id/projects/:projectId/cron-jobs/:jobId/runs?limit=&offset= - Run history with exit codes (requires auth)",
+					"GET /users/:id/projects/:projectId/cron-jobs/:jobId/runs/:runId - Run with its logs (requires auth)",
+				},
+				"addons": {
+					"GET /users/:id/addons - List managed databases (requires auth)",
+					"POST /users/:id/addons - Provision a Postgres, Redis or MySQL add-on (requires auth)",
+					"GET /users/:id/addons/:addonId - Add-on with the projects it is attached to (requires auth)",
+					"DELETE /users/:id/addons/:addonId - Delete a detached add-on and its data (requires auth)",
+					"GET /users/:id/addons/:addonId/connection - Connection URL with credentials (requires auth)",
+					"POST /users/:id/addons/:addonId/rotate-password - Rotate the password and redeploy attached projects (requires auth)",
+					"POST /users/:id/addons/:addonId/attachments - Inject the add-on URL into a project's environment (requires auth)",
+					"DELETE /users/:id/addons/:addonId/attachments/:projectId - Detach the add-on from a project (requires auth)",
+				},
+				"backups": {
+					"GET /users/:id/backup-policies - List backup policies of add-ons and volumes (requires auth)",
+					"POST /users/:id/backup-policies - Add a backup policy with a schedule, retention and local or S3 destination (requires auth)",
+					"GET /users/:id/backup-policies/:policyId - Backup policy and when it next runs (requires auth)",
+					"PUT /users/:id/backup-policies/:policyId - Update a backup policy (requires auth)",
+					"DELETE /users/:id/backup-policies/:policyId - Delete a backup policy and its history (requires auth)",
+					"POST /users/:id/backup-policies/:policyId/run - Back up now (requires auth)",
+					"GET /users/:id/backup-policies/:policyId/backups?limit=&offset= - Backup and restore history (requires auth)",
+					"POST /users/:id/backup-policies/:policyId/backups/:backupId/restore - Restore a backup (requires auth)",
+				},
+				"previews": {
+					"GET /users/:id/projects/:projectId/previews - List pull request previews (requires auth)",
+					"PUT /users/:id/projects/:projectId/previews - Configure pull request previews (requires auth)",
+				},
+				"webhooks": {
+					"POST /webhooks/github - GitHub webhook receiver (signature verified)",
+				},
+				"hooks": {
+					"POST /hooks/projects/:projectId/deploy?tag=X - Queue a deployment from CI (deploy hook token verified)",
+				},
 				"deployments": {
 					"Coming soon...",
 				},
@@ -222,6 +390,19 @@ func GetApp(store store.Store, userService *services.UserService, accountService
 	projectHandler := api.NewProjectHandler(projectService)
 	repositoryHandler := api.NewRepositoryHandler(accountService)
 	analyzerHandler := api.NewAnalyzerHandler(analyzerService, accountService)
+	installationHandler := api.NewInstallationHandler(installationService)
+	domainHandler := api.NewDomainHandler(domainService)
+	previewHandler := api.NewPreviewHandler(previewService)
+	logHandler := api.NewLogHandler(logService)
+	metricsHandler := api.NewMetricsHandler(metricsService)
+	runtimeHandler := api.NewRuntimeHandler(reconcilerService)
+	teardownHandler := api.NewTeardownHandler(teardownService)
+	gcHandler := api.NewGCHandler(gcService)
+	cronHandler := api.NewCronHandler(cronService)
+	addonHandler := api.NewAddonHandler(addonService)
+	backupHandler := api.NewBackupHandler(backupService)
+	webhookHandler := api.NewWebhookHandler(previewService, installationService)
+	oauthHandler := api.NewOAuthHandler(accountService)
 	authHandler := api.NewAuthHandler(authService, userService)
 	authHandler.RegisterRoutes(apiV1.Group("/auth"))
 	if cfg.Server.Env == config.DEVELOPMENT {
@@ -235,12 +416,29 @@ func GetApp(store store.Store, userService *services.UserService, accountService
 		log.Printf("📡 WebSocket request received: User=%s, Project=%s", c.Params("id"), c.Params("projectId"))
 		return wsHub.HandleWebSocket(c)
 	})
+	webhookHandler.RegisterRoutes(apiV1.Group("/webhooks"))
+	oauthHandler.RegisterPublicRoutes(apiV1.Group("/oauth"))
+	projectHandler.RegisterHookRoutes(apiV1.Group("/hooks"))
 	authenticatedGroup := apiV1.Group("/users", authHandler.RequireAuthMiddleware())
 	userHandler.RegisterRoutes(authenticatedGroup)
 	accountHandler.RegisterRoutes(authenticatedGroup)
+	oauthHandler.RegisterRoutes(authenticatedGroup)
+	installationHandler.RegisterRoutes(authenticatedGroup)
 	repositoryHandler.RegisterRoutes(authenticatedGroup)
 	analyzerHandler.RegisterRoutes(authenticatedGroup)
 	projectHandler.RegisterRoutes(authenticatedGroup)
+	domainHandler.RegisterRoutes(authenticatedGroup)
+	previewHandler.RegisterRoutes(authenticatedGroup)
+	logHandler.RegisterRoutes(authenticatedGroup)
+	metricsHandler.RegisterRoutes(authenticatedGroup)
+	runtimeHandler.RegisterRoutes(authenticatedGroup)
+	teardownHandler.RegisterRoutes(authenticatedGroup)
+	cronHandler.RegisterRoutes(authenticatedGroup)
+	addonHandler.RegisterRoutes(authenticatedGroup)
+	backupHandler.RegisterRoutes(authenticatedGroup)
+	systemGroup := apiV1.Group("/system", authHandler.RequireAuthMiddleware(), authHandler.RequireAdminMiddleware(cfg.Auth.AdminUserIDs))
+	runtimeHandler.RegisterSystemRoutes(systemGroup)
+	gcHandler.RegisterSystemRoutes(systemGroup)
 
 	log.Println("✅ Routes registered")
 
@@ -0,0 +1,70 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dopeCape/kova/internal/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	apiURL   string
+	login    string
+	password string
+
+	AccountCmd = &cobra.Command{
+		Use:   "account",
+		Short: "Manage GitHub accounts linked to kova",
+	}
+
+	LinkCmd = &cobra.Command{
+		Use:   "link",
+		Short: "Link a GitHub account using the GitHub device flow",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			c := client.New(apiURL)
+			if err := c.Login(ctx, login, password); err != nil {
+				return err
+			}
+
+			deviceCode, err := c.StartDeviceFlow(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to start device flow: %w", err)
+			}
+
+			fmt.Printf("Open %s and enter the code: %s\n", deviceCode.VerificationURI, deviceCode.UserCode)
+			fmt.Println("Waiting for authorization...")
+
+			ctx, cancel := context.WithTimeout(ctx, time.Duration(deviceCode.ExpiresIn)*time.Second)
+			defer cancel()
+
+			account, err := c.WaitForDeviceFlow(ctx, deviceCode)
+			if err != nil {
+				return fmt.Errorf("failed to link GitHub account: %w", err)
+			}
+
+			fmt.Printf("Linked GitHub account %s\n", account.GithubUsername)
+			return nil
+		},
+	}
+)
+
+func Execute() error {
+	return AccountCmd.Execute()
+}
+
+func init() {
+	LinkCmd.Flags().StringVar(&apiURL, "api-url", client.DEFAULT_API_URL, "Base URL of the kova API")
+	LinkCmd.Flags().StringVarP(&login, "login", "l", "", "Username or email of the kova user (required)")
+	LinkCmd.Flags().StringVarP(&password, "password", "p", "", "Password of the kova user (required)")
+	LinkCmd.MarkFlagRequired("login")
+	LinkCmd.MarkFlagRequired("password")
+
+	AccountCmd.AddCommand(LinkCmd)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/dopeCape/kova/cmd/cli/account"
 	install "github.com/dopeCape/kova/cmd/cli/install"
 	"github.com/dopeCape/kova/cmd/cli/interactive"
 	"github.com/spf13/cobra"
@@ -8,6 +9,6 @@ import (
 
 func main() {
 	var rootCmd = &cobra.Command{Use: "kova-cli [command] [flags]"}
-	rootCmd.AddCommand(install.InstallCmd, interactive.InteractiveCmd)
+	rootCmd.AddCommand(install.InstallCmd, interactive.InteractiveCmd, account.AccountCmd)
 	rootCmd.Execute()
 }
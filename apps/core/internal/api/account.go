@@ -19,7 +19,9 @@ func NewAccountHandler(accountService *services.AccountService) *AccountHandler
 }
 
 type CreateAccountRequest struct {
-	AccessToken string `json:"access_token" validate:"required,min=40"`
+	AccessToken string `json:"access_token" validate:"required,min=20"`
+	Provider    string `json:"provider" validate:"omitempty,oneof=github gitlab gitea"` // Defaults to github
+	ProviderURL string `json:"provider_url" validate:"omitempty,url"`                   // Required for self-hosted instances
 }
 
 type CreateAccountResponse struct {
@@ -88,7 +90,7 @@ func (h *AccountHandler) CreateAccountForUser(c fiber.Ctx) error {
 		})
 	}
 
-	account, err := h.accountService.CreateAccount(c.RequestCtx(), userID, req.AccessToken)
+	account, err := h.accountService.CreateAccount(c.RequestCtx(), userID, req.Provider, req.ProviderURL, req.AccessToken)
 	if err != nil {
 		if strings.Contains(err.Error(), "user not found") {
 			return c.Status(404).JSON(ErrorResponse{
@@ -96,6 +98,13 @@ func (h *AccountHandler) CreateAccountForUser(c fiber.Ctx) error {
 				Code:  "USER_NOT_FOUND",
 			})
 		}
+		if strings.Contains(err.Error(), "validation failed") {
+			return c.Status(400).JSON(ErrorResponse{
+				Error:   "Validation failed",
+				Code:    "VALIDATION_ERROR",
+				Details: err.Error(),
+			})
+		}
 		if strings.Contains(err.Error(), "access token is required") || strings.Contains(err.Error(), "invalid access token") {
 			return c.Status(400).JSON(ErrorResponse{
 				Error: "Invalid access token",
@@ -104,7 +113,7 @@ func (h *AccountHandler) CreateAccountForUser(c fiber.Ctx) error {
 		}
 		if strings.Contains(err.Error(), "invalid or expired") {
 			return c.Status(401).JSON(ErrorResponse{
-				Error: "Invalid or expired GitHub access token",
+				Error: "Invalid or expired access token",
 				Code:  "INVALID_GITHUB_TOKEN",
 			})
 		}
@@ -114,9 +123,9 @@ func (h *AccountHandler) CreateAccountForUser(c fiber.Ctx) error {
 				Code:  "ACCOUNT_ALREADY_LINKED",
 			})
 		}
-		if strings.Contains(err.Error(), "GitHub user details") {
+		if strings.Contains(err.Error(), "user details") {
 			return c.Status(502).JSON(ErrorResponse{
-				Error: "Failed to fetch GitHub user details",
+				Error: "Failed to fetch git provider user details",
 				Code:  "GITHUB_API_ERROR",
 			})
 		}
@@ -128,6 +137,6 @@ func (h *AccountHandler) CreateAccountForUser(c fiber.Ctx) error {
 
 	return c.Status(201).JSON(CreateAccountResponse{
 		Account: account,
-		Message: "GitHub account linked successfully",
+		Message: "Account linked successfully",
 	})
 }
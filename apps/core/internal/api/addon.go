@@ -0,0 +1,269 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/services"
+	"github.com/gofiber/fiber/v3"
+)
+
+type AddonHandler struct {
+	addonService *services.AddonService
+}
+
+func NewAddonHandler(addonService *services.AddonService) *AddonHandler {
+	return &AddonHandler{
+		addonService: addonService,
+	}
+}
+
+type AddonResponse struct {
+	Addon   *models.Addon `json:"addon"`
+	Message string        `json:"message,omitempty"`
+}
+
+type ListAddonsResponse struct {
+	Addons []*models.Addon `json:"addons"`
+	Total  int             `json:"total"`
+}
+
+type AddonConnectionResponse struct {
+	URL string `json:"url"`
+}
+
+type AddonAttachmentResponse struct {
+	Attachment *models.AddonAttachment `json:"attachment"`
+	Message    string                  `json:"message,omitempty"`
+}
+
+// RegisterRoutes registers all add-on routes
+func (h *AddonHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/:id/addons", h.GetAddons)                                      // GET /api/v1/users/:id/addons
+	router.Post("/:id/addons", h.CreateAddon)                                   // POST /api/v1/users/:id/addons
+	router.Get("/:id/addons/:addonId", h.GetAddon)                              // GET /api/v1/users/:id/addons/:addonId
+	router.Delete("/:id/addons/:addonId", h.DeleteAddon)                        // DELETE /api/v1/users/:id/addons/:addonId
+	router.Get("/:id/addons/:addonId/connection", h.GetConnection)              // GET /api/v1/users/:id/addons/:addonId/connection
+	router.Post("/:id/addons/:addonId/rotate-password", h.RotatePassword)       // POST /api/v1/users/:id/addons/:addonId/rotate-password
+	router.Post("/:id/addons/:addonId/attachments", h.AttachAddon)              // POST /api/v1/users/:id/addons/:addonId/attachments
+	router.Delete("/:id/addons/:addonId/attachments/:projectId", h.DetachAddon) // DELETE /api/v1/users/:id/addons/:addonId/attachments/:projectId
+}
+
+// GetAddons lists the add-ons of a user
+func (h *AddonHandler) GetAddons(c fiber.Ctx) error {
+	userID := c.Params("id")
+
+	if userID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID is required",
+			Code:  "MISSING_USER_ID",
+		})
+	}
+
+	addons, err := h.addonService.GetAddons(c.RequestCtx(), userID)
+	if err != nil {
+		return c.Status(500).JSON(ErrorResponse{
+			Error: "Failed to get add-ons",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+
+	return c.JSON(ListAddonsResponse{
+		Addons: addons,
+		Total:  len(addons),
+	})
+}
+
+// CreateAddon creates an add-on, which is provisioned in the background
+func (h *AddonHandler) CreateAddon(c fiber.Ctx) error {
+	userID := c.Params("id")
+
+	if userID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID is required",
+			Code:  "MISSING_USER_ID",
+		})
+	}
+
+	var req models.CreateAddonRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request body",
+			Code:  "INVALID_BODY",
+		})
+	}
+
+	addon, err := h.addonService.CreateAddon(c.RequestCtx(), userID, &req)
+	if err != nil {
+		return addonError(c, err, "Failed to create add-on")
+	}
+
+	return c.Status(202).JSON(AddonResponse{
+		Addon:   addon,
+		Message: "Add-on is being provisioned",
+	})
+}
+
+// GetAddon returns an add-on with the projects it is attached to
+func (h *AddonHandler) GetAddon(c fiber.Ctx) error {
+	userID := c.Params("id")
+	addonID := c.Params("addonId")
+
+	if userID == "" || addonID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Add-on ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	addon, err := h.addonService.GetAddon(c.RequestCtx(), userID, addonID)
+	if err != nil {
+		return addonError(c, err, "Failed to get add-on")
+	}
+
+	return c.JSON(AddonResponse{
+		Addon: addon,
+	})
+}
+
+// DeleteAddon deletes a detached add-on and its data in the background
+func (h *AddonHandler) DeleteAddon(c fiber.Ctx) error {
+	userID := c.Params("id")
+	addonID := c.Params("addonId")
+
+	if userID == "" || addonID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Add-on ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	addon, err := h.addonService.DeleteAddon(c.RequestCtx(), userID, addonID)
+	if err != nil {
+		return addonError(c, err, "Failed to delete add-on")
+	}
+
+	return c.Status(202).JSON(AddonResponse{
+		Addon:   addon,
+		Message: "Add-on is being deleted",
+	})
+}
+
+// GetConnection returns the URL of an add-on with its credentials
+func (h *AddonHandler) GetConnection(c fiber.Ctx) error {
+	userID := c.Params("id")
+	addonID := c.Params("addonId")
+
+	if userID == "" || addonID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Add-on ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	connectionURL, err := h.addonService.GetConnectionURL(c.RequestCtx(), userID, addonID)
+	if err != nil {
+		return addonError(c, err, "Failed to get add-on connection")
+	}
+
+	return c.JSON(AddonConnectionResponse{
+		URL: connectionURL,
+	})
+}
+
+// RotatePassword replaces the password of an add-on and redeploys its projects
+func (h *AddonHandler) RotatePassword(c fiber.Ctx) error {
+	userID := c.Params("id")
+	addonID := c.Params("addonId")
+
+	if userID == "" || addonID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Add-on ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	addon, err := h.addonService.RotatePassword(c.RequestCtx(), userID, addonID)
+	if err != nil {
+		return addonError(c, err, "Failed to rotate add-on password")
+	}
+
+	return c.JSON(AddonResponse{
+		Addon:   addon,
+		Message: "Password rotated, attached projects are being redeployed",
+	})
+}
+
+// AttachAddon injects an add-on's URL into a project's runtime environment
+func (h *AddonHandler) AttachAddon(c fiber.Ctx) error {
+	userID := c.Params("id")
+	addonID := c.Params("addonId")
+
+	if userID == "" || addonID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Add-on ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	var req models.AttachAddonRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request body",
+			Code:  "INVALID_BODY",
+		})
+	}
+
+	attachment, err := h.addonService.AttachAddon(c.RequestCtx(), userID, addonID, &req)
+	if err != nil {
+		return addonError(c, err, "Failed to attach add-on")
+	}
+
+	return c.Status(201).JSON(AddonAttachmentResponse{
+		Attachment: attachment,
+		Message:    "Add-on attached successfully",
+	})
+}
+
+// DetachAddon removes an add-on from a project
+func (h *AddonHandler) DetachAddon(c fiber.Ctx) error {
+	userID := c.Params("id")
+	addonID := c.Params("addonId")
+	projectID := c.Params("projectId")
+
+	if userID == "" || addonID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID, Add-on ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	if err := h.addonService.DetachAddon(c.RequestCtx(), userID, addonID, projectID); err != nil {
+		return addonError(c, err, "Failed to detach add-on")
+	}
+
+	return c.SendStatus(204)
+}
+
+// addonError maps the errors of add-on lookups and changes to responses
+func addonError(c fiber.Ctx, err error, message string) error {
+	if strings.Contains(err.Error(), "add-on attachment not found") {
+		return c.Status(404).JSON(ErrorResponse{
+			Error: "Add-on is not attached to this project",
+			Code:  "ADDON_ATTACHMENT_NOT_FOUND",
+		})
+	}
+	if strings.Contains(err.Error(), "add-on not found") {
+		return c.Status(404).JSON(ErrorResponse{
+			Error: "Add-on not found",
+			Code:  "ADDON_NOT_FOUND",
+		})
+	}
+	if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "already attached") {
+		return c.Status(409).JSON(ErrorResponse{
+			Error: err.Error(),
+			Code:  "ADDON_CONFLICT",
+		})
+	}
+	return projectLookupError(c, err, message)
+}
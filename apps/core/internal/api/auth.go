@@ -1,6 +1,7 @@
 package api
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/dopeCape/kova/internal/models"
@@ -220,6 +221,21 @@ func (h *AuthHandler) RequireAuthMiddleware() fiber.Handler {
 	return h.AuthMiddleware()
 }
 
+// RequireAdminMiddleware only lets the configured admin users through. It must run
+// after the auth middleware, which sets the user ID.
+func (h *AuthHandler) RequireAdminMiddleware(adminUserIDs []string) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		userID, _ := c.Locals("user_id").(string)
+		if userID == "" || !slices.Contains(adminUserIDs, userID) {
+			return c.Status(403).JSON(ErrorResponse{
+				Error: "Admin access required",
+				Code:  "ADMIN_REQUIRED",
+			})
+		}
+		return c.Next()
+	}
+}
+
 // GetUserFromContext extracts user information from Fiber context
 func GetUserFromContext(c fiber.Ctx) (string, string, string, bool) {
 	userID, _ := c.Locals("user_id").(string)
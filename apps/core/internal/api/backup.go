@@ -0,0 +1,298 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/services"
+	"github.com/gofiber/fiber/v3"
+)
+
+type BackupHandler struct {
+	backupService *services.BackupService
+}
+
+func NewBackupHandler(backupService *services.BackupService) *BackupHandler {
+	return &BackupHandler{
+		backupService: backupService,
+	}
+}
+
+type BackupPolicyResponse struct {
+	Policy  *models.BackupPolicy `json:"policy"`
+	Message string               `json:"message,omitempty"`
+}
+
+type ListBackupPoliciesResponse struct {
+	Policies []*models.BackupPolicy `json:"policies"`
+	Total    int                    `json:"total"`
+}
+
+type BackupResponse struct {
+	Backup  *models.Backup `json:"backup"`
+	Message string         `json:"message,omitempty"`
+}
+
+type ListBackupsResponse struct {
+	Backups []*models.Backup `json:"backups"`
+	Total   int64            `json:"total"`
+	Limit   int              `json:"limit"`
+	Offset  int              `json:"offset"`
+	HasMore bool             `json:"has_more"`
+}
+
+// RegisterRoutes registers all backup routes
+func (h *BackupHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/:id/backup-policies", h.GetBackupPolicies)                                  // GET /api/v1/users/:id/backup-policies
+	router.Post("/:id/backup-policies", h.CreateBackupPolicy)                                // POST /api/v1/users/:id/backup-policies
+	router.Get("/:id/backup-policies/:policyId", h.GetBackupPolicy)                          // GET /api/v1/users/:id/backup-policies/:policyId
+	router.Put("/:id/backup-policies/:policyId", h.UpdateBackupPolicy)                       // PUT /api/v1/users/:id/backup-policies/:policyId
+	router.Delete("/:id/backup-policies/:policyId", h.DeleteBackupPolicy)                    // DELETE /api/v1/users/:id/backup-policies/:policyId
+	router.Post("/:id/backup-policies/:policyId/run", h.TriggerBackup)                       // POST /api/v1/users/:id/backup-policies/:policyId/run
+	router.Get("/:id/backup-policies/:policyId/backups", h.GetBackups)                       // GET /api/v1/users/:id/backup-policies/:policyId/backups
+	router.Post("/:id/backup-policies/:policyId/backups/:backupId/restore", h.RestoreBackup) // POST /api/v1/users/:id/backup-policies/:policyId/backups/:backupId/restore
+}
+
+// GetBackupPolicies lists the backup policies of a user
+func (h *BackupHandler) GetBackupPolicies(c fiber.Ctx) error {
+	userID := c.Params("id")
+
+	if userID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID is required",
+			Code:  "MISSING_USER_ID",
+		})
+	}
+
+	policies, err := h.backupService.GetBackupPolicies(c.RequestCtx(), userID)
+	if err != nil {
+		return c.Status(500).JSON(ErrorResponse{
+			Error: "Failed to get backup policies",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+
+	return c.JSON(ListBackupPoliciesResponse{
+		Policies: policies,
+		Total:    len(policies),
+	})
+}
+
+// CreateBackupPolicy adds a backup policy for an add-on or a project volume
+func (h *BackupHandler) CreateBackupPolicy(c fiber.Ctx) error {
+	userID := c.Params("id")
+
+	if userID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID is required",
+			Code:  "MISSING_USER_ID",
+		})
+	}
+
+	var req models.CreateBackupPolicyRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request body",
+			Code:  "INVALID_BODY",
+		})
+	}
+
+	policy, err := h.backupService.CreateBackupPolicy(c.RequestCtx(), userID, &req)
+	if err != nil {
+		return backupError(c, err, "Failed to create backup policy")
+	}
+
+	return c.Status(201).JSON(BackupPolicyResponse{
+		Policy:  policy,
+		Message: "Backup policy created successfully",
+	})
+}
+
+// GetBackupPolicy returns a backup policy
+func (h *BackupHandler) GetBackupPolicy(c fiber.Ctx) error {
+	userID := c.Params("id")
+	policyID := c.Params("policyId")
+
+	if userID == "" || policyID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Backup Policy ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	policy, err := h.backupService.GetBackupPolicy(c.RequestCtx(), userID, policyID)
+	if err != nil {
+		return backupError(c, err, "Failed to get backup policy")
+	}
+
+	return c.JSON(BackupPolicyResponse{
+		Policy: policy,
+	})
+}
+
+// UpdateBackupPolicy changes the schedule, retention, destination or name of a policy
+func (h *BackupHandler) UpdateBackupPolicy(c fiber.Ctx) error {
+	userID := c.Params("id")
+	policyID := c.Params("policyId")
+
+	if userID == "" || policyID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Backup Policy ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	var req models.UpdateBackupPolicyRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request body",
+			Code:  "INVALID_BODY",
+		})
+	}
+
+	policy, err := h.backupService.UpdateBackupPolicy(c.RequestCtx(), userID, policyID, &req)
+	if err != nil {
+		return backupError(c, err, "Failed to update backup policy")
+	}
+
+	return c.JSON(BackupPolicyResponse{
+		Policy:  policy,
+		Message: "Backup policy updated successfully",
+	})
+}
+
+// DeleteBackupPolicy deletes a backup policy and its history, keeping the backups
+func (h *BackupHandler) DeleteBackupPolicy(c fiber.Ctx) error {
+	userID := c.Params("id")
+	policyID := c.Params("policyId")
+
+	if userID == "" || policyID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Backup Policy ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	if err := h.backupService.DeleteBackupPolicy(c.RequestCtx(), userID, policyID); err != nil {
+		return backupError(c, err, "Failed to delete backup policy")
+	}
+
+	return c.SendStatus(204)
+}
+
+// TriggerBackup starts a backup now
+func (h *BackupHandler) TriggerBackup(c fiber.Ctx) error {
+	userID := c.Params("id")
+	policyID := c.Params("policyId")
+
+	if userID == "" || policyID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Backup Policy ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	backup, err := h.backupService.TriggerBackup(c.RequestCtx(), userID, policyID)
+	if err != nil {
+		return backupError(c, err, "Failed to start backup")
+	}
+
+	return c.Status(202).JSON(BackupResponse{
+		Backup:  backup,
+		Message: "Backup started",
+	})
+}
+
+// GetBackups returns a page of a policy's backups and restores, newest first
+func (h *BackupHandler) GetBackups(c fiber.Ctx) error {
+	userID := c.Params("id")
+	policyID := c.Params("policyId")
+
+	if userID == "" || policyID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Backup Policy ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	limit := 20 // default
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	offset := 0 // default
+	if o := c.Query("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+
+	backups, total, err := h.backupService.GetBackups(c.RequestCtx(), userID, policyID, limit, offset)
+	if err != nil {
+		return backupError(c, err, "Failed to get backups")
+	}
+
+	return c.JSON(ListBackupsResponse{
+		Backups: backups,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+		HasMore: int64(offset+limit) < total,
+	})
+}
+
+// RestoreBackup replaces the data of the policy's target with a backup
+func (h *BackupHandler) RestoreBackup(c fiber.Ctx) error {
+	userID := c.Params("id")
+	policyID := c.Params("policyId")
+	backupID := c.Params("backupId")
+
+	if userID == "" || policyID == "" || backupID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID, Backup Policy ID and Backup ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	restore, err := h.backupService.RestoreBackup(c.RequestCtx(), userID, policyID, backupID)
+	if err != nil {
+		return backupError(c, err, "Failed to start restore")
+	}
+
+	return c.Status(202).JSON(BackupResponse{
+		Backup:  restore,
+		Message: "Restore started",
+	})
+}
+
+// backupError maps the errors of backup lookups and changes to responses
+func backupError(c fiber.Ctx, err error, message string) error {
+	if strings.Contains(err.Error(), "backup policy not found") {
+		return c.Status(404).JSON(ErrorResponse{
+			Error: "Backup policy not found",
+			Code:  "BACKUP_POLICY_NOT_FOUND",
+		})
+	}
+	if strings.Contains(err.Error(), "backup not found") {
+		return c.Status(404).JSON(ErrorResponse{
+			Error: "Backup not found",
+			Code:  "BACKUP_NOT_FOUND",
+		})
+	}
+	if strings.Contains(err.Error(), "add-on not found") {
+		return c.Status(404).JSON(ErrorResponse{
+			Error: "Add-on not found",
+			Code:  "ADDON_NOT_FOUND",
+		})
+	}
+	if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "already in progress") {
+		return c.Status(409).JSON(ErrorResponse{
+			Error: err.Error(),
+			Code:  "BACKUP_CONFLICT",
+		})
+	}
+	return projectLookupError(c, err, message)
+}
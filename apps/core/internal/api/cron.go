@@ -0,0 +1,283 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/services"
+	"github.com/gofiber/fiber/v3"
+)
+
+type CronHandler struct {
+	cronService *services.CronService
+}
+
+func NewCronHandler(cronService *services.CronService) *CronHandler {
+	return &CronHandler{
+		cronService: cronService,
+	}
+}
+
+type CronJobResponse struct {
+	CronJob *models.CronJob `json:"cron_job"`
+	Message string          `json:"message,omitempty"`
+}
+
+type ListCronJobsResponse struct {
+	CronJobs []*models.CronJob `json:"cron_jobs"`
+	Total    int               `json:"total"`
+}
+
+type CronJobRunResponse struct {
+	Run     *models.CronJobRun `json:"run"`
+	Message string             `json:"message,omitempty"`
+}
+
+type ListCronJobRunsResponse struct {
+	Runs    []*models.CronJobRun `json:"runs"`
+	Total   int64                `json:"total"`
+	Limit   int                  `json:"limit"`
+	Offset  int                  `json:"offset"`
+	HasMore bool                 `json:"has_more"`
+}
+
+// RegisterRoutes registers all cron job routes
+func (h *CronHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/:id/projects/:projectId/cron-jobs", h.GetCronJobs)                      // GET /api/v1/users/:id/projects/:projectId/cron-jobs
+	router.Post("/:id/projects/:projectId/cron-jobs", h.CreateCronJob)                   // POST /api/v1/users/:id/projects/:projectId/cron-jobs
+	router.Put("/:id/projects/:projectId/cron-jobs/:jobId", h.UpdateCronJob)             // PUT /api/v1/users/:id/projects/:projectId/cron-jobs/:jobId
+	router.Delete("/:id/projects/:projectId/cron-jobs/:jobId", h.DeleteCronJob)          // DELETE /api/v1/users/:id/projects/:projectId/cron-jobs/:jobId
+	router.Post("/:id/projects/:projectId/cron-jobs/:jobId/run", h.TriggerCronJob)       // POST /api/v1/users/:id/projects/:projectId/cron-jobs/:jobId/run
+	router.Get("/:id/projects/:projectId/cron-jobs/:jobId/runs", h.GetCronJobRuns)       // GET /api/v1/users/:id/projects/:projectId/cron-jobs/:jobId/runs
+	router.Get("/:id/projects/:projectId/cron-jobs/:jobId/runs/:runId", h.GetCronJobRun) // GET /api/v1/users/:id/projects/:projectId/cron-jobs/:jobId/runs/:runId
+}
+
+// GetCronJobs lists the cron jobs of a project
+func (h *CronHandler) GetCronJobs(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	jobs, err := h.cronService.GetCronJobs(c.RequestCtx(), userID, projectID)
+	if err != nil {
+		return projectLookupError(c, err, "Failed to get cron jobs")
+	}
+
+	return c.JSON(ListCronJobsResponse{
+		CronJobs: jobs,
+		Total:    len(jobs),
+	})
+}
+
+// CreateCronJob adds a cron job to a project
+func (h *CronHandler) CreateCronJob(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	var req models.CreateCronJobRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request body",
+			Code:  "INVALID_BODY",
+		})
+	}
+
+	job, err := h.cronService.CreateCronJob(c.RequestCtx(), userID, projectID, &req)
+	if err != nil {
+		return cronJobError(c, err, "Failed to create cron job")
+	}
+
+	return c.Status(201).JSON(CronJobResponse{
+		CronJob: job,
+		Message: "Cron job created successfully",
+	})
+}
+
+// UpdateCronJob changes the settings of a cron job
+func (h *CronHandler) UpdateCronJob(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+	jobID := c.Params("jobId")
+
+	if userID == "" || projectID == "" || jobID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID, Project ID and Cron Job ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	var req models.UpdateCronJobRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request body",
+			Code:  "INVALID_BODY",
+		})
+	}
+
+	job, err := h.cronService.UpdateCronJob(c.RequestCtx(), userID, projectID, jobID, &req)
+	if err != nil {
+		return cronJobError(c, err, "Failed to update cron job")
+	}
+
+	return c.JSON(CronJobResponse{
+		CronJob: job,
+		Message: "Cron job updated successfully",
+	})
+}
+
+// DeleteCronJob deletes a cron job and its run history
+func (h *CronHandler) DeleteCronJob(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+	jobID := c.Params("jobId")
+
+	if userID == "" || projectID == "" || jobID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID, Project ID and Cron Job ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	if err := h.cronService.DeleteCronJob(c.RequestCtx(), userID, projectID, jobID); err != nil {
+		return cronJobError(c, err, "Failed to delete cron job")
+	}
+
+	return c.SendStatus(204)
+}
+
+// TriggerCronJob starts a run of a cron job now
+func (h *CronHandler) TriggerCronJob(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+	jobID := c.Params("jobId")
+
+	if userID == "" || projectID == "" || jobID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID, Project ID and Cron Job ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	run, err := h.cronService.TriggerCronJob(c.RequestCtx(), userID, projectID, jobID)
+	if err != nil {
+		if strings.Contains(err.Error(), "is not deployed") {
+			return c.Status(409).JSON(ErrorResponse{
+				Error: "Project is not deployed",
+				Code:  "NOT_DEPLOYED",
+			})
+		}
+		return cronJobError(c, err, "Failed to run cron job")
+	}
+
+	message := "Cron job run started"
+	if run.Status == models.CRON_RUN_SKIPPED {
+		message = "Cron job run skipped, the previous run is still in progress"
+	}
+
+	return c.Status(202).JSON(CronJobRunResponse{
+		Run:     run,
+		Message: message,
+	})
+}
+
+// GetCronJobRuns returns a page of a cron job's runs, newest first
+func (h *CronHandler) GetCronJobRuns(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+	jobID := c.Params("jobId")
+
+	if userID == "" || projectID == "" || jobID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID, Project ID and Cron Job ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	limit := 20 // default
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	offset := 0 // default
+	if o := c.Query("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+
+	runs, total, err := h.cronService.GetCronJobRuns(c.RequestCtx(), userID, projectID, jobID, limit, offset)
+	if err != nil {
+		return cronJobError(c, err, "Failed to get cron job runs")
+	}
+
+	return c.JSON(ListCronJobRunsResponse{
+		Runs:    runs,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+		HasMore: int64(offset+limit) < total,
+	})
+}
+
+// GetCronJobRun returns a run of a cron job with its exit code and logs
+func (h *CronHandler) GetCronJobRun(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+	jobID := c.Params("jobId")
+	runID := c.Params("runId")
+
+	if userID == "" || projectID == "" || jobID == "" || runID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID, Project ID, Cron Job ID and Run ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	run, err := h.cronService.GetCronJobRun(c.RequestCtx(), userID, projectID, jobID, runID)
+	if err != nil {
+		return cronJobError(c, err, "Failed to get cron job run")
+	}
+
+	return c.JSON(CronJobRunResponse{
+		Run: run,
+	})
+}
+
+// cronJobError maps the errors of cron job lookups and changes to responses
+func cronJobError(c fiber.Ctx, err error, message string) error {
+	if strings.Contains(err.Error(), "cron job run not found") {
+		return c.Status(404).JSON(ErrorResponse{
+			Error: "Cron job run not found",
+			Code:  "CRON_JOB_RUN_NOT_FOUND",
+		})
+	}
+	if strings.Contains(err.Error(), "cron job not found") {
+		return c.Status(404).JSON(ErrorResponse{
+			Error: "Cron job not found",
+			Code:  "CRON_JOB_NOT_FOUND",
+		})
+	}
+	if strings.Contains(err.Error(), "already exists") {
+		return c.Status(409).JSON(ErrorResponse{
+			Error: err.Error(),
+			Code:  "CRON_JOB_EXISTS",
+		})
+	}
+	return projectLookupError(c, err, message)
+}
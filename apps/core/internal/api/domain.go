@@ -0,0 +1,181 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/services"
+	"github.com/gofiber/fiber/v3"
+)
+
+type DomainHandler struct {
+	domainService *services.DomainService
+}
+
+func NewDomainHandler(domainService *services.DomainService) *DomainHandler {
+	return &DomainHandler{
+		domainService: domainService,
+	}
+}
+
+type ListDomainsResponse struct {
+	Domains []*models.ProjectDomain `json:"domains"`
+	Total   int                     `json:"total"`
+}
+
+type DomainResponse struct {
+	Domain  *models.ProjectDomain `json:"domain"`
+	Message string                `json:"message"`
+}
+
+// RegisterRoutes registers all project domain routes
+func (h *DomainHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/:id/projects/:projectId/domains", h.GetDomains)                     // GET /api/v1/users/:id/projects/:projectId/domains
+	router.Post("/:id/projects/:projectId/domains", h.AddDomain)                     // POST /api/v1/users/:id/projects/:projectId/domains
+	router.Post("/:id/projects/:projectId/domains/:domainId/verify", h.VerifyDomain) // POST /api/v1/users/:id/projects/:projectId/domains/:domainId/verify
+	router.Delete("/:id/projects/:projectId/domains/:domainId", h.RemoveDomain)      // DELETE /api/v1/users/:id/projects/:projectId/domains/:domainId
+}
+
+// GetDomains lists the domains of a project
+func (h *DomainHandler) GetDomains(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	domains, err := h.domainService.GetDomains(c.RequestCtx(), userID, projectID)
+	if err != nil {
+		return domainError(c, err, "Failed to get domains")
+	}
+
+	return c.JSON(ListDomainsResponse{
+		Domains: domains,
+		Total:   len(domains),
+	})
+}
+
+// AddDomain adds a domain to a project, pending DNS verification
+func (h *DomainHandler) AddDomain(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	var req models.AddDomainRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request body",
+			Code:  "INVALID_BODY",
+		})
+	}
+
+	domain, err := h.domainService.AddDomain(c.RequestCtx(), userID, projectID, &req)
+	if err != nil {
+		return domainError(c, err, "Failed to add domain")
+	}
+
+	return c.Status(201).JSON(DomainResponse{
+		Domain:  domain,
+		Message: "Domain added. Create one of the DNS records, then verify the domain",
+	})
+}
+
+// VerifyDomain checks the DNS records proving ownership of a domain
+func (h *DomainHandler) VerifyDomain(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+	domainID := c.Params("domainId")
+
+	if userID == "" || projectID == "" || domainID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID, Project ID and Domain ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	domain, err := h.domainService.VerifyDomain(c.RequestCtx(), userID, projectID, domainID)
+	if err != nil {
+		return domainError(c, err, "Failed to verify domain")
+	}
+
+	message := "Domain verified"
+	if !domain.IsVerified() {
+		message = "Domain could not be verified: " + domain.VerificationError
+	}
+
+	return c.JSON(DomainResponse{
+		Domain:  domain,
+		Message: message,
+	})
+}
+
+// RemoveDomain removes a domain from a project
+func (h *DomainHandler) RemoveDomain(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+	domainID := c.Params("domainId")
+
+	if userID == "" || projectID == "" || domainID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID, Project ID and Domain ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	if err := h.domainService.RemoveDomain(c.RequestCtx(), userID, projectID, domainID); err != nil {
+		return domainError(c, err, "Failed to remove domain")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Domain removed successfully",
+	})
+}
+
+// domainError maps domain service errors to API responses
+func domainError(c fiber.Ctx, err error, message string) error {
+	if strings.Contains(err.Error(), "validation failed") {
+		return c.Status(400).JSON(ErrorResponse{
+			Error:   "Validation failed",
+			Code:    "VALIDATION_ERROR",
+			Details: err.Error(),
+		})
+	}
+	if strings.Contains(err.Error(), "domain already in use") {
+		return c.Status(409).JSON(ErrorResponse{
+			Error: err.Error(),
+			Code:  "DOMAIN_IN_USE",
+		})
+	}
+	if strings.Contains(err.Error(), "domain not found") {
+		return c.Status(404).JSON(ErrorResponse{
+			Error: "Domain not found",
+			Code:  "DOMAIN_NOT_FOUND",
+		})
+	}
+	if strings.Contains(err.Error(), "not found") {
+		return c.Status(404).JSON(ErrorResponse{
+			Error: "Project not found",
+			Code:  "PROJECT_NOT_FOUND",
+		})
+	}
+	if strings.Contains(err.Error(), "access denied") {
+		return c.Status(403).JSON(ErrorResponse{
+			Error: "Access denied",
+			Code:  "ACCESS_DENIED",
+		})
+	}
+	return c.Status(500).JSON(ErrorResponse{
+		Error: message,
+		Code:  "INTERNAL_ERROR",
+	})
+}
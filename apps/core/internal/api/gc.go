@@ -0,0 +1,65 @@
+package api
+
+import (
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/services"
+	"github.com/gofiber/fiber/v3"
+)
+
+type GCHandler struct {
+	gcService *services.GCService
+}
+
+func NewGCHandler(gcService *services.GCService) *GCHandler {
+	return &GCHandler{
+		gcService: gcService,
+	}
+}
+
+type GCStatusResponse struct {
+	Running    bool             `json:"running"`
+	LastReport *models.GCReport `json:"last_report"`
+}
+
+// RegisterSystemRoutes registers the garbage collection routes. GC runs host wide,
+// so the router must only let admins through.
+func (h *GCHandler) RegisterSystemRoutes(router fiber.Router) {
+	router.Get("/gc", h.GetStatus) // GET /api/v1/system/gc
+	router.Post("/gc", h.Run)      // POST /api/v1/system/gc
+}
+
+// GetStatus returns whether garbage collection is running and what the last run reclaimed
+func (h *GCHandler) GetStatus(c fiber.Ctx) error {
+	report, running := h.gcService.LastReport()
+	return c.JSON(GCStatusResponse{
+		Running:    running,
+		LastReport: report,
+	})
+}
+
+// Run starts garbage collection. A dry run reports what would be removed right
+// away, a real run is started in the background and reported by GetStatus.
+func (h *GCHandler) Run(c fiber.Ctx) error {
+	if c.Query("dry_run") == "true" || c.Query("dry_run") == "1" {
+		report, err := h.gcService.Run(c.RequestCtx(), true)
+		if err != nil {
+			return gcError(c, err)
+		}
+		return c.JSON(report)
+	}
+
+	if err := h.gcService.Trigger(); err != nil {
+		return gcError(c, err)
+	}
+	return c.Status(202).JSON(MessageResponse{
+		Message: "Garbage collection started",
+	})
+}
+
+func gcError(c fiber.Ctx, err error) error {
+	return c.Status(409).JSON(ErrorResponse{
+		Error:   "Garbage collection is already running",
+		Code:    "GC_RUNNING",
+		Details: err.Error(),
+	})
+}
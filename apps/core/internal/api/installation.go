@@ -0,0 +1,275 @@
+package api
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/services"
+	"github.com/gofiber/fiber/v3"
+)
+
+type InstallationHandler struct {
+	installationService *services.InstallationService
+}
+
+func NewInstallationHandler(installationService *services.InstallationService) *InstallationHandler {
+	return &InstallationHandler{
+		installationService: installationService,
+	}
+}
+
+type ListInstallationsResponse struct {
+	Installations []*models.GitHubInstallation `json:"installations"`
+	Total         int                          `json:"total"`
+}
+
+type CreateInstallationResponse struct {
+	Installation *models.GitHubInstallation `json:"installation"`
+	Message      string                     `json:"message"`
+}
+
+type InstallURLResponse struct {
+	URL string `json:"url"`
+}
+
+type GetInstallationRepositoriesResponse struct {
+	Repositories []*Repository `json:"repositories"`
+	Total        int           `json:"total"`
+	Page         int           `json:"page,omitempty"`
+	PerPage      int           `json:"per_page,omitempty"`
+}
+
+// RegisterRoutes registers GitHub App installation routes
+func (h *InstallationHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/:id/installations", h.GetInstallations)                                         // GET /api/v1/users/:id/installations
+	router.Post("/:id/installations", h.LinkInstallation)                                        // POST /api/v1/users/:id/installations
+	router.Get("/:id/installations/new", h.GetInstallURL)                                        // GET /api/v1/users/:id/installations/new
+	router.Get("/:id/installations/repositories", h.GetAllRepositories)                          // GET /api/v1/users/:id/installations/repositories
+	router.Get("/:id/installations/:installationId/repositories", h.GetInstallationRepositories) // GET /api/v1/users/:id/installations/:installationId/repositories
+	router.Delete("/:id/installations/:installationId", h.DeleteInstallation)                    // DELETE /api/v1/users/:id/installations/:installationId
+}
+
+// GetInstallURL returns the GitHub page for installing the Kova app
+func (h *InstallationHandler) GetInstallURL(c fiber.Ctx) error {
+	userID := c.Params("id")
+
+	url, err := h.installationService.GetInstallURL(c.RequestCtx(), userID)
+	if err != nil {
+		if strings.Contains(err.Error(), "user not found") {
+			return c.Status(404).JSON(ErrorResponse{
+				Error: "User not found",
+				Code:  "USER_NOT_FOUND",
+			})
+		}
+		if !errors.Is(err, services.ErrGitHubAppNotConfigured) {
+			return c.Status(500).JSON(ErrorResponse{
+				Error: "Failed to create install URL",
+				Code:  "INTERNAL_ERROR",
+			})
+		}
+		return c.Status(501).JSON(ErrorResponse{
+			Error: "GitHub App is not configured on this installation",
+			Code:  "GITHUB_APP_NOT_CONFIGURED",
+		})
+	}
+
+	return c.JSON(InstallURLResponse{URL: url})
+}
+
+// LinkInstallation links a GitHub App installation to the user
+func (h *InstallationHandler) LinkInstallation(c fiber.Ctx) error {
+	userID := c.Params("id")
+	if userID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID is required",
+			Code:  "MISSING_USER_ID",
+		})
+	}
+
+	var req models.CreateGitHubInstallationRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request body",
+			Code:  "INVALID_BODY",
+		})
+	}
+
+	installation, err := h.installationService.LinkInstallation(c.RequestCtx(), userID, &req)
+	if err != nil {
+		if strings.Contains(err.Error(), "validation failed") {
+			return c.Status(400).JSON(ErrorResponse{
+				Error:   "Validation failed",
+				Code:    "VALIDATION_ERROR",
+				Details: err.Error(),
+			})
+		}
+		if strings.Contains(err.Error(), "not configured") {
+			return c.Status(501).JSON(ErrorResponse{
+				Error: "GitHub App is not configured on this installation",
+				Code:  "GITHUB_APP_NOT_CONFIGURED",
+			})
+		}
+		if strings.Contains(err.Error(), "user not found") {
+			return c.Status(404).JSON(ErrorResponse{
+				Error: "User not found",
+				Code:  "USER_NOT_FOUND",
+			})
+		}
+		if strings.Contains(err.Error(), "access denied") {
+			return c.Status(403).JSON(ErrorResponse{
+				Error:   "Access denied",
+				Code:    "ACCESS_DENIED",
+				Details: err.Error(),
+			})
+		}
+		if strings.Contains(err.Error(), "already linked") {
+			return c.Status(409).JSON(ErrorResponse{
+				Error: err.Error(),
+				Code:  "INSTALLATION_EXISTS",
+			})
+		}
+		if strings.Contains(err.Error(), "GitHub") {
+			return c.Status(502).JSON(ErrorResponse{
+				Error:   "Failed to verify installation with GitHub",
+				Code:    "GITHUB_API_ERROR",
+				Details: err.Error(),
+			})
+		}
+		return c.Status(500).JSON(ErrorResponse{
+			Error: "Failed to link installation",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+
+	return c.Status(201).JSON(CreateInstallationResponse{
+		Installation: installation,
+		Message:      "GitHub App installation linked successfully",
+	})
+}
+
+// GetInstallations lists the user's linked installations
+func (h *InstallationHandler) GetInstallations(c fiber.Ctx) error {
+	userID := c.Params("id")
+
+	installations, err := h.installationService.GetInstallations(c.RequestCtx(), userID)
+	if err != nil {
+		if strings.Contains(err.Error(), "user not found") {
+			return c.Status(404).JSON(ErrorResponse{
+				Error: "User not found",
+				Code:  "USER_NOT_FOUND",
+			})
+		}
+		return c.Status(500).JSON(ErrorResponse{
+			Error: "Failed to get installations",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+
+	return c.JSON(ListInstallationsResponse{
+		Installations: installations,
+		Total:         len(installations),
+	})
+}
+
+// DeleteInstallation unlinks an installation from the user
+func (h *InstallationHandler) DeleteInstallation(c fiber.Ctx) error {
+	userID := c.Params("id")
+	installationID := c.Params("installationId")
+
+	if err := h.installationService.DeleteInstallation(c.RequestCtx(), userID, installationID); err != nil {
+		return h.handleInstallationError(c, err, "Failed to delete installation")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Installation unlinked successfully",
+	})
+}
+
+// GetInstallationRepositories lists repositories reachable through one installation
+func (h *InstallationHandler) GetInstallationRepositories(c fiber.Ctx) error {
+	userID := c.Params("id")
+	installationID := c.Params("installationId")
+
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	perPage := 30
+	if pp := c.Query("per_page"); pp != "" {
+		if parsed, err := strconv.Atoi(pp); err == nil && parsed > 0 && parsed <= 100 {
+			perPage = parsed
+		}
+	}
+
+	repositories, total, err := h.installationService.GetInstallationRepositories(c.RequestCtx(), userID, installationID, page, perPage)
+	if err != nil {
+		return h.handleInstallationError(c, err, "Failed to get repositories")
+	}
+
+	return c.JSON(GetInstallationRepositoriesResponse{
+		Repositories: toAPIInstallationRepositories(repositories),
+		Total:        total,
+		Page:         page,
+		PerPage:      perPage,
+	})
+}
+
+// GetAllRepositories lists repositories across every organization the app is installed on
+func (h *InstallationHandler) GetAllRepositories(c fiber.Ctx) error {
+	userID := c.Params("id")
+
+	repositories, err := h.installationService.GetAllRepositories(c.RequestCtx(), userID)
+	if err != nil {
+		return h.handleInstallationError(c, err, "Failed to get repositories")
+	}
+
+	return c.JSON(GetInstallationRepositoriesResponse{
+		Repositories: toAPIInstallationRepositories(repositories),
+		Total:        len(repositories),
+	})
+}
+
+func (h *InstallationHandler) handleInstallationError(c fiber.Ctx, err error, message string) error {
+	if strings.Contains(err.Error(), "not found") {
+		return c.Status(404).JSON(ErrorResponse{
+			Error: "Installation not found",
+			Code:  "INSTALLATION_NOT_FOUND",
+		})
+	}
+	if strings.Contains(err.Error(), "access denied") {
+		return c.Status(403).JSON(ErrorResponse{
+			Error: "Access denied",
+			Code:  "ACCESS_DENIED",
+		})
+	}
+	if strings.Contains(err.Error(), "not configured") {
+		return c.Status(501).JSON(ErrorResponse{
+			Error: "GitHub App is not configured on this installation",
+			Code:  "GITHUB_APP_NOT_CONFIGURED",
+		})
+	}
+	if strings.Contains(err.Error(), "GitHub") || strings.Contains(err.Error(), "installation token") {
+		return c.Status(502).JSON(ErrorResponse{
+			Error: "Failed to fetch repositories from GitHub",
+			Code:  "GITHUB_API_ERROR",
+		})
+	}
+	return c.Status(500).JSON(ErrorResponse{
+		Error: message,
+		Code:  "INTERNAL_ERROR",
+	})
+}
+
+func toAPIInstallationRepositories(repositories []*services.InstallationRepository) []*Repository {
+	apiRepositories := make([]*Repository, len(repositories))
+	for i, repo := range repositories {
+		apiRepositories[i] = toAPIRepository(repo.GitRepository)
+		apiRepositories[i].InstallationID = repo.InstallationID
+	}
+	return apiRepositories
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/services"
+	"github.com/gofiber/fiber/v3"
+)
+
+// Comment sent while a followed stream is quiet, so closed connections are noticed
+const LOG_KEEPALIVE_INTERVAL = 15 * time.Second
+
+type LogHandler struct {
+	logService *services.LogService
+}
+
+func NewLogHandler(logService *services.LogService) *LogHandler {
+	return &LogHandler{
+		logService: logService,
+	}
+}
+
+// RegisterRoutes registers all runtime log routes
+func (h *LogHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/:id/projects/:projectId/logs", h.StreamLogs) // GET /api/v1/users/:id/projects/:projectId/logs
+}
+
+// StreamLogs streams the runtime logs of a project's replicas as server-sent events.
+// Each line is a "log" event, and the stream ends with an "end" or "error" event.
+func (h *LogHandler) StreamLogs(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	req := models.LogsRequest{
+		Follow:  c.Query("follow") == "true" || c.Query("follow") == "1",
+		Since:   c.Query("since"),
+		Process: c.Query("process"),
+		Tail:    models.DEFAULT_LOG_TAIL,
+	}
+	if t := c.Query("tail"); t == "all" {
+		req.Tail = 0
+	} else if t != "" {
+		parsed, err := strconv.Atoi(t)
+		if err != nil {
+			return c.Status(400).JSON(ErrorResponse{
+				Error: "tail must be a number or \"all\"",
+				Code:  "INVALID_PARAMETERS",
+			})
+		}
+		req.Tail = parsed
+	}
+	if r := c.Query("replica"); r != "" {
+		parsed, err := strconv.Atoi(r)
+		if err != nil {
+			return c.Status(400).JSON(ErrorResponse{
+				Error: "replica must be a number",
+				Code:  "INVALID_PARAMETERS",
+			})
+		}
+		req.Replica = parsed
+	}
+
+	stream, err := h.logService.OpenLogs(c.RequestCtx(), userID, projectID, &req)
+	if err != nil {
+		return projectLookupError(c, err, "Failed to read logs")
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	// The server's write timeout covers a whole response, which would cut followed
+	// streams short, so the deadline is pushed back on every flush instead
+	conn := c.RequestCtx().Conn()
+
+	return c.SendStreamWriter(func(w *bufio.Writer) {
+		defer stream.Close()
+
+		keepalive := time.NewTicker(LOG_KEEPALIVE_INTERVAL)
+		defer keepalive.Stop()
+
+		for {
+			select {
+			case line, ok := <-stream.Lines:
+				if !ok {
+					if err := stream.Err(); err != nil {
+						writeEvent(w, "error", fiber.Map{"error": err.Error()})
+					} else {
+						writeEvent(w, "end", fiber.Map{})
+					}
+					w.Flush()
+					return
+				}
+				writeEvent(w, "log", line)
+				// Flush whatever is buffered before waiting on the next line
+				if len(stream.Lines) > 0 {
+					continue
+				}
+			case <-keepalive.C:
+				fmt.Fprint(w, ": keepalive\n\n")
+			}
+
+			// A failed flush means the client went away
+			conn.SetWriteDeadline(time.Now().Add(2 * LOG_KEEPALIVE_INTERVAL))
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+}
+
+func writeEvent(w *bufio.Writer, event string, data interface{}) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+}
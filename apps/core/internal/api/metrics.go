@@ -0,0 +1,42 @@
+package api
+
+import (
+	"github.com/dopeCape/kova/internal/services"
+	"github.com/gofiber/fiber/v3"
+)
+
+type MetricsHandler struct {
+	metricsService *services.MetricsService
+}
+
+func NewMetricsHandler(metricsService *services.MetricsService) *MetricsHandler {
+	return &MetricsHandler{
+		metricsService: metricsService,
+	}
+}
+
+// RegisterRoutes registers all resource metrics routes
+func (h *MetricsHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/:id/projects/:projectId/metrics", h.GetProjectMetrics) // GET /api/v1/users/:id/projects/:projectId/metrics
+}
+
+// GetProjectMetrics returns the CPU, memory, network and restart history of a
+// project's services over a range such as 1h, 24h or 7d
+func (h *MetricsHandler) GetProjectMetrics(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	metrics, err := h.metricsService.GetProjectMetrics(c.RequestCtx(), userID, projectID, c.Query("range"))
+	if err != nil {
+		return projectLookupError(c, err, "Failed to get metrics")
+	}
+
+	return c.JSON(metrics)
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/services"
+	"github.com/gofiber/fiber/v3"
+)
+
+type OAuthHandler struct {
+	accountService *services.AccountService
+}
+
+func NewOAuthHandler(accountService *services.AccountService) *OAuthHandler {
+	return &OAuthHandler{
+		accountService: accountService,
+	}
+}
+
+type StartOAuthResponse struct {
+	URL string `json:"url"`
+}
+
+type PollDeviceFlowRequest struct {
+	DeviceCode string `json:"device_code" validate:"required"`
+}
+
+type PollDeviceFlowResponse struct {
+	Status  string          `json:"status"` // "pending", "slow_down" or "linked"
+	Account *models.Account `json:"account,omitempty"`
+}
+
+// RegisterPublicRoutes registers the OAuth callback, which GitHub calls without a Kova session
+func (h *OAuthHandler) RegisterPublicRoutes(router fiber.Router) {
+	router.Get("/github/callback", h.HandleGitHubCallback) // GET /api/v1/oauth/github/callback
+}
+
+// RegisterRoutes registers account linking routes
+func (h *OAuthHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/:id/accounts/oauth/github", h.StartOAuth)     // GET /api/v1/users/:id/accounts/oauth/github
+	router.Post("/:id/accounts/device", h.StartDeviceFlow)     // POST /api/v1/users/:id/accounts/device
+	router.Post("/:id/accounts/device/poll", h.PollDeviceFlow) // POST /api/v1/users/:id/accounts/device/poll
+}
+
+// StartOAuth returns the GitHub authorization URL for the web flow
+func (h *OAuthHandler) StartOAuth(c fiber.Ctx) error {
+	userID := c.Params("id")
+
+	authorizeURL, err := h.accountService.StartOAuth(c.RequestCtx(), userID, c.Query("redirect_to"))
+	if err != nil {
+		return h.handleOAuthError(c, err, "Failed to start GitHub authorization")
+	}
+
+	return c.JSON(StartOAuthResponse{URL: authorizeURL})
+}
+
+// HandleGitHubCallback completes the web flow and links the account
+func (h *OAuthHandler) HandleGitHubCallback(c fiber.Ctx) error {
+	account, redirectTo, err := h.accountService.CompleteOAuth(c.RequestCtx(), c.Query("state"), c.Query("code"))
+
+	if redirectTo != "" {
+		target, parseErr := url.Parse(redirectTo)
+		if parseErr != nil {
+			return h.handleOAuthError(c, parseErr, "Failed to link GitHub account")
+		}
+		params := target.Query()
+		if err != nil {
+			params.Set("github_error", err.Error())
+		} else {
+			params.Set("github_linked", account.GithubUsername)
+		}
+		target.RawQuery = params.Encode()
+		return c.Redirect().To(target.String())
+	}
+
+	if err != nil {
+		return h.handleOAuthError(c, err, "Failed to link GitHub account")
+	}
+
+	return c.Status(201).JSON(CreateAccountResponse{
+		Account: account,
+		Message: "GitHub account linked successfully",
+	})
+}
+
+// StartDeviceFlow requests a device code the user confirms on github.com
+func (h *OAuthHandler) StartDeviceFlow(c fiber.Ctx) error {
+	userID := c.Params("id")
+
+	deviceCode, err := h.accountService.StartDeviceFlow(c.RequestCtx(), userID)
+	if err != nil {
+		return h.handleOAuthError(c, err, "Failed to start device flow")
+	}
+
+	return c.JSON(deviceCode)
+}
+
+// PollDeviceFlow checks whether the user approved the device code
+func (h *OAuthHandler) PollDeviceFlow(c fiber.Ctx) error {
+	userID := c.Params("id")
+
+	var req PollDeviceFlowRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request body",
+			Code:  "INVALID_BODY",
+		})
+	}
+
+	account, err := h.accountService.PollDeviceFlow(c.RequestCtx(), userID, req.DeviceCode)
+	if errors.Is(err, services.ErrAuthorizationPending) {
+		return c.Status(202).JSON(PollDeviceFlowResponse{Status: "pending"})
+	}
+	if errors.Is(err, services.ErrSlowDown) {
+		return c.Status(202).JSON(PollDeviceFlowResponse{Status: "slow_down"})
+	}
+	if err != nil {
+		return h.handleOAuthError(c, err, "Failed to complete device flow")
+	}
+
+	return c.Status(201).JSON(PollDeviceFlowResponse{
+		Status:  "linked",
+		Account: account,
+	})
+}
+
+func (h *OAuthHandler) handleOAuthError(c fiber.Ctx, err error, message string) error {
+	if errors.Is(err, services.ErrOAuthNotConfigured) {
+		return c.Status(501).JSON(ErrorResponse{
+			Error: "GitHub OAuth is not configured on this installation",
+			Code:  "OAUTH_NOT_CONFIGURED",
+		})
+	}
+	if strings.Contains(err.Error(), "validation failed") {
+		return c.Status(400).JSON(ErrorResponse{
+			Error:   "Validation failed",
+			Code:    "VALIDATION_ERROR",
+			Details: err.Error(),
+		})
+	}
+	if strings.Contains(err.Error(), "invalid oauth state") {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid or expired authorization state",
+			Code:  "INVALID_STATE",
+		})
+	}
+	if strings.Contains(err.Error(), "user not found") {
+		return c.Status(404).JSON(ErrorResponse{
+			Error: "User not found",
+			Code:  "USER_NOT_FOUND",
+		})
+	}
+	if strings.Contains(err.Error(), "already linked") {
+		return c.Status(409).JSON(ErrorResponse{
+			Error: err.Error(),
+			Code:  "ACCOUNT_EXISTS",
+		})
+	}
+	if strings.Contains(err.Error(), "expired") || strings.Contains(err.Error(), "denied") {
+		return c.Status(410).JSON(ErrorResponse{
+			Error: err.Error(),
+			Code:  "AUTHORIZATION_FAILED",
+		})
+	}
+	if strings.Contains(err.Error(), "GitHub") || strings.Contains(err.Error(), "github") {
+		return c.Status(502).JSON(ErrorResponse{
+			Error:   message,
+			Code:    "GITHUB_API_ERROR",
+			Details: err.Error(),
+		})
+	}
+	return c.Status(500).JSON(ErrorResponse{
+		Error: message,
+		Code:  "INTERNAL_ERROR",
+	})
+}
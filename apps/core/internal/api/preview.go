@@ -0,0 +1,134 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/services"
+	"github.com/gofiber/fiber/v3"
+)
+
+type PreviewHandler struct {
+	previewService *services.PreviewService
+}
+
+func NewPreviewHandler(previewService *services.PreviewService) *PreviewHandler {
+	return &PreviewHandler{
+		previewService: previewService,
+	}
+}
+
+type ListPreviewsResponse struct {
+	Previews []*models.Preview `json:"previews"`
+	Total    int               `json:"total"`
+}
+
+// RegisterRoutes registers all preview routes
+func (h *PreviewHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/:id/projects/:projectId/previews", h.GetPreviews)           // GET /api/v1/users/:id/projects/:projectId/previews
+	router.Put("/:id/projects/:projectId/previews", h.UpdatePreviewSettings) // PUT /api/v1/users/:id/projects/:projectId/previews
+}
+
+// GetPreviews lists the pull request previews of a project
+func (h *PreviewHandler) GetPreviews(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	previews, err := h.previewService.GetPreviews(c.RequestCtx(), userID, projectID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.Status(404).JSON(ErrorResponse{
+				Error: "Project not found",
+				Code:  "PROJECT_NOT_FOUND",
+			})
+		}
+		if strings.Contains(err.Error(), "access denied") {
+			return c.Status(403).JSON(ErrorResponse{
+				Error: "Access denied",
+				Code:  "ACCESS_DENIED",
+			})
+		}
+		return c.Status(500).JSON(ErrorResponse{
+			Error: "Failed to get previews",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+
+	return c.JSON(ListPreviewsResponse{
+		Previews: previews,
+		Total:    len(previews),
+	})
+}
+
+// UpdatePreviewSettings enables, disables or reconfigures pull request previews
+func (h *PreviewHandler) UpdatePreviewSettings(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	var req models.UpdatePreviewSettingsRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request body",
+			Code:  "INVALID_BODY",
+		})
+	}
+
+	project, err := h.previewService.UpdatePreviewSettings(c.RequestCtx(), userID, projectID, &req)
+	if err != nil {
+		if strings.Contains(err.Error(), "validation failed") {
+			return c.Status(400).JSON(ErrorResponse{
+				Error:   "Validation failed",
+				Code:    "VALIDATION_ERROR",
+				Details: err.Error(),
+			})
+		}
+		if strings.Contains(err.Error(), "project not found") {
+			return c.Status(404).JSON(ErrorResponse{
+				Error: "Project not found",
+				Code:  "PROJECT_NOT_FOUND",
+			})
+		}
+		if strings.Contains(err.Error(), "access denied") {
+			return c.Status(403).JSON(ErrorResponse{
+				Error: "Access denied",
+				Code:  "ACCESS_DENIED",
+			})
+		}
+		if strings.Contains(err.Error(), "previews unavailable") || strings.Contains(err.Error(), "no linked") || strings.Contains(err.Error(), "been unlinked") {
+			return c.Status(422).JSON(ErrorResponse{
+				Error: err.Error(),
+				Code:  "PREVIEWS_UNAVAILABLE",
+			})
+		}
+		if strings.Contains(err.Error(), "webhook") {
+			return c.Status(502).JSON(ErrorResponse{
+				Error:   "Failed to configure repository webhook",
+				Code:    "WEBHOOK_ERROR",
+				Details: err.Error(),
+			})
+		}
+		return c.Status(500).JSON(ErrorResponse{
+			Error: "Failed to update preview settings",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+
+	return c.JSON(UpdateProjectResponse{
+		Project: project,
+		Message: "Preview settings updated successfully",
+	})
+}
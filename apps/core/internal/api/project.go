@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"strconv"
 	"strings"
 
@@ -33,6 +34,16 @@ type UpdateProjectResponse struct {
 	Message string          `json:"message"`
 }
 
+type DeployKeyResponse struct {
+	PublicKey string `json:"public_key"`
+	Message   string `json:"message,omitempty"`
+}
+
+type DeployHookResponse struct {
+	DeployHook *models.DeployHook `json:"deploy_hook"`
+	Message    string             `json:"message"`
+}
+
 type ListProjectsResponse struct {
 	Projects []*models.Project `json:"projects"`
 	Total    int64             `json:"total"`
@@ -41,6 +52,14 @@ type ListProjectsResponse struct {
 	HasMore  bool              `json:"has_more"`
 }
 
+type TimelineResponse struct {
+	Events  []*models.DeploymentEvent `json:"events"`
+	Total   int64                     `json:"total"`
+	Limit   int                       `json:"limit"`
+	Offset  int                       `json:"offset"`
+	HasMore bool                      `json:"has_more"`
+}
+
 type SearchProjectsResponse struct {
 	Projects []*models.Project `json:"projects"`
 	Query    string            `json:"query"`
@@ -50,16 +69,38 @@ type SearchProjectsResponse struct {
 
 // RegisterRoutes registers all project routes
 func (h *ProjectHandler) RegisterRoutes(router fiber.Router) {
-	router.Post("/:id/projects", h.CreateProject)                        // POST /api/v1/users/:id/projects
-	router.Get("/:id/projects", h.GetProjectsByUser)                     // GET /api/v1/users/:id/projects
-	router.Get("/:id/projects/search", h.SearchProjectsByUser)           // GET /api/v1/users/:id/projects/search
-	router.Get("/:id/projects/active", h.GetActiveProjectsByUser)        // GET /api/v1/users/:id/projects/active
-	router.Get("/:id/projects/:projectId", h.GetProject)                 // GET /api/v1/users/:id/projects/:projectId
-	router.Put("/:id/projects/:projectId", h.UpdateProject)              // PUT /api/v1/users/:id/projects/:projectId
-	router.Put("/:id/projects/:projectId/status", h.UpdateProjectStatus) // PUT /api/v1/users/:id/projects/:projectId/status
-	router.Put("/:id/projects/:projectId/archive", h.ArchiveProject)     // PUT /api/v1/users/:id/projects/:projectId/archive
-	router.Put("/:id/projects/:projectId/activate", h.ActivateProject)   // PUT /api/v1/users/:id/projects/:projectId/activate
-	router.Delete("/:id/projects/:projectId", h.DeleteProject)           // DELETE /api/v1/users/:id/projects/:projectId
+	router.Post("/:id/projects", h.CreateProject)                                  // POST /api/v1/users/:id/projects
+	router.Get("/:id/projects", h.GetProjectsByUser)                               // GET /api/v1/users/:id/projects
+	router.Get("/:id/projects/search", h.SearchProjectsByUser)                     // GET /api/v1/users/:id/projects/search
+	router.Get("/:id/projects/active", h.GetActiveProjectsByUser)                  // GET /api/v1/users/:id/projects/active
+	router.Get("/:id/projects/:projectId", h.GetProject)                           // GET /api/v1/users/:id/projects/:projectId
+	router.Put("/:id/projects/:projectId", h.UpdateProject)                        // PUT /api/v1/users/:id/projects/:projectId
+	router.Put("/:id/projects/:projectId/status", h.UpdateProjectStatus)           // PUT /api/v1/users/:id/projects/:projectId/status
+	router.Put("/:id/projects/:projectId/archive", h.ArchiveProject)               // PUT /api/v1/users/:id/projects/:projectId/archive
+	router.Put("/:id/projects/:projectId/activate", h.ActivateProject)             // PUT /api/v1/users/:id/projects/:projectId/activate
+	router.Delete("/:id/projects/:projectId", h.DeleteProject)                     // DELETE /api/v1/users/:id/projects/:projectId
+	router.Post("/:id/projects/:projectId/deploy", h.DeployProject)                // POST /api/v1/users/:id/projects/:projectId/deploy
+	router.Get("/:id/projects/:projectId/deploy-key", h.GetDeployKey)              // GET /api/v1/users/:id/projects/:projectId/deploy-key
+	router.Post("/:id/projects/:projectId/deploy-key", h.RegenerateDeployKey)      // POST /api/v1/users/:id/projects/:projectId/deploy-key
+	router.Put("/:id/projects/:projectId/tls", h.UpdateTLSSettings)                // PUT /api/v1/users/:id/projects/:projectId/tls
+	router.Delete("/:id/projects/:projectId/tls/certificate", h.RemoveCertificate) // DELETE /api/v1/users/:id/projects/:projectId/tls/certificate
+	router.Put("/:id/projects/:projectId/routing", h.UpdateRoutingPolicy)          // PUT /api/v1/users/:id/projects/:projectId/routing
+	router.Put("/:id/projects/:projectId/processes", h.UpdateProcesses)            // PUT /api/v1/users/:id/projects/:projectId/processes
+	router.Put("/:id/projects/:projectId/volumes", h.UpdateVolumes)                // PUT /api/v1/users/:id/projects/:projectId/volumes
+	router.Put("/:id/projects/:projectId/static-site", h.UpdateStaticSite)         // PUT /api/v1/users/:id/projects/:projectId/static-site
+	router.Put("/:id/projects/:projectId/image", h.UpdateImageSource)              // PUT /api/v1/users/:id/projects/:projectId/image
+	router.Post("/:id/projects/:projectId/deploy-hook", h.RegenerateDeployHook)    // POST /api/v1/users/:id/projects/:projectId/deploy-hook
+	router.Delete("/:id/projects/:projectId/deploy-hook", h.DisableDeployHook)     // DELETE /api/v1/users/:id/projects/:projectId/deploy-hook
+	router.Post("/:id/projects/:projectId/stop", h.StopProject)                    // POST /api/v1/users/:id/projects/:projectId/stop
+	router.Post("/:id/projects/:projectId/start", h.StartProject)                  // POST /api/v1/users/:id/projects/:projectId/start
+	router.Post("/:id/projects/:projectId/restart", h.RestartProject)              // POST /api/v1/users/:id/projects/:projectId/restart
+	router.Get("/:id/projects/:projectId/timeline", h.GetTimeline)                 // GET /api/v1/users/:id/projects/:projectId/timeline
+}
+
+// RegisterHookRoutes registers the deploy hook routes. These are public and
+// authenticated by the project's deploy hook token.
+func (h *ProjectHandler) RegisterHookRoutes(router fiber.Router) {
+	router.Post("/projects/:projectId/deploy", h.TriggerDeployHook) // POST /api/v1/hooks/projects/:projectId/deploy
 }
 
 // CreateProject creates a new project for a user
@@ -101,15 +142,451 @@ func (h *ProjectHandler) CreateProject(c fiber.Ctx) error {
 				Code:  "PROJECT_EXISTS",
 			})
 		}
+		if strings.Contains(err.Error(), "domain already in use") {
+			return c.Status(409).JSON(ErrorResponse{
+				Error: err.Error(),
+				Code:  "DOMAIN_IN_USE",
+			})
+		}
+		if strings.Contains(err.Error(), "account not found") {
+			return c.Status(404).JSON(ErrorResponse{
+				Error: "Linked account not found",
+				Code:  "ACCOUNT_NOT_FOUND",
+			})
+		}
+		if strings.Contains(err.Error(), "installation not found") {
+			return c.Status(404).JSON(ErrorResponse{
+				Error: "GitHub App installation not found",
+				Code:  "INSTALLATION_NOT_FOUND",
+			})
+		}
+		if strings.Contains(err.Error(), "access denied") {
+			return c.Status(403).JSON(ErrorResponse{
+				Error: "Access denied",
+				Code:  "ACCESS_DENIED",
+			})
+		}
+		if strings.Contains(err.Error(), "git provider") {
+			return c.Status(502).JSON(ErrorResponse{
+				Error: "Failed to verify repository with git provider",
+				Code:  "GITHUB_API_ERROR",
+			})
+		}
 		return c.Status(500).JSON(ErrorResponse{
 			Error: "Failed to create project",
 			Code:  "INTERNAL_ERROR",
 		})
 	}
 
+	message := "Project created successfully"
+	if project.UsesDeployKey() {
+		message = "Project created. Add the deploy key to the repository, then deploy the project"
+	}
+
 	return c.Status(201).JSON(CreateProjectResponse{
 		Project: project,
-		Message: "Project created successfully",
+		Message: message,
+	})
+}
+
+// UpdateTLSSettings updates the HTTPS settings of a project
+func (h *ProjectHandler) UpdateTLSSettings(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	var req models.UpdateTLSSettingsRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request body",
+			Code:  "INVALID_BODY",
+		})
+	}
+
+	project, err := h.projectService.UpdateTLSSettings(c.RequestCtx(), userID, projectID, &req)
+	if err != nil {
+		return projectLookupError(c, err, "Failed to update TLS settings")
+	}
+
+	return c.JSON(UpdateProjectResponse{
+		Project: project,
+		Message: "TLS settings updated successfully",
+	})
+}
+
+// RemoveCertificate removes the custom TLS certificate of a project
+func (h *ProjectHandler) RemoveCertificate(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	project, err := h.projectService.RemoveCertificate(c.RequestCtx(), userID, projectID)
+	if err != nil {
+		return projectLookupError(c, err, "Failed to remove certificate")
+	}
+
+	return c.JSON(UpdateProjectResponse{
+		Project: project,
+		Message: "Custom certificate removed",
+	})
+}
+
+// UpdateRoutingPolicy replaces the basic auth, IP allowlist, header, rate limit and
+// redirect middlewares of a project
+func (h *ProjectHandler) UpdateRoutingPolicy(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	var req models.UpdateRoutingPolicyRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request body",
+			Code:  "INVALID_BODY",
+		})
+	}
+
+	project, err := h.projectService.UpdateRoutingPolicy(c.RequestCtx(), userID, projectID, &req)
+	if err != nil {
+		return projectLookupError(c, err, "Failed to update routing policy")
+	}
+
+	return c.JSON(UpdateProjectResponse{
+		Project: project,
+		Message: "Routing policy updated successfully",
+	})
+}
+
+// UpdateProcesses replaces the process types of a project, each run as its own
+// service with a start command, replica count and whether it is exposed
+func (h *ProjectHandler) UpdateProcesses(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	var req models.UpdateProcessesRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request body",
+			Code:  "INVALID_BODY",
+		})
+	}
+
+	project, err := h.projectService.UpdateProcesses(c.RequestCtx(), userID, projectID, &req)
+	if err != nil {
+		return projectLookupError(c, err, "Failed to update processes")
+	}
+
+	return c.JSON(UpdateProjectResponse{
+		Project: project,
+		Message: "Processes updated successfully",
+	})
+}
+
+// UpdateVolumes replaces the named volumes mounted into every process of a project
+func (h *ProjectHandler) UpdateVolumes(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	var req models.UpdateVolumesRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request body",
+			Code:  "INVALID_BODY",
+		})
+	}
+
+	project, err := h.projectService.UpdateVolumes(c.RequestCtx(), userID, projectID, &req)
+	if err != nil {
+		return projectLookupError(c, err, "Failed to update volumes")
+	}
+
+	return c.JSON(UpdateProjectResponse{
+		Project: project,
+		Message: "Volumes updated successfully",
+	})
+}
+
+// UpdateStaticSite turns static site mode on or off, and sets the output directory,
+// SPA fallback and 404 page the shared static file server uses
+func (h *ProjectHandler) UpdateStaticSite(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	var req models.UpdateStaticSiteRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request body",
+			Code:  "INVALID_BODY",
+		})
+	}
+
+	project, err := h.projectService.UpdateStaticSite(c.RequestCtx(), userID, projectID, &req)
+	if err != nil {
+		return projectLookupError(c, err, "Failed to update static site")
+	}
+
+	return c.JSON(UpdateProjectResponse{
+		Project: project,
+		Message: "Static site updated successfully",
+	})
+}
+
+// DeployProject queues a build of a project
+func (h *ProjectHandler) DeployProject(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	// The body is optional, it only carries the tag an image project should deploy
+	var req models.DeployProjectRequest
+	if len(c.Body()) > 0 {
+		if err := c.Bind().Body(&req); err != nil {
+			return c.Status(400).JSON(ErrorResponse{
+				Error: "Invalid request body",
+				Code:  "INVALID_BODY",
+			})
+		}
+	}
+
+	project, err := h.projectService.DeployProject(c.RequestCtx(), userID, projectID, &req)
+	if err != nil {
+		return projectLookupError(c, err, "Failed to deploy project")
+	}
+
+	return c.Status(202).JSON(UpdateProjectResponse{
+		Project: project,
+		Message: "Deployment queued",
+	})
+}
+
+// TriggerDeployHook queues a deploy for CI. The hook token is sent as a bearer
+// token and the tag to deploy either in the body or as the tag query parameter.
+func (h *ProjectHandler) TriggerDeployHook(c fiber.Ctx) error {
+	projectID := c.Params("projectId")
+	token, ok := strings.CutPrefix(c.Get("Authorization"), "Bearer ")
+
+	if projectID == "" || !ok || token == "" {
+		return c.Status(401).JSON(ErrorResponse{
+			Error: "Deploy hook token required",
+			Code:  "MISSING_TOKEN",
+		})
+	}
+
+	var req models.DeployProjectRequest
+	if len(c.Body()) > 0 {
+		if err := c.Bind().Body(&req); err != nil {
+			return c.Status(400).JSON(ErrorResponse{
+				Error: "Invalid request body",
+				Code:  "INVALID_BODY",
+			})
+		}
+	}
+	if req.Tag == "" {
+		req.Tag = c.Query("tag")
+	}
+
+	project, err := h.projectService.TriggerDeployHook(c.RequestCtx(), projectID, strings.TrimSpace(token), &req)
+	if err != nil {
+		return projectLookupError(c, err, "Failed to deploy project")
+	}
+
+	return c.Status(202).JSON(UpdateProjectResponse{
+		Project: project,
+		Message: "Deployment queued",
+	})
+}
+
+// UpdateImageSource changes the image and registry credentials of an image project
+func (h *ProjectHandler) UpdateImageSource(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	var req models.UpdateImageSourceRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request body",
+			Code:  "INVALID_BODY",
+		})
+	}
+
+	project, err := h.projectService.UpdateImageSource(c.RequestCtx(), userID, projectID, &req)
+	if err != nil {
+		return projectLookupError(c, err, "Failed to update image")
+	}
+
+	return c.JSON(UpdateProjectResponse{
+		Project: project,
+		Message: "Image updated successfully",
+	})
+}
+
+// RegenerateDeployHook creates a new deploy hook token for a project
+func (h *ProjectHandler) RegenerateDeployHook(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	hook, err := h.projectService.RegenerateDeployHook(c.RequestCtx(), userID, projectID)
+	if err != nil {
+		return projectLookupError(c, err, "Failed to regenerate deploy hook")
+	}
+
+	return c.JSON(DeployHookResponse{
+		DeployHook: hook,
+		Message:    "Deploy hook regenerated. Store the token in your CI, it won't be shown again",
+	})
+}
+
+// DisableDeployHook revokes a project's deploy hook token
+func (h *ProjectHandler) DisableDeployHook(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	project, err := h.projectService.DisableDeployHook(c.RequestCtx(), userID, projectID)
+	if err != nil {
+		return projectLookupError(c, err, "Failed to disable deploy hook")
+	}
+
+	return c.JSON(UpdateProjectResponse{
+		Project: project,
+		Message: "Deploy hook disabled",
+	})
+}
+
+// GetDeployKey returns the public SSH deploy key of a plain git project
+func (h *ProjectHandler) GetDeployKey(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	publicKey, err := h.projectService.GetDeployKey(c.RequestCtx(), userID, projectID)
+	if err != nil {
+		return projectLookupError(c, err, "Failed to get deploy key")
+	}
+
+	return c.JSON(DeployKeyResponse{
+		PublicKey: publicKey,
+	})
+}
+
+// RegenerateDeployKey replaces the SSH deploy key of a plain git project
+func (h *ProjectHandler) RegenerateDeployKey(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	publicKey, err := h.projectService.RegenerateDeployKey(c.RequestCtx(), userID, projectID)
+	if err != nil {
+		return projectLookupError(c, err, "Failed to regenerate deploy key")
+	}
+
+	return c.JSON(DeployKeyResponse{
+		PublicKey: publicKey,
+		Message:   "Deploy key regenerated. Replace the old key in the repository settings",
+	})
+}
+
+// projectLookupError maps errors from project-scoped service calls to responses
+func projectLookupError(c fiber.Ctx, err error, message string) error {
+	if strings.Contains(err.Error(), "validation failed") {
+		return c.Status(400).JSON(ErrorResponse{
+			Error:   "Validation failed",
+			Code:    "VALIDATION_ERROR",
+			Details: err.Error(),
+		})
+	}
+	if strings.Contains(err.Error(), "not found") {
+		return c.Status(404).JSON(ErrorResponse{
+			Error: "Project not found",
+			Code:  "PROJECT_NOT_FOUND",
+		})
+	}
+	if strings.Contains(err.Error(), "access denied") {
+		return c.Status(403).JSON(ErrorResponse{
+			Error: "Access denied",
+			Code:  "ACCESS_DENIED",
+		})
+	}
+	return c.Status(500).JSON(ErrorResponse{
+		Error: message,
+		Code:  "INTERNAL_ERROR",
 	})
 }
 
@@ -291,6 +768,18 @@ func (h *ProjectHandler) UpdateProject(c fiber.Ctx) error {
 				Code:  "PROJECT_EXISTS",
 			})
 		}
+		if strings.Contains(err.Error(), "domain already in use") {
+			return c.Status(409).JSON(ErrorResponse{
+				Error: err.Error(),
+				Code:  "DOMAIN_IN_USE",
+			})
+		}
+		if strings.Contains(err.Error(), "git provider") {
+			return c.Status(502).JSON(ErrorResponse{
+				Error: "Failed to verify repository with git provider",
+				Code:  "GITHUB_API_ERROR",
+			})
+		}
 		return c.Status(500).JSON(ErrorResponse{
 			Error: "Failed to update project",
 			Code:  "INTERNAL_ERROR",
@@ -340,6 +829,13 @@ func (h *ProjectHandler) UpdateProjectStatus(c fiber.Ctx) error {
 				Code:  "INVALID_STATUS",
 			})
 		}
+		if strings.Contains(err.Error(), "validation failed") {
+			return c.Status(400).JSON(ErrorResponse{
+				Error:   "Validation failed",
+				Code:    "VALIDATION_ERROR",
+				Details: err.Error(),
+			})
+		}
 		if strings.Contains(err.Error(), "not found") {
 			return c.Status(404).JSON(ErrorResponse{
 				Error: "Project not found",
@@ -473,7 +969,8 @@ func (h *ProjectHandler) DeleteProject(c fiber.Ctx) error {
 		})
 	}
 
-	err := h.projectService.DeleteProject(c.RequestCtx(), userID, projectID)
+	removeVolumes := c.Query("remove_volumes") == "true" || c.Query("remove_volumes") == "1"
+	teardown, err := h.projectService.DeleteProject(c.RequestCtx(), userID, projectID, removeVolumes)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return c.Status(404).JSON(ErrorResponse{
@@ -493,8 +990,9 @@ func (h *ProjectHandler) DeleteProject(c fiber.Ctx) error {
 		})
 	}
 
-	return c.JSON(MessageResponse{
-		Message: "Project deleted successfully",
+	return c.Status(202).JSON(TeardownResponse{
+		Teardown: teardown,
+		Message:  "Project deleted, teardown queued",
 	})
 }
 
@@ -552,3 +1050,86 @@ func (h *ProjectHandler) SearchProjectsByUser(c fiber.Ctx) error {
 		Offset:   offset,
 	})
 }
+
+// StopProject scales a deployed project's app down to zero replicas
+func (h *ProjectHandler) StopProject(c fiber.Ctx) error {
+	return h.controlProject(c, h.projectService.StopProject, "Project stopped", "Failed to stop project")
+}
+
+// StartProject brings a stopped project's app back up
+func (h *ProjectHandler) StartProject(c fiber.Ctx) error {
+	return h.controlProject(c, h.projectService.StartProject, "Project started", "Failed to start project")
+}
+
+// RestartProject replaces the containers of a deployed project's app
+func (h *ProjectHandler) RestartProject(c fiber.Ctx) error {
+	return h.controlProject(c, h.projectService.RestartProject, "Project restarting", "Failed to restart project")
+}
+
+func (h *ProjectHandler) controlProject(c fiber.Ctx, action func(context.Context, string, string) (*models.Project, error), message, failure string) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	project, err := action(c.RequestCtx(), userID, projectID)
+	if err != nil {
+		if strings.Contains(err.Error(), "is not deployed") {
+			return c.Status(409).JSON(ErrorResponse{
+				Error: "Project is not deployed",
+				Code:  "NOT_DEPLOYED",
+			})
+		}
+		return projectLookupError(c, err, failure)
+	}
+
+	return c.JSON(UpdateProjectResponse{
+		Project: project,
+		Message: message,
+	})
+}
+
+// GetTimeline returns a page of a project's deployment timeline, newest first
+func (h *ProjectHandler) GetTimeline(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	limit := 20 // default
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	offset := 0 // default
+	if o := c.Query("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+
+	events, total, err := h.projectService.GetTimeline(c.RequestCtx(), userID, projectID, limit, offset)
+	if err != nil {
+		return projectLookupError(c, err, "Failed to get timeline")
+	}
+
+	return c.JSON(TimelineResponse{
+		Events:  events,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+		HasMore: int64(offset+limit) < total,
+	})
+}
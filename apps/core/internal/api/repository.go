@@ -20,16 +20,17 @@ func NewRepositoryHandler(accountService *services.AccountService) *RepositoryHa
 }
 
 type Repository struct {
-	ID            string `json:"id"`
-	Name          string `json:"name"`
-	FullName      string `json:"full_name"`
-	Private       bool   `json:"private"`
-	Description   string `json:"description"`
-	Language      string `json:"language"`
-	Stars         int    `json:"stars"`
-	UpdatedAt     string `json:"updated_at"`
-	DefaultBranch string `json:"default_branch"`
-	URL           string `json:"url"`
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	FullName       string `json:"full_name"`
+	Private        bool   `json:"private"`
+	Description    string `json:"description"`
+	Language       string `json:"language"`
+	Stars          int    `json:"stars"`
+	UpdatedAt      string `json:"updated_at"`
+	DefaultBranch  string `json:"default_branch"`
+	URL            string `json:"url"`
+	InstallationID int64  `json:"installation_id,omitempty"`
 }
 
 type GetRepositoriesResponse struct {
@@ -40,16 +41,122 @@ type GetRepositoriesResponse struct {
 	AccountID    string        `json:"account_id"`
 }
 
+type GetBranchesResponse struct {
+	Branches   []*services.GitBranch `json:"branches"`
+	Repository string                `json:"repository"`
+	Page       int                   `json:"page"`
+	PerPage    int                   `json:"per_page"`
+	AccountID  string                `json:"account_id"`
+}
+
+type GetTagsResponse struct {
+	Tags       []*services.GitTag `json:"tags"`
+	Repository string             `json:"repository"`
+	Page       int                `json:"page"`
+	PerPage    int                `json:"per_page"`
+	AccountID  string             `json:"account_id"`
+}
+
 // RegisterRoutes registers repository routes under account routes
 func (h *RepositoryHandler) RegisterRoutes(router fiber.Router) {
-	router.Get(":id/:accountId/repositories", h.GetRepositoriesByAccount) // GET /api/v1/users/:id/accounts/:accountId/repositories
+	router.Get("/:id/accounts/:accountId/repositories", h.GetRepositoriesByAccount)                      // GET /api/v1/users/:id/accounts/:accountId/repositories
+	router.Get("/:id/accounts/:accountId/repositories/:owner/:repo/branches", h.GetBranchesByRepository) // GET /api/v1/users/:id/accounts/:accountId/repositories/:owner/:repo/branches
+	router.Get("/:id/accounts/:accountId/repositories/:owner/:repo/tags", h.GetTagsByRepository)         // GET /api/v1/users/:id/accounts/:accountId/repositories/:owner/:repo/tags
 }
 
-// GetRepositoriesByAccount retrieves all repositories for a specific GitHub account
+// GetRepositoriesByAccount retrieves all repositories for a specific linked account
 func (h *RepositoryHandler) GetRepositoriesByAccount(c fiber.Ctx) error {
 	userID := c.Params("id")
 	accountID := c.Params("accountId")
 
+	if err := requireAccountParams(c, userID, accountID); err != nil {
+		return err
+	}
+
+	page, perPage := parsePagination(c)
+
+	// all=true follows the provider's pagination and returns every repository at once
+	var repositories []*services.GitRepository
+	var err error
+	if c.Query("all") == "true" {
+		repositories, err = h.accountService.GetAllAccountRepositories(c.RequestCtx(), userID, accountID)
+		page, perPage = 1, len(repositories)
+	} else {
+		repositories, err = h.accountService.GetAccountRepositories(c.RequestCtx(), userID, accountID, page, perPage)
+	}
+	if err != nil {
+		return repositoryError(c, err, "Failed to get repositories")
+	}
+
+	// Convert GitHub repositories to API response format
+	apiRepositories := make([]*Repository, len(repositories))
+	for i, repo := range repositories {
+		apiRepositories[i] = toAPIRepository(repo)
+	}
+
+	return c.JSON(GetRepositoriesResponse{
+		Repositories: apiRepositories,
+		Total:        len(apiRepositories),
+		Page:         page,
+		PerPage:      perPage,
+		AccountID:    accountID,
+	})
+}
+
+// GetBranchesByRepository lists the branches of a repository through a linked account
+func (h *RepositoryHandler) GetBranchesByRepository(c fiber.Ctx) error {
+	userID := c.Params("id")
+	accountID := c.Params("accountId")
+
+	if err := requireAccountParams(c, userID, accountID); err != nil {
+		return err
+	}
+
+	repoFullName := c.Params("owner") + "/" + c.Params("repo")
+	page, perPage := parsePagination(c)
+
+	branches, err := h.accountService.GetAccountBranches(c.RequestCtx(), userID, accountID, repoFullName, page, perPage)
+	if err != nil {
+		return repositoryError(c, err, "Failed to get branches")
+	}
+
+	return c.JSON(GetBranchesResponse{
+		Branches:   branches,
+		Repository: repoFullName,
+		Page:       page,
+		PerPage:    perPage,
+		AccountID:  accountID,
+	})
+}
+
+// GetTagsByRepository lists the tags of a repository through a linked account
+func (h *RepositoryHandler) GetTagsByRepository(c fiber.Ctx) error {
+	userID := c.Params("id")
+	accountID := c.Params("accountId")
+
+	if err := requireAccountParams(c, userID, accountID); err != nil {
+		return err
+	}
+
+	repoFullName := c.Params("owner") + "/" + c.Params("repo")
+	page, perPage := parsePagination(c)
+
+	tags, err := h.accountService.GetAccountTags(c.RequestCtx(), userID, accountID, repoFullName, page, perPage)
+	if err != nil {
+		return repositoryError(c, err, "Failed to get tags")
+	}
+
+	return c.JSON(GetTagsResponse{
+		Tags:       tags,
+		Repository: repoFullName,
+		Page:       page,
+		PerPage:    perPage,
+		AccountID:  accountID,
+	})
+}
+
+// requireAccountParams writes a 400 response and returns a non-nil error when a path parameter is missing
+func requireAccountParams(c fiber.Ctx, userID, accountID string) error {
 	if userID == "" {
 		return c.Status(400).JSON(ErrorResponse{
 			Error: "User ID is required",
@@ -64,7 +171,11 @@ func (h *RepositoryHandler) GetRepositoriesByAccount(c fiber.Ctx) error {
 		})
 	}
 
-	// Parse pagination parameters
+	return nil
+}
+
+// parsePagination reads the page and per_page query parameters
+func parsePagination(c fiber.Ctx) (int, int) {
 	page := 1
 	if p := c.Query("page"); p != "" {
 		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
@@ -79,70 +190,76 @@ func (h *RepositoryHandler) GetRepositoriesByAccount(c fiber.Ctx) error {
 		}
 	}
 
-	repositories, err := h.accountService.GetAccountRepositories(c.RequestCtx(), userID, accountID, page, perPage)
-	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.Status(404).JSON(ErrorResponse{
-				Error: "Account not found",
-				Code:  "ACCOUNT_NOT_FOUND",
-			})
-		}
-		if strings.Contains(err.Error(), "access denied") {
-			return c.Status(403).JSON(ErrorResponse{
-				Error: "Access denied",
-				Code:  "ACCESS_DENIED",
-			})
-		}
-		if strings.Contains(err.Error(), "invalid or expired") {
-			return c.Status(401).JSON(ErrorResponse{
-				Error: "GitHub access token is invalid or expired",
-				Code:  "INVALID_GITHUB_TOKEN",
-			})
-		}
-		if strings.Contains(err.Error(), "GitHub") {
-			return c.Status(502).JSON(ErrorResponse{
-				Error: "Failed to fetch repositories from GitHub",
-				Code:  "GITHUB_API_ERROR",
-			})
-		}
-		return c.Status(500).JSON(ErrorResponse{
-			Error: "Failed to get repositories",
-			Code:  "INTERNAL_ERROR",
+	return page, perPage
+}
+
+// repositoryError maps errors from account repository lookups to responses
+func repositoryError(c fiber.Ctx, err error, message string) error {
+	if strings.Contains(err.Error(), "repository not found") {
+		return c.Status(404).JSON(ErrorResponse{
+			Error: "Repository not found",
+			Code:  "REPOSITORY_NOT_FOUND",
 		})
 	}
+	if strings.Contains(err.Error(), "not found") {
+		return c.Status(404).JSON(ErrorResponse{
+			Error: "Account not found",
+			Code:  "ACCOUNT_NOT_FOUND",
+		})
+	}
+	if strings.Contains(err.Error(), "access denied") {
+		return c.Status(403).JSON(ErrorResponse{
+			Error: "Access denied",
+			Code:  "ACCESS_DENIED",
+		})
+	}
+	if strings.Contains(err.Error(), "rate limit exceeded") {
+		return c.Status(429).JSON(ErrorResponse{
+			Error:   "Git provider rate limit exceeded",
+			Code:    "RATE_LIMITED",
+			Details: err.Error(),
+		})
+	}
+	if strings.Contains(err.Error(), "invalid or expired") {
+		return c.Status(401).JSON(ErrorResponse{
+			Error: "Access token is invalid or expired",
+			Code:  "INVALID_GITHUB_TOKEN",
+		})
+	}
+	if strings.Contains(err.Error(), "git provider") {
+		return c.Status(502).JSON(ErrorResponse{
+			Error: message + " from git provider",
+			Code:  "GITHUB_API_ERROR",
+		})
+	}
+	return c.Status(500).JSON(ErrorResponse{
+		Error: message,
+		Code:  "INTERNAL_ERROR",
+	})
+}
 
-	// Convert GitHub repositories to API response format
-	apiRepositories := make([]*Repository, len(repositories))
-	for i, repo := range repositories {
-		// Parse updated_at time and format it relative to now
-		updatedAt := "unknown"
-		if repo.UpdatedAt != "" {
-			if t, err := time.Parse(time.RFC3339, repo.UpdatedAt); err == nil {
-				updatedAt = formatRelativeTime(t)
-			}
-		}
-
-		apiRepositories[i] = &Repository{
-			ID:            strconv.FormatInt(repo.ID, 10),
-			Name:          repo.Name,
-			FullName:      repo.FullName,
-			Private:       repo.Private,
-			Description:   repo.Description,
-			Language:      repo.Language,
-			Stars:         repo.StarCount,
-			UpdatedAt:     updatedAt,
-			DefaultBranch: repo.DefaultBranch,
-			URL:           repo.HTMLURL,
+// toAPIRepository converts a provider repository to the API response format
+func toAPIRepository(repo *services.GitRepository) *Repository {
+	// Parse updated_at time and format it relative to now
+	updatedAt := "unknown"
+	if repo.UpdatedAt != "" {
+		if t, err := time.Parse(time.RFC3339, repo.UpdatedAt); err == nil {
+			updatedAt = formatRelativeTime(t)
 		}
 	}
 
-	return c.JSON(GetRepositoriesResponse{
-		Repositories: apiRepositories,
-		Total:        len(apiRepositories),
-		Page:         page,
-		PerPage:      perPage,
-		AccountID:    accountID,
-	})
+	return &Repository{
+		ID:            strconv.FormatInt(repo.ID, 10),
+		Name:          repo.Name,
+		FullName:      repo.FullName,
+		Private:       repo.Private,
+		Description:   repo.Description,
+		Language:      repo.Language,
+		Stars:         repo.StarCount,
+		UpdatedAt:     updatedAt,
+		DefaultBranch: repo.DefaultBranch,
+		URL:           repo.HTMLURL,
+	}
 }
 
 // formatRelativeTime formats a time.Time to a relative string like "2h", "1d", "3w"
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/services"
+	"github.com/gofiber/fiber/v3"
+)
+
+type RuntimeHandler struct {
+	reconcilerService *services.ReconcilerService
+}
+
+func NewRuntimeHandler(reconcilerService *services.ReconcilerService) *RuntimeHandler {
+	return &RuntimeHandler{
+		reconcilerService: reconcilerService,
+	}
+}
+
+type ListOrphanedStacksResponse struct {
+	Stacks []*models.OrphanedStack `json:"stacks"`
+	Total  int                     `json:"total"`
+}
+
+// RegisterRoutes registers all project runtime routes
+func (h *RuntimeHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/:id/projects/:projectId/runtime", h.GetProjectRuntime) // GET /api/v1/users/:id/projects/:projectId/runtime
+}
+
+// RegisterSystemRoutes registers the installation wide runtime routes. They list
+// every tenant's stacks, so the router must only let admins through.
+func (h *RuntimeHandler) RegisterSystemRoutes(router fiber.Router) {
+	router.Get("/orphaned-stacks", h.GetOrphanedStacks) // GET /api/v1/system/orphaned-stacks
+}
+
+// GetProjectRuntime returns the runtime status of a project and its replica counts
+func (h *RuntimeHandler) GetProjectRuntime(c fiber.Ctx) error {
+	userID := c.Params("id")
+	projectID := c.Params("projectId")
+
+	if userID == "" || projectID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Project ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	runtime, err := h.reconcilerService.GetProjectRuntime(c.RequestCtx(), userID, projectID)
+	if err != nil {
+		return projectLookupError(c, err, "Failed to get runtime status")
+	}
+
+	return c.JSON(runtime)
+}
+
+// GetOrphanedStacks lists the stacks still running for projects that no longer exist
+func (h *RuntimeHandler) GetOrphanedStacks(c fiber.Ctx) error {
+	stacks := h.reconcilerService.GetOrphanedStacks()
+	return c.JSON(ListOrphanedStacksResponse{
+		Stacks: stacks,
+		Total:  len(stacks),
+	})
+}
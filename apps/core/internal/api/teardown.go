@@ -0,0 +1,96 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/services"
+	"github.com/gofiber/fiber/v3"
+)
+
+type TeardownHandler struct {
+	teardownService *services.TeardownService
+}
+
+func NewTeardownHandler(teardownService *services.TeardownService) *TeardownHandler {
+	return &TeardownHandler{
+		teardownService: teardownService,
+	}
+}
+
+type TeardownResponse struct {
+	Teardown *models.Teardown `json:"teardown"`
+	Message  string           `json:"message,omitempty"`
+}
+
+type ListTeardownsResponse struct {
+	Teardowns []*models.Teardown `json:"teardowns"`
+	Total     int                `json:"total"`
+}
+
+// RegisterRoutes registers all teardown routes
+func (h *TeardownHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/:id/teardowns", h.GetTeardowns)            // GET /api/v1/users/:id/teardowns
+	router.Get("/:id/teardowns/:teardownId", h.GetTeardown) // GET /api/v1/users/:id/teardowns/:teardownId
+}
+
+// GetTeardowns lists the latest teardowns of a user's deleted projects
+func (h *TeardownHandler) GetTeardowns(c fiber.Ctx) error {
+	userID := c.Params("id")
+	if userID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID is required",
+			Code:  "MISSING_USER_ID",
+		})
+	}
+
+	teardowns, err := h.teardownService.GetTeardowns(c.RequestCtx(), userID)
+	if err != nil {
+		return c.Status(500).JSON(ErrorResponse{
+			Error: "Failed to get teardowns",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+
+	return c.JSON(ListTeardownsResponse{
+		Teardowns: teardowns,
+		Total:     len(teardowns),
+	})
+}
+
+// GetTeardown returns the progress of a teardown
+func (h *TeardownHandler) GetTeardown(c fiber.Ctx) error {
+	userID := c.Params("id")
+	teardownID := c.Params("teardownId")
+
+	if userID == "" || teardownID == "" {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "User ID and Teardown ID are required",
+			Code:  "MISSING_PARAMETERS",
+		})
+	}
+
+	teardown, err := h.teardownService.GetTeardown(c.RequestCtx(), userID, teardownID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.Status(404).JSON(ErrorResponse{
+				Error: "Teardown not found",
+				Code:  "TEARDOWN_NOT_FOUND",
+			})
+		}
+		if strings.Contains(err.Error(), "access denied") {
+			return c.Status(403).JSON(ErrorResponse{
+				Error: "Access denied",
+				Code:  "ACCESS_DENIED",
+			})
+		}
+		return c.Status(500).JSON(ErrorResponse{
+			Error: "Failed to get teardown",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+
+	return c.JSON(TeardownResponse{
+		Teardown: teardown,
+	})
+}
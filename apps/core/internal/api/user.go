@@ -48,6 +48,11 @@ type SearchUsersResponse struct {
 	Offset int            `json:"offset"`
 }
 
+type DeleteUserResponse struct {
+	Teardowns []*models.Teardown `json:"teardowns"`
+	Message   string             `json:"message"`
+}
+
 type MessageResponse struct {
 	Message string `json:"message"`
 }
@@ -245,7 +250,8 @@ func (h *UserHandler) DeleteUser(c fiber.Ctx) error {
 		})
 	}
 
-	err := h.userService.DeleteUser(c.RequestCtx(), userID)
+	removeVolumes := c.Query("remove_volumes") == "true" || c.Query("remove_volumes") == "1"
+	teardowns, err := h.userService.DeleteUser(c.RequestCtx(), userID, removeVolumes)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return c.Status(404).JSON(ErrorResponse{
@@ -259,8 +265,9 @@ func (h *UserHandler) DeleteUser(c fiber.Ctx) error {
 		})
 	}
 
-	return c.JSON(MessageResponse{
-		Message: "User deleted successfully",
+	return c.Status(202).JSON(DeleteUserResponse{
+		Teardowns: teardowns,
+		Message:   "User deleted, teardown of their projects queued",
 	})
 }
 
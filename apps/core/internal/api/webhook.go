@@ -0,0 +1,109 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"strings"
+
+	"github.com/dopeCape/kova/internal/services"
+	"github.com/gofiber/fiber/v3"
+)
+
+type WebhookHandler struct {
+	previewService      *services.PreviewService
+	installationService *services.InstallationService
+}
+
+func NewWebhookHandler(previewService *services.PreviewService, installationService *services.InstallationService) *WebhookHandler {
+	return &WebhookHandler{
+		previewService:      previewService,
+		installationService: installationService,
+	}
+}
+
+// RegisterRoutes registers webhook routes. These are public and authenticated by signature.
+func (h *WebhookHandler) RegisterRoutes(router fiber.Router) {
+	router.Post("/github", h.HandleGitHub) // POST /api/v1/webhooks/github
+}
+
+// HandleGitHub receives GitHub repository webhook deliveries
+func (h *WebhookHandler) HandleGitHub(c fiber.Ctx) error {
+	body := c.Body()
+
+	if !verifyGitHubSignature(h.previewService.WebhookSecret(), body, c.Get("X-Hub-Signature-256")) {
+		return c.Status(401).JSON(ErrorResponse{
+			Error: "Invalid webhook signature",
+			Code:  "INVALID_SIGNATURE",
+		})
+	}
+
+	event := c.Get("X-GitHub-Event")
+	switch event {
+	case "ping":
+		return c.JSON(fiber.Map{"message": "pong"})
+
+	case "pull_request":
+		var payload services.GitHubPullRequestEvent
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return c.Status(400).JSON(ErrorResponse{
+				Error: "Invalid webhook payload",
+				Code:  "INVALID_BODY",
+			})
+		}
+
+		log.Printf("🪝 pull_request %s for %s#%d", payload.Action, payload.Repository.FullName, payload.Number)
+		if err := h.previewService.HandlePullRequestEvent(c.RequestCtx(), &payload); err != nil {
+			log.Printf("❌ Failed to handle pull_request webhook: %v", err)
+			return c.Status(500).JSON(ErrorResponse{
+				Error: "Failed to handle webhook",
+				Code:  "INTERNAL_ERROR",
+			})
+		}
+		return c.JSON(fiber.Map{"message": "Webhook processed"})
+
+	case "installation":
+		var payload struct {
+			Action       string `json:"action"`
+			Installation struct {
+				ID int64 `json:"id"`
+			} `json:"installation"`
+		}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return c.Status(400).JSON(ErrorResponse{
+				Error: "Invalid webhook payload",
+				Code:  "INVALID_BODY",
+			})
+		}
+
+		if err := h.installationService.HandleInstallationEvent(c.RequestCtx(), payload.Action, payload.Installation.ID); err != nil {
+			log.Printf("❌ Failed to handle installation webhook: %v", err)
+			return c.Status(500).JSON(ErrorResponse{
+				Error: "Failed to handle webhook",
+				Code:  "INTERNAL_ERROR",
+			})
+		}
+		return c.JSON(fiber.Map{"message": "Webhook processed"})
+
+	default:
+		return c.JSON(fiber.Map{"message": "Event ignored"})
+	}
+}
+
+// verifyGitHubSignature checks the sha256 HMAC GitHub attaches to each delivery
+func verifyGitHubSignature(secret string, body []byte, signature string) bool {
+	if secret == "" || !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -0,0 +1,186 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/services"
+)
+
+const DEFAULT_API_URL = "http://localhost:8000/api/v1"
+
+// Client is a minimal Kova API client used by the CLI and TUI
+type Client struct {
+	baseURL     string
+	httpClient  *http.Client
+	accessToken string
+	user        *models.User
+}
+
+type apiError struct {
+	Error   string `json:"error"`
+	Code    string `json:"code"`
+	Details string `json:"details"`
+}
+
+type pollResponse struct {
+	Status  string          `json:"status"`
+	Account *models.Account `json:"account"`
+}
+
+func New(baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = DEFAULT_API_URL
+	}
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// User returns the logged in user, or nil before Login
+func (c *Client) User() *models.User {
+	return c.user
+}
+
+// Login authenticates with a username or email and password
+func (c *Client) Login(ctx context.Context, login, password string) error {
+	var resp struct {
+		User   *models.User        `json:"user"`
+		Tokens *services.TokenPair `json:"tokens"`
+	}
+	req := models.LoginRequest{Login: login, Password: password}
+	if _, err := c.do(ctx, "POST", "/auth/login", req, &resp); err != nil {
+		return fmt.Errorf("login failed: %w", err)
+	}
+	if resp.User == nil || resp.Tokens == nil {
+		return errors.New("login failed: unexpected response")
+	}
+
+	c.user = resp.User
+	c.accessToken = resp.Tokens.AccessToken
+	return nil
+}
+
+// StartDeviceFlow asks the server for a GitHub device code
+func (c *Client) StartDeviceFlow(ctx context.Context) (*services.GitHubDeviceCode, error) {
+	if c.user == nil {
+		return nil, errors.New("not logged in")
+	}
+
+	var deviceCode services.GitHubDeviceCode
+	if _, err := c.do(ctx, "POST", "/users/"+c.user.ID+"/accounts/device", nil, &deviceCode); err != nil {
+		return nil, err
+	}
+	return &deviceCode, nil
+}
+
+// PollDeviceFlow checks once whether the user approved the device code. It returns
+// services.ErrAuthorizationPending or services.ErrSlowDown while waiting.
+func (c *Client) PollDeviceFlow(ctx context.Context, deviceCode string) (*models.Account, error) {
+	if c.user == nil {
+		return nil, errors.New("not logged in")
+	}
+
+	var resp pollResponse
+	body := map[string]string{"device_code": deviceCode}
+	if _, err := c.do(ctx, "POST", "/users/"+c.user.ID+"/accounts/device/poll", body, &resp); err != nil {
+		return nil, err
+	}
+
+	switch resp.Status {
+	case "pending":
+		return nil, services.ErrAuthorizationPending
+	case "slow_down":
+		return nil, services.ErrSlowDown
+	case "linked":
+		return resp.Account, nil
+	default:
+		return nil, fmt.Errorf("unexpected device flow status: %s", resp.Status)
+	}
+}
+
+// WaitForDeviceFlow polls until the device code is approved, denied or expires
+func (c *Client) WaitForDeviceFlow(ctx context.Context, deviceCode *services.GitHubDeviceCode) (*models.Account, error) {
+	interval := time.Duration(deviceCode.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		account, err := c.PollDeviceFlow(ctx, deviceCode.DeviceCode)
+		if errors.Is(err, services.ErrAuthorizationPending) {
+			continue
+		}
+		if errors.Is(err, services.ErrSlowDown) {
+			// GitHub asks for an extra 5 seconds on every slow_down
+			interval += 5 * time.Second
+			continue
+		}
+		return account, err
+	}
+}
+
+func (c *Client) do(ctx context.Context, method, path string, payload, out interface{}) (int, error) {
+	var body *bytes.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal payload: %w", err)
+		}
+		body = bytes.NewReader(data)
+	} else {
+		body = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	if c.accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reach kova API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var apiErr apiError
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil || apiErr.Error == "" {
+			return resp.StatusCode, fmt.Errorf("kova API returned status %d", resp.StatusCode)
+		}
+		if apiErr.Details != "" {
+			return resp.StatusCode, fmt.Errorf("%s: %s", apiErr.Error, apiErr.Details)
+		}
+		return resp.StatusCode, errors.New(apiErr.Error)
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return resp.StatusCode, nil
+}
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"os"
+	"strings"
+
 	util "github.com/dopeCape/kova/internal/utils"
 )
 
@@ -15,12 +18,23 @@ type Config struct {
 	Server   ServerConfig
 	Database DatabaseConfig
 	Auth     AuthConfig
+	GitHub   GitHubConfig
+	TLS      TLSConfig
+	Docker   DockerConfig
+	Metrics  MetricsConfig
+	GC       GCConfig
 }
 
 type ServerConfig struct {
-	Port string
-	Host string
-	Env  Env
+	Port      string
+	Host      string
+	Env       Env
+	PublicURL string // Externally reachable base URL, used for webhook callbacks
+
+	// BaseDomain is the installation's own domain, set from the installer's domain.
+	// Projects created without a domain get a <project>-<user> subdomain of it, and
+	// custom domains can prove ownership with a CNAME to it instead of a TXT record.
+	BaseDomain string
 }
 
 type DatabaseConfig struct {
@@ -32,17 +46,83 @@ type DatabaseConfig struct {
 	SSLMode  string
 }
 
+// TLSConfig controls HTTPS for deployed apps. The installer enables it when the
+// installation has a domain, so Traefik can obtain certificates for it.
+type TLSConfig struct {
+	Enabled      bool
+	CertResolver string // Traefik ACME certificate resolver
+
+	// DynamicConfigDir is watched by Traefik's file provider. Custom project
+	// certificates are written there, so it must be shared with Traefik.
+	DynamicConfigDir string
+}
+
+type DockerConfig struct {
+	Host string // Engine API address in DOCKER_HOST form, the local socket when empty
+}
+
+// MetricsConfig controls the resource usage collector. Samples are kept per minute
+// for MinuteRetentionHours, and as hourly averages for RetentionDays.
+type MetricsConfig struct {
+	Enabled              bool
+	MinuteRetentionHours int
+	RetentionDays        int
+}
+
+// GCConfig controls the removal of old images and BuildKit cache. The last
+// KeepImages deployments of each project are kept so they can be rolled back to.
+type GCConfig struct {
+	Enabled           bool
+	IntervalHours     int
+	KeepImages        int
+	BuildCacheLimitGB int // BuildKit cache is pruned down to this size
+}
+
 type AuthConfig struct {
 	JWTSecret string
+
+	// AdminUserIDs may use the installation wide /system routes. Nobody can
+	// when it is empty.
+	AdminUserIDs []string
+}
+
+type GitHubConfig struct {
+	WebhookSecret string
+
+	// GitHub App credentials, optional. When set, users can link app
+	// installations instead of personal access tokens.
+	AppID             int64
+	AppSlug           string
+	AppPrivateKey     string
+	AppPrivateKeyPath string
+
+	// OAuth app (or GitHub App user authorization) credentials for
+	// linking accounts through the web and device flows
+	ClientID     string
+	ClientSecret string
+}
+
+// AppPrivateKeyPEM returns the GitHub App private key, reading it from
+// AppPrivateKeyPath when it isn't provided inline
+func (c GitHubConfig) AppPrivateKeyPEM() ([]byte, error) {
+	if c.AppPrivateKey != "" {
+		return []byte(strings.ReplaceAll(c.AppPrivateKey, `\n`, "\n")), nil
+	}
+	if c.AppPrivateKeyPath == "" {
+		return nil, nil
+	}
+	return os.ReadFile(c.AppPrivateKeyPath)
 }
 
 func Load() *Config {
 	env := Env(util.GetEnv("ENVIRONMENT", string(DEVELOPMENT)))
 	return &Config{
 		Server: ServerConfig{
-			Port: util.GetEnv("PORT", "8000"),
-			Host: util.GetEnv("HOST", "localhost"),
-			Env:  env,
+			Port:       util.GetEnv("PORT", "8000"),
+			Host:       util.GetEnv("HOST", "localhost"),
+			Env:        env,
+			PublicURL:  util.GetEnv("PUBLIC_URL", ""),
+			BaseDomain: util.GetEnv("BASE_DOMAIN", ""),
 		},
 		Database: DatabaseConfig{
 			Host:     util.GetEnv("DB_HOST", "localhost"),
@@ -53,7 +133,36 @@ func Load() *Config {
 			SSLMode:  util.GetEnv("DB_SSLMODE", "disable"),
 		},
 		Auth: AuthConfig{
-			JWTSecret: util.GetEnv("JWT_SECRET", "849cff22c983fb7a0ee113339c6486893c83f1e5d485ef2a797b43f802b21709"),
+			JWTSecret:    util.GetEnv("JWT_SECRET", "849cff22c983fb7a0ee113339c6486893c83f1e5d485ef2a797b43f802b21709"),
+			AdminUserIDs: util.GetEnvList("ADMIN_USER_IDS"),
+		},
+		GitHub: GitHubConfig{
+			WebhookSecret:     util.GetEnv("GITHUB_WEBHOOK_SECRET", ""),
+			AppID:             int64(util.GetEnvInt("GITHUB_APP_ID", 0)),
+			AppSlug:           util.GetEnv("GITHUB_APP_SLUG", ""),
+			AppPrivateKey:     util.GetEnv("GITHUB_APP_PRIVATE_KEY", ""),
+			AppPrivateKeyPath: util.GetEnv("GITHUB_APP_PRIVATE_KEY_PATH", ""),
+			ClientID:          util.GetEnv("GITHUB_CLIENT_ID", ""),
+			ClientSecret:      util.GetEnv("GITHUB_CLIENT_SECRET", ""),
+		},
+		TLS: TLSConfig{
+			Enabled:          util.GetEnvBool("TLS_ENABLED", false),
+			CertResolver:     util.GetEnv("TLS_CERT_RESOLVER", "letsencrypt"),
+			DynamicConfigDir: util.GetEnv("TRAEFIK_DYNAMIC_DIR", "/data/dynamic"),
+		},
+		Docker: DockerConfig{
+			Host: util.GetEnv("DOCKER_HOST", ""),
+		},
+		Metrics: MetricsConfig{
+			Enabled:              util.GetEnvBool("METRICS_ENABLED", true),
+			MinuteRetentionHours: util.GetEnvInt("METRICS_MINUTE_RETENTION_HOURS", 48),
+			RetentionDays:        util.GetEnvInt("METRICS_RETENTION_DAYS", 30),
+		},
+		GC: GCConfig{
+			Enabled:           util.GetEnvBool("GC_ENABLED", true),
+			IntervalHours:     util.GetEnvInt("GC_INTERVAL_HOURS", 24),
+			KeepImages:        util.GetEnvInt("GC_KEEP_IMAGES", 3),
+			BuildCacheLimitGB: util.GetEnvInt("GC_BUILD_CACHE_LIMIT_GB", 10),
 		},
 	}
 }
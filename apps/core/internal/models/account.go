@@ -5,14 +5,27 @@ import (
 )
 
 type Account struct {
-	ID             string    `json:"id"`
-	UserID         string    `json:"user_id"`
-	GithubUsername string    `json:"github_username"`
-	GithubID       int64     `json:"github_id"`
-	AvatarURL      string    `json:"avatar_url"`
-	AccessToken    string    `json:"-"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	ID                    string     `json:"id"`
+	UserID                string     `json:"user_id"`
+	GithubUsername        string     `json:"github_username"`
+	GithubID              int64      `json:"github_id"`
+	Provider              string     `json:"provider"`
+	ProviderURL           string     `json:"provider_url"`
+	AvatarURL             string     `json:"avatar_url"`
+	AccessToken           string     `json:"-"`
+	RefreshToken          string     `json:"-"`
+	TokenExpiresAt        *time.Time `json:"token_expires_at,omitempty"`
+	RefreshTokenExpiresAt *time.Time `json:"-"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+}
+
+// OAuthToken is a GitHub token issued through the OAuth web or device flow
+type OAuthToken struct {
+	AccessToken           string
+	RefreshToken          string
+	ExpiresAt             *time.Time
+	RefreshTokenExpiresAt *time.Time
 }
 
 type CreateAccountRequest struct {
@@ -42,6 +55,8 @@ type AccountWithUser struct {
 	UserID         string    `json:"user_id"`
 	GithubUsername string    `json:"github_username"`
 	GithubID       int64     `json:"github_id"`
+	Provider       string    `json:"provider"`
+	ProviderURL    string    `json:"provider_url"`
 	AvatarURL      string    `json:"avatar_url"`
 	AccessToken    string    `json:"-"`
 	CreatedAt      time.Time `json:"created_at"`
@@ -57,7 +72,10 @@ func (a *Account) ToPublic() *Account {
 		UserID:         a.UserID,
 		GithubUsername: a.GithubUsername,
 		GithubID:       a.GithubID,
+		Provider:       a.Provider,
+		ProviderURL:    a.ProviderURL,
 		AvatarURL:      a.AvatarURL,
+		TokenExpiresAt: a.TokenExpiresAt,
 		CreatedAt:      a.CreatedAt,
 		UpdatedAt:      a.UpdatedAt,
 	}
@@ -70,6 +88,8 @@ func (awu *AccountWithUser) ToPublic() *AccountWithUser {
 		UserID:         awu.UserID,
 		GithubUsername: awu.GithubUsername,
 		GithubID:       awu.GithubID,
+		Provider:       awu.Provider,
+		ProviderURL:    awu.ProviderURL,
 		AvatarURL:      awu.AvatarURL,
 		CreatedAt:      awu.CreatedAt,
 		UpdatedAt:      awu.UpdatedAt,
@@ -83,8 +103,36 @@ func (a *Account) HasValidToken() bool {
 	return len(a.AccessToken) >= 40
 }
 
+// CanRefresh checks if the account's token can be renewed with a refresh token
+func (a *Account) CanRefresh() bool {
+	if a.RefreshToken == "" {
+		return false
+	}
+	return a.RefreshTokenExpiresAt == nil || a.RefreshTokenExpiresAt.After(time.Now())
+}
+
+// TokenExpiresWithin checks if the access token expires within the given window.
+// Tokens without an expiry (personal access tokens) never do.
+func (a *Account) TokenExpiresWithin(window time.Duration) bool {
+	return a.TokenExpiresAt != nil && time.Until(*a.TokenExpiresAt) < window
+}
+
 // IsOwnedBy checks if the account belongs to the specified user
 func (a *Account) IsOwnedBy(userID string) bool {
 	return a.UserID == userID
 }
 
+// OAuthState is a pending OAuth authorization. The state value is sent to
+// GitHub and must come back unchanged on the callback.
+type OAuthState struct {
+	State      string    `json:"-"`
+	UserID     string    `json:"user_id"`
+	RedirectTo string    `json:"redirect_to,omitempty"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// IsExpired checks if the authorization window has passed
+func (s *OAuthState) IsExpired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
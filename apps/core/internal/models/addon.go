@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+const (
+	ADDON_TYPE_POSTGRES = "postgres"
+	ADDON_TYPE_REDIS    = "redis"
+	ADDON_TYPE_MYSQL    = "mysql"
+)
+
+const (
+	ADDON_STATUS_PROVISIONING = "provisioning"
+	ADDON_STATUS_RUNNING      = "running"
+	ADDON_STATUS_FAILED       = "failed"
+	ADDON_STATUS_DELETING     = "deleting"
+)
+
+// Addon is a managed database a user can attach to their projects
+type Addon struct {
+	ID           string    `json:"id"`
+	UserID       string    `json:"user_id"`
+	Name         string    `json:"name"`
+	Type         string    `json:"type"`
+	Version      string    `json:"version"`
+	Status       string    `json:"status"`
+	Error        string    `json:"error,omitempty"`
+	Host         string    `json:"host"` // Reachable from attached projects only
+	Port         int       `json:"port"`
+	Username     string    `json:"username,omitempty"`
+	Password     string    `json:"-"`
+	DatabaseName string    `json:"database_name,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	Attachments []*AddonAttachment `json:"attachments,omitempty"`
+}
+
+// AddonAttachment injects an add-on's URL into a project's runtime environment
+type AddonAttachment struct {
+	AddonID   string    `json:"addon_id"`
+	ProjectID string    `json:"project_id"`
+	EnvVar    string    `json:"env_var"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateAddonRequest struct {
+	Name    string `json:"name" validate:"required,min=1,max=50"`
+	Type    string `json:"type" validate:"required,oneof=postgres redis mysql"`
+	Version string `json:"version" validate:"omitempty,max=20"` // Image tag, the latest supported major by default
+}
+
+type AttachAddonRequest struct {
+	ProjectID string `json:"project_id" validate:"required"`
+	EnvVar    string `json:"env_var" validate:"omitempty,max=100"` // DATABASE_URL or REDIS_URL by default
+}
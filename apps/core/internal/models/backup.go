@@ -0,0 +1,115 @@
+package models
+
+import "time"
+
+// What a backup policy backs up
+const (
+	BACKUP_TARGET_ADDON  = "addon"  // The database of an add-on, dumped with its own tools
+	BACKUP_TARGET_VOLUME = "volume" // A volume of a project, as a tar snapshot
+)
+
+const (
+	BACKUP_DESTINATION_LOCAL = "local"
+	BACKUP_DESTINATION_S3    = "s3"
+)
+
+const (
+	BACKUP_OPERATION_BACKUP  = "backup"
+	BACKUP_OPERATION_RESTORE = "restore"
+)
+
+const (
+	BACKUP_STATUS_RUNNING   = "running"
+	BACKUP_STATUS_SUCCEEDED = "succeeded"
+	BACKUP_STATUS_FAILED    = "failed"
+)
+
+const (
+	BACKUP_TRIGGER_SCHEDULE = "schedule"
+	BACKUP_TRIGGER_MANUAL   = "manual"
+)
+
+const DEFAULT_BACKUP_RETENTION = 7
+
+// BackupDestination is where the backups of a policy are stored: a directory
+// under the installation's backup directory, or a bucket of an S3-compatible
+// store such as AWS S3 or MinIO
+type BackupDestination struct {
+	Type string `json:"type" validate:"required,oneof=local s3"`
+	Path string `json:"path,omitempty" validate:"omitempty,max=255"` // Relative to the backup directory, for local destinations
+
+	Endpoint        string `json:"endpoint,omitempty" validate:"omitempty,url,max=255"` // Like https://s3.us-east-1.amazonaws.com or http://minio:9000
+	Region          string `json:"region,omitempty" validate:"omitempty,max=50"`
+	Bucket          string `json:"bucket,omitempty" validate:"omitempty,max=63"`
+	Prefix          string `json:"prefix,omitempty" validate:"omitempty,max=255"`
+	AccessKeyID     string `json:"access_key_id,omitempty" validate:"omitempty,max=255"`
+	SecretAccessKey string `json:"secret_access_key,omitempty" validate:"omitempty,max=255"`
+}
+
+// BackupPolicy backs up an add-on or a project volume on a schedule, keeping the
+// latest Retention backups
+type BackupPolicy struct {
+	ID              string            `json:"id"`
+	UserID          string            `json:"user_id"`
+	Name            string            `json:"name"`
+	TargetType      string            `json:"target_type"`
+	AddonID         string            `json:"addon_id,omitempty"`
+	ProjectID       string            `json:"project_id,omitempty"`
+	VolumeName      string            `json:"volume_name,omitempty"`
+	Schedule        string            `json:"schedule"` // Cron expression in UTC, empty for on-demand backups only
+	Retention       int               `json:"retention"`
+	Destination     BackupDestination `json:"destination"`
+	Enabled         bool              `json:"enabled"`
+	LastScheduledAt *time.Time        `json:"last_scheduled_at,omitempty"`
+	NextRunAt       *time.Time        `json:"next_run_at,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// Backup is one backup or restore of a policy's target
+type Backup struct {
+	ID           string     `json:"id"`
+	PolicyID     string     `json:"policy_id"`
+	Operation    string     `json:"operation"`
+	Trigger      string     `json:"trigger"`
+	Status       string     `json:"status"`
+	ObjectKey    string     `json:"object_key,omitempty"` // Where the backup is stored in the destination
+	SizeBytes    int64      `json:"size_bytes"`
+	RestoredFrom string     `json:"restored_from,omitempty"` // The backup a restore applied
+	Error        string     `json:"error,omitempty"`
+	StartedAt    time.Time  `json:"started_at"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+}
+
+type CreateBackupPolicyRequest struct {
+	Name        string            `json:"name" validate:"required,min=1,max=100"`
+	TargetType  string            `json:"target_type" validate:"required,oneof=addon volume"`
+	AddonID     string            `json:"addon_id"`
+	ProjectID   string            `json:"project_id"`
+	VolumeName  string            `json:"volume_name" validate:"omitempty,max=255"`
+	Schedule    string            `json:"schedule" validate:"omitempty,max=100"`
+	Retention   int               `json:"retention" validate:"omitempty,min=1,max=365"`
+	Destination BackupDestination `json:"destination"`
+	Enabled     *bool             `json:"enabled"`
+}
+
+// SetDefaults fills in the optional fields of a backup policy
+func (req *CreateBackupPolicyRequest) SetDefaults() {
+	if req.Retention == 0 {
+		req.Retention = DEFAULT_BACKUP_RETENTION
+	}
+	if req.Enabled == nil {
+		enabled := true
+		req.Enabled = &enabled
+	}
+}
+
+// UpdateBackupPolicyRequest changes the fields that are set. A schedule of "-"
+// removes the schedule, leaving on-demand backups only.
+type UpdateBackupPolicyRequest struct {
+	Name        string             `json:"name" validate:"omitempty,min=1,max=100"`
+	Schedule    string             `json:"schedule" validate:"omitempty,max=100"`
+	Retention   int                `json:"retention" validate:"omitempty,min=1,max=365"`
+	Destination *BackupDestination `json:"destination"`
+	Enabled     *bool              `json:"enabled"`
+}
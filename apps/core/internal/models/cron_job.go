@@ -0,0 +1,89 @@
+package models
+
+import "time"
+
+// What a cron job does when it's due while a previous run is still going
+const (
+	CRON_CONCURRENCY_ALLOW   = "allow"   // Start another run alongside it
+	CRON_CONCURRENCY_FORBID  = "forbid"  // Skip this run
+	CRON_CONCURRENCY_REPLACE = "replace" // Cancel the running one and start over
+)
+
+const (
+	CRON_RUN_RUNNING   = "running"
+	CRON_RUN_SUCCEEDED = "succeeded"
+	CRON_RUN_FAILED    = "failed"
+	CRON_RUN_TIMED_OUT = "timed_out"
+	CRON_RUN_SKIPPED   = "skipped" // Due while another run was going, under the forbid policy
+	CRON_RUN_CANCELED  = "canceled"
+)
+
+const (
+	CRON_TRIGGER_SCHEDULE = "schedule"
+	CRON_TRIGGER_MANUAL   = "manual"
+)
+
+const DEFAULT_CRON_TIMEOUT_SECONDS = 3600
+
+// CronJob runs a command on a schedule, in a one-off container from the project's
+// current image
+type CronJob struct {
+	ID                string     `json:"id"`
+	ProjectID         string     `json:"project_id"`
+	Name              string     `json:"name"`
+	Schedule          string     `json:"schedule"` // Five field cron expression, or a macro like @daily, in UTC
+	Command           string     `json:"command"`  // Run with /bin/sh -c
+	TimeoutSeconds    int        `json:"timeout_seconds"`
+	ConcurrencyPolicy string     `json:"concurrency_policy"`
+	Enabled           bool       `json:"enabled"`
+	LastScheduledAt   *time.Time `json:"last_scheduled_at,omitempty"`
+	NextRunAt         *time.Time `json:"next_run_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// CronJobRun is one run of a cron job
+type CronJobRun struct {
+	ID         string     `json:"id"`
+	CronJobID  string     `json:"cron_job_id"`
+	ProjectID  string     `json:"project_id"`
+	Trigger    string     `json:"trigger"`
+	Status     string     `json:"status"`
+	ExitCode   *int       `json:"exit_code,omitempty"`
+	Logs       string     `json:"logs,omitempty"` // Tail of the combined stdout and stderr
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+type CreateCronJobRequest struct {
+	Name              string `json:"name" validate:"required,min=1,max=100"`
+	Schedule          string `json:"schedule" validate:"required,max=100"`
+	Command           string `json:"command" validate:"required,max=4096"`
+	TimeoutSeconds    int    `json:"timeout_seconds" validate:"omitempty,min=1,max=86400"`
+	ConcurrencyPolicy string `json:"concurrency_policy" validate:"omitempty,oneof=allow forbid replace"`
+	Enabled           *bool  `json:"enabled"`
+}
+
+// SetDefaults fills in the optional fields of a cron job
+func (req *CreateCronJobRequest) SetDefaults() {
+	if req.TimeoutSeconds == 0 {
+		req.TimeoutSeconds = DEFAULT_CRON_TIMEOUT_SECONDS
+	}
+	if req.ConcurrencyPolicy == "" {
+		req.ConcurrencyPolicy = CRON_CONCURRENCY_FORBID
+	}
+	if req.Enabled == nil {
+		enabled := true
+		req.Enabled = &enabled
+	}
+}
+
+// UpdateCronJobRequest changes the fields that are set
+type UpdateCronJobRequest struct {
+	Name              string `json:"name" validate:"omitempty,min=1,max=100"`
+	Schedule          string `json:"schedule" validate:"omitempty,max=100"`
+	Command           string `json:"command" validate:"omitempty,max=4096"`
+	TimeoutSeconds    int    `json:"timeout_seconds" validate:"omitempty,min=1,max=86400"`
+	ConcurrencyPolicy string `json:"concurrency_policy" validate:"omitempty,oneof=allow forbid replace"`
+	Enabled           *bool  `json:"enabled"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Actions recorded on a project's deployment timeline
+const (
+	DEPLOYMENT_ACTION_DEPLOY   = "deploy"
+	DEPLOYMENT_ACTION_START    = "start"
+	DEPLOYMENT_ACTION_STOP     = "stop"
+	DEPLOYMENT_ACTION_RESTART  = "restart"
+	DEPLOYMENT_ACTION_ARCHIVE  = "archive"
+	DEPLOYMENT_ACTION_ACTIVATE = "activate"
+)
+
+const (
+	DEPLOYMENT_EVENT_SUCCEEDED = "succeeded"
+	DEPLOYMENT_EVENT_FAILED    = "failed"
+)
+
+// DeploymentEvent is one entry of a project's deployment timeline
+type DeploymentEvent struct {
+	ID         string    `json:"id"`
+	ProjectID  string    `json:"project_id"`
+	UserID     string    `json:"user_id,omitempty"` // Who triggered it, empty once the user is deleted
+	Action     string    `json:"action"`
+	Status     string    `json:"status"`
+	Deployment string    `json:"deployment,omitempty"` // Deployment started by a deploy, as attributed in runtime logs
+	Message    string    `json:"message,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewDeploymentEvent creates a timeline entry for the outcome of an action
+func NewDeploymentEvent(projectID, userID, action string, err error) *DeploymentEvent {
+	event := &DeploymentEvent{
+		ProjectID: projectID,
+		UserID:    userID,
+		Action:    action,
+		Status:    DEPLOYMENT_EVENT_SUCCEEDED,
+	}
+	if err != nil {
+		event.Status = DEPLOYMENT_EVENT_FAILED
+		event.Message = err.Error()
+	}
+	return event
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	DOMAIN_STATUS_PENDING  = "pending"
+	DOMAIN_STATUS_VERIFIED = "verified"
+	DOMAIN_STATUS_FAILED   = "failed"
+
+	// DOMAIN_CHALLENGE_PREFIX is the label of the TXT record proving ownership of a domain
+	DOMAIN_CHALLENGE_PREFIX = "_kova-challenge"
+)
+
+type ProjectDomain struct {
+	ID                string           `json:"id"`
+	ProjectID         string           `json:"project_id"`
+	Domain            string           `json:"domain"`
+	VerificationToken string           `json:"-"`
+	Status            string           `json:"status"`
+	VerificationError string           `json:"verification_error,omitempty"`
+	VerifiedAt        *time.Time       `json:"verified_at,omitempty"`
+	LastCheckedAt     *time.Time       `json:"last_checked_at,omitempty"`
+	DNS               *DNSInstructions `json:"dns,omitempty"` // Set while the domain still needs verifying
+	CreatedAt         time.Time        `json:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at"`
+}
+
+type AddDomainRequest struct {
+	Domain string `json:"domain" validate:"required,fqdn,max=253"`
+}
+
+// DNSInstructions describes the records that prove ownership of a domain. Either
+// record is enough; the CNAME is only offered when the installation has a base domain.
+type DNSInstructions struct {
+	TXTName    string `json:"txt_name"`
+	TXTValue   string `json:"txt_value"`
+	CNAMEName  string `json:"cname_name,omitempty"`
+	CNAMEValue string `json:"cname_value,omitempty"`
+}
+
+// IsVerified checks if ownership of the domain has been proven
+func (d *ProjectDomain) IsVerified() bool {
+	return d.Status == DOMAIN_STATUS_VERIFIED
+}
+
+// ChallengeName returns the TXT record name checked for the verification token
+func (d *ProjectDomain) ChallengeName() string {
+	return DOMAIN_CHALLENGE_PREFIX + "." + d.Domain
+}
+
+// ChallengeValue returns the TXT record value expected at ChallengeName
+func (d *ProjectDomain) ChallengeValue() string {
+	return "kova-verification=" + d.VerificationToken
+}
+
+// NormalizeDomain lowercases a host name and strips surrounding whitespace and the trailing root dot
+func NormalizeDomain(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Why an image was removed by garbage collection
+const (
+	GC_REASON_PROJECT_DELETED = "project_deleted"
+	GC_REASON_SUPERSEDED      = "superseded" // Older than the deployments kept for rollback
+	GC_REASON_DANGLING        = "dangling"   // Untagged, left behind by a rebuild
+)
+
+// RemovedImage is an image tag removed, or to be removed in a dry run
+type RemovedImage struct {
+	Reference string `json:"reference"` // repository:tag, or the image ID when untagged
+	ProjectID string `json:"project_id,omitempty"`
+	Reason    string `json:"reason"`
+	SizeBytes int64  `json:"size_bytes"` // Zero when other tags keep the image
+}
+
+// GCReport describes one garbage collection run
+type GCReport struct {
+	DryRun                   bool            `json:"dry_run"`
+	StartedAt                time.Time       `json:"started_at"`
+	FinishedAt               *time.Time      `json:"finished_at,omitempty"`
+	Images                   []*RemovedImage `json:"images"`
+	ImagesReclaimedBytes     int64           `json:"images_reclaimed_bytes"`
+	BuildCacheBytes          int64           `json:"build_cache_bytes"` // Before pruning
+	BuildCacheLimitBytes     int64           `json:"build_cache_limit_bytes"`
+	BuildCacheReclaimedBytes int64           `json:"build_cache_reclaimed_bytes"`
+	ReclaimedBytes           int64           `json:"reclaimed_bytes"`
+	Errors                   []string        `json:"errors"`
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	GIT_PROVIDER_GITHUB = "github"
+	GIT_PROVIDER_GITLAB = "gitlab"
+	GIT_PROVIDER_GITEA  = "gitea"
+
+	// GIT_PROVIDER_GIT is a plain git remote with no linked account. It is
+	// cloned anonymously over HTTPS or with the project's deploy key over SSH.
+	GIT_PROVIDER_GIT = "git"
+)
+
+// scpLikeURL matches scp-style SSH remotes such as git@example.com:owner/repo.git
+var scpLikeURL = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:[^/].*$`)
+
+// DefaultProviderURL returns the hosted instance of a provider. Gitea has no
+// canonical host, so self-hosted servers must always pass their URL.
+func DefaultProviderURL(provider string) string {
+	switch provider {
+	case GIT_PROVIDER_GITHUB:
+		return "https://github.com"
+	case GIT_PROVIDER_GITLAB:
+		return "https://gitlab.com"
+	default:
+		return ""
+	}
+}
+
+// NormalizeProviderURL fills in the default instance and strips trailing slashes
+func NormalizeProviderURL(provider, providerURL string) string {
+	if providerURL == "" {
+		providerURL = DefaultProviderURL(provider)
+	}
+	return strings.TrimRight(providerURL, "/")
+}
+
+// IsGitURL reports whether repoURL is a remote git can clone: http(s), ssh, git or scp-style
+func IsGitURL(repoURL string) bool {
+	if scpLikeURL.MatchString(repoURL) {
+		return true
+	}
+
+	parsed, err := url.Parse(repoURL)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+	switch parsed.Scheme {
+	case "http", "https", "ssh", "git":
+		return strings.Trim(parsed.Path, "/") != ""
+	default:
+		return false
+	}
+}
+
+// IsSSHGitURL reports whether repoURL is cloned over SSH
+func IsSSHGitURL(repoURL string) bool {
+	return scpLikeURL.MatchString(repoURL) || strings.HasPrefix(repoURL, "ssh://")
+}
+
+// RepoFullNameFromURL returns the owner/repo path of a git remote, e.g.
+// "owner/repo" for git@example.com:owner/repo.git
+func RepoFullNameFromURL(repoURL string) string {
+	var repoPath string
+	if scpLikeURL.MatchString(repoURL) {
+		repoPath = repoURL[strings.Index(repoURL, ":")+1:]
+	} else if parsed, err := url.Parse(repoURL); err == nil {
+		repoPath = parsed.Path
+	}
+	return strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+}
+
+// RepoNameFromURL returns the last path element of a git remote
+func RepoNameFromURL(repoURL string) string {
+	return path.Base(RepoFullNameFromURL(repoURL))
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+)
+
+// GitHubInstallation is an installation of the Kova GitHub App on a user or organization
+type GitHubInstallation struct {
+	ID             string     `json:"id"`
+	UserID         string     `json:"user_id"`
+	InstallationID int64      `json:"installation_id"`
+	AccountLogin   string     `json:"account_login"`
+	AccountType    string     `json:"account_type"`
+	SuspendedAt    *time.Time `json:"suspended_at,omitempty"` // Set while the installation is suspended on GitHub
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+type CreateGitHubInstallationRequest struct {
+	InstallationID int64  `json:"installation_id" validate:"required,min=1"`
+	State          string `json:"state" validate:"required,max=128"` // The state GitHub passes back to the setup URL
+}
+
+// IsOwnedBy checks if the installation belongs to the specified user
+func (i *GitHubInstallation) IsOwnedBy(userID string) bool {
+	return i.UserID == userID
+}
+
+// IsSuspended checks if the installation is suspended on GitHub
+func (i *GitHubInstallation) IsSuspended() bool {
+	return i.SuspendedAt != nil
+}
+
+// IsOrganization checks if the app is installed on an organization rather than a personal account
+func (i *GitHubInstallation) IsOrganization() bool {
+	return i.AccountType == "Organization"
+}
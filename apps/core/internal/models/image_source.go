@@ -0,0 +1,67 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	PROJECT_SOURCE_GIT   = "git"   // Cloned from a repository and built with railpack
+	PROJECT_SOURCE_IMAGE = "image" // Built elsewhere and pulled from a registry
+)
+
+// imageReferencePattern matches image references like nginx, ghcr.io/org/app:1.2
+// or registry.example.com:5000/app@sha256:<digest>
+var imageReferencePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9._-]*[a-z0-9])?(:[0-9]+)?(/[a-z0-9]([a-z0-9._-]*[a-z0-9])?)*(:[A-Za-z0-9_][A-Za-z0-9_.-]{0,127})?(@sha256:[a-f0-9]{64})?$`)
+
+var imageTagPattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+
+// IsImageReference reports whether ref is a valid image reference
+func IsImageReference(ref string) bool {
+	return len(ref) <= 512 && imageReferencePattern.MatchString(ref)
+}
+
+// IsImageTag reports whether tag is a valid image tag
+func IsImageTag(tag string) bool {
+	return imageTagPattern.MatchString(tag)
+}
+
+// ImageRepository returns an image reference without its tag and digest
+func ImageRepository(ref string) string {
+	ref, _, _ = strings.Cut(ref, "@")
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		ref = ref[:i]
+	}
+	return ref
+}
+
+// ImageWithTag returns an image reference pointing at another tag of the same image
+func ImageWithTag(ref, tag string) string {
+	return ImageRepository(ref) + ":" + tag
+}
+
+// ImageRegistry returns the registry host of an image reference, empty for Docker Hub
+func ImageRegistry(ref string) string {
+	host, _, found := strings.Cut(ref, "/")
+	if found && (strings.ContainsAny(host, ".:") || host == "localhost") {
+		return host
+	}
+	return ""
+}
+
+type UpdateImageSourceRequest struct {
+	Image            string `json:"image" validate:"required,max=512"`
+	RegistryUsername string `json:"registry_username" validate:"omitempty,max=255"`
+	RegistryPassword string `json:"registry_password" validate:"omitempty,max=4096"`     // Keeps the stored password when empty
+	ContainerPort    int    `json:"container_port" validate:"omitempty,min=1,max=65535"` // Empty uses the port the image exposes
+}
+
+type DeployProjectRequest struct {
+	Tag string `json:"tag" validate:"omitempty,max=128"` // Deploys this tag of an image project's image
+}
+
+// DeployHook lets CI deploy a project without a user session
+type DeployHook struct {
+	URL   string `json:"url"`
+	Token string `json:"token"` // Only shown when generated, send it as a bearer token
+}
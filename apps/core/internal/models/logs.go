@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+const (
+	LOG_STREAM_STDOUT = "stdout"
+	LOG_STREAM_STDERR = "stderr"
+
+	DEFAULT_LOG_TAIL = 100
+)
+
+// LogLine is a single line written by one replica of a deployed app
+type LogLine struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Replica    int       `json:"replica"`              // Swarm task slot, stable across restarts of the replica
+	TaskID     string    `json:"task_id"`              // Container task, changes when a replica is replaced
+	Node       string    `json:"node"`                 // Swarm node running the task
+	Deployment string    `json:"deployment,omitempty"` // Deployment that started the task, empty for apps deployed before it was recorded
+	Stream     string    `json:"stream"`
+	Message    string    `json:"message"`
+}
+
+// LogsRequest filters and tails the runtime logs of a project
+type LogsRequest struct {
+	Follow  bool   `json:"follow"`
+	Since   string `json:"since" validate:"omitempty,max=64"`   // RFC 3339 timestamp or a duration like 10m
+	Tail    int    `json:"tail" validate:"min=0,max=10000"`     // Lines per replica, 0 for all
+	Replica int    `json:"replica" validate:"min=0"`            // Only show this replica, 0 for all
+	Process string `json:"process" validate:"omitempty,max=30"` // Process type, the exposed one by default
+}
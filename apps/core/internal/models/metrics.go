@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+const (
+	METRICS_RESOLUTION_MINUTE = 60   // Resolution of raw samples, in seconds
+	METRICS_RESOLUTION_HOUR   = 3600 // Resolution of rolled up samples, in seconds
+)
+
+// MetricsRanges maps the ranges served by the metrics API to how far back they reach
+var MetricsRanges = map[string]time.Duration{
+	"1h":  time.Hour,
+	"6h":  6 * time.Hour,
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+}
+
+// MetricSample is the resource usage of one swarm service over a bucket of time,
+// summed over its replicas
+type MetricSample struct {
+	ProjectID         string    `json:"-"`
+	Service           string    `json:"-"`
+	ResolutionSeconds int       `json:"-"`
+	Timestamp         time.Time `json:"timestamp"`
+	CPUPercent        float64   `json:"cpu_percent"` // 100 is one fully used core
+	MemoryBytes       int64     `json:"memory_bytes"`
+	MemoryLimitBytes  int64     `json:"memory_limit_bytes"`
+	NetworkRxRate     float64   `json:"network_rx_bytes_per_second"`
+	NetworkTxRate     float64   `json:"network_tx_bytes_per_second"`
+	Restarts          int       `json:"restarts"` // Tasks that exited and were replaced during the bucket
+}
+
+// MetricSeries is the history of one swarm service of a project
+type MetricSeries struct {
+	Service string          `json:"service"`
+	Samples []*MetricSample `json:"samples"`
+}
+
+type ProjectMetrics struct {
+	ProjectID         string          `json:"project_id"`
+	Range             string          `json:"range"`
+	ResolutionSeconds int             `json:"resolution_seconds"`
+	Series            []*MetricSeries `json:"series"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+type Preview struct {
+	ID         string    `json:"id"`
+	ProjectID  string    `json:"project_id"`
+	PRNumber   int       `json:"pr_number"`
+	PRTitle    string    `json:"pr_title"`
+	HeadBranch string    `json:"head_branch"`
+	HeadSHA    string    `json:"head_sha"`
+	Domain     string    `json:"domain"`
+	Status     string    `json:"status"`
+	CommentID  int64     `json:"-"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// StackName returns the swarm stack (and image) name used for this preview
+func (p *Preview) StackName() string {
+	return fmt.Sprintf("%s-pr-%d", p.ProjectID, p.PRNumber)
+}
+
+// IsClosed checks if the preview has been torn down
+func (p *Preview) IsClosed() bool {
+	return p.Status == "closed"
+}
+
+// PreviewDomain derives the preview host for a pull request, e.g. pr-42.app.example.com
+func PreviewDomain(projectDomain string, prNumber int) string {
+	return fmt.Sprintf("pr-%d.%s", prNumber, projectDomain)
+}
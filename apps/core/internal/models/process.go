@@ -0,0 +1,38 @@
+package models
+
+// DEFAULT_PROCESS_NAME is the process of projects without process types, which
+// runs the image's own start command
+const DEFAULT_PROCESS_NAME = "app"
+
+const MAX_PROCESS_REPLICAS = 20
+
+// MAX_PROCESS_NAME_LENGTH keeps service names within swarm's 63 characters. The
+// longest is a preview's <project ID>-pr-<number>_<process>, which leaves 15 for
+// the process with pull request numbers of up to 7 digits.
+const MAX_PROCESS_NAME_LENGTH = 15
+
+// Process is a process type of a project, like a line of a Procfile. Each one
+// runs as its own swarm service from the project's image.
+type Process struct {
+	Name     string `json:"name"`
+	Command  string `json:"command,omitempty"` // Run with /bin/sh -c, empty for the image's start command
+	Replicas int    `json:"replicas"`
+	Exposed  bool   `json:"exposed"` // Routed through Traefik on the project's domains
+}
+
+type UpdateProcessesRequest struct {
+	// Empty falls back to the repository's Procfile
+	Processes []ProcessRequest `json:"processes" validate:"omitempty,max=10,dive"`
+}
+
+type ProcessRequest struct {
+	Name     string `json:"name" validate:"required,min=1,max=15"`
+	Command  string `json:"command" validate:"omitempty,max=4096"`
+	Replicas *int   `json:"replicas" validate:"omitempty,min=0,max=20"` // Defaults to 1
+	Exposed  bool   `json:"exposed"`
+}
+
+// DefaultProcesses returns the single exposed process of projects that define none
+func DefaultProcesses() []Process {
+	return []Process{{Name: DEFAULT_PROCESS_NAME, Replicas: 1, Exposed: true}}
+}
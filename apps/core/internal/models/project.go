@@ -1,6 +1,9 @@
 package models
 
 import (
+	"fmt"
+	"path"
+	"regexp"
 	"time"
 )
 
@@ -9,6 +12,19 @@ type EnvironmentVariable struct {
 	Value string `json:"value"`
 }
 
+// envKeyPattern matches the environment variable names a shell can reference
+var envKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateEnvVariables returns an error for the first variable with an invalid name
+func ValidateEnvVariables(variables []EnvironmentVariable) error {
+	for _, variable := range variables {
+		if !envKeyPattern.MatchString(variable.Key) {
+			return fmt.Errorf("invalid environment variable name %q", variable.Key)
+		}
+	}
+	return nil
+}
+
 type Project struct {
 	ID               string                `json:"id"`
 	Name             string                `json:"name"`
@@ -21,28 +37,78 @@ type Project struct {
 	Status           string                `json:"status"`
 	EnvVariables     []EnvironmentVariable `json:"env_variables"`
 	DeploymentStatus string                `json:"deployment_status"`
-	Domain           string                `json:"domain,omitempty"`
+	RuntimeStatus    string                `json:"runtime_status"`   // What the swarm is running, see RUNTIME_STATUS_*
+	Stopped          bool                  `json:"stopped"`          // Stopped by its owner, redeploys keep it scaled to zero until started
+	Domain           string                `json:"domain,omitempty"` // Primary domain, generated under the base domain when none is given
+	URL              string                `json:"url,omitempty"`    // Where the primary domain is served, set in API responses
 	Port             int                   `json:"port,omitempty"`
+	PreviewsEnabled  bool                  `json:"previews_enabled"`
+	PreviewEnvVars   []EnvironmentVariable `json:"preview_env_variables"`
+	WebhookID        int64                 `json:"-"`
+	InstallationID   int64                 `json:"installation_id,omitempty"`
+	AccountID        string                `json:"account_id,omitempty"`
+	Provider         string                `json:"provider"`
+	ProviderURL      string                `json:"provider_url"`
+	DeployPublicKey  string                `json:"deploy_public_key,omitempty"`
+	DeployPrivateKey string                `json:"-"`
+	ForceHTTPS       bool                  `json:"force_https"`
+	TLSCertificate   string                `json:"tls_certificate,omitempty"` // Custom certificate chain, served instead of an ACME certificate
+	TLSPrivateKey    string                `json:"-"`
+	RoutingPolicy    RoutingPolicy         `json:"routing_policy"`
+	Processes        []Process             `json:"processes"` // Configured process types, empty to use the repository's Procfile
+	Volumes          []Volume              `json:"volumes"`   // Mounted into every process
+	StaticSite       StaticSite            `json:"static_site"`
+	SourceType       string                `json:"source_type"`                 // See PROJECT_SOURCE_*
+	Image            string                `json:"image,omitempty"`             // Registry reference deployed by image projects
+	RegistryUsername string                `json:"registry_username,omitempty"` // Pulls the image when set
+	RegistryPassword string                `json:"-"`
+	ContainerPort    int                   `json:"container_port,omitempty"` // Routed to in image projects' containers, empty for the port the image exposes
+	DeployHookHash   string                `json:"-"`                        // SHA-256 of the deploy hook token, empty when the hook is off
+	DeployHook       bool                  `json:"deploy_hook"`              // Whether CI can deploy through the deploy hook
 	CreatedAt        time.Time             `json:"created_at"`
 	UpdatedAt        time.Time             `json:"updated_at"`
 }
 
 type CreateProjectRequest struct {
-	Name         string                `json:"name" validate:"required,min=1,max=50"`
-	Domain       string                `json:"domain" validate:"required,min=3"`
-	RepoID       int64                 `json:"repo_id" validate:"required,min=1"`
-	RepoName     string                `json:"repo_name" validate:"required,min=1,max=255"`
-	RepoFullName string                `json:"repo_full_name" validate:"required,min=1,max=255"`
-	RepoURL      string                `json:"repo_url" validate:"required,url"`
-	RepoBranch   string                `json:"repo_branch" validate:"omitempty,min=1,max=255"`
-	EnvVariables []EnvironmentVariable `json:"env_variables" validate:"omitempty,dive"`
+	Name           string                `json:"name" validate:"required,min=1,max=50"`
+	Domain         string                `json:"domain" validate:"omitempty,fqdn,max=253"` // Added pending ownership verification
+	RepoID         int64                 `json:"repo_id" validate:"omitempty,min=1"`
+	RepoName       string                `json:"repo_name" validate:"omitempty,min=1,max=255"`
+	RepoFullName   string                `json:"repo_full_name" validate:"omitempty,min=1,max=255"`
+	RepoURL        string                `json:"repo_url" validate:"required_unless=SourceType image"`
+	RepoBranch     string                `json:"repo_branch" validate:"omitempty,min=1,max=255"`
+	EnvVariables   []EnvironmentVariable `json:"env_variables" validate:"omitempty,dive"`
+	InstallationID int64                 `json:"installation_id" validate:"omitempty,min=1"` // GitHub App installation to clone with
+	AccountID      string                `json:"account_id" validate:"omitempty"`            // Linked account to clone with
+	Provider       string                `json:"provider" validate:"omitempty,oneof=github gitlab gitea git"`
+	ProviderURL    string                `json:"provider_url" validate:"omitempty,url"`
+
+	// Image projects deploy a prebuilt image instead of a repository
+	SourceType       string `json:"source_type" validate:"omitempty,oneof=git image"`
+	Image            string `json:"image" validate:"required_if=SourceType image,max=512"`
+	RegistryUsername string `json:"registry_username" validate:"required_with=RegistryPassword,max=255"`
+	RegistryPassword string `json:"registry_password" validate:"max=4096"`
+	ContainerPort    int    `json:"container_port" validate:"omitempty,min=1,max=65535"` // Empty uses the port the image exposes
 }
 
 type UpdateProjectRequest struct {
 	Name       string `json:"name" validate:"omitempty,min=1,max=50,alphanum_dash"`
 	RepoBranch string `json:"repo_branch" validate:"omitempty,min=1,max=255"`
 	Status     string `json:"status" validate:"omitempty,oneof=active inactive archived"`
-	Domain     string `json:"domain" validate:"omitempty,min=3"`
+	Domain     string `json:"domain" validate:"omitempty,fqdn,max=253"`
+}
+
+type UpdateTLSSettingsRequest struct {
+	ForceHTTPS *bool `json:"force_https"`
+	// Certificate and PrivateKey upload a custom PEM certificate and must be given together.
+	// The certificate may only cover verified domains of the project.
+	Certificate string `json:"certificate" validate:"required_with=PrivateKey"`
+	PrivateKey  string `json:"private_key" validate:"required_with=Certificate"`
+}
+
+type UpdatePreviewSettingsRequest struct {
+	Enabled      bool                  `json:"enabled"`
+	EnvVariables []EnvironmentVariable `json:"env_variables" validate:"omitempty,dive"`
 }
 
 type ProjectWithRepository struct {
@@ -67,6 +133,21 @@ func (p *Project) IsOwnedBy(userID string) bool {
 	return p.UserID == userID
 }
 
+// UsesDeployKey reports whether the project is cloned over SSH with its deploy key
+func (p *Project) UsesDeployKey() bool {
+	return p.Provider == GIT_PROVIDER_GIT && IsSSHGitURL(p.RepoURL)
+}
+
+// IsImage reports whether the project deploys a prebuilt image rather than a repository
+func (p *Project) IsImage() bool {
+	return p.SourceType == PROJECT_SOURCE_IMAGE
+}
+
+// HasCustomCertificate reports whether the project serves its own TLS certificate
+func (p *Project) HasCustomCertificate() bool {
+	return p.TLSCertificate != "" && p.TLSPrivateKey != ""
+}
+
 // IsActive checks if the project is in active status
 func (p *Project) IsActive() bool {
 	return p.Status == "active"
@@ -85,8 +166,28 @@ func (p *Project) ToPublic() *Project {
 		RepoBranch:       p.RepoBranch,
 		Status:           p.Status,
 		DeploymentStatus: p.DeploymentStatus,
+		RuntimeStatus:    p.RuntimeStatus,
+		Stopped:          p.Stopped,
 		Domain:           p.Domain,
 		Port:             p.Port,
+		PreviewsEnabled:  p.PreviewsEnabled,
+		PreviewEnvVars:   p.PreviewEnvVars,
+		InstallationID:   p.InstallationID,
+		AccountID:        p.AccountID,
+		Provider:         p.Provider,
+		ProviderURL:      p.ProviderURL,
+		DeployPublicKey:  p.DeployPublicKey,
+		ForceHTTPS:       p.ForceHTTPS,
+		TLSCertificate:   p.TLSCertificate,
+		RoutingPolicy:    p.RoutingPolicy.Public(),
+		Processes:        p.Processes,
+		Volumes:          p.Volumes,
+		StaticSite:       p.StaticSite,
+		SourceType:       p.SourceType,
+		Image:            p.Image,
+		RegistryUsername: p.RegistryUsername,
+		ContainerPort:    p.ContainerPort,
+		DeployHook:       p.DeployHookHash != "",
 		EnvVariables:     p.EnvVariables,
 		CreatedAt:        p.CreatedAt,
 		UpdatedAt:        p.UpdatedAt,
@@ -104,9 +205,55 @@ func (p *Project) ValidateStatus() bool {
 	return false
 }
 
+// MergeEnvVariables returns base with every key in overrides replaced or appended
+func MergeEnvVariables(base, overrides []EnvironmentVariable) []EnvironmentVariable {
+	merged := make([]EnvironmentVariable, 0, len(base)+len(overrides))
+	index := make(map[string]int, len(base))
+	for _, env := range base {
+		index[env.Key] = len(merged)
+		merged = append(merged, env)
+	}
+	for _, env := range overrides {
+		if i, ok := index[env.Key]; ok {
+			merged[i] = env
+			continue
+		}
+		index[env.Key] = len(merged)
+		merged = append(merged, env)
+	}
+	return merged
+}
+
 // SetDefaults sets default values for optional fields
 func (req *CreateProjectRequest) SetDefaults() {
-	if req.RepoBranch == "" {
-		req.RepoBranch = "main"
+	if req.SourceType == "" {
+		req.SourceType = PROJECT_SOURCE_GIT
+	}
+
+	// Image projects have no repository, they are listed by the image they deploy
+	if req.SourceType == PROJECT_SOURCE_IMAGE {
+		req.Provider = GIT_PROVIDER_GIT
+		req.ProviderURL = ""
+		req.RepoURL = ""
+		req.RepoBranch = ""
+		req.RepoFullName = ImageRepository(req.Image)
+		req.RepoName = path.Base(req.RepoFullName)
+		return
+	}
+
+	if req.Provider == "" {
+		req.Provider = GIT_PROVIDER_GITHUB
+	}
+	req.ProviderURL = NormalizeProviderURL(req.Provider, req.ProviderURL)
+
+	// Plain git remotes carry their own location, so derive the repository names from it
+	if req.Provider == GIT_PROVIDER_GIT {
+		req.ProviderURL = ""
+		if req.RepoFullName == "" {
+			req.RepoFullName = RepoFullNameFromURL(req.RepoURL)
+		}
+		if req.RepoName == "" {
+			req.RepoName = RepoNameFromURL(req.RepoURL)
+		}
 	}
 }
@@ -0,0 +1,54 @@
+package models
+
+const (
+	REDIRECT_WWW_TO_APEX = "www_to_apex"
+	REDIRECT_APEX_TO_WWW = "apex_to_www"
+)
+
+// RoutingPolicy holds the Traefik middlewares applied in front of a project's app
+// and its previews
+type RoutingPolicy struct {
+	BasicAuth   []BasicAuthUser   `json:"basic_auth,omitempty"`
+	IPAllowList []string          `json:"ip_allowlist,omitempty"` // IPs or CIDR ranges allowed to reach the app
+	Headers     map[string]string `json:"headers,omitempty"`      // Custom response headers
+	RateLimit   *RateLimit        `json:"rate_limit,omitempty"`
+	Redirect    string            `json:"redirect,omitempty"` // www_to_apex or apex_to_www
+}
+
+type BasicAuthUser struct {
+	Username     string `json:"username"`
+	PasswordHash string `json:"password_hash,omitempty"` // bcrypt, never returned by the API
+}
+
+type RateLimit struct {
+	Average int `json:"average" validate:"required,min=1"` // Requests per second, averaged
+	Burst   int `json:"burst" validate:"omitempty,min=1"`  // Requests allowed above the average
+}
+
+type UpdateRoutingPolicyRequest struct {
+	BasicAuth   []BasicAuthCredentials `json:"basic_auth" validate:"omitempty,dive"`
+	IPAllowList []string               `json:"ip_allowlist" validate:"omitempty,dive,cidr|ip"`
+	Headers     map[string]string      `json:"headers" validate:"omitempty,dive,keys,required,max=100,endkeys,max=1000"`
+	RateLimit   *RateLimit             `json:"rate_limit" validate:"omitempty"`
+	Redirect    string                 `json:"redirect" validate:"omitempty,oneof=www_to_apex apex_to_www"`
+}
+
+type BasicAuthCredentials struct {
+	Username string `json:"username" validate:"required,min=1,max=100,excludesall=:0x2C"` // 0x2C is a comma, which would split the htpasswd users list
+	Password string `json:"password" validate:"omitempty,min=8,max=72"`                   // Empty keeps the stored password of an existing user
+}
+
+// IsEmpty checks if the policy adds no middlewares
+func (p RoutingPolicy) IsEmpty() bool {
+	return len(p.BasicAuth) == 0 && len(p.IPAllowList) == 0 && len(p.Headers) == 0 && p.RateLimit == nil && p.Redirect == ""
+}
+
+// Public returns a copy of the policy without password hashes
+func (p RoutingPolicy) Public() RoutingPolicy {
+	public := p
+	public.BasicAuth = make([]BasicAuthUser, len(p.BasicAuth))
+	for i, user := range p.BasicAuth {
+		public.BasicAuth[i] = BasicAuthUser{Username: user.Username}
+	}
+	return public
+}
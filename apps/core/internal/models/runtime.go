@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Runtime statuses kept by the reconciler from the swarm state
+const (
+	RUNTIME_STATUS_UNKNOWN  = "unknown"  // Not inspected yet
+	RUNTIME_STATUS_RUNNING  = "running"  // Every replica is running
+	RUNTIME_STATUS_DEGRADED = "degraded" // Some replicas are not running
+	RUNTIME_STATUS_STOPPED  = "stopped"  // Scaled to zero, or never deployed
+	RUNTIME_STATUS_MISSING  = "missing"  // Deployed, but the stack is gone
+)
+
+// ServiceReplicas counts the replicas of one swarm service of a stack
+type ServiceReplicas struct {
+	Service string `json:"service"`
+	Running int    `json:"running"`
+	Desired int    `json:"desired"`
+}
+
+// OrphanedStack is a swarm stack deployed by Kova whose project no longer exists
+type OrphanedStack struct {
+	StackName string    `json:"stack_name"`
+	Services  []string  `json:"services"`
+	FirstSeen time.Time `json:"first_seen"`
+}
@@ -0,0 +1,18 @@
+package models
+
+// StaticSite configures a project whose build output is a set of static files.
+// Static projects don't run a container of their own, their files are copied out
+// of the built image and served by the shared static file server.
+type StaticSite struct {
+	Enabled      bool   `json:"enabled"`
+	OutputDir    string `json:"output_dir,omitempty"`     // Relative to the app directory of the image, detected when empty
+	SPA          bool   `json:"spa"`                      // Serve index.html for paths that match no file
+	NotFoundPage string `json:"not_found_page,omitempty"` // Page served with 404 responses, relative to the output directory
+}
+
+type UpdateStaticSiteRequest struct {
+	Enabled      bool   `json:"enabled"`
+	OutputDir    string `json:"output_dir" validate:"omitempty,max=255"`
+	SPA          bool   `json:"spa"`
+	NotFoundPage string `json:"not_found_page" validate:"omitempty,max=255"`
+}
@@ -0,0 +1,97 @@
+package models
+
+import "time"
+
+const (
+	TEARDOWN_STATUS_PREPARED  = "prepared" // Planned, its project hasn't been deleted yet
+	TEARDOWN_STATUS_PENDING   = "pending"
+	TEARDOWN_STATUS_RUNNING   = "running"
+	TEARDOWN_STATUS_COMPLETED = "completed"
+	TEARDOWN_STATUS_FAILED    = "failed" // Finished, but some steps failed and left resources behind
+)
+
+// Steps of a teardown, run in this order
+const (
+	TEARDOWN_STEP_STACKS      = "stacks" // The project's stack and its previews
+	TEARDOWN_STEP_WEBHOOK     = "webhook"
+	TEARDOWN_STEP_IMAGES      = "images"
+	TEARDOWN_STEP_VOLUMES     = "volumes"
+	TEARDOWN_STEP_DIRECTORIES = "directories"
+)
+
+const (
+	TEARDOWN_STEP_PENDING   = "pending"
+	TEARDOWN_STEP_SUCCEEDED = "succeeded"
+	TEARDOWN_STEP_FAILED    = "failed"
+	TEARDOWN_STEP_SKIPPED   = "skipped"
+)
+
+// Teardown removes what a deleted project left on the host. It outlives the
+// project, so its progress can be followed after the project is gone.
+type Teardown struct {
+	ID             string          `json:"id"`
+	UserID         string          `json:"user_id"`
+	ProjectID      string          `json:"project_id"`
+	ProjectName    string          `json:"project_name"`
+	RepoFullName   string          `json:"-"`
+	WebhookID      int64           `json:"-"`
+	Provider       string          `json:"-"` // Where the repository lives and whose credentials remove its webhook
+	ProviderURL    string          `json:"-"`
+	AccountID      string          `json:"-"`
+	InstallationID int64           `json:"-"`
+	RemoveVolumes  bool            `json:"remove_volumes"`
+	Status         string          `json:"status"`
+	Steps          []*TeardownStep `json:"steps"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	FinishedAt     *time.Time      `json:"finished_at,omitempty"`
+}
+
+type TeardownStep struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// NewTeardown plans the teardown of a project. Volumes are only removed when
+// asked for, since they hold data that can't be rebuilt.
+func NewTeardown(project *Project, removeVolumes bool) *Teardown {
+	teardown := &Teardown{
+		UserID:         project.UserID,
+		ProjectID:      project.ID,
+		ProjectName:    project.Name,
+		RepoFullName:   project.RepoFullName,
+		WebhookID:      project.WebhookID,
+		Provider:       project.Provider,
+		ProviderURL:    project.ProviderURL,
+		AccountID:      project.AccountID,
+		InstallationID: project.InstallationID,
+		RemoveVolumes:  removeVolumes,
+		Status:         TEARDOWN_STATUS_PREPARED,
+	}
+
+	for _, name := range []string{TEARDOWN_STEP_STACKS, TEARDOWN_STEP_WEBHOOK, TEARDOWN_STEP_IMAGES, TEARDOWN_STEP_VOLUMES, TEARDOWN_STEP_DIRECTORIES} {
+		step := &TeardownStep{Name: name, Status: TEARDOWN_STEP_PENDING}
+		if (name == TEARDOWN_STEP_WEBHOOK && project.WebhookID == 0) || (name == TEARDOWN_STEP_VOLUMES && !removeVolumes) {
+			step.Status = TEARDOWN_STEP_SKIPPED
+		}
+		teardown.Steps = append(teardown.Steps, step)
+	}
+	return teardown
+}
+
+// Repository returns what the teardown knows of its deleted project's repository,
+// enough to resolve the credentials that reach it
+func (t *Teardown) Repository() *Project {
+	return &Project{
+		ID:             t.ProjectID,
+		Name:           t.ProjectName,
+		UserID:         t.UserID,
+		RepoFullName:   t.RepoFullName,
+		WebhookID:      t.WebhookID,
+		Provider:       t.Provider,
+		ProviderURL:    t.ProviderURL,
+		AccountID:      t.AccountID,
+		InstallationID: t.InstallationID,
+	}
+}
@@ -0,0 +1,32 @@
+package models
+
+// Volume is a named volume mounted into every process of a project. Swarm creates
+// it as <project ID>_<name> on first deploy, and it is kept across redeploys.
+type Volume struct {
+	Name string `json:"name"`
+	Path string `json:"path"` // Absolute mount path inside the containers
+}
+
+type UpdateVolumesRequest struct {
+	Volumes []VolumeRequest `json:"volumes" validate:"omitempty,max=10,dive"`
+}
+
+type VolumeRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=50"`
+	Path string `json:"path" validate:"required,max=255"`
+}
+
+// VolumeName returns the name of the docker volume a project volume is created as
+func (p *Project) VolumeName(name string) string {
+	return p.ID + "_" + name
+}
+
+// HasVolume reports whether the project declares a volume with this name
+func (p *Project) HasVolume(name string) bool {
+	for _, volume := range p.Volumes {
+		if volume.Name == name {
+			return true
+		}
+	}
+	return false
+}
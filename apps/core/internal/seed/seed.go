@@ -22,7 +22,7 @@ func NewSeederWithDefaults(store store.Store) *Seed {
 		SeedConfig: SeedConfig{AdminEmail: DEFAULT_EMAIL,
 			AdminPassword: DEFAULT_PASSWORD,
 			AdminUserName: DEFAULT_USERNAME},
-		userService: services.NewUserService(store),
+		userService: services.NewUserService(store, nil),
 	}
 }
 
@@ -34,6 +34,6 @@ func NewSeederFromEnvs(store store.Store) *Seed {
 		SeedConfig: SeedConfig{AdminEmail: adminEmail,
 			AdminPassword: adminPassword,
 			AdminUserName: adminUserName},
-		userService: services.NewUserService(store),
+		userService: services.NewUserService(store, nil),
 	}
 }
@@ -2,8 +2,12 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/dopeCape/kova/internal/models"
@@ -12,16 +16,18 @@ import (
 )
 
 type AccountService struct {
-	store         store.Store
-	validator     *validator.Validate
-	githubService *GitHubService
+	store        store.Store
+	validator    *validator.Validate
+	providers    *GitProviders
+	oauthService *GitHubOAuthService
 }
 
-func NewAccountService(store store.Store, githubService *GitHubService) *AccountService {
+func NewAccountService(store store.Store, providers *GitProviders, oauthService *GitHubOAuthService) *AccountService {
 	return &AccountService{
-		store:         store,
-		validator:     validator.New(),
-		githubService: githubService,
+		store:        store,
+		validator:    validator.New(),
+		providers:    providers,
+		oauthService: oauthService,
 	}
 }
 
@@ -52,8 +58,10 @@ func (s *AccountService) GetAccountsByUserID(ctx context.Context, userID string)
 	return publicAccounts, nil
 }
 
-// CreateAccount creates a new GitHub account for a user using an access token
-func (s *AccountService) CreateAccount(ctx context.Context, userID, accessToken string) (*models.Account, error) {
+// CreateAccount links a git provider account to a user using an access token.
+// An empty provider links a GitHub account, and an empty providerURL selects the
+// provider's hosted instance.
+func (s *AccountService) CreateAccount(ctx context.Context, userID, providerType, providerURL, accessToken string) (*models.Account, error) {
 	if userID == "" {
 		return nil, errors.New("user ID is required")
 	}
@@ -61,47 +69,45 @@ func (s *AccountService) CreateAccount(ctx context.Context, userID, accessToken
 		return nil, errors.New("access token is required")
 	}
 
-	// Validate access token length (GitHub personal access tokens are typically 40+ characters)
-	if len(accessToken) < 40 {
+	// Validate access token length (GitLab tokens are the shortest at 20+ characters)
+	if len(accessToken) < 20 {
 		return nil, errors.New("invalid access token format")
 	}
 
-	// Check if user exists
-	_, err := s.store.GetUserByID(ctx, userID)
+	provider, err := s.providers.Get(providerType, providerURL)
 	if err != nil {
-		return nil, fmt.Errorf("user not found: %w", err)
+		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
-	// Get GitHub user details using the access token
-	githubUser, err := s.githubService.GetUser(ctx, accessToken)
+	// Check if user exists
+	_, err = s.store.GetUserByID(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get GitHub user details: %w", err)
+		return nil, fmt.Errorf("user not found: %w", err)
 	}
 
-	// Check if this GitHub account is already linked to any user
-	exists, err := s.store.AccountExistsByGithubID(ctx, githubUser.ID)
+	// Get provider user details using the access token
+	gitUser, err := provider.GetUser(ctx, accessToken)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check if GitHub account exists: %w", err)
-	}
-	if exists {
-		return nil, errors.New("this GitHub account is already linked to another user")
+		return nil, fmt.Errorf("failed to get git provider user details: %w", err)
 	}
 
-	// Check if this GitHub account is already linked to this specific user
-	accountExists, err := s.store.AccountExistsForUser(ctx, userID, githubUser.ID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check if account exists for user: %w", err)
-	}
-	if accountExists {
-		return nil, errors.New("this GitHub account is already linked to your account")
+	// Check if this provider account is already linked
+	existing, err := s.store.GetAccountByProviderID(ctx, provider.URL(), gitUser.ID)
+	if err == nil {
+		if existing.IsOwnedBy(userID) {
+			return nil, errors.New("this account is already linked to your account")
+		}
+		return nil, errors.New("this account is already linked to another user")
 	}
 
 	// Create account
 	account := &models.Account{
 		UserID:         userID,
-		GithubUsername: githubUser.Login,
-		GithubID:       githubUser.ID,
-		AvatarURL:      githubUser.AvatarURL,
+		GithubUsername: gitUser.Login,
+		GithubID:       gitUser.ID,
+		Provider:       provider.Type(),
+		ProviderURL:    provider.URL(),
+		AvatarURL:      gitUser.AvatarURL,
 		AccessToken:    accessToken,
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
@@ -136,7 +142,7 @@ func (s *AccountService) GetAccountByID(ctx context.Context, userID, accountID s
 	return account.ToPublic(), nil
 }
 
-// DeleteAccount removes a GitHub account
+// DeleteAccount removes a linked git provider account
 func (s *AccountService) DeleteAccount(ctx context.Context, userID, accountID string) error {
 	if userID == "" {
 		return errors.New("user ID is required")
@@ -176,7 +182,7 @@ func (s *AccountService) RefreshAccountToken(ctx context.Context, userID, accoun
 	}
 
 	// Validate new access token length
-	if len(newAccessToken) < 40 {
+	if len(newAccessToken) < 20 {
 		return nil, errors.New("invalid access token format")
 	}
 
@@ -191,15 +197,20 @@ func (s *AccountService) RefreshAccountToken(ctx context.Context, userID, accoun
 		return nil, errors.New("access denied: account does not belong to user")
 	}
 
-	// Validate new token with GitHub
-	githubUser, err := s.githubService.GetUser(ctx, newAccessToken)
+	provider, err := s.providers.ForAccount(account)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate new token with the provider
+	gitUser, err := provider.GetUser(ctx, newAccessToken)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate new access token: %w", err)
 	}
 
-	// Ensure the token belongs to the same GitHub account
-	if githubUser.ID != account.GithubID {
-		return nil, errors.New("access token does not belong to the linked GitHub account")
+	// Ensure the token belongs to the same provider account
+	if gitUser.ID != account.GithubID {
+		return nil, errors.New("access token does not belong to the linked account")
 	}
 
 	// Update token
@@ -211,32 +222,104 @@ func (s *AccountService) RefreshAccountToken(ctx context.Context, userID, accoun
 }
 
 // GetAccountRepositories retrieves repositories for a specific account
-func (s *AccountService) GetAccountRepositories(ctx context.Context, userID, accountID string, page, perPage int) ([]*GitHubRepository, error) {
+func (s *AccountService) GetAccountRepositories(ctx context.Context, userID, accountID string, page, perPage int) ([]*GitRepository, error) {
+	account, provider, err := s.getAccountProvider(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch repositories from the provider using the stored access token
+	repositories, err := provider.GetRepositories(ctx, account.AccessToken, page, perPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch repositories from git provider: %w", err)
+	}
+
+	return repositories, nil
+}
+
+// GetAllAccountRepositories retrieves every repository for a specific account across all pages
+func (s *AccountService) GetAllAccountRepositories(ctx context.Context, userID, accountID string) ([]*GitRepository, error) {
+	account, provider, err := s.getAccountProvider(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	repositories, err := provider.GetAllRepositories(ctx, account.AccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch repositories from git provider: %w", err)
+	}
+
+	return repositories, nil
+}
+
+// GetAccountBranches retrieves the branches of a repository the account can access
+func (s *AccountService) GetAccountBranches(ctx context.Context, userID, accountID, repoFullName string, page, perPage int) ([]*GitBranch, error) {
+	account, provider, err := s.getAccountProvider(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	branches, err := provider.GetBranches(ctx, account.AccessToken, repoFullName, page, perPage)
+	if err != nil {
+		if errors.Is(err, errResourceNotFound) {
+			return nil, fmt.Errorf("repository not found: %s", repoFullName)
+		}
+		return nil, fmt.Errorf("failed to fetch branches from git provider: %w", err)
+	}
+
+	return branches, nil
+}
+
+// GetAccountTags retrieves the tags of a repository the account can access
+func (s *AccountService) GetAccountTags(ctx context.Context, userID, accountID, repoFullName string, page, perPage int) ([]*GitTag, error) {
+	account, provider, err := s.getAccountProvider(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := provider.GetTags(ctx, account.AccessToken, repoFullName, page, perPage)
+	if err != nil {
+		if errors.Is(err, errResourceNotFound) {
+			return nil, fmt.Errorf("repository not found: %s", repoFullName)
+		}
+		return nil, fmt.Errorf("failed to fetch tags from git provider: %w", err)
+	}
+
+	return tags, nil
+}
+
+// getAccountProvider verifies account ownership and returns the account, with a
+// fresh access token, and its provider client
+func (s *AccountService) getAccountProvider(ctx context.Context, userID, accountID string) (*models.Account, GitProvider, error) {
 	if userID == "" {
-		return nil, errors.New("user ID is required")
+		return nil, nil, errors.New("user ID is required")
 	}
 	if accountID == "" {
-		return nil, errors.New("account ID is required")
+		return nil, nil, errors.New("account ID is required")
 	}
 
 	// Get account to verify ownership and get access token
 	account, err := s.store.GetAccountByID(ctx, accountID)
 	if err != nil {
-		return nil, fmt.Errorf("account not found: %w", err)
+		return nil, nil, fmt.Errorf("account not found: %w", err)
 	}
 
 	// Verify ownership
 	if !account.IsOwnedBy(userID) {
-		return nil, errors.New("access denied: account does not belong to user")
+		return nil, nil, errors.New("access denied: account does not belong to user")
 	}
 
-	// Fetch repositories from GitHub using the stored access token
-	repositories, err := s.githubService.GetRepositories(ctx, account.AccessToken, page, perPage)
+	account, err = s.oauthService.EnsureFreshToken(ctx, account)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch repositories from GitHub: %w", err)
+		return nil, nil, err
 	}
 
-	return repositories, nil
+	provider, err := s.providers.ForAccount(account)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return account, provider, nil
 }
 
 // GetAccountWithToken retrieves an account with its access token (for internal use)
@@ -260,5 +343,184 @@ func (s *AccountService) GetAccountWithToken(ctx context.Context, userID, accoun
 	}
 
 	// Return account with token (don't call ToPublic)
-	return account, nil
+	return s.oauthService.EnsureFreshToken(ctx, account)
+}
+
+// resolveRedirect checks that redirectTo is a path on the dashboard and resolves
+// it against the dashboard origin, so the callback can't be sent off-site
+func (s *AccountService) resolveRedirect(redirectTo string) (string, error) {
+	invalid := errors.New("validation failed: redirect_to must be a relative path")
+
+	// Browsers treat a backslash like a slash, so /\evil.com is protocol relative
+	if strings.Contains(redirectTo, "\\") || !strings.HasPrefix(redirectTo, "/") || strings.HasPrefix(redirectTo, "//") {
+		return "", invalid
+	}
+	target, err := url.Parse(redirectTo)
+	if err != nil || target.Scheme != "" || target.Host != "" || target.User != nil {
+		return "", invalid
+	}
+
+	if s.oauthService.publicURL == "" {
+		return target.String(), nil
+	}
+	origin, err := url.Parse(s.oauthService.publicURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid public URL: %w", err)
+	}
+	resolved := origin.ResolveReference(target)
+	if resolved.Host != origin.Host {
+		return "", invalid
+	}
+	return resolved.String(), nil
+}
+
+// StartOAuth begins the OAuth web flow and returns the GitHub authorization URL.
+// redirectTo must be a relative path and is where the callback sends the browser.
+func (s *AccountService) StartOAuth(ctx context.Context, userID, redirectTo string) (string, error) {
+	if userID == "" {
+		return "", errors.New("user ID is required")
+	}
+	if redirectTo != "" {
+		resolved, err := s.resolveRedirect(redirectTo)
+		if err != nil {
+			return "", err
+		}
+		redirectTo = resolved
+	}
+
+	_, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("user not found: %w", err)
+	}
+
+	stateBytes := make([]byte, 32)
+	if _, err := rand.Read(stateBytes); err != nil {
+		return "", fmt.Errorf("failed to generate state: %w", err)
+	}
+
+	state := &models.OAuthState{
+		State:      hex.EncodeToString(stateBytes),
+		UserID:     userID,
+		RedirectTo: redirectTo,
+		ExpiresAt:  time.Now().Add(OAUTH_STATE_TTL),
+	}
+
+	authorizeURL, err := s.oauthService.AuthorizeURL(state.State)
+	if err != nil {
+		return "", err
+	}
+
+	// Opportunistically clear abandoned authorizations
+	if err := s.store.DeleteExpiredOAuthStates(ctx, time.Now()); err != nil {
+		return "", fmt.Errorf("failed to clean up oauth states: %w", err)
+	}
+	if err := s.store.CreateOAuthState(ctx, state); err != nil {
+		return "", fmt.Errorf("failed to save oauth state: %w", err)
+	}
+
+	return authorizeURL, nil
+}
+
+// CompleteOAuth finishes the web flow. The state must match one issued by
+// StartOAuth, which ties the callback to the user who started it.
+func (s *AccountService) CompleteOAuth(ctx context.Context, stateValue, code string) (*models.Account, string, error) {
+	if stateValue == "" || code == "" {
+		return nil, "", errors.New("invalid oauth state: state and code are required")
+	}
+
+	state, err := s.store.ConsumeOAuthState(ctx, stateValue)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid oauth state: %w", err)
+	}
+	if state.IsExpired() {
+		return nil, state.RedirectTo, errors.New("invalid oauth state: authorization expired")
+	}
+
+	token, err := s.oauthService.ExchangeCode(ctx, code)
+	if err != nil {
+		return nil, state.RedirectTo, err
+	}
+
+	account, err := s.linkOAuthAccount(ctx, state.UserID, token)
+	return account, state.RedirectTo, err
+}
+
+// StartDeviceFlow begins the device flow for clients without a browser redirect (CLI, TUI)
+func (s *AccountService) StartDeviceFlow(ctx context.Context, userID string) (*GitHubDeviceCode, error) {
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+
+	_, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	return s.oauthService.RequestDeviceCode(ctx)
+}
+
+// PollDeviceFlow links the account once the user approves the device code.
+// Returns ErrAuthorizationPending or ErrSlowDown while waiting.
+func (s *AccountService) PollDeviceFlow(ctx context.Context, userID, deviceCode string) (*models.Account, error) {
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	if deviceCode == "" {
+		return nil, errors.New("validation failed: device code is required")
+	}
+
+	token, err := s.oauthService.PollDeviceToken(ctx, deviceCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.linkOAuthAccount(ctx, userID, token)
+}
+
+// linkOAuthAccount creates the account for an OAuth token, or refreshes the
+// tokens when the GitHub account is already linked to this user
+func (s *AccountService) linkOAuthAccount(ctx context.Context, userID string, token *models.OAuthToken) (*models.Account, error) {
+	provider, err := s.providers.Get(models.GIT_PROVIDER_GITHUB, "")
+	if err != nil {
+		return nil, err
+	}
+
+	githubUser, err := provider.GetUser(ctx, token.AccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub user details: %w", err)
+	}
+
+	existing, err := s.store.GetAccountByProviderID(ctx, provider.URL(), githubUser.ID)
+	if err == nil {
+		if !existing.IsOwnedBy(userID) {
+			return nil, errors.New("this GitHub account is already linked to another user")
+		}
+
+		updated, err := s.store.UpdateAccountOAuthTokens(ctx, existing.ID, token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update account tokens: %w", err)
+		}
+		return updated.ToPublic(), nil
+	}
+
+	account := &models.Account{
+		UserID:                userID,
+		GithubUsername:        githubUser.Login,
+		GithubID:              githubUser.ID,
+		Provider:              provider.Type(),
+		ProviderURL:           provider.URL(),
+		AvatarURL:             githubUser.AvatarURL,
+		AccessToken:           token.AccessToken,
+		RefreshToken:          token.RefreshToken,
+		TokenExpiresAt:        token.ExpiresAt,
+		RefreshTokenExpiresAt: token.RefreshTokenExpiresAt,
+		CreatedAt:             time.Now(),
+		UpdatedAt:             time.Now(),
+	}
+
+	if err := s.store.CreateAccount(ctx, account); err != nil {
+		return nil, fmt.Errorf("failed to create account: %w", err)
+	}
+
+	return account.ToPublic(), nil
 }
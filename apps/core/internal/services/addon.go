@@ -0,0 +1,742 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	ADDON_STACK_PREFIX     = "kova-addon-"
+	ADDON_SERVICE          = "db"
+	ADDON_USERNAME         = "kova"
+	ADDON_CLEANUP_INTERVAL = 10 * time.Minute
+)
+
+// envVarPattern matches the environment variable names an add-on can be injected as
+var envVarPattern = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+
+// addonVersionPattern matches image tags like 16, 8.4 or 7-alpine
+var addonVersionPattern = regexp.MustCompile(`^[0-9][0-9a-z.-]*$`)
+
+// addonEngine describes how an add-on type is run and connected to
+type addonEngine struct {
+	Image          string
+	DefaultVersion string
+	Port           int
+	DataPath       string // Where the volume is mounted
+	DefaultEnvVar  string
+}
+
+var addonEngines = map[string]addonEngine{
+	models.ADDON_TYPE_POSTGRES: {Image: "postgres", DefaultVersion: "17", Port: 5432, DataPath: "/var/lib/postgresql/data", DefaultEnvVar: "DATABASE_URL"},
+	models.ADDON_TYPE_MYSQL:    {Image: "mysql", DefaultVersion: "8.4", Port: 3306, DataPath: "/var/lib/mysql", DefaultEnvVar: "DATABASE_URL"},
+	models.ADDON_TYPE_REDIS:    {Image: "redis", DefaultVersion: "7", Port: 6379, DataPath: "/data", DefaultEnvVar: "REDIS_URL"},
+}
+
+// AddonService runs managed databases as stacks of their own. Each one has a
+// volume and a private network, which the projects it is attached to join.
+type AddonService struct {
+	store        store.Store
+	buildService *BuildService
+	validator    *validator.Validate
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewAddonService(store store.Store, buildService *BuildService) *AddonService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &AddonService{
+		store:        store,
+		buildService: buildService,
+		validator:    validator.New(),
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
+
+// Start resumes the provisioning and deletion a restart interrupted, and removes
+// the stacks of add-ons deleted along with their user
+func (s *AddonService) Start() {
+	for _, status := range []string{models.ADDON_STATUS_PROVISIONING, models.ADDON_STATUS_DELETING} {
+		addons, err := s.store.GetAddonsByStatus(s.ctx, status)
+		if err != nil {
+			log.Printf("⚠️  Failed to load %s add-ons: %v", status, err)
+			continue
+		}
+		for _, addon := range addons {
+			s.runInBackground(addon, status)
+		}
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(ADDON_CLEANUP_INTERVAL)
+		defer ticker.Stop()
+
+		for {
+			s.removeOrphans()
+
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	log.Println("🗄️  Add-on service started")
+}
+
+// Shutdown stops the add-on service once the current operations are done
+func (s *AddonService) Shutdown() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// GetAddons returns the add-ons of a user
+func (s *AddonService) GetAddons(ctx context.Context, userID string) ([]*models.Addon, error) {
+	addons, err := s.store.GetAddonsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get add-ons: %w", err)
+	}
+	for _, addon := range addons {
+		withConnection(addon)
+	}
+	return addons, nil
+}
+
+// GetAddon returns an add-on with the projects it is attached to
+func (s *AddonService) GetAddon(ctx context.Context, userID, addonID string) (*models.Addon, error) {
+	addon, err := s.getAddon(ctx, userID, addonID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.store.GetAddonAttachmentsByAddonID(ctx, addon.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get add-on attachments: %w", err)
+	}
+	addon.Attachments = attachments
+	return withConnection(addon), nil
+}
+
+// GetConnectionURL returns the URL of an add-on with its credentials
+func (s *AddonService) GetConnectionURL(ctx context.Context, userID, addonID string) (string, error) {
+	addon, err := s.getAddon(ctx, userID, addonID)
+	if err != nil {
+		return "", err
+	}
+	return addonURL(addon), nil
+}
+
+// CreateAddon records an add-on and provisions it in the background
+func (s *AddonService) CreateAddon(ctx context.Context, userID string, req *models.CreateAddonRequest) (*models.Addon, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	engine := addonEngines[req.Type]
+	version := req.Version
+	if version == "" {
+		version = engine.DefaultVersion
+	}
+	if !addonVersionPattern.MatchString(version) {
+		return nil, fmt.Errorf("validation failed: invalid version %q", version)
+	}
+
+	exists, err := s.store.AddonExistsByUserIDAndName(ctx, userID, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check add-on name: %w", err)
+	}
+	if exists {
+		return nil, fmt.Errorf("add-on %s already exists", req.Name)
+	}
+
+	password, err := generateAddonPassword()
+	if err != nil {
+		return nil, err
+	}
+
+	addon := &models.Addon{
+		UserID:       userID,
+		Name:         req.Name,
+		Type:         req.Type,
+		Version:      version,
+		Username:     ADDON_USERNAME,
+		Password:     password,
+		DatabaseName: ADDON_USERNAME,
+	}
+	if req.Type == models.ADDON_TYPE_REDIS {
+		addon.Username = "default"
+		addon.DatabaseName = "0"
+	}
+
+	if err := s.store.CreateAddon(ctx, addon); err != nil {
+		return nil, fmt.Errorf("failed to create add-on: %w", err)
+	}
+
+	log.Printf("🗄️  Provisioning %s add-on %s (%s)", addon.Type, addon.Name, addon.ID)
+	s.runInBackground(addon, models.ADDON_STATUS_PROVISIONING)
+	return withConnection(addon), nil
+}
+
+// DeleteAddon removes an add-on, its data and its network in the background. It
+// must be detached from every project first.
+func (s *AddonService) DeleteAddon(ctx context.Context, userID, addonID string) (*models.Addon, error) {
+	addon, err := s.getAddon(ctx, userID, addonID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.store.GetAddonAttachmentsByAddonID(ctx, addon.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get add-on attachments: %w", err)
+	}
+	if len(attachments) > 0 {
+		return nil, fmt.Errorf("validation failed: add-on is attached to %d projects, detach it first", len(attachments))
+	}
+	if addon.Status == models.ADDON_STATUS_DELETING {
+		return withConnection(addon), nil
+	}
+
+	addon, err = s.store.UpdateAddonStatus(ctx, addon.ID, models.ADDON_STATUS_DELETING, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to update add-on: %w", err)
+	}
+
+	log.Printf("🗄️  Deleting add-on %s (%s)", addon.Name, addon.ID)
+	s.runInBackground(addon, models.ADDON_STATUS_DELETING)
+	return withConnection(addon), nil
+}
+
+// AttachAddon injects an add-on's URL into a project's runtime environment. A
+// deployed project is redeployed to join the add-on's network.
+func (s *AddonService) AttachAddon(ctx context.Context, userID, addonID string, req *models.AttachAddonRequest) (*models.AddonAttachment, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	addon, err := s.getAddon(ctx, userID, addonID)
+	if err != nil {
+		return nil, err
+	}
+	if addon.Status == models.ADDON_STATUS_DELETING {
+		return nil, errors.New("validation failed: add-on is being deleted")
+	}
+
+	project, err := s.store.GetProjectByID(ctx, req.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+
+	envVar := req.EnvVar
+	if envVar == "" {
+		envVar = addonEngines[addon.Type].DefaultEnvVar
+	}
+	if !envVarPattern.MatchString(envVar) {
+		return nil, fmt.Errorf("validation failed: invalid environment variable name %q", envVar)
+	}
+
+	attachments, err := s.store.GetAddonAttachmentsByProjectID(ctx, project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project add-ons: %w", err)
+	}
+	for _, attachment := range attachments {
+		if attachment.AddonID == addon.ID {
+			return nil, errors.New("add-on is already attached to this project")
+		}
+		if attachment.EnvVar == envVar {
+			return nil, fmt.Errorf("validation failed: %s is already set by another add-on", envVar)
+		}
+	}
+	for _, env := range project.EnvVariables {
+		if env.Key == envVar {
+			return nil, fmt.Errorf("validation failed: %s is already set in the project's environment", envVar)
+		}
+	}
+
+	attachment := &models.AddonAttachment{
+		AddonID:   addon.ID,
+		ProjectID: project.ID,
+		EnvVar:    envVar,
+	}
+	if err := s.store.CreateAddonAttachment(ctx, attachment); err != nil {
+		return nil, fmt.Errorf("failed to attach add-on: %w", err)
+	}
+
+	log.Printf("🗄️  Add-on %s attached to project %s as %s", addon.Name, project.ID, envVar)
+	s.redeploy(project)
+	return attachment, nil
+}
+
+// DetachAddon removes an add-on from a project's runtime environment and network
+func (s *AddonService) DetachAddon(ctx context.Context, userID, addonID, projectID string) error {
+	addon, err := s.getAddon(ctx, userID, addonID)
+	if err != nil {
+		return err
+	}
+
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("project not found: %w", err)
+	}
+
+	attachments, err := s.store.GetAddonAttachmentsByAddonID(ctx, addon.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get add-on attachments: %w", err)
+	}
+	attached := false
+	for _, attachment := range attachments {
+		attached = attached || attachment.ProjectID == project.ID
+	}
+	if !attached {
+		return errors.New("add-on attachment not found")
+	}
+
+	if err := s.store.DeleteAddonAttachment(ctx, addon.ID, project.ID); err != nil {
+		return fmt.Errorf("failed to detach add-on: %w", err)
+	}
+
+	log.Printf("🗄️  Add-on %s detached from project %s", addon.Name, project.ID)
+	s.redeploy(project)
+	return nil
+}
+
+// RotatePassword replaces the password of a running add-on and redeploys the
+// projects it is attached to with the new URL
+func (s *AddonService) RotatePassword(ctx context.Context, userID, addonID string) (*models.Addon, error) {
+	addon, err := s.getAddon(ctx, userID, addonID)
+	if err != nil {
+		return nil, err
+	}
+	if addon.Status != models.ADDON_STATUS_RUNNING {
+		return nil, fmt.Errorf("validation failed: add-on is %s, not running", addon.Status)
+	}
+
+	password, err := generateAddonPassword()
+	if err != nil {
+		return nil, err
+	}
+
+	// The databases only read their password from the environment when first
+	// initialized, so the running one is changed in place
+	if err := changeAddonPassword(addon, password); err != nil {
+		return nil, fmt.Errorf("failed to change add-on password: %w", err)
+	}
+
+	updatedAddon, err := s.store.UpdateAddonPassword(ctx, addon.ID, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save add-on password, it was changed to the new one already: %w", err)
+	}
+
+	if err := writeAddonCompose(updatedAddon); err != nil {
+		log.Printf("⚠️  Failed to update the compose file of add-on %s: %v", addon.ID, err)
+	} else if addon.Type == models.ADDON_TYPE_REDIS {
+		// Redis reads its password from the environment on every start
+		if err := deployAddonStack(updatedAddon); err != nil {
+			return nil, fmt.Errorf("failed to redeploy add-on: %w", err)
+		}
+	}
+
+	attachments, err := s.store.GetAddonAttachmentsByAddonID(ctx, addon.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get add-on attachments: %w", err)
+	}
+	for _, attachment := range attachments {
+		if project, err := s.store.GetProjectByID(ctx, attachment.ProjectID); err == nil {
+			s.redeploy(project)
+		}
+	}
+
+	log.Printf("🔑 Password of add-on %s rotated", addon.ID)
+	return withConnection(updatedAddon), nil
+}
+
+// runInBackground provisions or deletes an add-on, depending on its status
+func (s *AddonService) runInBackground(addon *models.Addon, status string) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		var err error
+		if status == models.ADDON_STATUS_DELETING {
+			err = s.remove(addon)
+		} else {
+			err = s.provision(addon)
+		}
+		if err == nil || s.ctx.Err() != nil {
+			return
+		}
+
+		log.Printf("⚠️  Add-on %s: %v", addon.ID, err)
+		if _, err := s.store.UpdateAddonStatus(context.Background(), addon.ID, models.ADDON_STATUS_FAILED, err.Error()); err != nil {
+			log.Printf("⚠️  Failed to update status of add-on %s: %v", addon.ID, err)
+		}
+	}()
+}
+
+// provision creates the add-on's network and deploys its stack
+func (s *AddonService) provision(addon *models.Addon) error {
+	if err := ensureAddonNetwork(addon.ID); err != nil {
+		return err
+	}
+	if err := writeAddonCompose(addon); err != nil {
+		return err
+	}
+	if err := deployAddonStack(addon); err != nil {
+		return err
+	}
+
+	if _, err := s.store.UpdateAddonStatus(context.Background(), addon.ID, models.ADDON_STATUS_RUNNING, ""); err != nil {
+		return fmt.Errorf("failed to update add-on status: %w", err)
+	}
+	log.Printf("✅ Add-on %s is running", addon.ID)
+	return nil
+}
+
+// remove deletes the add-on's stack, volume, network and compose file, then its record
+func (s *AddonService) remove(addon *models.Addon) error {
+	if err := removeAddonResources(s.ctx, addon.ID); err != nil {
+		return fmt.Errorf("deletion failed: %w", err)
+	}
+	if err := s.store.DeleteAddon(context.Background(), addon.ID); err != nil {
+		return fmt.Errorf("failed to delete add-on: %w", err)
+	}
+	log.Printf("✅ Add-on %s deleted", addon.ID)
+	return nil
+}
+
+// removeOrphans removes the stacks of add-ons that no longer exist, such as those
+// of deleted users
+func (s *AddonService) removeOrphans() {
+	output, err := runDocker("stack", "ls", "--format", "{{.Name}}")
+	if err != nil {
+		log.Printf("⚠️  Failed to list add-on stacks: %v", err)
+		return
+	}
+
+	for _, stackName := range strings.Fields(output) {
+		addonID, ok := strings.CutPrefix(stackName, ADDON_STACK_PREFIX)
+		if !ok {
+			continue
+		}
+		exists, err := s.store.AddonExists(s.ctx, addonID)
+		if err != nil || exists {
+			continue
+		}
+
+		log.Printf("🧹 Removing stack of deleted add-on %s", addonID)
+		if err := removeAddonResources(s.ctx, addonID); err != nil {
+			log.Printf("⚠️  Failed to remove deleted add-on %s: %v", addonID, err)
+		}
+	}
+}
+
+// redeploy applies a project's add-ons by redeploying it, if it is deployed
+func (s *AddonService) redeploy(project *models.Project) {
+	if s.buildService != nil && project.DeploymentStatus == "deployed" {
+		s.buildService.Enqueue(project.ID, project.UserID)
+	}
+}
+
+func (s *AddonService) getAddon(ctx context.Context, userID, addonID string) (*models.Addon, error) {
+	addon, err := s.store.GetAddonByID(ctx, addonID)
+	if err != nil {
+		return nil, fmt.Errorf("add-on not found: %w", err)
+	}
+
+	if addon.UserID != userID {
+		return nil, errors.New("access denied: add-on does not belong to user")
+	}
+	return addon, nil
+}
+
+// attachedAddons returns the environment variables and networks that give a
+// project access to its add-ons
+func attachedAddons(ctx context.Context, store store.Store, projectID string) ([]models.EnvironmentVariable, []string, error) {
+	attachments, err := store.GetAddonAttachmentsByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get project add-ons: %w", err)
+	}
+
+	var env []models.EnvironmentVariable
+	var networks []string
+	for _, attachment := range attachments {
+		addon, err := store.GetAddonByID(ctx, attachment.AddonID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get add-on %s: %w", attachment.AddonID, err)
+		}
+		env = append(env, models.EnvironmentVariable{Key: attachment.EnvVar, Value: addonURL(addon)})
+		networks = append(networks, addonNetworkName(addon.ID))
+	}
+	return env, networks, nil
+}
+
+// withConnection fills in where attached projects reach an add-on
+func withConnection(addon *models.Addon) *models.Addon {
+	addon.Host = addonStackName(addon.ID)
+	addon.Port = addonEngines[addon.Type].Port
+	return addon
+}
+
+// addonURL returns the URL attached projects connect to an add-on with
+func addonURL(addon *models.Addon) string {
+	engine := addonEngines[addon.Type]
+	u := url.URL{
+		Scheme: addon.Type,
+		User:   url.UserPassword(addon.Username, addon.Password),
+		Host:   addonStackName(addon.ID) + ":" + strconv.Itoa(engine.Port),
+		Path:   "/" + addon.DatabaseName,
+	}
+	if addon.Type == models.ADDON_TYPE_POSTGRES {
+		// Traffic stays on the add-on's private network
+		u.RawQuery = "sslmode=disable"
+	}
+	return u.String()
+}
+
+// addonStackName returns the stack of an add-on, which is also the host name it
+// is reachable at
+func addonStackName(addonID string) string {
+	return ADDON_STACK_PREFIX + addonID
+}
+
+func addonNetworkName(addonID string) string {
+	return addonStackName(addonID) + "-net"
+}
+
+func addonVolumeName(addonID string) string {
+	return addonStackName(addonID) + "-data"
+}
+
+// ensureAddonNetwork creates the private network of an add-on. It is internal, so
+// the database can't reach or be reached from outside the swarm.
+func ensureAddonNetwork(addonID string) error {
+	name := addonNetworkName(addonID)
+	if _, err := runDocker("network", "inspect", name); err == nil {
+		return nil
+	}
+	_, err := runDocker("network", "create", "--driver", "overlay", "--attachable", "--internal", "--scope", "swarm", name)
+	return err
+}
+
+// writeAddonCompose writes the compose file of an add-on. It holds the password,
+// so only the owner can read it.
+func writeAddonCompose(addon *models.Addon) error {
+	engine := addonEngines[addon.Type]
+	data := struct {
+		Image, Service, Alias, Network, Volume, DataPath string
+		Environment                                      []string
+		Command                                          string
+	}{
+		Image:    engine.Image + ":" + addon.Version,
+		Service:  ADDON_SERVICE,
+		Alias:    addonStackName(addon.ID),
+		Network:  addonNetworkName(addon.ID),
+		Volume:   addonVolumeName(addon.ID),
+		DataPath: engine.DataPath,
+	}
+
+	switch addon.Type {
+	case models.ADDON_TYPE_POSTGRES:
+		data.Environment = composeEnvironment(map[string]string{
+			"POSTGRES_USER":     addon.Username,
+			"POSTGRES_PASSWORD": addon.Password,
+			"POSTGRES_DB":       addon.DatabaseName,
+		})
+	case models.ADDON_TYPE_MYSQL:
+		data.Environment = composeEnvironment(map[string]string{
+			"MYSQL_USER":          addon.Username,
+			"MYSQL_PASSWORD":      addon.Password,
+			"MYSQL_DATABASE":      addon.DatabaseName,
+			"MYSQL_ROOT_PASSWORD": addon.Password,
+		})
+	case models.ADDON_TYPE_REDIS:
+		data.Environment = composeEnvironment(map[string]string{
+			"REDIS_PASSWORD": addon.Password,
+		})
+		data.Command = `["sh", "-c", "exec redis-server --appendonly yes --requirepass \"$$REDIS_PASSWORD\""]`
+	}
+
+	tmpl := `version: '3.8'
+services:
+  {{.Service}}:
+    image: {{.Image}}
+{{- if .Command}}
+    command: {{.Command}}
+{{- end}}
+    environment:
+{{- range .Environment}}
+      - {{.}}
+{{- end}}
+    volumes:
+      - {{.Volume}}:{{.DataPath}}
+    networks:
+      addon:
+        aliases:
+          - {{.Alias}}
+    deploy:
+      replicas: 1
+      restart_policy:
+        condition: any
+
+volumes:
+  {{.Volume}}:
+    name: {{.Volume}}
+
+networks:
+  addon:
+    external: true
+    name: {{.Network}}
+`
+
+	t, err := template.New("addon-compose").Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	servicePath := filepath.Join(SERVICES_BASE_PATH, addonStackName(addon.ID))
+	if err := os.MkdirAll(servicePath, 0755); err != nil {
+		return fmt.Errorf("failed to create service directory: %w", err)
+	}
+
+	f, err := os.OpenFile(filepath.Join(servicePath, "docker-compose.yml"), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create docker-compose file: %w", err)
+	}
+	defer f.Close()
+
+	if err := t.Execute(f, data); err != nil {
+		return fmt.Errorf("failed to write docker-compose: %w", err)
+	}
+	return nil
+}
+
+func deployAddonStack(addon *models.Addon) error {
+	stackName := addonStackName(addon.ID)
+	composePath := filepath.Join(SERVICES_BASE_PATH, stackName, "docker-compose.yml")
+	if _, err := runDocker("stack", "deploy", "--prune", "-c", composePath, stackName); err != nil {
+		return fmt.Errorf("failed to deploy add-on: %w", err)
+	}
+	return nil
+}
+
+// removeAddonResources removes what an add-on leaves on the host, whether or not
+// it was fully provisioned
+func removeAddonResources(ctx context.Context, addonID string) error {
+	stackName := addonStackName(addonID)
+	if _, err := runDocker("stack", "rm", stackName); err != nil {
+		return err
+	}
+	if err := waitForStackContainers(ctx, stackName, time.Now().Add(TEARDOWN_STOP_TIMEOUT)); err != nil {
+		return err
+	}
+
+	var errs []error
+	if output, err := runDocker("volume", "ls", "--quiet", "--filter", "name=^"+addonVolumeName(addonID)+"$"); err != nil {
+		errs = append(errs, err)
+	} else if output != "" {
+		if _, err := runDocker("volume", "rm", addonVolumeName(addonID)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if _, err := runDocker("network", "inspect", addonNetworkName(addonID)); err == nil {
+		if _, err := runDocker("network", "rm", addonNetworkName(addonID)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := os.RemoveAll(filepath.Join(SERVICES_BASE_PATH, stackName)); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// changeAddonPassword changes the password inside a running add-on. The statements
+// go through stdin and the old password through the environment, so neither shows
+// up in the process list.
+func changeAddonPassword(addon *models.Addon, password string) error {
+	container, err := addonContainer(addon.ID)
+	if err != nil {
+		return err
+	}
+
+	var cmd *exec.Cmd
+	switch addon.Type {
+	case models.ADDON_TYPE_POSTGRES:
+		cmd = exec.Command("docker", "exec", "-i", container, "psql", "-v", "ON_ERROR_STOP=1", "-U", addon.Username, "-d", addon.DatabaseName)
+		cmd.Stdin = strings.NewReader(fmt.Sprintf("ALTER USER \"%s\" WITH PASSWORD '%s';\n", addon.Username, password))
+	case models.ADDON_TYPE_MYSQL:
+		cmd = exec.Command("docker", "exec", "-i", "-e", "MYSQL_PWD", container, "mysql", "-uroot")
+		cmd.Env = append(os.Environ(), "MYSQL_PWD="+addon.Password)
+		cmd.Stdin = strings.NewReader(fmt.Sprintf(
+			"ALTER USER '%[1]s'@'%%' IDENTIFIED BY '%[2]s';\nALTER USER 'root'@'%%' IDENTIFIED BY '%[2]s';\nALTER USER 'root'@'localhost' IDENTIFIED BY '%[2]s';\n",
+			addon.Username, password))
+	case models.ADDON_TYPE_REDIS:
+		cmd = exec.Command("docker", "exec", "-i", "-e", "REDISCLI_AUTH", container, "redis-cli")
+		cmd.Env = append(os.Environ(), "REDISCLI_AUTH="+addon.Password)
+		cmd.Stdin = strings.NewReader(fmt.Sprintf("CONFIG SET requirepass %s\n", password))
+	default:
+		return fmt.Errorf("unknown add-on type %q", addon.Type)
+	}
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w, output: %s", err, scrubSecrets(strings.TrimSpace(string(output)), addon.Password, password))
+	}
+	return nil
+}
+
+// addonContainer returns the running container of an add-on
+func addonContainer(addonID string) (string, error) {
+	output, err := runDocker("ps", "--quiet", "--filter", "label=com.docker.swarm.service.name="+addonStackName(addonID)+"_"+ADDON_SERVICE)
+	if err != nil {
+		return "", err
+	}
+	containers := strings.Fields(output)
+	if len(containers) == 0 {
+		return "", errors.New("add-on container is not running")
+	}
+	return containers[0], nil
+}
+
+// composeEnvironment renders environment variables as compose list entries
+func composeEnvironment(env map[string]string) []string {
+	variables := make([]models.EnvironmentVariable, 0, len(env))
+	for key, value := range env {
+		variables = append(variables, models.EnvironmentVariable{Key: key, Value: value})
+	}
+	return composeEnvEntries(variables)
+}
+
+// generateAddonPassword returns a random password that is safe in URLs and SQL literals
+func generateAddonPassword() (string, error) {
+	passwordBytes := make([]byte, 24)
+	if _, err := rand.Read(passwordBytes); err != nil {
+		return "", fmt.Errorf("failed to generate password: %w", err)
+	}
+	return hex.EncodeToString(passwordBytes), nil
+}
@@ -0,0 +1,724 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	BACKUP_HELPER_IMAGE       = "alpine:3" // Runs the tar snapshots and restores of volumes
+	BACKUP_REDIS_SAVE_TIMEOUT = 10 * time.Minute
+	BACKUP_ERROR_LIMIT        = 4 * 1024 // Bytes of a failed command's output kept with the backup
+)
+
+// BackupService backs up add-on databases and project volumes on the schedules
+// of their policies, and on demand. One backup or restore of a policy runs at a
+// time.
+type BackupService struct {
+	store     store.Store
+	validator *validator.Validate
+
+	mu   sync.Mutex
+	busy map[string]bool // Policy IDs with a backup or restore in progress
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewBackupService(store store.Store) *BackupService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &BackupService{
+		store:     store,
+		validator: validator.New(),
+		busy:      make(map[string]bool),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// Start runs the scheduler, which checks every policy at the start of each minute.
+// Minutes missed while Kova was down aren't caught up on.
+func (s *BackupService) Start() {
+	// Backups recorded as running were cut off by the last shutdown
+	if err := s.store.FailRunningBackups(s.ctx, "interrupted by a restart of Kova"); err != nil {
+		log.Printf("⚠️  Failed to close interrupted backups: %v", err)
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		for {
+			next := time.Now().Truncate(time.Minute).Add(time.Minute)
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-time.After(time.Until(next)):
+			}
+			s.schedule(next)
+		}
+	}()
+	log.Println("💾 Backup scheduler started")
+}
+
+// Shutdown stops the scheduler and the backups and restores in progress
+func (s *BackupService) Shutdown() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// GetBackupPolicies returns the backup policies of a user
+func (s *BackupService) GetBackupPolicies(ctx context.Context, userID string) ([]*models.BackupPolicy, error) {
+	policies, err := s.store.GetBackupPoliciesByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backup policies: %w", err)
+	}
+	for _, policy := range policies {
+		withBackupNextRun(policy)
+	}
+	return policies, nil
+}
+
+// GetBackupPolicy returns a backup policy
+func (s *BackupService) GetBackupPolicy(ctx context.Context, userID, policyID string) (*models.BackupPolicy, error) {
+	policy, err := s.getPolicy(ctx, userID, policyID)
+	if err != nil {
+		return nil, err
+	}
+	return withBackupNextRun(policy), nil
+}
+
+// CreateBackupPolicy adds a backup policy for an add-on or a project volume
+func (s *BackupService) CreateBackupPolicy(ctx context.Context, userID string, req *models.CreateBackupPolicyRequest) (*models.BackupPolicy, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if req.Schedule != "" {
+		if _, err := parseCronSchedule(req.Schedule); err != nil {
+			return nil, fmt.Errorf("validation failed: invalid schedule: %w", err)
+		}
+	}
+	if err := validateBackupDestination(&req.Destination); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	req.SetDefaults()
+
+	policy := &models.BackupPolicy{
+		UserID:      userID,
+		Name:        req.Name,
+		TargetType:  req.TargetType,
+		Schedule:    strings.TrimSpace(req.Schedule),
+		Retention:   req.Retention,
+		Destination: req.Destination,
+		Enabled:     *req.Enabled,
+	}
+
+	switch req.TargetType {
+	case models.BACKUP_TARGET_ADDON:
+		addon, err := s.store.GetAddonByID(ctx, req.AddonID)
+		if err != nil {
+			return nil, fmt.Errorf("add-on not found: %w", err)
+		}
+		if addon.UserID != userID {
+			return nil, errors.New("access denied: add-on does not belong to user")
+		}
+		policy.AddonID = addon.ID
+	case models.BACKUP_TARGET_VOLUME:
+		project, err := s.store.GetProjectByID(ctx, req.ProjectID)
+		if err != nil {
+			return nil, fmt.Errorf("project not found: %w", err)
+		}
+		if !project.IsOwnedBy(userID) {
+			return nil, errors.New("access denied: project does not belong to user")
+		}
+		// Volumes are named as declared on the project, or by their docker name
+		volumeName := req.VolumeName
+		if project.HasVolume(volumeName) {
+			volumeName = project.VolumeName(volumeName)
+		}
+		if err := checkProjectVolume(project.ID, volumeName); err != nil {
+			return nil, fmt.Errorf("validation failed: %w", err)
+		}
+		policy.ProjectID = project.ID
+		policy.VolumeName = volumeName
+	}
+
+	exists, err := s.store.BackupPolicyExistsByUserIDAndName(ctx, userID, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check backup policy name: %w", err)
+	}
+	if exists {
+		return nil, fmt.Errorf("backup policy %s already exists", req.Name)
+	}
+
+	if err := s.store.CreateBackupPolicy(ctx, policy); err != nil {
+		return nil, fmt.Errorf("failed to create backup policy: %w", err)
+	}
+
+	log.Printf("💾 Backup policy %s created for %s %s", policy.Name, policy.TargetType, backupTargetName(policy))
+	return withBackupNextRun(policy), nil
+}
+
+// UpdateBackupPolicy changes the settings of a backup policy. The target can't be
+// changed, since the existing backups are of it.
+func (s *BackupService) UpdateBackupPolicy(ctx context.Context, userID, policyID string, req *models.UpdateBackupPolicyRequest) (*models.BackupPolicy, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	policy, err := s.getPolicy(ctx, userID, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" && req.Name != policy.Name {
+		exists, err := s.store.BackupPolicyExistsByUserIDAndName(ctx, userID, req.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check backup policy name: %w", err)
+		}
+		if exists {
+			return nil, fmt.Errorf("backup policy %s already exists", req.Name)
+		}
+		policy.Name = req.Name
+	}
+	switch req.Schedule {
+	case "":
+	case "-":
+		policy.Schedule = ""
+	default:
+		if _, err := parseCronSchedule(req.Schedule); err != nil {
+			return nil, fmt.Errorf("validation failed: invalid schedule: %w", err)
+		}
+		policy.Schedule = strings.TrimSpace(req.Schedule)
+	}
+	if req.Retention != 0 {
+		policy.Retention = req.Retention
+	}
+	if req.Destination != nil {
+		destination := *req.Destination
+		// The secret isn't returned, so it is kept when left out
+		if destination.Type == models.BACKUP_DESTINATION_S3 && destination.SecretAccessKey == "" && policy.Destination.Type == models.BACKUP_DESTINATION_S3 {
+			destination.SecretAccessKey = policy.Destination.SecretAccessKey
+		}
+		if err := validateBackupDestination(&destination); err != nil {
+			return nil, fmt.Errorf("validation failed: %w", err)
+		}
+		policy.Destination = destination
+	}
+	if req.Enabled != nil {
+		policy.Enabled = *req.Enabled
+	}
+
+	updatedPolicy, err := s.store.UpdateBackupPolicy(ctx, policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update backup policy: %w", err)
+	}
+	return withBackupNextRun(updatedPolicy), nil
+}
+
+// DeleteBackupPolicy deletes a backup policy and its history. The backups stay in
+// the destination.
+func (s *BackupService) DeleteBackupPolicy(ctx context.Context, userID, policyID string) error {
+	policy, err := s.getPolicy(ctx, userID, policyID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.DeleteBackupPolicy(ctx, policy.ID); err != nil {
+		return fmt.Errorf("failed to delete backup policy: %w", err)
+	}
+
+	log.Printf("💾 Backup policy %s deleted", policy.Name)
+	return nil
+}
+
+// TriggerBackup starts a backup of a policy's target now, whether or not the
+// policy is enabled
+func (s *BackupService) TriggerBackup(ctx context.Context, userID, policyID string) (*models.Backup, error) {
+	policy, err := s.getPolicy(ctx, userID, policyID)
+	if err != nil {
+		return nil, err
+	}
+	return s.dispatch(ctx, policy, &models.Backup{
+		PolicyID:  policy.ID,
+		Operation: models.BACKUP_OPERATION_BACKUP,
+		Trigger:   models.BACKUP_TRIGGER_MANUAL,
+	})
+}
+
+// RestoreBackup replaces the data of a policy's target with one of its backups.
+// The target's services are stopped while its data is replaced.
+func (s *BackupService) RestoreBackup(ctx context.Context, userID, policyID, backupID string) (*models.Backup, error) {
+	policy, err := s.getPolicy(ctx, userID, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := s.store.GetBackupByID(ctx, backupID)
+	if err != nil || source.PolicyID != policy.ID {
+		return nil, errors.New("backup not found")
+	}
+	if source.Operation != models.BACKUP_OPERATION_BACKUP || source.Status != models.BACKUP_STATUS_SUCCEEDED {
+		return nil, errors.New("validation failed: only successful backups can be restored")
+	}
+
+	return s.dispatch(ctx, policy, &models.Backup{
+		PolicyID:     policy.ID,
+		Operation:    models.BACKUP_OPERATION_RESTORE,
+		Trigger:      models.BACKUP_TRIGGER_MANUAL,
+		RestoredFrom: source.ID,
+		ObjectKey:    source.ObjectKey,
+	})
+}
+
+// GetBackups returns a page of a policy's backups and restores, newest first,
+// with the total number of them
+func (s *BackupService) GetBackups(ctx context.Context, userID, policyID string, limit, offset int) ([]*models.Backup, int64, error) {
+	policy, err := s.getPolicy(ctx, userID, policyID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	backups, err := s.store.GetBackupsByPolicyID(ctx, policy.ID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get backups: %w", err)
+	}
+
+	total, err := s.store.CountBackupsByPolicyID(ctx, policy.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count backups: %w", err)
+	}
+	return backups, total, nil
+}
+
+// schedule starts the backups of the enabled policies due in the minute starting at t
+func (s *BackupService) schedule(t time.Time) {
+	policies, err := s.store.GetScheduledBackupPolicies(s.ctx)
+	if err != nil {
+		log.Printf("⚠️  Failed to load backup policies: %v", err)
+		return
+	}
+
+	for _, policy := range policies {
+		schedule, err := parseCronSchedule(policy.Schedule)
+		if err != nil || !schedule.Matches(t) {
+			continue
+		}
+		// Guards against backing up twice for one minute when the clock steps back
+		if policy.LastScheduledAt != nil && !policy.LastScheduledAt.Before(t) {
+			continue
+		}
+
+		if err := s.store.UpdateBackupPolicyLastScheduledAt(s.ctx, policy.ID, t); err != nil {
+			log.Printf("⚠️  Failed to schedule backup policy %s: %v", policy.ID, err)
+			continue
+		}
+		_, err = s.dispatch(s.ctx, policy, &models.Backup{
+			PolicyID:  policy.ID,
+			Operation: models.BACKUP_OPERATION_BACKUP,
+			Trigger:   models.BACKUP_TRIGGER_SCHEDULE,
+		})
+		if err != nil {
+			log.Printf("⚠️  Backup policy %s not run: %v", policy.Name, err)
+		}
+	}
+}
+
+// dispatch records a backup or restore and runs it in the background
+func (s *BackupService) dispatch(ctx context.Context, policy *models.BackupPolicy, backup *models.Backup) (*models.Backup, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.busy[policy.ID] {
+		return nil, errors.New("a backup or restore of this policy is already in progress")
+	}
+
+	objectKey := backup.ObjectKey
+	if err := s.store.CreateBackup(ctx, backup); err != nil {
+		return nil, fmt.Errorf("failed to record backup: %w", err)
+	}
+	backup.ObjectKey = objectKey
+
+	s.busy[policy.ID] = true
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			s.mu.Lock()
+			delete(s.busy, policy.ID)
+			s.mu.Unlock()
+		}()
+		s.execute(policy, backup)
+	}()
+
+	log.Printf("💾 %s of %s %s started (%s)", backup.Operation, policy.TargetType, backupTargetName(policy), backup.Trigger)
+	return backup, nil
+}
+
+// execute runs a backup or restore and records its outcome
+func (s *BackupService) execute(policy *models.BackupPolicy, backup *models.Backup) {
+	var err error
+	if backup.Operation == models.BACKUP_OPERATION_RESTORE {
+		err = s.restore(policy, backup)
+	} else {
+		err = s.backup(policy, backup)
+	}
+
+	backup.Status = models.BACKUP_STATUS_SUCCEEDED
+	if err != nil {
+		backup.Status = models.BACKUP_STATUS_FAILED
+		backup.Error = err.Error()
+		if len(backup.Error) > BACKUP_ERROR_LIMIT {
+			backup.Error = backup.Error[:BACKUP_ERROR_LIMIT]
+		}
+	}
+	if err := s.store.FinishBackup(context.Background(), backup); err != nil {
+		log.Printf("⚠️  Failed to record the outcome of backup %s: %v", backup.ID, err)
+	}
+	log.Printf("💾 %s of %s %s %s", backup.Operation, policy.TargetType, backupTargetName(policy), backup.Status)
+
+	if backup.Operation == models.BACKUP_OPERATION_BACKUP && err == nil {
+		s.prune(policy)
+	}
+}
+
+// backup dumps a policy's target to a temporary file, then uploads it
+func (s *BackupService) backup(policy *models.BackupPolicy, backup *models.Backup) error {
+	storage, err := newBackupStorage(policy.Destination)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp("", "kova-backup-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	extension, err := s.dump(policy, file)
+	if err != nil {
+		return err
+	}
+
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	key := path.Join(policy.ID, backup.StartedAt.UTC().Format("20060102T150405Z")+"-"+backup.ID+"."+extension)
+	if err := storage.Put(s.ctx, key, file, size); err != nil {
+		return fmt.Errorf("upload failed: %w", err)
+	}
+
+	backup.ObjectKey = key
+	backup.SizeBytes = size
+	return nil
+}
+
+// restore downloads a backup to a temporary file, then loads it into the target
+func (s *BackupService) restore(policy *models.BackupPolicy, backup *models.Backup) error {
+	storage, err := newBackupStorage(policy.Destination)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp("", "kova-restore-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	if err := storage.Get(s.ctx, backup.ObjectKey, file); err != nil {
+		return fmt.Errorf("download failed: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	return s.load(policy, file)
+}
+
+// prune removes the successful backups of a policy beyond its retention
+func (s *BackupService) prune(policy *models.BackupPolicy) {
+	expired, err := s.store.GetExpiredBackups(s.ctx, policy.ID, policy.Retention)
+	if err != nil || len(expired) == 0 {
+		return
+	}
+
+	storage, err := newBackupStorage(policy.Destination)
+	if err != nil {
+		return
+	}
+	for _, backup := range expired {
+		if err := storage.Delete(s.ctx, backup.ObjectKey); err != nil {
+			log.Printf("⚠️  Failed to remove expired backup %s: %v", backup.ID, err)
+			continue
+		}
+		if err := s.store.DeleteBackup(s.ctx, backup.ID); err != nil {
+			log.Printf("⚠️  Failed to delete expired backup %s: %v", backup.ID, err)
+		}
+	}
+	log.Printf("🧹 Removed %d backups of %s beyond its retention of %d", len(expired), policy.Name, policy.Retention)
+}
+
+// dump writes a snapshot of a policy's target and returns its file extension:
+// pg_dump's custom format for Postgres, SQL for MySQL, the RDB file of a BGSAVE
+// for Redis and a tar of the volume's files for volumes
+func (s *BackupService) dump(policy *models.BackupPolicy, w io.Writer) (string, error) {
+	if policy.TargetType == models.BACKUP_TARGET_VOLUME {
+		err := runBackupCommand(s.ctx, nil, nil, w, "run", "--rm", "-v", policy.VolumeName+":/data:ro", BACKUP_HELPER_IMAGE, "tar", "-czf", "-", "-C", "/data", ".")
+		return "tar.gz", err
+	}
+
+	addon, container, err := s.addonTarget(policy)
+	if err != nil {
+		return "", err
+	}
+
+	switch addon.Type {
+	case models.ADDON_TYPE_POSTGRES:
+		err := runBackupCommand(s.ctx, nil, nil, w, "exec", container, "pg_dump", "-U", addon.Username, "-d", addon.DatabaseName, "--format=custom")
+		return "dump", err
+	case models.ADDON_TYPE_MYSQL:
+		err := runBackupCommand(s.ctx, []string{"MYSQL_PWD=" + addon.Password}, nil, w,
+			"exec", "-e", "MYSQL_PWD", container, "mysqldump", "-uroot", "--single-transaction", "--routines", "--triggers", addon.DatabaseName)
+		return "sql", err
+	case models.ADDON_TYPE_REDIS:
+		if err := redisSave(s.ctx, addon, container); err != nil {
+			return "", err
+		}
+		// docker cp writes a tar of the file to stdout
+		err := runBackupCommand(s.ctx, nil, nil, w, "cp", container+":/data/dump.rdb", "-")
+		return "tar", err
+	}
+	return "", fmt.Errorf("unknown add-on type %q", addon.Type)
+}
+
+// load replaces the data of a policy's target with a snapshot written by dump
+func (s *BackupService) load(policy *models.BackupPolicy, r io.Reader) error {
+	if policy.TargetType == models.BACKUP_TARGET_VOLUME {
+		output, err := runDocker("volume", "inspect", "--format", `{{index .Labels "`+STACK_NAMESPACE_LABEL+`"}}`, policy.VolumeName)
+		if err != nil {
+			return err
+		}
+		return withStackPaused(s.ctx, output, func() error {
+			return runBackupCommand(s.ctx, nil, r, nil, "run", "--rm", "-i", "-v", policy.VolumeName+":/data", BACKUP_HELPER_IMAGE,
+				"sh", "-c", "find /data -mindepth 1 -delete && tar -xzf - -C /data")
+		})
+	}
+
+	addon, container, err := s.addonTarget(policy)
+	if err != nil {
+		return err
+	}
+
+	switch addon.Type {
+	case models.ADDON_TYPE_POSTGRES:
+		return runBackupCommand(s.ctx, nil, r, nil, "exec", "-i", container,
+			"pg_restore", "-U", addon.Username, "-d", addon.DatabaseName, "--clean", "--if-exists", "--no-owner")
+	case models.ADDON_TYPE_MYSQL:
+		return runBackupCommand(s.ctx, []string{"MYSQL_PWD=" + addon.Password}, r, nil,
+			"exec", "-i", "-e", "MYSQL_PWD", container, "mysql", "-uroot", addon.DatabaseName)
+	case models.ADDON_TYPE_REDIS:
+		// Redis only loads its data at startup. The append only files are removed so
+		// it starts from the restored RDB file.
+		return withStackPaused(s.ctx, addonStackName(addon.ID), func() error {
+			return runBackupCommand(s.ctx, nil, r, nil, "run", "--rm", "-i", "-v", addonVolumeName(addon.ID)+":/data", BACKUP_HELPER_IMAGE,
+				"sh", "-c", "rm -rf /data/appendonlydir /data/dump.rdb && tar -xf - -C /data")
+		})
+	}
+	return fmt.Errorf("unknown add-on type %q", addon.Type)
+}
+
+// addonTarget returns the add-on of a policy and its running container
+func (s *BackupService) addonTarget(policy *models.BackupPolicy) (*models.Addon, string, error) {
+	addon, err := s.store.GetAddonByID(s.ctx, policy.AddonID)
+	if err != nil {
+		return nil, "", fmt.Errorf("add-on not found: %w", err)
+	}
+	if addon.Status != models.ADDON_STATUS_RUNNING {
+		return nil, "", fmt.Errorf("add-on is %s, not running", addon.Status)
+	}
+
+	container, err := addonContainer(addon.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	return addon, container, nil
+}
+
+func (s *BackupService) getPolicy(ctx context.Context, userID, policyID string) (*models.BackupPolicy, error) {
+	policy, err := s.store.GetBackupPolicyByID(ctx, policyID)
+	if err != nil {
+		return nil, fmt.Errorf("backup policy not found: %w", err)
+	}
+
+	if policy.UserID != userID {
+		return nil, errors.New("access denied: backup policy does not belong to user")
+	}
+	return policy, nil
+}
+
+// withBackupNextRun fills in when a policy next backs up and leaves out the
+// destination's secret
+func withBackupNextRun(policy *models.BackupPolicy) *models.BackupPolicy {
+	policy.Destination.SecretAccessKey = ""
+	if !policy.Enabled || policy.Schedule == "" {
+		return policy
+	}
+	if schedule, err := parseCronSchedule(policy.Schedule); err == nil {
+		if next := schedule.Next(time.Now()); !next.IsZero() {
+			policy.NextRunAt = &next
+		}
+	}
+	return policy
+}
+
+func backupTargetName(policy *models.BackupPolicy) string {
+	if policy.TargetType == models.BACKUP_TARGET_VOLUME {
+		return policy.VolumeName
+	}
+	return policy.AddonID
+}
+
+// checkProjectVolume checks a volume was created by a stack of a project
+func checkProjectVolume(projectID, volumeName string) error {
+	if volumeName == "" {
+		return errors.New("volume_name is required for volume backups")
+	}
+	output, err := runDocker("volume", "inspect", "--format", `{{index .Labels "`+STACK_NAMESPACE_LABEL+`"}}`, volumeName)
+	if err != nil {
+		return fmt.Errorf("volume %s not found", volumeName)
+	}
+	if output == "" || projectIDForStack(output) != projectID {
+		return fmt.Errorf("volume %s does not belong to the project", volumeName)
+	}
+	return nil
+}
+
+// redisSave runs a BGSAVE and waits for it to finish, so dump.rdb is current
+func redisSave(ctx context.Context, addon *models.Addon, container string) error {
+	redisCLI := func(args ...string) (string, error) {
+		var output strings.Builder
+		err := runBackupCommand(ctx, []string{"REDISCLI_AUTH=" + addon.Password}, nil, &output,
+			append([]string{"exec", "-e", "REDISCLI_AUTH", container, "redis-cli"}, args...)...)
+		return strings.TrimSpace(output.String()), err
+	}
+
+	lastSave, err := redisCLI("LASTSAVE")
+	if err != nil {
+		return err
+	}
+	if _, err := redisCLI("BGSAVE"); err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(BACKUP_REDIS_SAVE_TIMEOUT)
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+
+		current, err := redisCLI("LASTSAVE")
+		if err != nil {
+			return err
+		}
+		if current != lastSave {
+			return nil
+		}
+	}
+	return errors.New("redis BGSAVE did not finish in time")
+}
+
+// withStackPaused scales the services of a stack to zero while fn runs, then back
+// to the replicas they had
+func withStackPaused(ctx context.Context, stackName string, fn func() error) error {
+	if stackName == "" {
+		return fn()
+	}
+
+	output, err := runDocker("service", "ls", "--filter", "label="+STACK_NAMESPACE_LABEL+"="+stackName, "--format", "{{.Name}} {{.Replicas}}")
+	if err != nil {
+		return err
+	}
+
+	pause := []string{"service", "scale", "--detach"}
+	resume := []string{"service", "scale", "--detach"}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		// Replicas are listed as running/desired
+		_, desired, _ := strings.Cut(fields[1], "/")
+		if _, err := strconv.Atoi(desired); err != nil {
+			continue
+		}
+		pause = append(pause, fields[0]+"=0")
+		resume = append(resume, fields[0]+"="+desired)
+	}
+	if len(pause) == 3 {
+		return fn()
+	}
+
+	log.Printf("⏸️  Pausing stack %s", stackName)
+	if _, err := runDocker(pause...); err != nil {
+		return err
+	}
+	defer func() {
+		log.Printf("▶️  Resuming stack %s", stackName)
+		if _, err := runDocker(resume...); err != nil {
+			log.Printf("⚠️  Failed to resume stack %s: %v", stackName, err)
+		}
+	}()
+
+	if err := waitForStackContainers(ctx, stackName, time.Now().Add(TEARDOWN_STOP_TIMEOUT)); err != nil {
+		return err
+	}
+	return fn()
+}
+
+// runBackupCommand runs a docker CLI command with the given stdin and stdout.
+// Secret values are passed through env so they stay out of the process list.
+func runBackupCommand(ctx context.Context, env []string, stdin io.Reader, stdout io.Writer, args ...string) error {
+	stderr := &tailBuffer{limit: BACKUP_ERROR_LIMIT}
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	if env != nil {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		var secrets []string
+		for _, variable := range env {
+			_, value, _ := strings.Cut(variable, "=")
+			secrets = append(secrets, value)
+		}
+		return fmt.Errorf("docker %s failed: %w, output: %s", args[0], err, scrubSecrets(strings.TrimSpace(stderr.String()), secrets...))
+	}
+	return nil
+}
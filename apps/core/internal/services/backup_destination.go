@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+)
+
+const (
+	BACKUP_BASE_PATH         = "/data/kova/backups" // Local destinations are directories under it
+	BACKUP_S3_DEFAULT_REGION = "us-east-1"
+)
+
+// backupStorage stores the backups of a policy under keys like
+// <policy>/<time>-<backup>.<ext>
+type backupStorage interface {
+	Put(ctx context.Context, key string, file *os.File, size int64) error
+	Get(ctx context.Context, key string, w io.Writer) error
+	Delete(ctx context.Context, key string) error
+}
+
+// validateBackupDestination checks a destination is complete and, for local
+// ones, stays inside the backup directory
+func validateBackupDestination(destination *models.BackupDestination) error {
+	switch destination.Type {
+	case models.BACKUP_DESTINATION_LOCAL:
+		if destination.Path != "" && !filepath.IsLocal(destination.Path) {
+			return fmt.Errorf("path %q must be relative to the backup directory", destination.Path)
+		}
+	case models.BACKUP_DESTINATION_S3:
+		if destination.Endpoint == "" || destination.Bucket == "" || destination.AccessKeyID == "" || destination.SecretAccessKey == "" {
+			return errors.New("s3 destinations need an endpoint, a bucket and credentials")
+		}
+		endpoint, err := url.Parse(destination.Endpoint)
+		if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+			return fmt.Errorf("invalid endpoint %q", destination.Endpoint)
+		}
+		destination.Prefix = strings.Trim(destination.Prefix, "/")
+	default:
+		return fmt.Errorf("unknown destination type %q", destination.Type)
+	}
+	return nil
+}
+
+// newBackupStorage returns the storage of a validated destination
+func newBackupStorage(destination models.BackupDestination) (backupStorage, error) {
+	if destination.Type == models.BACKUP_DESTINATION_LOCAL {
+		return &localBackupStorage{dir: filepath.Join(BACKUP_BASE_PATH, destination.Path)}, nil
+	}
+
+	endpoint, err := url.Parse(destination.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	region := destination.Region
+	if region == "" {
+		region = BACKUP_S3_DEFAULT_REGION
+	}
+	return &s3BackupStorage{
+		endpoint:  endpoint,
+		region:    region,
+		bucket:    destination.Bucket,
+		prefix:    destination.Prefix,
+		accessKey: destination.AccessKeyID,
+		secretKey: destination.SecretAccessKey,
+		client:    &http.Client{},
+	}, nil
+}
+
+// localBackupStorage keeps backups in a directory on the host
+type localBackupStorage struct {
+	dir string
+}
+
+func (s *localBackupStorage) Put(ctx context.Context, key string, file *os.File, size int64) error {
+	target := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	// Written next to the target and renamed, so a partial backup is never left behind
+	out, err := os.CreateTemp(filepath.Dir(target), ".partial-*")
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	defer os.Remove(out.Name())
+	defer out.Close()
+
+	if _, err := io.Copy(out, file); err != nil {
+		return fmt.Errorf("failed to write backup file: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to write backup file: %w", err)
+	}
+	return os.Rename(out.Name(), target)
+}
+
+func (s *localBackupStorage) Get(ctx context.Context, key string, w io.Writer) error {
+	file, err := os.Open(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if err != nil {
+		return fmt.Errorf("failed to open backup file: %w", err)
+	}
+	defer file.Close()
+
+	_, err = io.Copy(w, file)
+	return err
+}
+
+func (s *localBackupStorage) Delete(ctx context.Context, key string) error {
+	err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// s3BackupStorage keeps backups in a bucket of an S3-compatible store. Requests
+// are signed with AWS Signature Version 4 and address the bucket in the path, which
+// MinIO and the other S3-compatible stores support as well as AWS.
+type s3BackupStorage struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	prefix    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+}
+
+func (s *s3BackupStorage) Put(ctx context.Context, key string, file *os.File, size int64) error {
+	resp, err := s.do(ctx, http.MethodPut, key, file, size)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *s3BackupStorage) Get(ctx context.Context, key string, w io.Writer) error {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, 0)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+func (s *s3BackupStorage) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, 0)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// do sends a signed request for an object and fails on error responses
+func (s *s3BackupStorage) do(ctx context.Context, method, key string, body io.Reader, size int64) (*http.Response, error) {
+	objectPath := "/" + s.bucket + "/" + path.Join(s.prefix, key)
+	objectURL := *s.endpoint
+	objectURL.Path = strings.TrimSuffix(objectURL.Path, "/") + objectPath
+	objectURL.RawPath = s3EscapePath(objectURL.Path)
+
+	req, err := http.NewRequestWithContext(ctx, method, objectURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	s.sign(req, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 %s failed: %w", method, err)
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("s3 %s of %s failed with status %d: %s", method, key, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return resp, nil
+}
+
+// sign adds the AWS Signature Version 4 headers to a request. The payload isn't
+// hashed, so backups are streamed from disk without being read twice.
+func (s *s3BackupStorage) sign(req *http.Request, now time.Time) {
+	const payloadHash = "UNSIGNED-PAYLOAD"
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		"", // No query
+		"host:" + req.URL.Host + "\nx-amz-content-sha256:" + payloadHash + "\nx-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3EscapePath escapes a path the way Signature Version 4 expects, leaving only
+// unreserved characters and slashes as they are
+func s3EscapePath(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if c == '/' || c == '-' || c == '_' || c == '.' || c == '~' ||
+			('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
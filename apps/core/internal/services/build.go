@@ -3,14 +3,18 @@ package services
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"text/template"
+	"time"
 
 	"github.com/dopeCape/kova/internal/models"
 	"github.com/dopeCape/kova/internal/store"
@@ -22,32 +26,200 @@ const (
 	REPO_BASE_PATH             = "/data/kova/repo"
 	SERVICES_BASE_PATH         = "/data/kova/services"
 	NETWORK_NAME               = "proxy"
+	DEPLOYMENT_LABEL           = "kova.deployment" // Container label and log attribute naming the deployment that started a task
+	DEFAULT_CONTAINER_PORT     = 3000              // Port built images serve on, and images that expose none
 )
 
 type BuildJob struct {
 	ProjectID string
 	UserID    string
+	PreviewID string // Set for pull request preview jobs
+	Teardown  bool   // Remove the preview instead of building it
+}
+
+// deploySpec describes a single swarm stack rendered from a build
+type deploySpec struct {
+	StackName    string
+	Image        string
+	Port         int      // Port the exposed process serves on inside its containers
+	Domains      []string // Hosts routed to the stack, only verified domains are listed
+	TLS          bool     // Also route the hosts over HTTPS on the websecure entrypoint
+	CertResolver string
+	ForceHTTPS   bool            // Redirect plain HTTP to HTTPS
+	Deployment   string          // Recorded on the containers and every line they log
+	Processes    []deployProcess // One service each, the exposed one is routed to the domains
+	Stopped      bool            // Deploy every process with zero replicas, they keep their count in a label
+	Volumes      []models.Volume // Mounted into every process, previews run without them
+
+	// Runtime environment and extra networks of every process, which give it
+	// access to the project's add-ons
+	Environment []models.EnvironmentVariable
+	Networks    []string
+
+	// Middlewares rendered from the project's routing policy, in the order routers apply them
+	Middlewares      []string
+	MiddlewareLabels []string
+}
+
+// HTTPMiddlewares returns the middleware chain of the plain HTTP router, which only
+// redirects when HTTPS is forced
+func (s deploySpec) HTTPMiddlewares() string {
+	if s.ForceHTTPS {
+		return s.StackName + "-https-redirect"
+	}
+	return strings.Join(s.Middlewares, ",")
+}
+
+// SecureMiddlewares returns the middleware chain of the HTTPS router
+func (s deploySpec) SecureMiddlewares() string {
+	return strings.Join(s.Middlewares, ",")
+}
+
+// DeploymentLabel returns the label naming the deployment, which the log driver
+// attaches to every line
+func (s deploySpec) DeploymentLabel() string {
+	return DEPLOYMENT_LABEL
+}
+
+// ProcessLabel returns the label naming the process a service runs
+func (s deploySpec) ProcessLabel() string {
+	return PROCESS_LABEL
+}
+
+// EnvironmentEntries returns the runtime environment as compose list entries
+func (s deploySpec) EnvironmentEntries() []string {
+	return composeEnvEntries(s.Environment)
+}
+
+// HostRule returns the Traefik rule matching every routed domain
+func (s deploySpec) HostRule() string {
+	rules := make([]string, len(s.Domains))
+	for i, domain := range s.Domains {
+		rules[i] = "Host(`" + domain + "`)"
+	}
+	return strings.Join(rules, " || ")
+}
+
+// projectDeploySpec returns the spec of a project's main stack
+func projectDeploySpec(project *models.Project, verifiedDomains []string, tlsOptions TLSOptions) deploySpec {
+	spec := deploySpec{
+		StackName:    project.ID,
+		Image:        project.ID,
+		Port:         DEFAULT_CONTAINER_PORT,
+		Domains:      verifiedDomains,
+		TLS:          tlsOptions.Enabled,
+		CertResolver: tlsOptions.CertResolver,
+		ForceHTTPS:   tlsOptions.Enabled && project.ForceHTTPS,
+		Stopped:      project.Stopped || project.Status == "archived",
+		Volumes:      project.Volumes,
+	}
+	spec.MiddlewareLabels, spec.Middlewares = routingMiddlewares(spec.StackName, project.RoutingPolicy, spec.Domains)
+	return spec
+}
+
+// previewDeploySpec returns the spec of a pull request preview stack. Previews are
+// subdomains of the project's primary domain and are only routed once it is verified.
+func previewDeploySpec(project *models.Project, preview *models.Preview, verifiedDomains []string, tlsOptions TLSOptions) deploySpec {
+	spec := deploySpec{
+		StackName:    preview.StackName(),
+		Image:        preview.StackName(),
+		Port:         DEFAULT_CONTAINER_PORT,
+		TLS:          tlsOptions.Enabled,
+		CertResolver: tlsOptions.CertResolver,
+		ForceHTTPS:   tlsOptions.Enabled && project != nil && project.ForceHTTPS,
+	}
+	for _, domain := range verifiedDomains {
+		if strings.HasSuffix(preview.Domain, "."+domain) {
+			spec.Domains = []string{preview.Domain}
+			break
+		}
+	}
+	// Previews are protected by the same policy as the project
+	if project != nil {
+		spec.MiddlewareLabels, spec.Middlewares = routingMiddlewares(spec.StackName, project.RoutingPolicy, spec.Domains)
+	}
+	return spec
+}
+
+// composeEnvEntries renders environment variables as quoted compose list entries.
+// JSON strings are valid YAML, and $ is doubled so compose doesn't interpolate it.
+func composeEnvEntries(variables []models.EnvironmentVariable) []string {
+	entries := make([]string, 0, len(variables))
+	for _, variable := range variables {
+		encoded, _ := json.Marshal(variable.Key + "=" + strings.ReplaceAll(variable.Value, "$", "$$"))
+		entries = append(entries, string(encoded))
+	}
+	sort.Strings(entries)
+	return entries
+}
+
+// newDeploymentID identifies a deployment by when it started and the commit it deploys
+func newDeploymentID(sha string) string {
+	id := time.Now().UTC().Format("20060102-150405")
+	if len(sha) > 7 {
+		sha = sha[:7]
+	}
+	if sha != "" {
+		id += "-" + sha
+	}
+	return id
+}
+
+// SiteURL returns the URL a domain of a deployed app is served at
+func (bs *BuildService) SiteURL(domain string) string {
+	return bs.tlsOptions.siteURL(domain)
+}
+
+// syncCertificate installs or removes the custom TLS certificate of a project in Traefik
+func (bs *BuildService) syncCertificate(project *models.Project) error {
+	if bs.tlsOptions.Enabled && project.HasCustomCertificate() {
+		log.Printf("🔒 Installing custom certificate for project: %s", project.ID)
+		return bs.tlsOptions.writeCertificateConfig(project.ID, project.TLSCertificate, project.TLSPrivateKey)
+	}
+	return bs.tlsOptions.removeCertificateConfig(project.ID)
+}
+
+// verifiedDomains returns the domains of a project whose ownership has been proven
+func (bs *BuildService) verifiedDomains(ctx context.Context, projectID string) ([]string, error) {
+	projectDomains, err := bs.store.GetVerifiedProjectDomainsByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project domains: %w", err)
+	}
+
+	domains := make([]string, len(projectDomains))
+	for i, projectDomain := range projectDomains {
+		domains[i] = projectDomain.Domain
+	}
+	return domains, nil
 }
 
 type BuildService struct {
-	store        store.Store
-	accountStore store.AccountStore
-	queue        chan BuildJob
-	wg           sync.WaitGroup
-	wsHub        *WebSocketHub
-	ctx          context.Context
-	cancel       context.CancelFunc
+	store         store.Store
+	accountStore  store.AccountStore
+	githubService *GitHubService
+	appService    *GitHubAppService
+	providers     *GitProviders
+	tlsOptions    TLSOptions
+	queue         chan BuildJob
+	wg            sync.WaitGroup
+	wsHub         *WebSocketHub
+	ctx           context.Context
+	cancel        context.CancelFunc
 }
 
-func NewBuildService(store store.Store, accountStore store.AccountStore, wsHub *WebSocketHub) *BuildService {
+func NewBuildService(store store.Store, accountStore store.AccountStore, githubService *GitHubService, appService *GitHubAppService, providers *GitProviders, tlsOptions TLSOptions, wsHub *WebSocketHub) *BuildService {
 	ctx, cancel := context.WithCancel(context.Background())
 	bs := &BuildService{
-		store:        store,
-		accountStore: accountStore,
-		queue:        make(chan BuildJob, 100), // Buffer of 100 jobs
-		wsHub:        wsHub,
-		ctx:          ctx,
-		cancel:       cancel,
+		store:         store,
+		accountStore:  accountStore,
+		githubService: githubService,
+		appService:    appService,
+		providers:     providers,
+		tlsOptions:    tlsOptions,
+		queue:         make(chan BuildJob, 100), // Buffer of 100 jobs
+		wsHub:         wsHub,
+		ctx:           ctx,
+		cancel:        cancel,
 	}
 
 	// Start single worker
@@ -66,6 +238,27 @@ func (bs *BuildService) Enqueue(projectID, userID string) {
 	log.Printf("📦 Build job enqueued for project: %s", projectID)
 }
 
+// EnqueuePreview queues a build of a pull request preview
+func (bs *BuildService) EnqueuePreview(projectID, userID, previewID string) {
+	bs.queue <- BuildJob{
+		ProjectID: projectID,
+		UserID:    userID,
+		PreviewID: previewID,
+	}
+	log.Printf("📦 Preview build job enqueued for project: %s (preview: %s)", projectID, previewID)
+}
+
+// EnqueuePreviewTeardown queues the removal of a pull request preview
+func (bs *BuildService) EnqueuePreviewTeardown(projectID, userID, previewID string) {
+	bs.queue <- BuildJob{
+		ProjectID: projectID,
+		UserID:    userID,
+		PreviewID: previewID,
+		Teardown:  true,
+	}
+	log.Printf("📦 Preview teardown job enqueued for project: %s (preview: %s)", projectID, previewID)
+}
+
 func (bs *BuildService) worker() {
 	defer bs.wg.Done()
 
@@ -75,6 +268,11 @@ func (bs *BuildService) worker() {
 			log.Println("🛑 Build worker shutting down...")
 			return
 		case job := <-bs.queue:
+			if job.PreviewID != "" {
+				bs.handlePreviewJob(job)
+				continue
+			}
+
 			log.Printf("🔨 Processing build job for project: %s", job.ProjectID)
 			if err := bs.processBuild(job); err != nil {
 				log.Printf("❌ Build failed for project %s: %v", job.ProjectID, err)
@@ -86,7 +284,7 @@ func (bs *BuildService) worker() {
 	}
 }
 
-func (bs *BuildService) processBuild(job BuildJob) error {
+func (bs *BuildService) processBuild(job BuildJob) (err error) {
 	ctx := context.Background()
 
 	log.Printf("🔨 ============================================")
@@ -95,7 +293,7 @@ func (bs *BuildService) processBuild(job BuildJob) error {
 	log.Printf("🔨 ============================================")
 
 	// Get project
-	log.Printf("🔨 [1/8] Fetching project details...")
+	log.Printf("🔨 [1/7] Fetching project details...")
 	project, err := bs.store.GetProjectByID(ctx, job.ProjectID)
 	if err != nil {
 		log.Printf("❌ Failed to get project: %v", err)
@@ -103,76 +301,120 @@ func (bs *BuildService) processBuild(job BuildJob) error {
 	}
 	log.Printf("✅ Project fetched: %s (Repo: %s, Branch: %s)", project.Name, project.RepoFullName, project.RepoBranch)
 
-	// Get user's first account for GitHub token
-	log.Printf("🔨 [2/8] Fetching user accounts...")
-	accounts, err := bs.accountStore.GetAccountsByUserID(ctx, job.UserID)
-	if err != nil || len(accounts) == 0 {
-		log.Printf("❌ Failed to get user accounts: %v (count: %d)", err, len(accounts))
-		return fmt.Errorf("failed to get user account: %w", err)
-	}
-	log.Printf("✅ Found %d account(s) for user", len(accounts))
-
-	// Get account with token
-	log.Printf("🔨 [3/8] Fetching account tokens...")
-	accountsWithTokens, err := bs.accountStore.GetAccountsByUserIDWithTokens(ctx, job.UserID)
-	if err != nil || len(accountsWithTokens) == 0 {
-		log.Printf("❌ Failed to get account tokens: %v (count: %d)", err, len(accountsWithTokens))
-		return fmt.Errorf("failed to get account tokens: %w", err)
+	// Archived projects stay stopped until they're activated, whatever queued the build
+	if project.Status == "archived" {
+		log.Printf("⏭️  Skipping build of archived project %s", project.ID)
+		return nil
 	}
-	token := accountsWithTokens[0].AccessToken
-	log.Printf("✅ Retrieved access token for account: %s", accountsWithTokens[0].GithubUsername)
 
-	// Stage 1: Clone repository
-	log.Printf("🔨 [4/8] Starting repository clone stage...")
-	bs.updateDeploymentStatus(job.ProjectID, "building")
-	bs.broadcastStatus(job.ProjectID, "building")
-	log.Printf("📡 Status updated to: building")
+	var deploymentID string
+	defer func() {
+		event := models.NewDeploymentEvent(project.ID, job.UserID, models.DEPLOYMENT_ACTION_DEPLOY, err)
+		event.Deployment = deploymentID
+		recordDeploymentEvent(bs.store, event)
+	}()
 
+	var token, sha string
 	repoPath := filepath.Join(REPO_BASE_PATH, job.ProjectID)
-	log.Printf("🔨 Repository will be cloned to: %s", repoPath)
+	if project.IsImage() {
+		// Prebuilt images skip the clone and build stages
+		log.Printf("🔨 [2/7] Pulling image %s...", project.Image)
+		bs.updateDeploymentStatus(job.ProjectID, "building")
+		bs.broadcastStatus(job.ProjectID, "building")
+		log.Printf("📡 Status updated to: building")
+
+		digest, err := bs.pullProjectImage(project)
+		if err != nil {
+			log.Printf("❌ Pull failed: %v", err)
+			return fmt.Errorf("pull failed: %w", err)
+		}
+		deploymentID = newDeploymentID(strings.TrimPrefix(digest, "sha256:"))
+		log.Printf("✅ Image pulled: %s", digest)
+	} else {
+		// Resolve credentials for the repository
+		log.Printf("🔨 [2/7] Resolving repository credentials...")
+		token, err = bs.getAccessToken(ctx, project)
+		if err != nil {
+			return err
+		}
 
-	if err := bs.cloneRepository(project, token, repoPath); err != nil {
-		log.Printf("❌ Clone failed: %v", err)
-		bs.cleanup(job.ProjectID)
-		return fmt.Errorf("clone failed: %w", err)
+		// Stage 1: Clone repository
+		log.Printf("🔨 [3/7] Starting repository clone stage...")
+		bs.updateDeploymentStatus(job.ProjectID, "building")
+		bs.broadcastStatus(job.ProjectID, "building")
+		log.Printf("📡 Status updated to: building")
+
+		log.Printf("🔨 Repository will be cloned to: %s", repoPath)
+
+		if err := bs.cloneProjectRepository(project, project.RepoBranch, token, repoPath); err != nil {
+			log.Printf("❌ Clone failed: %v", err)
+			bs.cleanup(job.ProjectID)
+			return fmt.Errorf("clone failed: %w", err)
+		}
+		log.Printf("✅ Repository cloned successfully")
+
+		// Report progress on the commit being deployed
+		sha = headCommit(repoPath)
+		deploymentID = newDeploymentID(sha)
+		bs.reportCommitStatus(project, token, sha, COMMIT_STATE_PENDING, "Building and deploying")
+		defer func() {
+			if err != nil {
+				bs.reportCommitStatus(project, token, sha, COMMIT_STATE_FAILURE, "Deployment failed")
+			}
+		}()
+
+		// Stage 2: Build with railpack
+		log.Printf("🔨 [4/7] Starting railpack build stage...")
+		if err := bs.buildWithRailpack(project.ID, project.EnvVariables, repoPath); err != nil {
+			log.Printf("❌ Build failed: %v", err)
+			bs.cleanup(job.ProjectID)
+			return fmt.Errorf("build failed: %w", err)
+		}
+		log.Printf("✅ Railpack build completed successfully")
 	}
-	log.Printf("✅ Repository cloned successfully")
 
-	// Stage 2: Build with railpack
-	log.Printf("🔨 [5/8] Starting railpack build stage...")
-	if err := bs.buildWithRailpack(project, repoPath); err != nil {
-		log.Printf("❌ Build failed: %v", err)
-		bs.cleanup(job.ProjectID)
-		return fmt.Errorf("build failed: %w", err)
+	// Each deployment keeps its own tag, so the image outlives the next build and
+	// can be rolled back to until garbage collection removes it
+	if output, err := exec.Command("docker", "tag", project.ID+":latest", project.ID+":"+deploymentID).CombinedOutput(); err != nil {
+		log.Printf("⚠️  Failed to tag image with deployment %s: %v\n%s", deploymentID, err, string(output))
 	}
-	log.Printf("✅ Railpack build completed successfully")
 
 	// Stage 3: Generate docker-compose and deploy
-	log.Printf("🔨 [6/8] Starting deployment preparation...")
+	log.Printf("🔨 [5/7] Starting deployment preparation...")
 	bs.updateDeploymentStatus(job.ProjectID, "deploying")
 	bs.broadcastStatus(job.ProjectID, "deploying")
 	log.Printf("📡 Status updated to: deploying")
 
-	if err := bs.generateDockerCompose(project); err != nil {
-		log.Printf("❌ Docker-compose generation failed: %v", err)
+	domains, err := bs.verifiedDomains(ctx, project.ID)
+	if err != nil {
 		bs.cleanup(job.ProjectID)
-		return fmt.Errorf("docker-compose generation failed: %w", err)
+		return err
+	}
+	if len(domains) == 0 {
+		log.Printf("⚠️  Project has no verified domains, it will not be routed")
 	}
-	log.Printf("✅ Docker-compose file generated successfully")
 
-	// Stage 4: Deploy with docker swarm
-	log.Printf("🔨 [7/8] Deploying to Docker Swarm...")
-	if err := bs.deployWithSwarm(project); err != nil {
-		log.Printf("❌ Deployment failed: %v", err)
+	if err := bs.syncCertificate(project); err != nil {
 		bs.cleanup(job.ProjectID)
-		return fmt.Errorf("deployment failed: %w", err)
+		return err
+	}
+
+	// Static sites are served by the shared static file server instead of a stack
+	if project.StaticSite.Enabled {
+		err = bs.deployStaticSite(project, deploymentID, domains)
+	} else {
+		err = bs.deployProjectStack(ctx, project, deploymentID, domains, repoPath)
+	}
+	if err != nil {
+		bs.cleanup(job.ProjectID)
+		return err
 	}
-	log.Printf("✅ Deployed to Docker Swarm successfully")
 
 	// Success!
-	log.Printf("🔨 [8/8] Finalizing deployment...")
+	log.Printf("🔨 [7/7] Finalizing deployment...")
 	bs.updateDeploymentStatus(job.ProjectID, "deployed")
 	bs.broadcastStatus(job.ProjectID, "deployed")
+	bs.reportCommitStatus(project, token, sha, COMMIT_STATE_SUCCESS, "Deployed")
 	log.Printf("📡 Status updated to: deployed")
 
 	log.Printf("🎉 ============================================")
@@ -183,13 +425,114 @@ func (bs *BuildService) processBuild(job BuildJob) error {
 	return nil
 }
 
-func (bs *BuildService) cloneRepository(project *models.Project, token, repoPath string) error {
+// deployProjectStack deploys a built project as a swarm stack with a service per process
+func (bs *BuildService) deployProjectStack(ctx context.Context, project *models.Project, deploymentID string, domains []string, repoPath string) error {
+	processes, err := resolveProcesses(project, repoPath)
+	if err != nil {
+		return err
+	}
+
+	spec := projectDeploySpec(project, domains, bs.tlsOptions)
+	spec.Deployment = deploymentID
+	spec.Processes = toDeployProcesses(processes)
+	if project.IsImage() {
+		spec.Port = imageContainerPort(project)
+	}
+
+	spec.Environment, spec.Networks, err = attachedAddons(ctx, bs.store, project.ID)
+	if err != nil {
+		return err
+	}
+	if len(spec.Networks) > 0 {
+		log.Printf("🗄️  Attaching %d add-ons", len(spec.Networks))
+	}
+
+	if err := bs.generateDockerCompose(spec); err != nil {
+		log.Printf("❌ Docker-compose generation failed: %v", err)
+		return fmt.Errorf("docker-compose generation failed: %w", err)
+	}
+	log.Printf("✅ Docker-compose file generated successfully")
+
+	// Stage 4: Deploy with docker swarm
+	log.Printf("🔨 [6/7] Deploying to Docker Swarm...")
+	if err := bs.deployWithSwarm(spec); err != nil {
+		log.Printf("❌ Deployment failed: %v", err)
+		return fmt.Errorf("deployment failed: %w", err)
+	}
+	log.Printf("✅ Deployed to Docker Swarm successfully")
+
+	// The stack now serves the domains, so files from when the project was a static site can go
+	if err := removeStaticSite(bs.tlsOptions, project.ID); err != nil {
+		log.Printf("⚠️  Failed to remove static site of project %s: %v", project.ID, err)
+	}
+	return nil
+}
+
+// getAccessToken returns the GitHub token used to clone and report on a project
+func (bs *BuildService) getAccessToken(ctx context.Context, project *models.Project) (string, error) {
+	if project.Provider == models.GIT_PROVIDER_GIT {
+		log.Printf("✅ Plain git repository, no access token needed")
+		return "", nil
+	}
+
+	token, err := resolveProjectToken(ctx, bs.store, bs.appService, project)
+	if err != nil {
+		log.Printf("❌ Failed to resolve repository credentials: %v", err)
+		return "", fmt.Errorf("failed to get repository credentials: %w", err)
+	}
+	if project.InstallationID != 0 {
+		log.Printf("✅ Minted installation token for installation: %d", project.InstallationID)
+	} else {
+		log.Printf("✅ Retrieved access token for project owner")
+	}
+	return token, nil
+}
+
+// cloneProjectRepository clones a branch of a project's repository using its provider's credentials
+func (bs *BuildService) cloneProjectRepository(project *models.Project, branch, token, repoPath string) error {
+	if project.Provider == models.GIT_PROVIDER_GIT {
+		return bs.clonePlainRepository(project, branch, repoPath)
+	}
+
+	provider, err := bs.providers.ForProject(project)
+	if err != nil {
+		return err
+	}
+
+	username, password := provider.CloneCredentials(token)
+	return bs.cloneRepository(provider.CloneURL(project.RepoFullName), branch, username, password, repoPath)
+}
+
+// clonePlainRepository clones a remote with no linked account: anonymously over
+// HTTPS, or with the project's deploy key over SSH
+func (bs *BuildService) clonePlainRepository(project *models.Project, branch, repoPath string) error {
+	if !project.UsesDeployKey() {
+		return bs.cloneRepository(project.RepoURL, branch, "", "", repoPath)
+	}
+
+	env, cleanup, err := gitRemoteEnv(project)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	log.Printf("🔑 Cloning with project deploy key")
+	return bs.cloneRepository(project.RepoURL, branch, "", "", repoPath, env...)
+}
+
+func (bs *BuildService) cloneRepository(repoURL, branch, username, password, repoPath string, env ...string) error {
 	log.Printf("📥 ============================================")
-	log.Printf("📥 Cloning repository: %s", project.RepoURL)
+	log.Printf("📥 Cloning repository: %s", repoURL)
 	log.Printf("📥 Target path: %s", repoPath)
-	log.Printf("📥 Branch: %s", project.RepoBranch)
+	log.Printf("📥 Branch: %s", branch)
 	log.Printf("📥 ============================================")
 
+	// Start from a clean checkout so rebuilds don't trip over a previous clone
+	if err := os.RemoveAll(repoPath); err != nil {
+		log.Printf("❌ Failed to remove previous checkout: %v", err)
+		return fmt.Errorf("failed to remove previous checkout: %w", err)
+	}
+
 	// Create directory
 	log.Printf("📥 Creating directory: %s", repoPath)
 	if err := os.MkdirAll(repoPath, 0755); err != nil {
@@ -198,44 +541,48 @@ func (bs *BuildService) cloneRepository(project *models.Project, token, repoPath
 	}
 	log.Printf("✅ Directory created successfully")
 
-	// Build authenticated URL
-	authURL := strings.Replace(project.RepoURL, "https://", fmt.Sprintf("https://%s@", token), 1)
-	log.Printf("📥 Constructed authenticated URL (token hidden)")
+	// Credentials go through the environment so they stay out of the remote URL
+	if username != "" {
+		env = append(env, gitAuthEnv(username, password)...)
+		log.Printf("📥 Authenticating with http.extraHeader (token hidden)")
+	}
 
 	// Clone with specific branch
-	log.Printf("📥 Executing: git clone -b %s --single-branch [REPO_URL] %s", project.RepoBranch, repoPath)
-	cmd := exec.Command("git", "clone", "-b", project.RepoBranch, "--single-branch", authURL, repoPath)
+	log.Printf("📥 Executing: git clone -b %s --single-branch %s %s", branch, repoURL, repoPath)
+	cmd := exec.Command("git", "clone", "-b", branch, "--single-branch", repoURL, repoPath)
 	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0") // Disable interactive prompts
+	cmd.Env = append(cmd.Env, env...)
 
-	output, err := cmd.CombinedOutput()
+	rawOutput, err := cmd.CombinedOutput()
+	output := scrubSecrets(string(rawOutput), password)
 	if err != nil {
 		log.Printf("❌ Git clone failed")
 		log.Printf("❌ Error: %v", err)
-		log.Printf("❌ Output: %s", string(output))
-		return fmt.Errorf("git clone failed: %w, output: %s", err, string(output))
+		log.Printf("❌ Output: %s", output)
+		return fmt.Errorf("git clone failed: %w, output: %s", err, output)
 	}
 
-	log.Printf("✅ Git clone output: %s", string(output))
+	log.Printf("✅ Git clone output: %s", output)
 	log.Printf("✅ Repository cloned successfully")
 	return nil
 }
 
-func (bs *BuildService) buildWithRailpack(project *models.Project, repoPath string) error {
+func (bs *BuildService) buildWithRailpack(imageName string, envVariables []models.EnvironmentVariable, repoPath string) error {
 	log.Printf("🏗️  ============================================")
 	log.Printf("🏗️  Building with railpack")
-	log.Printf("🏗️  Project ID: %s", project.ID)
+	log.Printf("🏗️  Image: %s", imageName)
 	log.Printf("🏗️  Working directory: %s", repoPath)
-	log.Printf("🏗️  Environment variables: %d", len(project.EnvVariables))
+	log.Printf("🏗️  Environment variables: %d", len(envVariables))
 	log.Printf("🏗️  ============================================")
 
 	// Build env flags
 	envFlags := []string{"build", "."}
-	envFlags = append(envFlags, "--name", project.ID)
-	log.Printf("🏗️  Image name: %s:latest", project.ID)
+	envFlags = append(envFlags, "--name", imageName)
+	log.Printf("🏗️  Image name: %s:latest", imageName)
 
-	if len(project.EnvVariables) > 0 {
+	if len(envVariables) > 0 {
 		log.Printf("🏗️  Adding environment variables:")
-		for _, env := range project.EnvVariables {
+		for _, env := range envVariables {
 			if env.Key != "" && env.Value != "" {
 				log.Printf("🏗️    - %s=%s", env.Key, strings.Repeat("*", min(len(env.Value), 8)))
 				envFlags = append(envFlags, "--env", fmt.Sprintf("%s=%s", env.Key, env.Value))
@@ -268,11 +615,14 @@ func (bs *BuildService) buildWithRailpack(project *models.Project, repoPath stri
 	}
 	log.Printf("🏗️  Railpack process started (PID: %d)", cmd.Process.Pid)
 
+	// Build output can echo environment values, so scrub them before logging
+	secrets := envSecrets(envVariables)
+
 	// Monitor output
 	go func() {
 		scanner := bufio.NewScanner(stdout)
 		for scanner.Scan() {
-			line := scanner.Text()
+			line := scrubSecrets(scanner.Text(), secrets...)
 			log.Printf("🏗️  [STDOUT] %s", line)
 		}
 		if err := scanner.Err(); err != nil {
@@ -284,7 +634,7 @@ func (bs *BuildService) buildWithRailpack(project *models.Project, repoPath stri
 	errScanner := bufio.NewScanner(stderr)
 	buildkitError := false
 	for errScanner.Scan() {
-		line := errScanner.Text()
+		line := scrubSecrets(errScanner.Text(), secrets...)
 		log.Printf("🏗️  [STDERR] %s", line)
 
 		// Check for buildkit issues
@@ -310,7 +660,7 @@ func (bs *BuildService) buildWithRailpack(project *models.Project, repoPath stri
 	}
 
 	log.Printf("✅ Build completed successfully")
-	log.Printf("✅ Image created: %s:latest", project.ID)
+	log.Printf("✅ Image created: %s:latest", imageName)
 	return nil
 }
 
@@ -322,15 +672,15 @@ func min(a, b int) int {
 	return b
 }
 
-func (bs *BuildService) generateDockerCompose(project *models.Project) error {
+func (bs *BuildService) generateDockerCompose(spec deploySpec) error {
 	log.Printf("📝 ============================================")
 	log.Printf("📝 Generating docker-compose")
-	log.Printf("📝 Project ID: %s", project.ID)
-	log.Printf("📝 Domain: %s", project.Domain)
+	log.Printf("📝 Stack: %s", spec.StackName)
+	log.Printf("📝 Domains: %s", strings.Join(spec.Domains, ", "))
 	log.Printf("📝 ============================================")
 
 	// Create services directory
-	servicePath := filepath.Join(SERVICES_BASE_PATH, project.ID)
+	servicePath := filepath.Join(SERVICES_BASE_PATH, spec.StackName)
 	log.Printf("📝 Creating service directory: %s", servicePath)
 	if err := os.MkdirAll(servicePath, 0755); err != nil {
 		log.Printf("❌ Failed to create service directory: %v", err)
@@ -341,23 +691,97 @@ func (bs *BuildService) generateDockerCompose(project *models.Project) error {
 	// Docker compose template for Traefik v3 Swarm
 	tmpl := `version: '3.8'
 services:
-  app:
-    image: {{.ProjectID}}:latest
+{{- range .Processes}}
+  {{.Name}}:
+    image: {{$.Image}}:latest
+{{- if .Command}}
+    entrypoint: ["/bin/sh", "-c"]
+    command: [{{.CommandJSON}}]
+{{- end}}
+{{- if $.Environment}}
+    environment:
+{{- range $.EnvironmentEntries}}
+      - {{.}}
+{{- end}}
+{{- end}}
+{{- if $.Volumes}}
+    volumes:
+{{- range $.Volumes}}
+      - "{{.Name}}:{{.Path}}"
+{{- end}}
+{{- end}}
     networks:
       - proxy
+{{- range $.Networks}}
+      - {{.}}
+{{- end}}
+{{- if $.Deployment}}
+    labels:
+      - "{{$.DeploymentLabel}}={{$.Deployment}}"
+{{- end}}
+    logging:
+      driver: json-file
+      options:
+        labels: "{{$.DeploymentLabel}}"
+        max-size: "10m"
+        max-file: "3"
     deploy:
+      replicas: {{if $.Stopped}}0{{else}}{{.Replicas}}{{end}}
       restart_policy:
         condition: any
       labels:
+        - "{{$.ProcessLabel}}={{.Name}}"
+        - "{{$.ProcessLabel}}.replicas={{.Replicas}}"
+{{- if .Exposed}}
+        - "{{$.ProcessLabel}}.exposed=true"
+{{- end}}
+{{- if and .Exposed $.Domains}}
         - "traefik.enable=true"
-        - "traefik.http.routers.{{.ProjectID}}.rule=Host(` + "`{{.Domain}}`" + `)"
-        - "traefik.http.routers.{{.ProjectID}}.entrypoints=web"
-        - "traefik.http.services.{{.ProjectID}}.loadbalancer.server.port=3000"
+        - "traefik.http.routers.{{$.StackName}}.rule={{$.HostRule}}"
+        - "traefik.http.routers.{{$.StackName}}.entrypoints=web"
+        - "traefik.http.routers.{{$.StackName}}.service={{$.StackName}}"
+{{- if $.HTTPMiddlewares}}
+        - "traefik.http.routers.{{$.StackName}}.middlewares={{$.HTTPMiddlewares}}"
+{{- end}}
+{{- if $.ForceHTTPS}}
+        - "traefik.http.middlewares.{{$.StackName}}-https-redirect.redirectscheme.scheme=https"
+        - "traefik.http.middlewares.{{$.StackName}}-https-redirect.redirectscheme.permanent=true"
+{{- end}}
+{{- range $.MiddlewareLabels}}
+        - {{.}}
+{{- end}}
+{{- if $.TLS}}
+        - "traefik.http.routers.{{$.StackName}}-secure.rule={{$.HostRule}}"
+        - "traefik.http.routers.{{$.StackName}}-secure.entrypoints=websecure"
+        - "traefik.http.routers.{{$.StackName}}-secure.service={{$.StackName}}"
+        - "traefik.http.routers.{{$.StackName}}-secure.tls=true"
+        - "traefik.http.routers.{{$.StackName}}-secure.tls.certresolver={{$.CertResolver}}"
+{{- if $.Middlewares}}
+        - "traefik.http.routers.{{$.StackName}}-secure.middlewares={{$.SecureMiddlewares}}"
+{{- end}}
+{{- end}}
+        - "traefik.http.services.{{$.StackName}}.loadbalancer.server.port={{$.Port}}"
+{{- else}}
+        - "traefik.enable=false"
+{{- end}}
+{{- end}}
 
 networks:
   proxy:
     external: true
     name: proxy
+{{- range .Networks}}
+  {{.}}:
+    external: true
+    name: {{.}}
+{{- end}}
+{{- if .Volumes}}
+
+volumes:
+{{- range .Volumes}}
+  {{.Name}}:
+{{- end}}
+{{- end}}
 `
 
 	log.Printf("📝 Parsing docker-compose template...")
@@ -368,26 +792,31 @@ networks:
 	}
 	log.Printf("✅ Template parsed successfully")
 
-	// Create docker-compose file
+	// Create docker-compose file, only readable by the owner since it holds the
+	// credentials of attached add-ons
 	composePath := filepath.Join(servicePath, "docker-compose.yml")
 	log.Printf("📝 Creating docker-compose file: %s", composePath)
-	f, err := os.Create(composePath)
+	f, err := os.OpenFile(composePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
 	if err != nil {
 		log.Printf("❌ Failed to create docker-compose file: %v", err)
 		return fmt.Errorf("failed to create docker-compose file: %w", err)
 	}
 	defer f.Close()
 
-	data := map[string]interface{}{
-		"ProjectID": project.ID,
-		"Domain":    project.Domain,
-	}
-
 	log.Printf("📝 Writing docker-compose with data:")
-	log.Printf("📝   - ProjectID: %s", project.ID)
-	log.Printf("📝   - Domain: %s", project.Domain)
+	log.Printf("📝   - Stack: %s", spec.StackName)
+	log.Printf("📝   - Image: %s:latest", spec.Image)
+	log.Printf("📝   - Domains: %s", strings.Join(spec.Domains, ", "))
+	log.Printf("📝   - Port: %d", spec.Port)
+	log.Printf("📝   - Stopped: %t", spec.Stopped)
+	for _, process := range spec.Processes {
+		log.Printf("📝   - Process: %s x%d (exposed: %t)", process.Name, process.Replicas, process.Exposed)
+	}
+	for _, volume := range spec.Volumes {
+		log.Printf("📝   - Volume: %s at %s", volume.Name, volume.Path)
+	}
 
-	if err := t.Execute(f, data); err != nil {
+	if err := t.Execute(f, spec); err != nil {
 		log.Printf("❌ Failed to write docker-compose: %v", err)
 		return fmt.Errorf("failed to write docker-compose: %w", err)
 	}
@@ -396,11 +825,10 @@ networks:
 	return nil
 }
 
-func (bs *BuildService) deployWithSwarm(project *models.Project) error {
+func (bs *BuildService) deployWithSwarm(spec deploySpec) error {
 	log.Printf("🚀 ============================================")
 	log.Printf("🚀 Deploying to Docker Swarm")
-	log.Printf("🚀 Project ID: %s", project.ID)
-	log.Printf("🚀 Stack name: %s", project.ID)
+	log.Printf("🚀 Stack name: %s", spec.StackName)
 	log.Printf("🚀 ============================================")
 
 	// Check and create network if it doesn't exist
@@ -411,7 +839,7 @@ func (bs *BuildService) deployWithSwarm(project *models.Project) error {
 	}
 	log.Printf("✅ Network %s is ready", NETWORK_NAME)
 
-	composePath := filepath.Join(SERVICES_BASE_PATH, project.ID, "docker-compose.yml")
+	composePath := filepath.Join(SERVICES_BASE_PATH, spec.StackName, "docker-compose.yml")
 	log.Printf("🚀 Docker-compose path: %s", composePath)
 
 	// Check if file exists
@@ -421,19 +849,21 @@ func (bs *BuildService) deployWithSwarm(project *models.Project) error {
 	}
 	log.Printf("✅ Docker-compose file exists")
 
-	log.Printf("🚀 Executing: docker stack deploy -c %s %s", composePath, project.ID)
-	cmd := exec.Command("docker", "stack", "deploy", "-c", composePath, project.ID)
-	output, err := cmd.CombinedOutput()
+	// Pruning removes the services of processes that are no longer defined
+	log.Printf("🚀 Executing: docker stack deploy --prune -c %s %s", composePath, spec.StackName)
+	cmd := exec.Command("docker", "stack", "deploy", "--prune", "-c", composePath, spec.StackName)
+	rawOutput, err := cmd.CombinedOutput()
+	output := scrubSecrets(string(rawOutput))
 
 	if err != nil {
 		log.Printf("❌ Docker stack deploy failed")
 		log.Printf("❌ Error: %v", err)
-		log.Printf("❌ Output: %s", string(output))
-		return fmt.Errorf("docker stack deploy failed: %w, output: %s", err, string(output))
+		log.Printf("❌ Output: %s", output)
+		return fmt.Errorf("docker stack deploy failed: %w, output: %s", err, output)
 	}
 
 	log.Printf("✅ Docker stack deploy output:")
-	log.Printf("%s", string(output))
+	log.Printf("%s", output)
 	log.Printf("✅ Deployed successfully to Docker Swarm")
 
 	return nil
@@ -511,6 +941,240 @@ func (bs *BuildService) createSwarmNetwork() error {
 	return nil
 }
 
+func (bs *BuildService) handlePreviewJob(job BuildJob) {
+	if job.Teardown {
+		log.Printf("🧹 Processing preview teardown for project: %s (preview: %s)", job.ProjectID, job.PreviewID)
+		if err := bs.processPreviewTeardown(job); err != nil {
+			log.Printf("❌ Preview teardown failed for project %s: %v", job.ProjectID, err)
+		}
+		return
+	}
+
+	log.Printf("🔨 Processing preview build for project: %s (preview: %s)", job.ProjectID, job.PreviewID)
+	if err := bs.processPreviewBuild(job); err != nil {
+		log.Printf("❌ Preview build failed for project %s: %v", job.ProjectID, err)
+		if preview := bs.updatePreviewStatus(job.PreviewID, "failed"); preview != nil {
+			bs.notifyPullRequest(job, preview)
+		}
+	}
+}
+
+func (bs *BuildService) processPreviewBuild(job BuildJob) error {
+	ctx := context.Background()
+
+	project, err := bs.store.GetProjectByID(ctx, job.ProjectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	preview, err := bs.store.GetPreviewByID(ctx, job.PreviewID)
+	if err != nil {
+		return fmt.Errorf("failed to get preview: %w", err)
+	}
+	if preview.IsClosed() {
+		log.Printf("⏭️  Preview for PR #%d is closed, skipping build", preview.PRNumber)
+		return nil
+	}
+
+	log.Printf("🔨 ============================================")
+	log.Printf("🔨 Building preview for PR #%d of %s", preview.PRNumber, project.RepoFullName)
+	log.Printf("🔨 Branch: %s (%s)", preview.HeadBranch, preview.HeadSHA)
+	log.Printf("🔨 ============================================")
+
+	token, err := bs.getAccessToken(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	domains, err := bs.verifiedDomains(ctx, project.ID)
+	if err != nil {
+		return err
+	}
+
+	spec := previewDeploySpec(project, preview, domains, bs.tlsOptions)
+	spec.Deployment = newDeploymentID(preview.HeadSHA)
+	bs.updatePreviewStatus(preview.ID, "building")
+
+	repoPath := filepath.Join(REPO_BASE_PATH, spec.StackName)
+	if err := bs.cloneProjectRepository(project, preview.HeadBranch, token, repoPath); err != nil {
+		bs.cleanup(spec.StackName)
+		return fmt.Errorf("clone failed: %w", err)
+	}
+
+	envVariables := models.MergeEnvVariables(project.EnvVariables, project.PreviewEnvVars)
+	if err := bs.buildWithRailpack(spec.Image, envVariables, repoPath); err != nil {
+		bs.cleanup(spec.StackName)
+		return fmt.Errorf("build failed: %w", err)
+	}
+
+	bs.updatePreviewStatus(preview.ID, "deploying")
+
+	processes, err := resolveProcesses(project, repoPath)
+	if err != nil {
+		bs.cleanup(spec.StackName)
+		return err
+	}
+	spec.Processes = toDeployProcesses(processes)
+
+	if err := bs.generateDockerCompose(spec); err != nil {
+		bs.cleanup(spec.StackName)
+		return fmt.Errorf("compose generation failed: %w", err)
+	}
+
+	if err := bs.deployWithSwarm(spec); err != nil {
+		bs.cleanup(spec.StackName)
+		return fmt.Errorf("deployment failed: %w", err)
+	}
+
+	if updated := bs.updatePreviewStatus(preview.ID, "deployed"); updated != nil {
+		bs.notifyPullRequest(job, updated)
+	}
+
+	log.Printf("🎉 Preview for PR #%d deployed at %s", preview.PRNumber, bs.tlsOptions.siteURL(preview.Domain))
+	return nil
+}
+
+func (bs *BuildService) processPreviewTeardown(job BuildJob) error {
+	ctx := context.Background()
+
+	preview, err := bs.store.GetPreviewByID(ctx, job.PreviewID)
+	if err != nil {
+		return fmt.Errorf("failed to get preview: %w", err)
+	}
+
+	spec := previewDeploySpec(nil, preview, nil, bs.tlsOptions)
+	log.Printf("🧹 Removing preview stack: %s", spec.StackName)
+
+	cmd := exec.Command("docker", "stack", "rm", spec.StackName)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		log.Printf("⚠️  Failed to remove stack %s: %v\n%s", spec.StackName, err, string(output))
+	}
+
+	cmd = exec.Command("docker", "image", "rm", spec.Image+":latest")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		log.Printf("⚠️  Failed to remove image %s:latest: %v\n%s", spec.Image, err, string(output))
+	}
+
+	bs.cleanup(spec.StackName)
+
+	if updated := bs.updatePreviewStatus(preview.ID, "closed"); updated != nil {
+		bs.notifyPullRequest(job, updated)
+	}
+
+	log.Printf("✅ Preview for PR #%d removed", preview.PRNumber)
+	return nil
+}
+
+func (bs *BuildService) updatePreviewStatus(previewID, status string) *models.Preview {
+	ctx := context.Background()
+	preview, err := bs.store.UpdatePreviewStatus(ctx, previewID, status)
+	if err != nil {
+		log.Printf("❌ Failed to update preview status: %v", err)
+		return nil
+	}
+
+	if bs.wsHub != nil {
+		bs.wsHub.BroadcastToProject(preview.ProjectID, map[string]string{
+			"type":      "preview_status",
+			"status":    status,
+			"pr_number": strconv.Itoa(preview.PRNumber),
+		})
+	}
+	return preview
+}
+
+// notifyPullRequest creates or updates the Kova comment on the pull request
+func (bs *BuildService) notifyPullRequest(job BuildJob, preview *models.Preview) {
+	if bs.githubService == nil {
+		return
+	}
+
+	ctx := context.Background()
+	project, err := bs.store.GetProjectByID(ctx, job.ProjectID)
+	if err != nil {
+		log.Printf("⚠️  Failed to load project for PR comment: %v", err)
+		return
+	}
+
+	token, err := bs.getAccessToken(ctx, project)
+	if err != nil {
+		return
+	}
+
+	body := previewCommentBody(preview, bs.tlsOptions.siteURL(preview.Domain))
+	if preview.CommentID != 0 {
+		if err := bs.githubService.UpdateIssueComment(ctx, token, project.RepoFullName, preview.CommentID, body); err != nil {
+			log.Printf("⚠️  Failed to update PR comment: %v", err)
+		}
+		return
+	}
+
+	commentID, err := bs.githubService.CreateIssueComment(ctx, token, project.RepoFullName, preview.PRNumber, body)
+	if err != nil {
+		log.Printf("⚠️  Failed to comment on PR #%d: %v", preview.PRNumber, err)
+		return
+	}
+	if err := bs.store.UpdatePreviewCommentID(ctx, preview.ID, commentID); err != nil {
+		log.Printf("⚠️  Failed to save PR comment id: %v", err)
+	}
+}
+
+// reportCommitStatus attaches a deployment status to a commit on the project's provider.
+// Failures are logged, since a missing status shouldn't fail the deployment.
+func (bs *BuildService) reportCommitStatus(project *models.Project, token, sha, state, description string) {
+	// Plain git remotes have no API to report to
+	if sha == "" || token == "" {
+		return
+	}
+
+	provider, err := bs.providers.ForProject(project)
+	if err != nil {
+		log.Printf("⚠️  Failed to report commit status: %v", err)
+		return
+	}
+
+	status := &CommitStatus{
+		State:       state,
+		Description: description,
+		Context:     COMMIT_STATUS_CONTEXT,
+	}
+	if project.Domain != "" {
+		status.TargetURL = bs.tlsOptions.siteURL(project.Domain)
+	}
+
+	if err := provider.SetCommitStatus(context.Background(), token, project.RepoFullName, sha, status); err != nil {
+		log.Printf("⚠️  Failed to report commit status for %s: %v", sha, err)
+	}
+}
+
+// headCommit returns the SHA checked out in a repository, or "" when it can't be read
+func headCommit(repoPath string) string {
+	output, err := exec.Command("git", "-C", repoPath, "rev-parse", "HEAD").Output()
+	if err != nil {
+		log.Printf("⚠️  Failed to read HEAD commit: %v", err)
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func previewCommentBody(preview *models.Preview, previewURL string) string {
+	sha := preview.HeadSHA
+	if len(sha) > 7 {
+		sha = sha[:7]
+	}
+
+	switch preview.Status {
+	case "deployed":
+		return fmt.Sprintf("🚀 **Kova preview** is live at %s\n\nBuilt from `%s`.", previewURL, sha)
+	case "failed":
+		return fmt.Sprintf("❌ **Kova preview** failed to deploy `%s`.", sha)
+	case "closed":
+		return "🧹 **Kova preview** for this pull request has been removed."
+	default:
+		return fmt.Sprintf("⏳ **Kova preview** is building `%s`...", sha)
+	}
+}
+
 func (bs *BuildService) updateDeploymentStatus(projectID, status string) {
 	ctx := context.Background()
 	if _, err := bs.store.UpdateProjectDeploymentStatus(ctx, projectID, status); err != nil {
@@ -0,0 +1,562 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	CRON_RUN_LABEL      = "kova.cron.run" // Set on the container of each run, to the run ID
+	CRON_LOG_LIMIT      = 64 * 1024       // Bytes of output kept per run, from the end
+	CRON_RUN_STOP_GRACE = 10 * time.Second
+)
+
+var (
+	errCronTimedOut = errors.New("run timed out")
+	errCronReplaced = errors.New("run replaced by a newer one")
+	errCronCanceled = errors.New("cron job deleted")
+)
+
+// CronService runs the cron jobs of projects, each run in a one-off container from
+// the project's current image with its environment variables
+type CronService struct {
+	store     store.Store
+	validator *validator.Validate
+
+	mu     sync.Mutex
+	active map[string]map[string]context.CancelCauseFunc // cron job ID -> run ID -> cancel
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewCronService(store store.Store) *CronService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &CronService{
+		store:     store,
+		validator: validator.New(),
+		active:    make(map[string]map[string]context.CancelCauseFunc),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// Start runs the scheduler, which checks every job at the start of each minute.
+// Minutes missed while Kova was down aren't caught up on.
+func (s *CronService) Start() {
+	// Runs recorded as running were cut off by the last shutdown
+	if err := s.store.FailRunningCronJobRuns(s.ctx, "interrupted by a restart of Kova"); err != nil {
+		log.Printf("⚠️  Failed to close interrupted cron job runs: %v", err)
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		for {
+			next := time.Now().Truncate(time.Minute).Add(time.Minute)
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-time.After(time.Until(next)):
+			}
+			s.schedule(next)
+		}
+	}()
+	log.Println("⏰ Cron scheduler started")
+}
+
+// Shutdown stops the scheduler and the runs in progress
+func (s *CronService) Shutdown() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// GetCronJobs returns the cron jobs of a project
+func (s *CronService) GetCronJobs(ctx context.Context, userID, projectID string) ([]*models.CronJob, error) {
+	if _, err := s.getProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	jobs, err := s.store.GetCronJobsByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cron jobs: %w", err)
+	}
+	for _, job := range jobs {
+		withNextRun(job)
+	}
+	return jobs, nil
+}
+
+// CreateCronJob adds a cron job to a project
+func (s *CronService) CreateCronJob(ctx context.Context, userID, projectID string, req *models.CreateCronJobRequest) (*models.CronJob, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if _, err := parseCronSchedule(req.Schedule); err != nil {
+		return nil, fmt.Errorf("validation failed: invalid schedule: %w", err)
+	}
+	req.SetDefaults()
+
+	if _, err := s.getProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.store.CronJobExistsByProjectIDAndName(ctx, projectID, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check cron job name: %w", err)
+	}
+	if exists {
+		return nil, fmt.Errorf("cron job %s already exists", req.Name)
+	}
+
+	job := &models.CronJob{
+		ProjectID:         projectID,
+		Name:              req.Name,
+		Schedule:          strings.TrimSpace(req.Schedule),
+		Command:           req.Command,
+		TimeoutSeconds:    req.TimeoutSeconds,
+		ConcurrencyPolicy: req.ConcurrencyPolicy,
+		Enabled:           *req.Enabled,
+	}
+	if err := s.store.CreateCronJob(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create cron job: %w", err)
+	}
+
+	log.Printf("⏰ Cron job %s created for project %s (%s)", job.Name, projectID, job.Schedule)
+	return withNextRun(job), nil
+}
+
+// UpdateCronJob changes the settings of a cron job. Runs in progress keep going
+// with the settings they started with.
+func (s *CronService) UpdateCronJob(ctx context.Context, userID, projectID, jobID string, req *models.UpdateCronJobRequest) (*models.CronJob, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	job, err := s.getCronJob(ctx, userID, projectID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" && req.Name != job.Name {
+		exists, err := s.store.CronJobExistsByProjectIDAndName(ctx, projectID, req.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check cron job name: %w", err)
+		}
+		if exists {
+			return nil, fmt.Errorf("cron job %s already exists", req.Name)
+		}
+		job.Name = req.Name
+	}
+	if req.Schedule != "" {
+		if _, err := parseCronSchedule(req.Schedule); err != nil {
+			return nil, fmt.Errorf("validation failed: invalid schedule: %w", err)
+		}
+		job.Schedule = strings.TrimSpace(req.Schedule)
+	}
+	if req.Command != "" {
+		job.Command = req.Command
+	}
+	if req.TimeoutSeconds != 0 {
+		job.TimeoutSeconds = req.TimeoutSeconds
+	}
+	if req.ConcurrencyPolicy != "" {
+		job.ConcurrencyPolicy = req.ConcurrencyPolicy
+	}
+	if req.Enabled != nil {
+		job.Enabled = *req.Enabled
+	}
+
+	updatedJob, err := s.store.UpdateCronJob(ctx, job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update cron job: %w", err)
+	}
+	return withNextRun(updatedJob), nil
+}
+
+// DeleteCronJob deletes a cron job and its run history, stopping its runs in progress
+func (s *CronService) DeleteCronJob(ctx context.Context, userID, projectID, jobID string) error {
+	job, err := s.getCronJob(ctx, userID, projectID, jobID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.DeleteCronJob(ctx, job.ID); err != nil {
+		return fmt.Errorf("failed to delete cron job: %w", err)
+	}
+
+	s.mu.Lock()
+	s.cancelRuns(job.ID, errCronCanceled)
+	s.mu.Unlock()
+
+	log.Printf("⏰ Cron job %s deleted from project %s", job.Name, projectID)
+	return nil
+}
+
+// TriggerCronJob starts a run of a cron job now, subject to its concurrency
+// policy, whether or not it is enabled
+func (s *CronService) TriggerCronJob(ctx context.Context, userID, projectID, jobID string) (*models.CronJobRun, error) {
+	job, err := s.getCronJob(ctx, userID, projectID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.dispatch(ctx, job, models.CRON_TRIGGER_MANUAL)
+}
+
+// GetCronJobRuns returns a page of a cron job's runs, newest first, with the
+// total number of runs. Logs are left out, they come with each run on its own.
+func (s *CronService) GetCronJobRuns(ctx context.Context, userID, projectID, jobID string, limit, offset int) ([]*models.CronJobRun, int64, error) {
+	job, err := s.getCronJob(ctx, userID, projectID, jobID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	runs, err := s.store.GetCronJobRunsByCronJobID(ctx, job.ID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get cron job runs: %w", err)
+	}
+
+	total, err := s.store.CountCronJobRunsByCronJobID(ctx, job.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count cron job runs: %w", err)
+	}
+
+	for _, run := range runs {
+		run.Logs = ""
+	}
+	return runs, total, nil
+}
+
+// GetCronJobRun returns a run of a cron job with its logs
+func (s *CronService) GetCronJobRun(ctx context.Context, userID, projectID, jobID, runID string) (*models.CronJobRun, error) {
+	job, err := s.getCronJob(ctx, userID, projectID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := s.store.GetCronJobRunByID(ctx, runID)
+	if err != nil || run.CronJobID != job.ID {
+		return nil, errors.New("cron job run not found")
+	}
+	return run, nil
+}
+
+// schedule dispatches the enabled jobs due in the minute starting at t
+func (s *CronService) schedule(t time.Time) {
+	jobs, err := s.store.GetEnabledCronJobs(s.ctx)
+	if err != nil {
+		log.Printf("⚠️  Failed to load cron jobs: %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		schedule, err := parseCronSchedule(job.Schedule)
+		if err != nil || !schedule.Matches(t) {
+			continue
+		}
+		// Guards against running twice for one minute when the clock steps back
+		if job.LastScheduledAt != nil && !job.LastScheduledAt.Before(t) {
+			continue
+		}
+
+		if err := s.store.UpdateCronJobLastScheduledAt(s.ctx, job.ID, t); err != nil {
+			log.Printf("⚠️  Failed to schedule cron job %s: %v", job.ID, err)
+			continue
+		}
+		if _, err := s.dispatch(s.ctx, job, models.CRON_TRIGGER_SCHEDULE); err != nil {
+			log.Printf("⚠️  Cron job %s of project %s not run: %v", job.Name, job.ProjectID, err)
+		}
+	}
+}
+
+// dispatch starts a run of a job according to its concurrency policy. Under the
+// forbid policy a run due while another is in progress is recorded as skipped.
+func (s *CronService) dispatch(ctx context.Context, job *models.CronJob, trigger string) (*models.CronJobRun, error) {
+	project, err := s.store.GetProjectByID(ctx, job.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+	if project.DeploymentStatus != "deployed" {
+		return nil, errStackNotDeployed
+	}
+	if project.Status == "archived" {
+		return nil, errors.New("validation failed: project is archived, activate it first")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	run := &models.CronJobRun{
+		CronJobID: job.ID,
+		ProjectID: job.ProjectID,
+		Trigger:   trigger,
+		Status:    models.CRON_RUN_RUNNING,
+	}
+
+	if len(s.active[job.ID]) > 0 {
+		switch job.ConcurrencyPolicy {
+		case models.CRON_CONCURRENCY_FORBID:
+			run.Status = models.CRON_RUN_SKIPPED
+			if err := s.store.CreateCronJobRun(ctx, run); err != nil {
+				return nil, fmt.Errorf("failed to record cron job run: %w", err)
+			}
+			run.Logs = "skipped, the previous run is still in progress"
+			s.finish(run)
+			return run, nil
+		case models.CRON_CONCURRENCY_REPLACE:
+			s.cancelRuns(job.ID, errCronReplaced)
+		}
+	}
+
+	if err := s.store.CreateCronJobRun(ctx, run); err != nil {
+		return nil, fmt.Errorf("failed to record cron job run: %w", err)
+	}
+
+	runCtx, cancel := context.WithCancelCause(s.ctx)
+	if s.active[job.ID] == nil {
+		s.active[job.ID] = make(map[string]context.CancelCauseFunc)
+	}
+	s.active[job.ID][run.ID] = cancel
+
+	log.Printf("⏰ Running cron job %s of project %s (%s)", job.Name, job.ProjectID, trigger)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer cancel(nil)
+
+		s.execute(runCtx, job, project, run)
+
+		s.mu.Lock()
+		delete(s.active[job.ID], run.ID)
+		if len(s.active[job.ID]) == 0 {
+			delete(s.active, job.ID)
+		}
+		s.mu.Unlock()
+	}()
+
+	started := *run
+	return &started, nil
+}
+
+// execute runs a job's command in a one-off container and records the outcome.
+// A timed out or canceled run has its container removed.
+func (s *CronService) execute(ctx context.Context, job *models.CronJob, project *models.Project, run *models.CronJobRun) {
+	timeout := time.Duration(job.TimeoutSeconds) * time.Second
+	ctx, cancel := context.WithTimeoutCause(ctx, timeout, errCronTimedOut)
+	defer cancel()
+
+	containerName := "kova-cron-" + run.ID
+	args := []string{
+		"run", "--rm",
+		"--name", containerName,
+		"--label", CRON_RUN_LABEL + "=" + run.ID,
+		"--network", NETWORK_NAME,
+	}
+
+	// Runs reach the project's add-ons like its services do
+	addonEnv, addonNetworks, err := attachedAddons(ctx, s.store, project.ID)
+	if err != nil {
+		log.Printf("⚠️  Cron job %s runs without add-ons: %v", job.Name, err)
+	}
+	for _, network := range addonNetworks {
+		args = append(args, "--network", network)
+	}
+	variables := append(append([]models.EnvironmentVariable{}, project.EnvVariables...), addonEnv...)
+
+	output := &tailBuffer{limit: CRON_LOG_LIMIT}
+
+	// Values are passed in a file so they stay out of the process list, and never
+	// reach the environment of the docker CLI itself
+	envFile, skipped, err := writeEnvFile(variables)
+	if err != nil {
+		run.Status = models.CRON_RUN_FAILED
+		run.Logs = fmt.Sprintf("[kova] failed to run: %v\n", err)
+		s.finish(run)
+		log.Printf("⏰ Cron job %s of project %s %s", job.Name, job.ProjectID, run.Status)
+		return
+	}
+	defer os.Remove(envFile)
+	for _, key := range skipped {
+		output.WriteString(fmt.Sprintf("[kova] %s is not set, env files can't hold multi-line values\n", key))
+	}
+	args = append(args, "--env-file", envFile)
+	args = append(args, "--entrypoint", "/bin/sh", project.ID+":latest", "-c", job.Command)
+
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	cmd.Cancel = func() error {
+		// Killing the CLI leaves the container running
+		exec.Command("docker", "rm", "--force", containerName).Run()
+		return cmd.Process.Kill()
+	}
+	cmd.WaitDelay = CRON_RUN_STOP_GRACE
+
+	err = cmd.Run()
+
+	run.Status = models.CRON_RUN_SUCCEEDED
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() >= 0 {
+		exitCode := exitErr.ExitCode()
+		run.ExitCode = &exitCode
+	} else if err == nil {
+		exitCode := 0
+		run.ExitCode = &exitCode
+	}
+
+	switch cause := context.Cause(ctx); {
+	case errors.Is(cause, errCronTimedOut):
+		run.Status = models.CRON_RUN_TIMED_OUT
+		output.WriteString(fmt.Sprintf("\n[kova] timed out after %s\n", timeout))
+	case errors.Is(cause, errCronReplaced), errors.Is(cause, errCronCanceled):
+		run.Status = models.CRON_RUN_CANCELED
+		output.WriteString(fmt.Sprintf("\n[kova] canceled: %s\n", cause))
+	case cause != nil:
+		run.Status = models.CRON_RUN_FAILED
+		output.WriteString("\n[kova] interrupted by a shutdown of Kova\n")
+	case err != nil:
+		run.Status = models.CRON_RUN_FAILED
+		if run.ExitCode == nil {
+			output.WriteString(fmt.Sprintf("\n[kova] failed to run: %v\n", err))
+		}
+	}
+
+	run.Logs = scrubSecrets(output.String(), envSecrets(variables)...)
+	s.finish(run)
+
+	log.Printf("⏰ Cron job %s of project %s %s", job.Name, job.ProjectID, run.Status)
+}
+
+// writeEnvFile writes variables to a temporary env file for docker run and returns
+// its path along with the keys of the multi-line values it can't hold. The caller
+// must remove the file once done.
+func writeEnvFile(variables []models.EnvironmentVariable) (path string, skipped []string, err error) {
+	var content strings.Builder
+	for _, variable := range variables {
+		// Names from before keys were validated are left out
+		if models.ValidateEnvVariables([]models.EnvironmentVariable{variable}) != nil {
+			continue
+		}
+		if strings.ContainsAny(variable.Value, "\r\n") {
+			skipped = append(skipped, variable.Key)
+			continue
+		}
+		content.WriteString(variable.Key + "=" + variable.Value + "\n")
+	}
+
+	// CreateTemp opens files 0600, so other users can't read the values
+	file, err := os.CreateTemp("", "kova-cron-env-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create env file: %w", err)
+	}
+	if _, err := file.WriteString(content.String()); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return "", nil, fmt.Errorf("failed to write env file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return "", nil, fmt.Errorf("failed to write env file: %w", err)
+	}
+	return file.Name(), skipped, nil
+}
+
+// cancelRuns stops the runs in progress of a job. Callers hold s.mu.
+func (s *CronService) cancelRuns(jobID string, cause error) {
+	for _, cancel := range s.active[jobID] {
+		cancel(cause)
+	}
+}
+
+func (s *CronService) finish(run *models.CronJobRun) {
+	if err := s.store.FinishCronJobRun(context.Background(), run); err != nil {
+		log.Printf("⚠️  Failed to record outcome of cron job run %s: %v", run.ID, err)
+	}
+}
+
+func (s *CronService) getProject(ctx context.Context, userID, projectID string) (*models.Project, error) {
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+	return project, nil
+}
+
+func (s *CronService) getCronJob(ctx context.Context, userID, projectID, jobID string) (*models.CronJob, error) {
+	if _, err := s.getProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	job, err := s.store.GetCronJobByID(ctx, jobID)
+	if err != nil || job.ProjectID != projectID {
+		return nil, errors.New("cron job not found")
+	}
+	return job, nil
+}
+
+// withNextRun fills in when an enabled job is next due
+func withNextRun(job *models.CronJob) *models.CronJob {
+	job.NextRunAt = nil
+	if !job.Enabled {
+		return job
+	}
+	if schedule, err := parseCronSchedule(job.Schedule); err == nil {
+		if next := schedule.Next(time.Now()); !next.IsZero() {
+			job.NextRunAt = &next
+		}
+	}
+	return job
+}
+
+// tailBuffer keeps the last bytes written to it, up to its limit
+type tailBuffer struct {
+	mu        sync.Mutex
+	limit     int
+	buf       []byte
+	truncated bool
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > b.limit {
+		b.buf = append(b.buf[:0], b.buf[len(b.buf)-b.limit:]...)
+		b.truncated = true
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) WriteString(s string) {
+	b.Write([]byte(s))
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.truncated {
+		return "[kova] earlier output truncated\n" + string(b.buf)
+	}
+	return string(b.buf)
+}
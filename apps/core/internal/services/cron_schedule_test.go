@@ -0,0 +1,79 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr bool
+	}{
+		{expr: "* * * * *"},
+		{expr: "*/15 0-6,18-23 1,15 jan-jun mon-fri"},
+		{expr: "5/10 * * * *"},
+		{expr: "0 0 * * 7"},
+		{expr: "  @Daily "},
+		{expr: "@hourly"},
+		{expr: "* * * *", wantErr: true},
+		{expr: "* * * * * *", wantErr: true},
+		{expr: "60 * * * *", wantErr: true},
+		{expr: "* 24 * * *", wantErr: true},
+		{expr: "* * 0 * *", wantErr: true},
+		{expr: "* * * 13 *", wantErr: true},
+		{expr: "* * * * 8", wantErr: true},
+		{expr: "10-5 * * * *", wantErr: true},
+		{expr: "*/0 * * * *", wantErr: true},
+		{expr: "*/x * * * *", wantErr: true},
+		{expr: "* * * foo *", wantErr: true},
+		{expr: "@reboot", wantErr: true},
+		{expr: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := parseCronSchedule(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseCronSchedule(%q) error = %v, want error %v", tt.expr, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// A Wednesday
+	from := time.Date(2025, time.January, 15, 10, 30, 45, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{expr: "* * * * *", want: time.Date(2025, time.January, 15, 10, 31, 0, 0, time.UTC)},
+		{expr: "*/15 * * * *", want: time.Date(2025, time.January, 15, 10, 45, 0, 0, time.UTC)},
+		{expr: "5/20 * * * *", want: time.Date(2025, time.January, 15, 10, 45, 0, 0, time.UTC)},
+		{expr: "@daily", want: time.Date(2025, time.January, 16, 0, 0, 0, 0, time.UTC)},
+		{expr: "@monthly", want: time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 9 * * sun", want: time.Date(2025, time.January, 19, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 9 * * 7", want: time.Date(2025, time.January, 19, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 feb *", want: time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		// Either restricted day field is enough: the 1st, or any Friday
+		{expr: "0 0 1 * fri", want: time.Date(2025, time.January, 17, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 30 feb *", want: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := parseCronSchedule(tt.expr)
+			if err != nil {
+				t.Fatalf("parseCronSchedule(%q) error = %v", tt.expr, err)
+			}
+			if got := schedule.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+			if !tt.want.IsZero() && !schedule.Matches(tt.want) {
+				t.Errorf("Matches(%v) = false for the next run", tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// generateDeployKey creates an ed25519 key pair and returns the public key in
+// authorized_keys format and the private key as an OpenSSH PEM block
+func generateDeployKey(comment string) (string, string, error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode public key: %w", err)
+	}
+
+	block, err := ssh.MarshalPrivateKey(privateKey, comment)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode private key: %w", err)
+	}
+
+	authorizedKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPublicKey))) + " " + comment
+	return authorizedKey, string(pem.EncodeToMemory(block)), nil
+}
+
+// writeDeployKey writes a private key to a temporary file outside any checkout and
+// returns the GIT_SSH_COMMAND that uses it. The caller must call cleanup once done.
+func writeDeployKey(privateKey string) (sshCommand string, cleanup func(), err error) {
+	keyFile, err := os.CreateTemp("", "kova-deploy-key-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create key file: %w", err)
+	}
+	cleanup = func() { os.Remove(keyFile.Name()) }
+
+	// CreateTemp opens files 0600, which ssh requires of identity files
+	if _, err := keyFile.WriteString(privateKey); err != nil {
+		keyFile.Close()
+		cleanup()
+		return "", nil, fmt.Errorf("failed to write key file: %w", err)
+	}
+	if err := keyFile.Close(); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to write key file: %w", err)
+	}
+
+	sshCommand = fmt.Sprintf("ssh -i %s -o IdentitiesOnly=yes -o StrictHostKeyChecking=accept-new -o BatchMode=yes", keyFile.Name())
+	return sshCommand, cleanup, nil
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	DEFAULT_DOCKER_HOST   = "unix:///var/run/docker.sock"
+	DOCKER_API_TIMEOUT    = 30 * time.Second
+	STACK_NAMESPACE_LABEL = "com.docker.stack.namespace" // Set by `docker stack deploy` on services and their containers
+)
+
+// DockerClient is a small client for the Docker Engine API, used where the CLI's
+// output isn't structured enough to work with
+type DockerClient struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewDockerClient connects to a daemon given in DOCKER_HOST form, such as
+// unix:///var/run/docker.sock or tcp://127.0.0.1:2375
+func NewDockerClient(host string) (*DockerClient, error) {
+	if host == "" {
+		host = DEFAULT_DOCKER_HOST
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid docker host %q: %w", host, err)
+	}
+
+	switch u.Scheme {
+	case "unix":
+		socket := u.Path
+		transport := &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socket)
+			},
+		}
+		return &DockerClient{
+			client:  &http.Client{Transport: transport, Timeout: DOCKER_API_TIMEOUT},
+			baseURL: "http://docker",
+		}, nil
+	case "tcp", "http":
+		return &DockerClient{
+			client:  &http.Client{Timeout: DOCKER_API_TIMEOUT},
+			baseURL: "http://" + u.Host,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported docker host scheme: %s", u.Scheme)
+	}
+}
+
+type dockerService struct {
+	ID   string `json:"ID"`
+	Spec struct {
+		Name   string            `json:"Name"`
+		Labels map[string]string `json:"Labels"`
+	} `json:"Spec"`
+}
+
+type dockerTask struct {
+	ID           string `json:"ID"`
+	ServiceID    string `json:"ServiceID"`
+	DesiredState string `json:"DesiredState"`
+	Status       struct {
+		Timestamp       time.Time `json:"Timestamp"`
+		State           string    `json:"State"`
+		ContainerStatus struct {
+			ContainerID string `json:"ContainerID"`
+		} `json:"ContainerStatus"`
+	} `json:"Status"`
+}
+
+type dockerImage struct {
+	ID       string   `json:"Id"`
+	RepoTags []string `json:"RepoTags"`
+	Created  int64    `json:"Created"` // Unix time
+	Size     int64    `json:"Size"`
+}
+
+type dockerCPUStats struct {
+	CPUUsage struct {
+		TotalUsage  uint64   `json:"total_usage"`
+		PercpuUsage []uint64 `json:"percpu_usage"`
+	} `json:"cpu_usage"`
+	SystemUsage uint64 `json:"system_cpu_usage"`
+	OnlineCPUs  uint32 `json:"online_cpus"`
+}
+
+type dockerStats struct {
+	CPUStats    dockerCPUStats `json:"cpu_stats"`
+	PreCPUStats dockerCPUStats `json:"precpu_stats"`
+	MemoryStats struct {
+		Usage uint64            `json:"usage"`
+		Limit uint64            `json:"limit"`
+		Stats map[string]uint64 `json:"stats"`
+	} `json:"memory_stats"`
+	Networks map[string]struct {
+		RxBytes uint64 `json:"rx_bytes"`
+		TxBytes uint64 `json:"tx_bytes"`
+	} `json:"networks"`
+}
+
+// listServices returns the swarm services carrying a label
+func (d *DockerClient) listServices(ctx context.Context, label string) ([]dockerService, error) {
+	var services []dockerService
+	err := d.get(ctx, "/services", filtersQuery(map[string][]string{"label": {label}}), &services)
+	return services, err
+}
+
+// listTasks returns the tasks of swarm services, including those that have exited
+func (d *DockerClient) listTasks(ctx context.Context, serviceIDs []string) ([]dockerTask, error) {
+	if len(serviceIDs) == 0 {
+		return nil, nil
+	}
+	var tasks []dockerTask
+	err := d.get(ctx, "/tasks", filtersQuery(map[string][]string{"service": serviceIDs}), &tasks)
+	return tasks, err
+}
+
+// listImages returns the images on this node, without intermediate layers
+func (d *DockerClient) listImages(ctx context.Context) ([]dockerImage, error) {
+	var images []dockerImage
+	err := d.get(ctx, "/images/json", nil, &images)
+	return images, err
+}
+
+// containerStats takes a single stats reading of a container on this node. The
+// daemon waits for a second reading so CPU usage can be computed.
+func (d *DockerClient) containerStats(ctx context.Context, containerID string) (*dockerStats, error) {
+	var stats dockerStats
+	if err := d.get(ctx, "/containers/"+containerID+"/stats", url.Values{"stream": {"false"}}, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// CPUPercent returns the CPU used between the two readings, where 100 is one core
+func (s *dockerStats) CPUPercent() float64 {
+	cpuDelta := float64(s.CPUStats.CPUUsage.TotalUsage) - float64(s.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(s.CPUStats.SystemUsage) - float64(s.PreCPUStats.SystemUsage)
+	if cpuDelta <= 0 || systemDelta <= 0 {
+		return 0
+	}
+
+	cpus := float64(s.CPUStats.OnlineCPUs)
+	if cpus == 0 {
+		cpus = float64(len(s.CPUStats.CPUUsage.PercpuUsage))
+	}
+	return cpuDelta / systemDelta * cpus * 100
+}
+
+// MemoryUsage returns the memory used without the page cache, like `docker stats`
+func (s *dockerStats) MemoryUsage() uint64 {
+	usage := s.MemoryStats.Usage
+	cache, ok := s.MemoryStats.Stats["inactive_file"] // cgroup v2
+	if !ok {
+		cache = s.MemoryStats.Stats["total_inactive_file"] // cgroup v1
+	}
+	if cache < usage {
+		return usage - cache
+	}
+	return usage
+}
+
+// NetworkBytes returns the bytes received and sent over all interfaces
+func (s *dockerStats) NetworkBytes() (rx, tx uint64) {
+	for _, network := range s.Networks {
+		rx += network.RxBytes
+		tx += network.TxBytes
+	}
+	return rx, tx
+}
+
+func (d *DockerClient) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	endpoint := d.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create docker request: %w", err)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("docker request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			return &dockerAPIError{StatusCode: resp.StatusCode, Message: apiErr.Message}
+		}
+		return &dockerAPIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(body))}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode docker response: %w", err)
+	}
+	return nil
+}
+
+type dockerAPIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *dockerAPIError) Error() string {
+	return fmt.Sprintf("docker API error (%d): %s", e.StatusCode, e.Message)
+}
+
+// isDockerNotFound checks if a docker API call failed because the object doesn't exist
+func isDockerNotFound(err error) bool {
+	apiErr, ok := err.(*dockerAPIError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+func filtersQuery(filters map[string][]string) url.Values {
+	encoded, _ := json.Marshal(filters)
+	return url.Values{"filters": {string(encoded)}}
+}
@@ -0,0 +1,405 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// DOMAIN_VERIFY_TIMEOUT bounds the DNS lookups of a single verification
+	DOMAIN_VERIFY_TIMEOUT = 10 * time.Second
+
+	// GENERATED_DOMAIN_ATTEMPTS is how many numbered candidates are tried before a
+	// generated subdomain falls back to a random suffix
+	GENERATED_DOMAIN_ATTEMPTS = 10
+
+	// DNS labels are at most 63 characters, leave room for a collision suffix
+	GENERATED_LABEL_MAX_LENGTH = 63 - 8
+)
+
+var nonLabelChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// errDomainLookupFailed marks a verification that failed because DNS didn't answer,
+// which says nothing about who owns the domain
+var errDomainLookupFailed = errors.New("DNS lookup failed")
+
+// DNSResolver looks up the records that prove ownership of a domain. *net.Resolver
+// satisfies it; tests can inject a fake to verify domains offline.
+type DNSResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+	LookupCNAME(ctx context.Context, host string) (string, error)
+}
+
+type DomainService struct {
+	store        store.Store
+	validator    *validator.Validate
+	buildService *BuildService
+	resolver     DNSResolver
+	baseDomain   string
+}
+
+// NewDomainService creates the project domain service. resolver defaults to the
+// system resolver; baseDomain enables verification by CNAME when set.
+func NewDomainService(store store.Store, buildService *BuildService, resolver DNSResolver, baseDomain string) *DomainService {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	return &DomainService{
+		store:        store,
+		validator:    validator.New(),
+		buildService: buildService,
+		resolver:     resolver,
+		baseDomain:   models.NormalizeDomain(baseDomain),
+	}
+}
+
+// GetDomains lists the domains of a project with the DNS records still needed to verify them
+func (s *DomainService) GetDomains(ctx context.Context, userID, projectID string) ([]*models.ProjectDomain, error) {
+	if _, err := s.getOwnedProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	domains, err := s.store.GetProjectDomainsByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domains: %w", err)
+	}
+
+	for _, domain := range domains {
+		s.withInstructions(domain)
+	}
+	return domains, nil
+}
+
+// AddDomain attaches a pending domain to a project. It is routed once VerifyDomain succeeds.
+func (s *DomainService) AddDomain(ctx context.Context, userID, projectID string, req *models.AddDomainRequest) (*models.ProjectDomain, error) {
+	req.Domain = models.NormalizeDomain(req.Domain)
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	project, err := s.getOwnedProject(ctx, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	domain, err := s.ClaimDomain(ctx, project.ID, req.Domain)
+	if err != nil {
+		return nil, err
+	}
+
+	// The first domain of a project becomes its primary domain, which previews are built under
+	if project.Domain == "" {
+		if _, err := s.store.UpdateProjectDomain(ctx, project.ID, domain.Domain); err != nil {
+			return nil, fmt.Errorf("failed to update project domain: %w", err)
+		}
+	}
+
+	return s.withInstructions(domain), nil
+}
+
+// VerifyDomain checks the DNS records of a domain and records the outcome. The project
+// is redeployed whenever the check changes which domains are routed.
+func (s *DomainService) VerifyDomain(ctx context.Context, userID, projectID, domainID string) (*models.ProjectDomain, error) {
+	project, err := s.getOwnedProject(ctx, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	domain, err := s.getProjectDomain(ctx, project.ID, domainID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := models.DOMAIN_STATUS_VERIFIED
+	verificationError := ""
+	verifiedAt := domain.VerifiedAt
+	if err := s.checkOwnership(ctx, domain); err != nil && domain.IsVerified() && errors.Is(err, errDomainLookupFailed) {
+		// A verified domain stays routed until DNS positively stops pointing at it
+		status = domain.Status
+		verificationError = err.Error()
+	} else if err != nil {
+		status = models.DOMAIN_STATUS_FAILED
+		verificationError = err.Error()
+		verifiedAt = nil
+	} else if verifiedAt == nil {
+		now := time.Now()
+		verifiedAt = &now
+	}
+
+	updated, err := s.store.UpdateProjectDomainVerification(ctx, domain.ID, status, verificationError, verifiedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update domain: %w", err)
+	}
+
+	if updated.IsVerified() != domain.IsVerified() {
+		s.redeploy(project)
+	}
+
+	return s.withInstructions(updated), nil
+}
+
+// RemoveDomain detaches a domain from a project, releasing it for other projects
+func (s *DomainService) RemoveDomain(ctx context.Context, userID, projectID, domainID string) error {
+	project, err := s.getOwnedProject(ctx, userID, projectID)
+	if err != nil {
+		return err
+	}
+
+	domain, err := s.getProjectDomain(ctx, project.ID, domainID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.DeleteProjectDomain(ctx, domain.ID); err != nil {
+		return fmt.Errorf("failed to delete domain: %w", err)
+	}
+
+	// Promote the next remaining domain when the primary one is removed
+	if project.Domain == domain.Domain {
+		remaining, err := s.store.GetProjectDomainsByProjectID(ctx, project.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get domains: %w", err)
+		}
+		primary := ""
+		if len(remaining) > 0 {
+			primary = remaining[0].Domain
+		}
+		if _, err := s.store.UpdateProjectDomain(ctx, project.ID, primary); err != nil {
+			return fmt.Errorf("failed to update project domain: %w", err)
+		}
+	}
+
+	if domain.IsVerified() {
+		s.redeploy(project)
+	}
+
+	return nil
+}
+
+// CheckAvailable validates a domain and checks that no project has claimed it yet
+func (s *DomainService) CheckAvailable(ctx context.Context, domain string) error {
+	if err := s.validator.Var(domain, "required,fqdn,max=253"); err != nil {
+		return fmt.Errorf("validation failed: invalid domain %q", domain)
+	}
+	if s.baseDomain != "" && (domain == s.baseDomain || strings.HasSuffix(domain, "."+s.baseDomain)) {
+		return fmt.Errorf("validation failed: %s and its subdomains are reserved by the installation", s.baseDomain)
+	}
+
+	exists, err := s.store.ProjectDomainExists(ctx, domain)
+	if err != nil {
+		return fmt.Errorf("failed to check domain existence: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("domain already in use: %s", domain)
+	}
+	return nil
+}
+
+// ClaimDomain records a pending domain for a project after checking it is available
+func (s *DomainService) ClaimDomain(ctx context.Context, projectID, domain string) (*models.ProjectDomain, error) {
+	domain = models.NormalizeDomain(domain)
+	if err := s.CheckAvailable(ctx, domain); err != nil {
+		return nil, err
+	}
+
+	return s.createDomain(ctx, projectID, domain, nil)
+}
+
+// HasBaseDomain reports whether projects without a domain get a generated subdomain
+func (s *DomainService) HasBaseDomain() bool {
+	return s.baseDomain != ""
+}
+
+// GenerateDomain picks a free subdomain of the base domain for a project, named
+// <project>-<user>.<base> and numbered or randomized when that is taken
+func (s *DomainService) GenerateDomain(ctx context.Context, projectName, username string) (string, error) {
+	if s.baseDomain == "" {
+		return "", errors.New("no base domain configured")
+	}
+
+	label := subdomainLabel(projectName + "-" + username)
+	for attempt := 1; attempt <= GENERATED_DOMAIN_ATTEMPTS+1; attempt++ {
+		candidate := label
+		switch {
+		case attempt > GENERATED_DOMAIN_ATTEMPTS:
+			suffix, err := generateVerificationToken()
+			if err != nil {
+				return "", err
+			}
+			candidate = label + "-" + suffix[:6]
+		case attempt > 1:
+			candidate = fmt.Sprintf("%s-%d", label, attempt)
+		}
+
+		domain := candidate + "." + s.baseDomain
+		exists, err := s.store.ProjectDomainExists(ctx, domain)
+		if err != nil {
+			return "", fmt.Errorf("failed to check domain existence: %w", err)
+		}
+		if !exists {
+			return domain, nil
+		}
+	}
+
+	return "", errors.New("failed to find a free subdomain")
+}
+
+// ClaimGeneratedDomain records a subdomain returned by GenerateDomain. The installation
+// controls the base domain, so the subdomain is verified right away.
+func (s *DomainService) ClaimGeneratedDomain(ctx context.Context, projectID, domain string) (*models.ProjectDomain, error) {
+	now := time.Now()
+	return s.createDomain(ctx, projectID, domain, &now)
+}
+
+// createDomain stores a domain of a project, verified when verifiedAt is set
+func (s *DomainService) createDomain(ctx context.Context, projectID, domain string, verifiedAt *time.Time) (*models.ProjectDomain, error) {
+	token, err := generateVerificationToken()
+	if err != nil {
+		return nil, err
+	}
+
+	projectDomain := &models.ProjectDomain{
+		ProjectID:         projectID,
+		Domain:            domain,
+		VerificationToken: token,
+		Status:            models.DOMAIN_STATUS_PENDING,
+		VerifiedAt:        verifiedAt,
+	}
+	if verifiedAt != nil {
+		projectDomain.Status = models.DOMAIN_STATUS_VERIFIED
+	}
+	// The unique constraint still guards against a concurrent claim slipping past the check
+	if err := s.store.CreateProjectDomain(ctx, projectDomain); err != nil {
+		if strings.Contains(err.Error(), "unique") {
+			return nil, fmt.Errorf("domain already in use: %s", domain)
+		}
+		return nil, fmt.Errorf("failed to create domain: %w", err)
+	}
+
+	return projectDomain, nil
+}
+
+// checkOwnership accepts a domain when its challenge TXT record carries the verification
+// token, or when it is a CNAME to the installation's base domain
+func (s *DomainService) checkOwnership(ctx context.Context, domain *models.ProjectDomain) error {
+	ctx, cancel := context.WithTimeout(ctx, DOMAIN_VERIFY_TIMEOUT)
+	defer cancel()
+
+	records, txtErr := s.resolver.LookupTXT(ctx, domain.ChallengeName())
+	for _, record := range records {
+		if strings.TrimSpace(record) == domain.ChallengeValue() {
+			return nil
+		}
+	}
+
+	var cnameErr error
+	if s.baseDomain != "" {
+		var target string
+		target, cnameErr = s.resolver.LookupCNAME(ctx, domain.Domain)
+		if cnameErr == nil && models.NormalizeDomain(target) == s.baseDomain {
+			return nil
+		}
+	}
+
+	if txtErr != nil && !isNotFoundDNSError(txtErr) {
+		return fmt.Errorf("%w: TXT record %s: %v", errDomainLookupFailed, domain.ChallengeName(), txtErr)
+	}
+	if cnameErr != nil && !isNotFoundDNSError(cnameErr) {
+		return fmt.Errorf("%w: CNAME record %s: %v", errDomainLookupFailed, domain.Domain, cnameErr)
+	}
+
+	if s.baseDomain != "" {
+		return fmt.Errorf("no TXT record %q at %s and %s is not a CNAME to %s", domain.ChallengeValue(), domain.ChallengeName(), domain.Domain, s.baseDomain)
+	}
+	return fmt.Errorf("no TXT record %q at %s", domain.ChallengeValue(), domain.ChallengeName())
+}
+
+// isNotFoundDNSError reports whether a lookup error means the record doesn't exist,
+// as opposed to the resolver failing to answer
+func isNotFoundDNSError(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// withInstructions attaches the DNS records that verify a domain that isn't verified yet
+func (s *DomainService) withInstructions(domain *models.ProjectDomain) *models.ProjectDomain {
+	if domain.IsVerified() {
+		return domain
+	}
+
+	domain.DNS = &models.DNSInstructions{
+		TXTName:  domain.ChallengeName(),
+		TXTValue: domain.ChallengeValue(),
+	}
+	if s.baseDomain != "" {
+		domain.DNS.CNAMEName = domain.Domain
+		domain.DNS.CNAMEValue = s.baseDomain
+	}
+	return domain
+}
+
+// redeploy rebuilds a deployed project so its routing picks up the current verified domains
+func (s *DomainService) redeploy(project *models.Project) {
+	if s.buildService == nil || project.DeploymentStatus != "deployed" {
+		return
+	}
+	s.buildService.Enqueue(project.ID, project.UserID)
+}
+
+func (s *DomainService) getOwnedProject(ctx context.Context, userID, projectID string) (*models.Project, error) {
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+
+	return project, nil
+}
+
+func (s *DomainService) getProjectDomain(ctx context.Context, projectID, domainID string) (*models.ProjectDomain, error) {
+	domain, err := s.store.GetProjectDomainByID(ctx, domainID)
+	if err != nil {
+		return nil, fmt.Errorf("domain not found: %w", err)
+	}
+
+	if domain.ProjectID != projectID {
+		return nil, errors.New("domain not found")
+	}
+
+	return domain, nil
+}
+
+// subdomainLabel turns a name into a DNS label: lowercase alphanumerics separated by single dashes
+func subdomainLabel(name string) string {
+	label := strings.Trim(nonLabelChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if len(label) > GENERATED_LABEL_MAX_LENGTH {
+		label = strings.TrimRight(label[:GENERATED_LABEL_MAX_LENGTH], "-")
+	}
+	if label == "" {
+		label = "app"
+	}
+	return label
+}
+
+// generateVerificationToken returns a random token for the ownership TXT record
+func generateVerificationToken() (string, error) {
+	tokenBytes := make([]byte, 16)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", fmt.Errorf("failed to generate verification token: %w", err)
+	}
+	return hex.EncodeToString(tokenBytes), nil
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store"
+)
+
+// fakeResolver answers lookups from fixed records, or with a fixed error
+type fakeResolver struct {
+	txt      map[string][]string
+	cname    map[string]string
+	txtErr   error
+	cnameErr error
+}
+
+func (r *fakeResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if r.txtErr != nil {
+		return nil, r.txtErr
+	}
+	records, ok := r.txt[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return records, nil
+}
+
+func (r *fakeResolver) LookupCNAME(ctx context.Context, host string) (string, error) {
+	if r.cnameErr != nil {
+		return "", r.cnameErr
+	}
+	target, ok := r.cname[host]
+	if !ok {
+		return "", &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return target, nil
+}
+
+// domainStore holds a single project and domain. Only the calls VerifyDomain makes
+// are implemented, anything else panics on the nil embedded store.
+type domainStore struct {
+	store.Store
+	project *models.Project
+	domain  *models.ProjectDomain
+}
+
+func (s *domainStore) GetProjectByID(ctx context.Context, id string) (*models.Project, error) {
+	return s.project, nil
+}
+
+func (s *domainStore) GetProjectDomainByID(ctx context.Context, id string) (*models.ProjectDomain, error) {
+	domain := *s.domain
+	return &domain, nil
+}
+
+func (s *domainStore) UpdateProjectDomainVerification(ctx context.Context, id, status, verificationError string, verifiedAt *time.Time) (*models.ProjectDomain, error) {
+	s.domain.Status = status
+	s.domain.VerificationError = verificationError
+	s.domain.VerifiedAt = verifiedAt
+	domain := *s.domain
+	return &domain, nil
+}
+
+func TestVerifyDomain(t *testing.T) {
+	const baseDomain = "kova.example.com"
+	verifiedAt := time.Now().Add(-time.Hour)
+	timeout := &net.DNSError{Err: "i/o timeout", Name: "app.example.org", IsTimeout: true}
+
+	tests := []struct {
+		name       string
+		resolver   *fakeResolver
+		status     string
+		wantStatus string
+		wantError  bool
+	}{
+		{
+			name: "TXT record matches",
+			resolver: &fakeResolver{
+				txt: map[string][]string{"_kova-challenge.app.example.org": {"unrelated", " kova-verification=token "}},
+			},
+			status:     models.DOMAIN_STATUS_PENDING,
+			wantStatus: models.DOMAIN_STATUS_VERIFIED,
+		},
+		{
+			name: "CNAME to the base domain",
+			resolver: &fakeResolver{
+				cname: map[string]string{"app.example.org": "Kova.Example.com."},
+			},
+			status:     models.DOMAIN_STATUS_PENDING,
+			wantStatus: models.DOMAIN_STATUS_VERIFIED,
+		},
+		{
+			name: "CNAME elsewhere",
+			resolver: &fakeResolver{
+				cname: map[string]string{"app.example.org": "other.example.net."},
+			},
+			status:     models.DOMAIN_STATUS_PENDING,
+			wantStatus: models.DOMAIN_STATUS_FAILED,
+			wantError:  true,
+		},
+		{
+			name:       "NXDOMAIN",
+			resolver:   &fakeResolver{},
+			status:     models.DOMAIN_STATUS_PENDING,
+			wantStatus: models.DOMAIN_STATUS_FAILED,
+			wantError:  true,
+		},
+		{
+			name:       "NXDOMAIN unroutes a verified domain",
+			resolver:   &fakeResolver{},
+			status:     models.DOMAIN_STATUS_VERIFIED,
+			wantStatus: models.DOMAIN_STATUS_FAILED,
+			wantError:  true,
+		},
+		{
+			name:       "lookup failure keeps a verified domain routed",
+			resolver:   &fakeResolver{txtErr: timeout, cnameErr: timeout},
+			status:     models.DOMAIN_STATUS_VERIFIED,
+			wantStatus: models.DOMAIN_STATUS_VERIFIED,
+			wantError:  true,
+		},
+		{
+			name:       "lookup failure fails a pending domain",
+			resolver:   &fakeResolver{txtErr: timeout, cnameErr: timeout},
+			status:     models.DOMAIN_STATUS_PENDING,
+			wantStatus: models.DOMAIN_STATUS_FAILED,
+			wantError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domain := &models.ProjectDomain{
+				ID:                "domain-1",
+				ProjectID:         "project-1",
+				Domain:            "app.example.org",
+				VerificationToken: "token",
+				Status:            tt.status,
+			}
+			if tt.status == models.DOMAIN_STATUS_VERIFIED {
+				domain.VerifiedAt = &verifiedAt
+			}
+			fake := &domainStore{
+				project: &models.Project{ID: "project-1", UserID: "user-1"},
+				domain:  domain,
+			}
+			service := NewDomainService(fake, nil, tt.resolver, baseDomain)
+
+			updated, err := service.VerifyDomain(context.Background(), "user-1", "project-1", "domain-1")
+			if err != nil {
+				t.Fatalf("VerifyDomain() error = %v", err)
+			}
+			if updated.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", updated.Status, tt.wantStatus)
+			}
+			if (updated.VerificationError != "") != tt.wantError {
+				t.Errorf("verification error = %q, want error %v", updated.VerificationError, tt.wantError)
+			}
+			if tt.wantStatus == models.DOMAIN_STATUS_VERIFIED && updated.VerifiedAt == nil {
+				t.Error("verified domain has no verified_at")
+			}
+			if tt.wantStatus != models.DOMAIN_STATUS_VERIFIED && updated.VerifiedAt != nil {
+				t.Error("unverified domain kept its verified_at")
+			}
+		})
+	}
+}
+
+func TestCheckOwnershipLookupFailure(t *testing.T) {
+	domain := &models.ProjectDomain{Domain: "app.example.org", VerificationToken: "token"}
+	service := &DomainService{
+		resolver:   &fakeResolver{txtErr: errors.New("server misbehaving")},
+		baseDomain: "kova.example.com",
+	}
+
+	err := service.checkOwnership(context.Background(), domain)
+	if !errors.Is(err, errDomainLookupFailed) {
+		t.Fatalf("checkOwnership() error = %v, want %v", err, errDomainLookupFailed)
+	}
+}
@@ -0,0 +1,356 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store"
+	"github.com/dopeCape/kova/internal/store/postgres/repository"
+)
+
+// GC_DANGLING_MIN_AGE keeps untagged images of builds still in progress
+const GC_DANGLING_MIN_AGE = time.Hour
+
+// deploymentTagPattern matches the image tags given to each deployment by newDeploymentID
+var deploymentTagPattern = regexp.MustCompile(`^\d{8}-\d{6}(-[0-9a-f]+)?$`)
+
+// humanSizePattern matches sizes as printed by buildctl, such as 1.2GB or 512kB
+var humanSizePattern = regexp.MustCompile(`^([0-9.]+)\s*([A-Za-z]*)$`)
+
+var errGCRunning = errors.New("garbage collection is already running")
+
+// GCOptions configures image and build cache garbage collection
+type GCOptions struct {
+	Enabled         bool
+	Interval        time.Duration
+	KeepImages      int   // Deployments kept per project for rollback
+	BuildCacheLimit int64 // Bytes of BuildKit cache left after pruning
+}
+
+// GCService removes the images and BuildKit cache that builds leave behind, on a
+// schedule or when triggered
+type GCService struct {
+	store   store.Store
+	docker  *DockerClient
+	options GCOptions
+
+	running    sync.Mutex // Held for the duration of a run
+	inProgress atomic.Bool
+
+	mu         sync.RWMutex
+	lastReport *models.GCReport
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewGCService(store store.Store, docker *DockerClient, options GCOptions) *GCService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &GCService{
+		store:   store,
+		docker:  docker,
+		options: options,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Start runs garbage collection on its schedule
+func (s *GCService) Start() {
+	if !s.options.Enabled || s.options.Interval <= 0 {
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.options.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if _, err := s.Run(s.ctx, false); err != nil {
+				log.Printf("⚠️  Scheduled garbage collection skipped: %v", err)
+			}
+		}
+	}()
+	log.Printf("🗑️  Garbage collection scheduled every %s", s.options.Interval)
+}
+
+// Shutdown stops scheduled garbage collection and waits for a running one
+func (s *GCService) Shutdown() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// Run collects garbage and returns what was removed. A dry run only reports what
+// would be.
+func (s *GCService) Run(ctx context.Context, dryRun bool) (*models.GCReport, error) {
+	if !s.running.TryLock() {
+		return nil, errGCRunning
+	}
+	defer s.running.Unlock()
+
+	return s.run(ctx, dryRun), nil
+}
+
+// Trigger starts a garbage collection run in the background
+func (s *GCService) Trigger() error {
+	if !s.running.TryLock() {
+		return errGCRunning
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer s.running.Unlock()
+		s.run(s.ctx, false)
+	}()
+	return nil
+}
+
+// LastReport returns the report of the last run, dry runs aside, and whether a
+// run is in progress
+func (s *GCService) LastReport() (*models.GCReport, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastReport, s.inProgress.Load()
+}
+
+func (s *GCService) run(ctx context.Context, dryRun bool) *models.GCReport {
+	s.inProgress.Store(true)
+	defer s.inProgress.Store(false)
+
+	report := &models.GCReport{
+		DryRun:               dryRun,
+		StartedAt:            time.Now(),
+		Images:               []*models.RemovedImage{},
+		BuildCacheLimitBytes: s.options.BuildCacheLimit,
+		Errors:               []string{},
+	}
+
+	if err := s.collectImages(ctx, report); err != nil {
+		report.Errors = append(report.Errors, err.Error())
+	}
+	if err := s.pruneBuildCache(ctx, report); err != nil {
+		report.Errors = append(report.Errors, err.Error())
+	}
+
+	finishedAt := time.Now()
+	report.FinishedAt = &finishedAt
+	report.ReclaimedBytes = report.ImagesReclaimedBytes + report.BuildCacheReclaimedBytes
+
+	if !dryRun {
+		log.Printf("🗑️  Garbage collection removed %d images and reclaimed %d bytes (%d errors)", len(report.Images), report.ReclaimedBytes, len(report.Errors))
+		s.mu.Lock()
+		s.lastReport = report
+		s.mu.Unlock()
+	}
+	return report
+}
+
+// collectImages removes the images of deleted projects, the deployments of each
+// project beyond the ones kept for rollback, and dangling images
+func (s *GCService) collectImages(ctx context.Context, report *models.GCReport) error {
+	images, err := s.docker.listImages(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list images: %w", err)
+	}
+
+	// Only a project the store reports missing is deleted, any other lookup error
+	// aborts the collection so live images are never removed
+	projects := make(map[string]bool)
+	projectExists := func(projectID string) (bool, error) {
+		exists, checked := projects[projectID]
+		if !checked {
+			_, err := s.store.GetProjectByID(ctx, projectID)
+			if err != nil && !errors.Is(err, repository.ErrProjectNotFound) {
+				return false, fmt.Errorf("failed to look up project %s, skipping image collection: %w", projectID, err)
+			}
+			exists = err == nil
+			projects[projectID] = exists
+		}
+		return exists, nil
+	}
+
+	type deployment struct {
+		tag   string
+		image *dockerImage
+	}
+	deployments := make(map[string][]deployment) // project ID -> deployment tags
+	removals := make(map[*dockerImage][]*models.RemovedImage)
+
+	for i := range images {
+		image := &images[i]
+
+		if isDangling(image) {
+			if time.Since(time.Unix(image.Created, 0)) > GC_DANGLING_MIN_AGE {
+				removals[image] = append(removals[image], &models.RemovedImage{Reference: image.ID, Reason: models.GC_REASON_DANGLING})
+			}
+			continue
+		}
+
+		for _, reference := range image.RepoTags {
+			repository, tag := splitImageReference(reference)
+			if !kovaStackPattern.MatchString(repository) {
+				continue
+			}
+
+			projectID := projectIDForStack(repository)
+			exists, err := projectExists(projectID)
+			if err != nil {
+				return err
+			}
+			switch {
+			case !exists:
+				removals[image] = append(removals[image], &models.RemovedImage{Reference: reference, ProjectID: projectID, Reason: models.GC_REASON_PROJECT_DELETED})
+			case repository == projectID && deploymentTagPattern.MatchString(tag):
+				deployments[projectID] = append(deployments[projectID], deployment{tag, image})
+			}
+		}
+	}
+
+	// Deployment tags start with when they were built, so they sort newest last
+	for projectID, projectDeployments := range deployments {
+		sort.Slice(projectDeployments, func(i, j int) bool {
+			return projectDeployments[i].tag < projectDeployments[j].tag
+		})
+		for i := 0; i < len(projectDeployments)-s.options.KeepImages; i++ {
+			d := projectDeployments[i]
+			removals[d.image] = append(removals[d.image], &models.RemovedImage{Reference: projectID + ":" + d.tag, ProjectID: projectID, Reason: models.GC_REASON_SUPERSEDED})
+		}
+	}
+
+	for image, removed := range removals {
+		// Removing a tag only frees space once the image has no tags left
+		freed := isDangling(image) || len(removed) == len(image.RepoTags)
+		for _, r := range removed {
+			if !report.DryRun {
+				if _, err := runDocker("image", "rm", r.Reference); err != nil {
+					report.Errors = append(report.Errors, err.Error())
+					freed = false
+					continue
+				}
+			}
+			report.Images = append(report.Images, r)
+		}
+
+		if freed && len(removed) > 0 {
+			removed[0].SizeBytes = image.Size
+			report.ImagesReclaimedBytes += image.Size
+		}
+	}
+
+	sort.Slice(report.Images, func(i, j int) bool {
+		return report.Images[i].Reference < report.Images[j].Reference
+	})
+	return nil
+}
+
+// pruneBuildCache prunes the BuildKit cache railpack builds with down to the
+// configured limit
+func (s *GCService) pruneBuildCache(ctx context.Context, report *models.GCReport) error {
+	before, err := buildCacheSize(ctx)
+	if err != nil {
+		return err
+	}
+	report.BuildCacheBytes = before
+
+	if before <= s.options.BuildCacheLimit {
+		return nil
+	}
+
+	if report.DryRun {
+		// The cache in use can't be pruned, so this is the most that could be reclaimed
+		report.BuildCacheReclaimedBytes = before - s.options.BuildCacheLimit
+		return nil
+	}
+
+	keepMB := strconv.FormatInt(s.options.BuildCacheLimit/1_000_000, 10)
+	if output, err := exec.CommandContext(ctx, "buildctl", "prune", "--keep-storage", keepMB).CombinedOutput(); err != nil {
+		return fmt.Errorf("buildctl prune failed: %w, output: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	after, err := buildCacheSize(ctx)
+	if err != nil {
+		return err
+	}
+	if after < before {
+		report.BuildCacheReclaimedBytes = before - after
+	}
+	return nil
+}
+
+// buildCacheSize returns the disk used by the BuildKit cache, read from the
+// total line of `buildctl du`
+func buildCacheSize(ctx context.Context) (int64, error) {
+	output, err := exec.CommandContext(ctx, "buildctl", "du").CombinedOutput()
+	if err != nil {
+		return 0, fmt.Errorf("buildctl du failed: %w, output: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), "Total:"); ok {
+			return parseHumanSize(strings.TrimSpace(value))
+		}
+	}
+	// An empty cache has no records and no total
+	return 0, nil
+}
+
+// parseHumanSize parses a size in decimal or binary units
+func parseHumanSize(size string) (int64, error) {
+	match := humanSizePattern.FindStringSubmatch(size)
+	if match == nil {
+		return 0, fmt.Errorf("invalid size %q", size)
+	}
+
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: %w", size, err)
+	}
+
+	units := map[string]float64{
+		"": 1, "B": 1,
+		"kB": 1e3, "KB": 1e3, "MB": 1e6, "GB": 1e9, "TB": 1e12,
+		"KiB": 1 << 10, "MiB": 1 << 20, "GiB": 1 << 30, "TiB": 1 << 40,
+	}
+	unit, ok := units[match[2]]
+	if !ok {
+		return 0, fmt.Errorf("invalid size unit in %q", size)
+	}
+	return int64(value * unit), nil
+}
+
+// isDangling checks if an image has no tags left
+func isDangling(image *dockerImage) bool {
+	return len(image.RepoTags) == 0 || (len(image.RepoTags) == 1 && image.RepoTags[0] == "<none>:<none>")
+}
+
+// splitImageReference splits repository:tag, where the repository may include a
+// registry port
+func splitImageReference(reference string) (string, string) {
+	i := strings.LastIndex(reference, ":")
+	if i < 0 || strings.Contains(reference[i:], "/") {
+		return reference, "latest"
+	}
+	return reference[:i], reference[i+1:]
+}
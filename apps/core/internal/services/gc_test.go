@@ -0,0 +1,61 @@
+package services
+
+import "testing"
+
+func TestParseHumanSize(t *testing.T) {
+	tests := []struct {
+		size    string
+		want    int64
+		wantErr bool
+	}{
+		{size: "0B", want: 0},
+		{size: "512", want: 512},
+		{size: "1.5kB", want: 1500},
+		{size: "235MB", want: 235_000_000},
+		{size: "1.2GB", want: 1_200_000_000},
+		{size: "2 TB", want: 2_000_000_000_000},
+		{size: "1KiB", want: 1024},
+		{size: "1.5MiB", want: 1_572_864},
+		{size: "2GiB", want: 2 << 30},
+		{size: "", wantErr: true},
+		{size: "MB", wantErr: true},
+		{size: "1.2.3GB", wantErr: true},
+		{size: "12XB", wantErr: true},
+		{size: "-1GB", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.size, func(t *testing.T) {
+			got, err := parseHumanSize(tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseHumanSize(%q) error = %v, want error %v", tt.size, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseHumanSize(%q) = %d, want %d", tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitImageReference(t *testing.T) {
+	tests := []struct {
+		reference  string
+		repository string
+		tag        string
+	}{
+		{reference: "kova-app", repository: "kova-app", tag: "latest"},
+		{reference: "kova-app:20250115-103045-abc1234", repository: "kova-app", tag: "20250115-103045-abc1234"},
+		{reference: "ghcr.io/acme/api:v1.2", repository: "ghcr.io/acme/api", tag: "v1.2"},
+		{reference: "localhost:5000/acme/api", repository: "localhost:5000/acme/api", tag: "latest"},
+		{reference: "localhost:5000/acme/api:v2", repository: "localhost:5000/acme/api", tag: "v2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reference, func(t *testing.T) {
+			repository, tag := splitImageReference(tt.reference)
+			if repository != tt.repository || tag != tt.tag {
+				t.Errorf("splitImageReference(%q) = %q, %q, want %q, %q", tt.reference, repository, tag, tt.repository, tt.tag)
+			}
+		})
+	}
+}
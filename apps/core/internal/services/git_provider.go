@@ -0,0 +1,255 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+)
+
+const (
+	COMMIT_STATE_PENDING = "pending"
+	COMMIT_STATE_SUCCESS = "success"
+	COMMIT_STATE_FAILURE = "failure"
+
+	// COMMIT_STATUS_CONTEXT labels the commit statuses Kova reports
+	COMMIT_STATUS_CONTEXT = "kova/deploy"
+)
+
+// GitProvider is a git hosting service Kova can link accounts to and build from
+type GitProvider interface {
+	// Type returns the provider type, one of the models.GIT_PROVIDER_* values
+	Type() string
+	// URL returns the web URL of the provider instance
+	URL() string
+
+	GetUser(ctx context.Context, accessToken string) (*GitUser, error)
+	GetRepositories(ctx context.Context, accessToken string, page, perPage int) ([]*GitRepository, error)
+	// GetAllRepositories returns every repository the token can access across all pages
+	GetAllRepositories(ctx context.Context, accessToken string) ([]*GitRepository, error)
+	GetRepository(ctx context.Context, accessToken, repoFullName string) (*GitRepository, error)
+	GetBranches(ctx context.Context, accessToken, repoFullName string, page, perPage int) ([]*GitBranch, error)
+	// GetBranch returns a single branch, or an error wrapping errResourceNotFound if it doesn't exist
+	GetBranch(ctx context.Context, accessToken, repoFullName, branch string) (*GitBranch, error)
+	GetTags(ctx context.Context, accessToken, repoFullName string, page, perPage int) ([]*GitTag, error)
+
+	// CloneURL returns the HTTPS clone URL of a repository, without credentials
+	CloneURL(repoFullName string) string
+	// CloneCredentials returns the basic auth pair git uses to clone with a token
+	CloneCredentials(accessToken string) (username, password string)
+
+	// CreateWebhook subscribes hookURL to push and pull/merge request events and returns the hook ID
+	CreateWebhook(ctx context.Context, accessToken, repoFullName, hookURL, secret string) (int64, error)
+	DeleteWebhook(ctx context.Context, accessToken, repoFullName string, hookID int64) error
+
+	SetCommitStatus(ctx context.Context, accessToken, repoFullName, sha string, status *CommitStatus) error
+}
+
+// GitUser is the account behind an access token
+type GitUser struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	AvatarURL string `json:"avatar_url"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+}
+
+// GitRepository is a repository as reported by any provider
+type GitRepository struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	Private       bool   `json:"private"`
+	Description   string `json:"description"`
+	Language      string `json:"language"`
+	StarCount     int    `json:"stars"`
+	UpdatedAt     string `json:"updated_at"`
+	DefaultBranch string `json:"default_branch"`
+	HTMLURL       string `json:"html_url"`
+	CloneURL      string `json:"clone_url"`
+}
+
+type GitBranch struct {
+	Name      string `json:"name"`
+	CommitSHA string `json:"commit_sha"`
+	Protected bool   `json:"protected"`
+}
+
+type GitTag struct {
+	Name      string `json:"name"`
+	CommitSHA string `json:"commit_sha"`
+}
+
+// CommitStatus is a build result attached to a commit. State is one of the COMMIT_STATE_* values.
+type CommitStatus struct {
+	State       string
+	TargetURL   string
+	Description string
+	Context     string
+}
+
+// errResourceNotFound is returned by provider API calls that get a 404
+var errResourceNotFound = errors.New("resource not found")
+
+// GitProviders builds GitProvider clients for the provider instances accounts and projects point at
+type GitProviders struct {
+	githubService *GitHubService
+	client        *http.Client
+
+	// enterprise keeps one GitHubService per GitHub Enterprise Server so each
+	// instance keeps its response cache and rate limits between requests
+	mu         sync.Mutex
+	enterprise map[string]*GitHubService
+}
+
+func NewGitProviders(githubService *GitHubService) *GitProviders {
+	return &GitProviders{
+		githubService: githubService,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		enterprise: make(map[string]*GitHubService),
+	}
+}
+
+// Get returns the provider client for a provider type and instance URL. An empty
+// URL selects the provider's hosted instance.
+func (g *GitProviders) Get(providerType, providerURL string) (GitProvider, error) {
+	providerURL = models.NormalizeProviderURL(providerType, providerURL)
+
+	switch providerType {
+	case "", models.GIT_PROVIDER_GITHUB:
+		if providerType == "" {
+			providerURL = models.DefaultProviderURL(models.GIT_PROVIDER_GITHUB)
+		}
+		service := g.githubService
+		if providerURL != models.DefaultProviderURL(models.GIT_PROVIDER_GITHUB) {
+			service = g.enterpriseService(providerURL)
+		}
+		return &githubProvider{service: service, webURL: providerURL}, nil
+	case models.GIT_PROVIDER_GITLAB:
+		return &gitlabProvider{client: g.client, webURL: providerURL, apiURL: providerURL + "/api/v4"}, nil
+	case models.GIT_PROVIDER_GITEA:
+		if providerURL == "" {
+			return nil, errors.New("provider_url is required for gitea")
+		}
+		return &giteaProvider{client: g.client, webURL: providerURL, apiURL: providerURL + "/api/v1"}, nil
+	default:
+		return nil, fmt.Errorf("unsupported git provider: %s", providerType)
+	}
+}
+
+// enterpriseService returns the client of a GitHub Enterprise Server instance
+func (g *GitProviders) enterpriseService(providerURL string) *GitHubService {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	service, ok := g.enterprise[providerURL]
+	if !ok {
+		// GitHub Enterprise Server serves its API under /api/v3
+		service = newGitHubService(g.client, providerURL+"/api/v3")
+		g.enterprise[providerURL] = service
+	}
+	return service
+}
+
+// ForAccount returns the provider an account is linked to
+func (g *GitProviders) ForAccount(account *models.Account) (GitProvider, error) {
+	return g.Get(account.Provider, account.ProviderURL)
+}
+
+// ForProject returns the provider hosting a project's repository
+func (g *GitProviders) ForProject(project *models.Project) (GitProvider, error) {
+	return g.Get(project.Provider, project.ProviderURL)
+}
+
+// doProviderJSON performs an authenticated API call against a GitLab or Gitea instance
+func doProviderJSON(ctx context.Context, client *http.Client, method, url, authorization string, payload, out interface{}) error {
+	if authorization == "" {
+		return fmt.Errorf("access token is required")
+	}
+
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "Kova-App")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		// Success, continue to parse response
+	case resp.StatusCode == http.StatusUnauthorized:
+		return fmt.Errorf("invalid or expired access token")
+	case resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("access token lacks required permissions")
+	case resp.StatusCode == http.StatusNotFound:
+		return errResourceNotFound
+	default:
+		return fmt.Errorf("git provider API returned status %d", resp.StatusCode)
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// collectAllPages calls fetch for pages of 100 repositories until one comes back
+// short, for providers whose pagination doesn't need Link header parsing
+func collectAllPages(fetch func(page, perPage int) ([]*GitRepository, error)) ([]*GitRepository, error) {
+	all := []*GitRepository{}
+	for page := 1; page <= GITHUB_MAX_PAGES; page++ {
+		items, err := fetch(page, 100)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if len(items) < 100 {
+			break
+		}
+	}
+	return all, nil
+}
+
+func normalizePagination(page, perPage int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 30
+	}
+	return page, perPage
+}
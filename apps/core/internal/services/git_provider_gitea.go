@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/dopeCape/kova/internal/models"
+)
+
+// giteaProvider talks to a Gitea (or Forgejo) server through the v1 API
+type giteaProvider struct {
+	client *http.Client
+	webURL string
+	apiURL string
+}
+
+type giteaRepository struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	Private       bool   `json:"private"`
+	Description   string `json:"description"`
+	Language      string `json:"language"`
+	StarsCount    int    `json:"stars_count"`
+	UpdatedAt     string `json:"updated_at"`
+	DefaultBranch string `json:"default_branch"`
+	HTMLURL       string `json:"html_url"`
+	CloneURL      string `json:"clone_url"`
+}
+
+type giteaBranch struct {
+	Name   string `json:"name"`
+	Commit struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+	Protected bool `json:"protected"`
+}
+
+func (repo *giteaRepository) toGitRepository() *GitRepository {
+	return &GitRepository{
+		ID:            repo.ID,
+		Name:          repo.Name,
+		FullName:      repo.FullName,
+		Private:       repo.Private,
+		Description:   repo.Description,
+		Language:      repo.Language,
+		StarCount:     repo.StarsCount,
+		UpdatedAt:     repo.UpdatedAt,
+		DefaultBranch: repo.DefaultBranch,
+		HTMLURL:       repo.HTMLURL,
+		CloneURL:      repo.CloneURL,
+	}
+}
+
+func (b *giteaBranch) toGitBranch() *GitBranch {
+	return &GitBranch{
+		Name:      b.Name,
+		CommitSHA: b.Commit.ID,
+		Protected: b.Protected,
+	}
+}
+
+func (p *giteaProvider) Type() string {
+	return models.GIT_PROVIDER_GITEA
+}
+
+func (p *giteaProvider) URL() string {
+	return p.webURL
+}
+
+func (p *giteaProvider) GetUser(ctx context.Context, accessToken string) (*GitUser, error) {
+	var user struct {
+		ID        int64  `json:"id"`
+		Login     string `json:"login"`
+		FullName  string `json:"full_name"`
+		Email     string `json:"email"`
+		AvatarURL string `json:"avatar_url"`
+	}
+	if err := p.do(ctx, "GET", "/user", accessToken, nil, &user); err != nil {
+		return nil, err
+	}
+
+	if user.ID == 0 || user.Login == "" {
+		return nil, fmt.Errorf("invalid Gitea user: missing ID or login")
+	}
+
+	return &GitUser{
+		ID:        user.ID,
+		Login:     user.Login,
+		AvatarURL: user.AvatarURL,
+		Name:      user.FullName,
+		Email:     user.Email,
+	}, nil
+}
+
+func (p *giteaProvider) GetRepositories(ctx context.Context, accessToken string, page, perPage int) ([]*GitRepository, error) {
+	page, perPage = normalizePagination(page, perPage)
+
+	var repositories []*giteaRepository
+	path := fmt.Sprintf("/user/repos?page=%d&limit=%d", page, perPage)
+	if err := p.do(ctx, "GET", path, accessToken, nil, &repositories); err != nil {
+		return nil, err
+	}
+
+	result := make([]*GitRepository, len(repositories))
+	for i, repo := range repositories {
+		result[i] = repo.toGitRepository()
+	}
+	return result, nil
+}
+
+func (p *giteaProvider) GetAllRepositories(ctx context.Context, accessToken string) ([]*GitRepository, error) {
+	return collectAllPages(func(page, perPage int) ([]*GitRepository, error) {
+		return p.GetRepositories(ctx, accessToken, page, perPage)
+	})
+}
+
+func (p *giteaProvider) GetRepository(ctx context.Context, accessToken, repoFullName string) (*GitRepository, error) {
+	var repo giteaRepository
+	if err := p.do(ctx, "GET", "/repos/"+repoFullName, accessToken, nil, &repo); err != nil {
+		return nil, fmt.Errorf("failed to fetch repository: %w", err)
+	}
+	return repo.toGitRepository(), nil
+}
+
+func (p *giteaProvider) GetBranches(ctx context.Context, accessToken, repoFullName string, page, perPage int) ([]*GitBranch, error) {
+	page, perPage = normalizePagination(page, perPage)
+
+	var branches []*giteaBranch
+	path := fmt.Sprintf("/repos/%s/branches?page=%d&limit=%d", repoFullName, page, perPage)
+	if err := p.do(ctx, "GET", path, accessToken, nil, &branches); err != nil {
+		return nil, fmt.Errorf("failed to fetch branches: %w", err)
+	}
+
+	result := make([]*GitBranch, len(branches))
+	for i, branch := range branches {
+		result[i] = branch.toGitBranch()
+	}
+	return result, nil
+}
+
+func (p *giteaProvider) GetBranch(ctx context.Context, accessToken, repoFullName, branch string) (*GitBranch, error) {
+	var result giteaBranch
+	path := fmt.Sprintf("/repos/%s/branches/%s", repoFullName, url.PathEscape(branch))
+	if err := p.do(ctx, "GET", path, accessToken, nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to fetch branch: %w", err)
+	}
+	return result.toGitBranch(), nil
+}
+
+func (p *giteaProvider) GetTags(ctx context.Context, accessToken, repoFullName string, page, perPage int) ([]*GitTag, error) {
+	page, perPage = normalizePagination(page, perPage)
+
+	var tags []struct {
+		Name   string `json:"name"`
+		Commit struct {
+			SHA string `json:"sha"`
+		} `json:"commit"`
+	}
+	path := fmt.Sprintf("/repos/%s/tags?page=%d&limit=%d", repoFullName, page, perPage)
+	if err := p.do(ctx, "GET", path, accessToken, nil, &tags); err != nil {
+		return nil, fmt.Errorf("failed to fetch tags: %w", err)
+	}
+
+	result := make([]*GitTag, len(tags))
+	for i, tag := range tags {
+		result[i] = &GitTag{Name: tag.Name, CommitSHA: tag.Commit.SHA}
+	}
+	return result, nil
+}
+
+func (p *giteaProvider) CloneURL(repoFullName string) string {
+	return fmt.Sprintf("%s/%s.git", p.webURL, repoFullName)
+}
+
+func (p *giteaProvider) CloneCredentials(accessToken string) (string, string) {
+	// Gitea accepts an access token as the password for any username
+	return "oauth2", accessToken
+}
+
+func (p *giteaProvider) CreateWebhook(ctx context.Context, accessToken, repoFullName, hookURL, secret string) (int64, error) {
+	payload := map[string]interface{}{
+		"type":   "gitea",
+		"active": true,
+		"events": []string{"push", "pull_request"},
+		"config": map[string]string{
+			"url":          hookURL,
+			"content_type": "json",
+			"secret":       secret,
+		},
+	}
+
+	var hook struct {
+		ID int64 `json:"id"`
+	}
+	if err := p.do(ctx, "POST", "/repos/"+repoFullName+"/hooks", accessToken, payload, &hook); err != nil {
+		return 0, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return hook.ID, nil
+}
+
+func (p *giteaProvider) DeleteWebhook(ctx context.Context, accessToken, repoFullName string, hookID int64) error {
+	path := fmt.Sprintf("/repos/%s/hooks/%d", repoFullName, hookID)
+	if err := p.do(ctx, "DELETE", path, accessToken, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+func (p *giteaProvider) SetCommitStatus(ctx context.Context, accessToken, repoFullName, sha string, status *CommitStatus) error {
+	payload := map[string]string{
+		"state":       status.State,
+		"target_url":  status.TargetURL,
+		"description": status.Description,
+		"context":     status.Context,
+	}
+	path := fmt.Sprintf("/repos/%s/statuses/%s", repoFullName, sha)
+	if err := p.do(ctx, "POST", path, accessToken, payload, nil); err != nil {
+		return fmt.Errorf("failed to set commit status: %w", err)
+	}
+	return nil
+}
+
+func (p *giteaProvider) do(ctx context.Context, method, path, accessToken string, payload, out interface{}) error {
+	if accessToken == "" {
+		return fmt.Errorf("access token is required")
+	}
+	return doProviderJSON(ctx, p.client, method, p.apiURL+path, "token "+accessToken, payload, out)
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/dopeCape/kova/internal/models"
+)
+
+// githubProvider adapts GitHubService to the GitProvider interface, for github.com
+// and GitHub Enterprise Server
+type githubProvider struct {
+	service *GitHubService
+	webURL  string
+}
+
+type githubBranch struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+	Protected bool `json:"protected"`
+}
+
+func (b *githubBranch) toGitBranch() *GitBranch {
+	return &GitBranch{
+		Name:      b.Name,
+		CommitSHA: b.Commit.SHA,
+		Protected: b.Protected,
+	}
+}
+
+func (p *githubProvider) Type() string {
+	return models.GIT_PROVIDER_GITHUB
+}
+
+func (p *githubProvider) URL() string {
+	return p.webURL
+}
+
+func (p *githubProvider) GetUser(ctx context.Context, accessToken string) (*GitUser, error) {
+	user, err := p.service.GetUser(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GitUser{
+		ID:        user.ID,
+		Login:     user.Login,
+		AvatarURL: user.AvatarURL,
+		Name:      user.Name,
+		Email:     user.Email,
+	}, nil
+}
+
+func (p *githubProvider) GetRepositories(ctx context.Context, accessToken string, page, perPage int) ([]*GitRepository, error) {
+	repositories, err := p.service.GetRepositories(ctx, accessToken, page, perPage)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*GitRepository, len(repositories))
+	for i, repo := range repositories {
+		result[i] = repo.toGitRepository()
+	}
+	return result, nil
+}
+
+func (p *githubProvider) GetAllRepositories(ctx context.Context, accessToken string) ([]*GitRepository, error) {
+	repositories, err := p.service.GetAllRepositories(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*GitRepository, len(repositories))
+	for i, repo := range repositories {
+		result[i] = repo.toGitRepository()
+	}
+	return result, nil
+}
+
+func (p *githubProvider) GetRepository(ctx context.Context, accessToken, repoFullName string) (*GitRepository, error) {
+	var repo GitHubRepository
+	if err := p.service.doJSON(ctx, "GET", "/repos/"+repoFullName, accessToken, nil, &repo); err != nil {
+		return nil, fmt.Errorf("failed to fetch repository: %w", err)
+	}
+	return repo.toGitRepository(), nil
+}
+
+func (p *githubProvider) GetBranches(ctx context.Context, accessToken, repoFullName string, page, perPage int) ([]*GitBranch, error) {
+	page, perPage = normalizePagination(page, perPage)
+
+	var branches []*githubBranch
+	path := fmt.Sprintf("/repos/%s/branches?page=%d&per_page=%d", repoFullName, page, perPage)
+	if err := p.service.doJSON(ctx, "GET", path, accessToken, nil, &branches); err != nil {
+		return nil, fmt.Errorf("failed to fetch branches: %w", err)
+	}
+
+	result := make([]*GitBranch, len(branches))
+	for i, branch := range branches {
+		result[i] = branch.toGitBranch()
+	}
+	return result, nil
+}
+
+func (p *githubProvider) GetBranch(ctx context.Context, accessToken, repoFullName, branch string) (*GitBranch, error) {
+	var result githubBranch
+	path := fmt.Sprintf("/repos/%s/branches/%s", repoFullName, url.PathEscape(branch))
+	if err := p.service.doJSON(ctx, "GET", path, accessToken, nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to fetch branch: %w", err)
+	}
+	return result.toGitBranch(), nil
+}
+
+func (p *githubProvider) GetTags(ctx context.Context, accessToken, repoFullName string, page, perPage int) ([]*GitTag, error) {
+	page, perPage = normalizePagination(page, perPage)
+
+	var tags []struct {
+		Name   string `json:"name"`
+		Commit struct {
+			SHA string `json:"sha"`
+		} `json:"commit"`
+	}
+	path := fmt.Sprintf("/repos/%s/tags?page=%d&per_page=%d", repoFullName, page, perPage)
+	if err := p.service.doJSON(ctx, "GET", path, accessToken, nil, &tags); err != nil {
+		return nil, fmt.Errorf("failed to fetch tags: %w", err)
+	}
+
+	result := make([]*GitTag, len(tags))
+	for i, tag := range tags {
+		result[i] = &GitTag{Name: tag.Name, CommitSHA: tag.Commit.SHA}
+	}
+	return result, nil
+}
+
+func (p *githubProvider) CloneURL(repoFullName string) string {
+	return fmt.Sprintf("%s/%s.git", p.webURL, repoFullName)
+}
+
+func (p *githubProvider) CloneCredentials(accessToken string) (string, string) {
+	// x-access-token works for personal, OAuth and installation tokens alike
+	return "x-access-token", accessToken
+}
+
+func (p *githubProvider) CreateWebhook(ctx context.Context, accessToken, repoFullName, hookURL, secret string) (int64, error) {
+	return p.service.CreateRepositoryWebhook(ctx, accessToken, repoFullName, hookURL, secret, []string{"push", "pull_request"})
+}
+
+func (p *githubProvider) DeleteWebhook(ctx context.Context, accessToken, repoFullName string, hookID int64) error {
+	return p.service.DeleteRepositoryWebhook(ctx, accessToken, repoFullName, hookID)
+}
+
+func (p *githubProvider) SetCommitStatus(ctx context.Context, accessToken, repoFullName, sha string, status *CommitStatus) error {
+	payload := map[string]string{
+		"state":       status.State,
+		"target_url":  status.TargetURL,
+		"description": status.Description,
+		"context":     status.Context,
+	}
+	path := fmt.Sprintf("/repos/%s/statuses/%s", repoFullName, sha)
+	if err := p.service.doJSON(ctx, "POST", path, accessToken, payload, nil); err != nil {
+		return fmt.Errorf("failed to set commit status: %w", err)
+	}
+	return nil
+}
+
+// toGitRepository converts a GitHub API repository to the provider-neutral form
+func (r *GitHubRepository) toGitRepository() *GitRepository {
+	return &GitRepository{
+		ID:            r.ID,
+		Name:          r.Name,
+		FullName:      r.FullName,
+		Private:       r.Private,
+		Description:   r.Description,
+		Language:      r.Language,
+		StarCount:     r.StarCount,
+		UpdatedAt:     r.UpdatedAt,
+		DefaultBranch: r.DefaultBranch,
+		HTMLURL:       r.HTMLURL,
+		CloneURL:      r.CloneURL,
+	}
+}
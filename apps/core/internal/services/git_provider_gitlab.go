@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/dopeCape/kova/internal/models"
+)
+
+// gitlabProvider talks to gitlab.com or a self-hosted GitLab through the v4 API
+type gitlabProvider struct {
+	client *http.Client
+	webURL string
+	apiURL string
+}
+
+type gitlabProject struct {
+	ID                int64  `json:"id"`
+	Name              string `json:"name"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	Visibility        string `json:"visibility"`
+	Description       string `json:"description"`
+	StarCount         int    `json:"star_count"`
+	LastActivityAt    string `json:"last_activity_at"`
+	DefaultBranch     string `json:"default_branch"`
+	WebURL            string `json:"web_url"`
+	HTTPURLToRepo     string `json:"http_url_to_repo"`
+}
+
+type gitlabBranch struct {
+	Name   string `json:"name"`
+	Commit struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+	Protected bool `json:"protected"`
+}
+
+func (project *gitlabProject) toGitRepository() *GitRepository {
+	return &GitRepository{
+		ID:            project.ID,
+		Name:          project.Name,
+		FullName:      project.PathWithNamespace,
+		Private:       project.Visibility != "public",
+		Description:   project.Description,
+		StarCount:     project.StarCount,
+		UpdatedAt:     project.LastActivityAt,
+		DefaultBranch: project.DefaultBranch,
+		HTMLURL:       project.WebURL,
+		CloneURL:      project.HTTPURLToRepo,
+	}
+}
+
+func (b *gitlabBranch) toGitBranch() *GitBranch {
+	return &GitBranch{
+		Name:      b.Name,
+		CommitSHA: b.Commit.ID,
+		Protected: b.Protected,
+	}
+}
+
+func (p *gitlabProvider) Type() string {
+	return models.GIT_PROVIDER_GITLAB
+}
+
+func (p *gitlabProvider) URL() string {
+	return p.webURL
+}
+
+func (p *gitlabProvider) GetUser(ctx context.Context, accessToken string) (*GitUser, error) {
+	var user struct {
+		ID        int64  `json:"id"`
+		Username  string `json:"username"`
+		Name      string `json:"name"`
+		Email     string `json:"email"`
+		AvatarURL string `json:"avatar_url"`
+	}
+	if err := p.do(ctx, "GET", "/user", accessToken, nil, &user); err != nil {
+		return nil, err
+	}
+
+	if user.ID == 0 || user.Username == "" {
+		return nil, fmt.Errorf("invalid GitLab user: missing ID or username")
+	}
+
+	return &GitUser{
+		ID:        user.ID,
+		Login:     user.Username,
+		AvatarURL: user.AvatarURL,
+		Name:      user.Name,
+		Email:     user.Email,
+	}, nil
+}
+
+func (p *gitlabProvider) GetRepositories(ctx context.Context, accessToken string, page, perPage int) ([]*GitRepository, error) {
+	page, perPage = normalizePagination(page, perPage)
+
+	var projects []*gitlabProject
+	path := fmt.Sprintf("/projects?membership=true&order_by=last_activity_at&sort=desc&page=%d&per_page=%d", page, perPage)
+	if err := p.do(ctx, "GET", path, accessToken, nil, &projects); err != nil {
+		return nil, err
+	}
+
+	result := make([]*GitRepository, len(projects))
+	for i, project := range projects {
+		result[i] = project.toGitRepository()
+	}
+	return result, nil
+}
+
+func (p *gitlabProvider) GetAllRepositories(ctx context.Context, accessToken string) ([]*GitRepository, error) {
+	return collectAllPages(func(page, perPage int) ([]*GitRepository, error) {
+		return p.GetRepositories(ctx, accessToken, page, perPage)
+	})
+}
+
+func (p *gitlabProvider) GetRepository(ctx context.Context, accessToken, repoFullName string) (*GitRepository, error) {
+	var project gitlabProject
+	if err := p.do(ctx, "GET", "/projects/"+gitlabProjectID(repoFullName), accessToken, nil, &project); err != nil {
+		return nil, fmt.Errorf("failed to fetch repository: %w", err)
+	}
+	return project.toGitRepository(), nil
+}
+
+func (p *gitlabProvider) GetBranches(ctx context.Context, accessToken, repoFullName string, page, perPage int) ([]*GitBranch, error) {
+	page, perPage = normalizePagination(page, perPage)
+
+	var branches []*gitlabBranch
+	path := fmt.Sprintf("/projects/%s/repository/branches?page=%d&per_page=%d", gitlabProjectID(repoFullName), page, perPage)
+	if err := p.do(ctx, "GET", path, accessToken, nil, &branches); err != nil {
+		return nil, fmt.Errorf("failed to fetch branches: %w", err)
+	}
+
+	result := make([]*GitBranch, len(branches))
+	for i, branch := range branches {
+		result[i] = branch.toGitBranch()
+	}
+	return result, nil
+}
+
+func (p *gitlabProvider) GetBranch(ctx context.Context, accessToken, repoFullName, branch string) (*GitBranch, error) {
+	var result gitlabBranch
+	path := fmt.Sprintf("/projects/%s/repository/branches/%s", gitlabProjectID(repoFullName), url.PathEscape(branch))
+	if err := p.do(ctx, "GET", path, accessToken, nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to fetch branch: %w", err)
+	}
+	return result.toGitBranch(), nil
+}
+
+func (p *gitlabProvider) GetTags(ctx context.Context, accessToken, repoFullName string, page, perPage int) ([]*GitTag, error) {
+	page, perPage = normalizePagination(page, perPage)
+
+	var tags []struct {
+		Name   string `json:"name"`
+		Commit struct {
+			ID string `json:"id"`
+		} `json:"commit"`
+	}
+	path := fmt.Sprintf("/projects/%s/repository/tags?page=%d&per_page=%d", gitlabProjectID(repoFullName), page, perPage)
+	if err := p.do(ctx, "GET", path, accessToken, nil, &tags); err != nil {
+		return nil, fmt.Errorf("failed to fetch tags: %w", err)
+	}
+
+	result := make([]*GitTag, len(tags))
+	for i, tag := range tags {
+		result[i] = &GitTag{Name: tag.Name, CommitSHA: tag.Commit.ID}
+	}
+	return result, nil
+}
+
+func (p *gitlabProvider) CloneURL(repoFullName string) string {
+	return fmt.Sprintf("%s/%s.git", p.webURL, repoFullName)
+}
+
+func (p *gitlabProvider) CloneCredentials(accessToken string) (string, string) {
+	return "oauth2", accessToken
+}
+
+func (p *gitlabProvider) CreateWebhook(ctx context.Context, accessToken, repoFullName, hookURL, secret string) (int64, error) {
+	payload := map[string]interface{}{
+		"url":                     hookURL,
+		"token":                   secret,
+		"push_events":             true,
+		"merge_requests_events":   true,
+		"enable_ssl_verification": true,
+	}
+
+	var hook struct {
+		ID int64 `json:"id"`
+	}
+	path := fmt.Sprintf("/projects/%s/hooks", gitlabProjectID(repoFullName))
+	if err := p.do(ctx, "POST", path, accessToken, payload, &hook); err != nil {
+		return 0, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return hook.ID, nil
+}
+
+func (p *gitlabProvider) DeleteWebhook(ctx context.Context, accessToken, repoFullName string, hookID int64) error {
+	path := fmt.Sprintf("/projects/%s/hooks/%d", gitlabProjectID(repoFullName), hookID)
+	if err := p.do(ctx, "DELETE", path, accessToken, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+func (p *gitlabProvider) SetCommitStatus(ctx context.Context, accessToken, repoFullName, sha string, status *CommitStatus) error {
+	// GitLab calls a failed pipeline "failed" rather than "failure"
+	state := status.State
+	if state == COMMIT_STATE_FAILURE {
+		state = "failed"
+	}
+
+	payload := map[string]string{
+		"state":       state,
+		"target_url":  status.TargetURL,
+		"description": status.Description,
+		"name":        status.Context,
+	}
+	path := fmt.Sprintf("/projects/%s/statuses/%s", gitlabProjectID(repoFullName), sha)
+	if err := p.do(ctx, "POST", path, accessToken, payload, nil); err != nil {
+		return fmt.Errorf("failed to set commit status: %w", err)
+	}
+	return nil
+}
+
+func (p *gitlabProvider) do(ctx context.Context, method, path, accessToken string, payload, out interface{}) error {
+	if accessToken == "" {
+		return fmt.Errorf("access token is required")
+	}
+	return doProviderJSON(ctx, p.client, method, p.apiURL+path, "Bearer "+accessToken, payload, out)
+}
+
+// gitlabProjectID encodes a namespaced path for use as a project ID, e.g. group%2Fproject
+func gitlabProjectID(repoFullName string) string {
+	return url.PathEscape(repoFullName)
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+)
+
+// GIT_REMOTE_TIMEOUT bounds ls-remote calls made while validating a project
+const GIT_REMOTE_TIMEOUT = 30 * time.Second
+
+// gitRemoteEnv returns the extra environment git needs to reach a plain git
+// project's remote. cleanup removes any key file and must always be called.
+func gitRemoteEnv(project *models.Project) (env []string, cleanup func(), err error) {
+	if !project.UsesDeployKey() {
+		return nil, func() {}, nil
+	}
+
+	if project.DeployPrivateKey == "" {
+		return nil, nil, fmt.Errorf("project has no deploy key")
+	}
+
+	// The key lives in a temp file while git runs, never in a checkout
+	sshCommand, cleanup, err := writeDeployKey(project.DeployPrivateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return []string{"GIT_SSH_COMMAND=" + sshCommand}, cleanup, nil
+}
+
+// lsRemote runs git ls-remote against a remote and returns its output
+func lsRemote(ctx context.Context, repoURL string, env []string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, GIT_REMOTE_TIMEOUT)
+	defer cancel()
+
+	cmdArgs := append([]string{"ls-remote"}, args...)
+	cmdArgs = append(cmdArgs, repoURL)
+
+	cmd := exec.CommandContext(ctx, "git", cmdArgs...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Env = append(cmd.Env, env...)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git ls-remote failed: %w, output: %s", err, scrubSecrets(strings.TrimSpace(string(output))))
+	}
+	return string(output), nil
+}
+
+// remoteDefaultBranch returns the branch a remote's HEAD points at
+func remoteDefaultBranch(ctx context.Context, repoURL string, env []string) (string, error) {
+	output, err := lsRemote(ctx, repoURL, env, "--symref")
+	if err != nil {
+		return "", err
+	}
+
+	// The first line looks like "ref: refs/heads/main\tHEAD"
+	for _, line := range strings.Split(output, "\n") {
+		if ref, ok := strings.CutPrefix(line, "ref: refs/heads/"); ok && strings.HasSuffix(ref, "\tHEAD") {
+			return strings.TrimSuffix(ref, "\tHEAD"), nil
+		}
+	}
+	return "", fmt.Errorf("remote has no default branch")
+}
+
+// remoteBranchExists reports whether a remote has the given branch
+func remoteBranchExists(ctx context.Context, repoURL, branch string, env []string) (bool, error) {
+	output, err := lsRemote(ctx, repoURL, env, "--heads")
+	if err != nil {
+		return false, err
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		if _, ref, ok := strings.Cut(line, "\t"); ok && ref == "refs/heads/"+branch {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -1,12 +1,17 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"os"
 	"os/exec"
+	"strings"
 	"time"
 )
 
@@ -30,6 +35,7 @@ type GitHubRepository struct {
 	UpdatedAt     string `json:"updated_at"`
 	DefaultBranch string `json:"default_branch"`
 	HTMLURL       string `json:"html_url"`
+	CloneURL      string `json:"clone_url"`
 }
 
 type GithubCloneRequest struct {
@@ -40,60 +46,58 @@ type GithubCloneRequest struct {
 	RepoOwner string `json:"repo_owner" validate:"required"`
 }
 
+// GitHubPullRequestEvent is the subset of the pull_request webhook payload Kova acts on
+type GitHubPullRequestEvent struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Title string `json:"title"`
+		State string `json:"state"`
+		Head  struct {
+			Ref  string `json:"ref"`
+			SHA  string `json:"sha"`
+			Repo struct {
+				ID       int64  `json:"id"`
+				FullName string `json:"full_name"`
+			} `json:"repo"`
+		} `json:"head"`
+	} `json:"pull_request"`
+	Repository struct {
+		ID       int64  `json:"id"`
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
 type GitHubService struct {
-	client  *http.Client
-	baseURL string
+	client      *http.Client
+	baseURL     string
+	cache       *githubResponseCache
+	rateLimiter *githubRateLimiter
 }
 
 func NewGitHubService() *GitHubService {
+	return newGitHubService(&http.Client{
+		Timeout: 30 * time.Second,
+	}, "https://api.github.com")
+}
+
+func newGitHubService(client *http.Client, baseURL string) *GitHubService {
 	return &GitHubService{
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		baseURL: "https://api.github.com",
+		client:      client,
+		baseURL:     baseURL,
+		cache:       newGitHubResponseCache(),
+		rateLimiter: newGitHubRateLimiter(),
 	}
 }
 
 // GetUser retrieves GitHub user information using an access token
 func (s *GitHubService) GetUser(ctx context.Context, accessToken string) (*GitHubUser, error) {
-	if accessToken == "" {
-		return nil, fmt.Errorf("access token is required")
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "GET", s.baseURL+"/user", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set authorization header
-	req.Header.Set("Authorization", "Bearer "+accessToken)
-	req.Header.Set("Accept", "application/vnd.github+json")
-	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
-	req.Header.Set("User-Agent", "Kova-App")
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Handle different HTTP status codes
-	switch resp.StatusCode {
-	case http.StatusOK:
-		// Success, continue to parse response
-	case http.StatusUnauthorized:
-		return nil, fmt.Errorf("invalid or expired access token")
-	case http.StatusForbidden:
-		return nil, fmt.Errorf("access token lacks required permissions")
-	case http.StatusNotFound:
-		return nil, fmt.Errorf("user not found")
-	default:
-		return nil, fmt.Errorf("github API returned status %d", resp.StatusCode)
-	}
-
 	var githubUser GitHubUser
-	if err := json.NewDecoder(resp.Body).Decode(&githubUser); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := s.doJSON(ctx, "GET", "/user", accessToken, nil, &githubUser); err != nil {
+		if errors.Is(err, errResourceNotFound) {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, err
 	}
 
 	// Validate required fields
@@ -119,62 +123,202 @@ func (s *GitHubService) GetUserByUsername(ctx context.Context, username string)
 		return nil, fmt.Errorf("username is required")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", s.baseURL+"/users/"+username, nil)
+	resp, err := s.do(ctx, "GET", "/users/"+username, "", nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		if errors.Is(err, errResourceNotFound) {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, err
 	}
 
-	req.Header.Set("Accept", "application/vnd.github+json")
-	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
-	req.Header.Set("User-Agent", "Kova-App")
+	var githubUser GitHubUser
+	if err := json.Unmarshal(resp.Body, &githubUser); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
 
-	resp, err := s.client.Do(req)
+	return &githubUser, nil
+}
+
+func (s *GitHubService) GetRepositories(ctx context.Context, accessToken string, page, perPage int) ([]*GitHubRepository, error) {
+	page, perPage = normalizePagination(page, perPage)
+
+	var repositories []*GitHubRepository
+	path := fmt.Sprintf("/user/repos?page=%d&per_page=%d&sort=updated&direction=desc", page, perPage)
+	if err := s.doJSON(ctx, "GET", path, accessToken, nil, &repositories); err != nil {
+		return nil, err
+	}
+
+	return repositories, nil
+}
+
+// GetAllRepositories retrieves every repository the token can access, following Link headers
+func (s *GitHubService) GetAllRepositories(ctx context.Context, accessToken string) ([]*GitHubRepository, error) {
+	repositories := []*GitHubRepository{}
+	err := s.getAllPages(ctx, "/user/repos?per_page=100&sort=updated&direction=desc", accessToken, func(body []byte) error {
+		var page []*GitHubRepository
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+		repositories = append(repositories, page...)
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-		// Success, continue to parse response
-	case http.StatusNotFound:
-		return nil, fmt.Errorf("user not found")
-	default:
-		return nil, fmt.Errorf("github API returned status %d", resp.StatusCode)
+	return repositories, nil
+}
+
+// CreateRepositoryWebhook registers a webhook on a repository and returns its ID
+func (s *GitHubService) CreateRepositoryWebhook(ctx context.Context, accessToken, repoFullName, hookURL, secret string, events []string) (int64, error) {
+	payload := map[string]interface{}{
+		"name":   "web",
+		"active": true,
+		"events": events,
+		"config": map[string]string{
+			"url":          hookURL,
+			"content_type": "json",
+			"secret":       secret,
+		},
 	}
 
-	var githubUser GitHubUser
-	if err := json.NewDecoder(resp.Body).Decode(&githubUser); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	var hook struct {
+		ID int64 `json:"id"`
+	}
+	if err := s.doJSON(ctx, "POST", "/repos/"+repoFullName+"/hooks", accessToken, payload, &hook); err != nil {
+		return 0, fmt.Errorf("failed to create webhook: %w", err)
 	}
 
-	return &githubUser, nil
+	return hook.ID, nil
 }
 
-func (s *GitHubService) GetRepositories(ctx context.Context, accessToken string, page, perPage int) ([]*GitHubRepository, error) {
+// DeleteRepositoryWebhook removes a webhook from a repository
+func (s *GitHubService) DeleteRepositoryWebhook(ctx context.Context, accessToken, repoFullName string, hookID int64) error {
+	path := fmt.Sprintf("/repos/%s/hooks/%d", repoFullName, hookID)
+	if err := s.doJSON(ctx, "DELETE", path, accessToken, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+// CreateIssueComment posts a comment on an issue or pull request and returns its ID
+func (s *GitHubService) CreateIssueComment(ctx context.Context, accessToken, repoFullName string, number int, body string) (int64, error) {
+	var comment struct {
+		ID int64 `json:"id"`
+	}
+	path := fmt.Sprintf("/repos/%s/issues/%d/comments", repoFullName, number)
+	if err := s.doJSON(ctx, "POST", path, accessToken, map[string]string{"body": body}, &comment); err != nil {
+		return 0, fmt.Errorf("failed to create comment: %w", err)
+	}
+	return comment.ID, nil
+}
+
+// UpdateIssueComment replaces the body of an existing issue or pull request comment
+func (s *GitHubService) UpdateIssueComment(ctx context.Context, accessToken, repoFullName string, commentID int64, body string) error {
+	path := fmt.Sprintf("/repos/%s/issues/comments/%d", repoFullName, commentID)
+	if err := s.doJSON(ctx, "PATCH", path, accessToken, map[string]string{"body": body}, nil); err != nil {
+		return fmt.Errorf("failed to update comment: %w", err)
+	}
+	return nil
+}
+
+// doJSON performs an authenticated GitHub API call, encoding payload and decoding into out when provided
+func (s *GitHubService) doJSON(ctx context.Context, method, path, accessToken string, payload, out interface{}) error {
 	if accessToken == "" {
-		return nil, fmt.Errorf("access token is required")
+		return fmt.Errorf("access token is required")
+	}
+
+	resp, err := s.do(ctx, method, path, accessToken, payload)
+	if err != nil {
+		return err
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent || len(resp.Body) == 0 {
+		return nil
 	}
 
-	// Default pagination values
-	if page < 1 {
-		page = 1
+	if err := json.Unmarshal(resp.Body, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
 	}
-	if perPage < 1 || perPage > 100 {
-		perPage = 30
+
+	return nil
+}
+
+// getAllPages requests path and every following page from its Link headers, handing
+// each page's body to collect
+func (s *GitHubService) getAllPages(ctx context.Context, path, accessToken string, collect func(body []byte) error) error {
+	for page := 0; path != ""; page++ {
+		if page >= GITHUB_MAX_PAGES {
+			log.Printf("⚠️  Stopped following GitHub pagination after %d pages", GITHUB_MAX_PAGES)
+			return nil
+		}
+
+		resp, err := s.do(ctx, "GET", path, accessToken, nil)
+		if err != nil {
+			return err
+		}
+		if err := collect(resp.Body); err != nil {
+			return err
+		}
+
+		path = nextPageURL(resp.Link)
+	}
+	return nil
+}
+
+// do performs a GitHub API call. GET responses are cached per token and revalidated
+// with ETags, and requests wait out an exhausted rate limit. path is relative to the
+// API base URL, or an absolute URL from a Link header. Non-2xx responses are errors.
+func (s *GitHubService) do(ctx context.Context, method, path, accessToken string, payload interface{}) (*githubResponse, error) {
+	requestURL := path
+	if !strings.HasPrefix(path, "https://") && !strings.HasPrefix(path, "http://") {
+		requestURL = s.baseURL + path
+	} else if !strings.HasPrefix(path, s.baseURL+"/") {
+		// Never send the token anywhere but the API this service talks to
+		return nil, fmt.Errorf("refusing to request %s outside %s", path, s.baseURL)
+	}
+
+	tokenKey := githubTokenKey(accessToken)
+	cacheKey := tokenKey + " " + requestURL
+
+	var cached *githubCacheEntry
+	if method == "GET" {
+		cached = s.cache.get(cacheKey)
+		if cached != nil && time.Since(cached.fetchedAt) < GITHUB_CACHE_TTL {
+			return &githubResponse{StatusCode: http.StatusOK, Body: cached.body, Link: cached.link}, nil
+		}
+	}
+
+	if err := s.rateLimiter.wait(ctx, tokenKey); err != nil {
+		return nil, err
 	}
 
-	url := fmt.Sprintf("%s/user/repos?page=%d&per_page=%d&sort=updated&direction=desc", s.baseURL, page, perPage)
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Set authorization header
-	req.Header.Set("Authorization", "Bearer "+accessToken)
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
 	req.Header.Set("Accept", "application/vnd.github+json")
 	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
 	req.Header.Set("User-Agent", "Kova-App")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if cached != nil && cached.etag != "" {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
 
 	resp, err := s.client.Do(req)
 	if err != nil {
@@ -182,30 +326,52 @@ func (s *GitHubService) GetRepositories(ctx context.Context, accessToken string,
 	}
 	defer resp.Body.Close()
 
-	// Handle different HTTP status codes
-	switch resp.StatusCode {
-	case http.StatusOK:
-		// Success, continue to parse response
-	case http.StatusUnauthorized:
+	s.rateLimiter.update(tokenKey, resp)
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		s.cache.touch(cacheKey)
+		return &githubResponse{StatusCode: http.StatusOK, Body: cached.body, Link: cached.link}, nil
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		// Success, continue to read response
+	case isRateLimited(resp):
+		return nil, fmt.Errorf("github API rate limit exceeded")
+	case resp.StatusCode == http.StatusUnauthorized:
 		return nil, fmt.Errorf("invalid or expired access token")
-	case http.StatusForbidden:
+	case resp.StatusCode == http.StatusForbidden:
 		return nil, fmt.Errorf("access token lacks required permissions")
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, errResourceNotFound
 	default:
 		return nil, fmt.Errorf("github API returned status %d", resp.StatusCode)
 	}
 
-	var repositories []*GitHubRepository
-	if err := json.NewDecoder(resp.Body).Decode(&repositories); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	return repositories, nil
+	result := &githubResponse{
+		StatusCode: resp.StatusCode,
+		Body:       data,
+		Link:       resp.Header.Get("Link"),
+	}
+
+	if method == "GET" && resp.StatusCode == http.StatusOK {
+		s.cache.set(cacheKey, &githubCacheEntry{
+			etag:      resp.Header.Get("ETag"),
+			body:      data,
+			link:      result.Link,
+			fetchedAt: time.Now(),
+		})
+	}
+
+	return result, nil
 }
 
 func (s *GitHubService) cloneRepository(ctx context.Context, accessToken string, req GithubCloneRequest, destDir string) error {
-	// Build clone URL with token
-	// Format: https://<token>@github.com/<owner>/<repo>.git
-	cloneURL := fmt.Sprintf("https://%s@github.com/%s/%s.git", accessToken, req.RepoOwner, req.RepoName)
+	// The token is sent through http.extraHeader rather than embedded in the URL
+	cloneURL := fmt.Sprintf("https://github.com/%s/%s.git", req.RepoOwner, req.RepoName)
 
 	// Prepare git clone command with shallow clone for performance
 	args := []string{"clone", "--depth", "1"}
@@ -218,11 +384,13 @@ func (s *GitHubService) cloneRepository(ctx context.Context, accessToken string,
 	args = append(args, cloneURL, destDir)
 
 	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Env = append(cmd.Env, gitAuthEnv("x-access-token", accessToken)...)
 
 	// Capture output for debugging
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("git clone failed: %w, output: %s", err, string(output))
+		return fmt.Errorf("git clone failed: %w, output: %s", err, scrubSecrets(string(output), accessToken))
 	}
 
 	return nil
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrGitHubAppNotConfigured is returned when no app ID or private key has been provided
+var ErrGitHubAppNotConfigured = errors.New("github app not configured")
+
+type GitHubAppInstallation struct {
+	ID      int64 `json:"id"`
+	Account struct {
+		Login string `json:"login"`
+		Type  string `json:"type"`
+	} `json:"account"`
+}
+
+type installationToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+// GitHubAppService authenticates as the Kova GitHub App and mints
+// short-lived installation tokens
+type GitHubAppService struct {
+	githubService *GitHubService
+	appID         int64
+	slug          string
+	privateKey    *rsa.PrivateKey
+
+	mu     sync.Mutex
+	tokens map[int64]installationToken
+}
+
+// NewGitHubAppService creates the app service. A zero app ID or empty key
+// yields a service that reports IsConfigured() == false.
+func NewGitHubAppService(githubService *GitHubService, appID int64, slug string, privateKeyPEM []byte) (*GitHubAppService, error) {
+	s := &GitHubAppService{
+		githubService: githubService,
+		appID:         appID,
+		slug:          slug,
+		tokens:        make(map[int64]installationToken),
+	}
+
+	if appID == 0 || len(privateKeyPEM) == 0 {
+		return s, nil
+	}
+
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid github app private key: %w", err)
+	}
+	s.privateKey = key
+
+	return s, nil
+}
+
+// IsConfigured reports whether app credentials are available
+func (s *GitHubAppService) IsConfigured() bool {
+	return s != nil && s.privateKey != nil
+}
+
+// InstallURL returns the page where users install the app, carrying state back to the setup URL
+func (s *GitHubAppService) InstallURL(state string) (string, error) {
+	if !s.IsConfigured() || s.slug == "" {
+		return "", ErrGitHubAppNotConfigured
+	}
+	return fmt.Sprintf("https://github.com/apps/%s/installations/new?state=%s", s.slug, url.QueryEscape(state)), nil
+}
+
+// GetInstallation retrieves an installation of the app
+func (s *GitHubAppService) GetInstallation(ctx context.Context, installationID int64) (*GitHubAppInstallation, error) {
+	appToken, err := s.appJWT()
+	if err != nil {
+		return nil, err
+	}
+
+	var installation GitHubAppInstallation
+	path := fmt.Sprintf("/app/installations/%d", installationID)
+	if err := s.githubService.doJSON(ctx, "GET", path, appToken, nil, &installation); err != nil {
+		return nil, fmt.Errorf("failed to get installation: %w", err)
+	}
+
+	return &installation, nil
+}
+
+// InstallationToken returns a token scoped to an installation, minting a new one
+// when the cached token is missing or about to expire
+func (s *GitHubAppService) InstallationToken(ctx context.Context, installationID int64) (string, error) {
+	s.mu.Lock()
+	cached, ok := s.tokens[installationID]
+	s.mu.Unlock()
+	if ok && time.Until(cached.expiresAt) > 5*time.Minute {
+		return cached.token, nil
+	}
+
+	appToken, err := s.appJWT()
+	if err != nil {
+		return "", err
+	}
+
+	var resp struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	path := fmt.Sprintf("/app/installations/%d/access_tokens", installationID)
+	if err := s.githubService.doJSON(ctx, "POST", path, appToken, nil, &resp); err != nil {
+		return "", fmt.Errorf("failed to create installation token: %w", err)
+	}
+
+	s.mu.Lock()
+	s.tokens[installationID] = installationToken{token: resp.Token, expiresAt: resp.ExpiresAt}
+	s.mu.Unlock()
+
+	return resp.Token, nil
+}
+
+// InvalidateInstallation drops any cached token for an installation
+func (s *GitHubAppService) InvalidateInstallation(installationID int64) {
+	s.mu.Lock()
+	delete(s.tokens, installationID)
+	s.mu.Unlock()
+}
+
+// GetInstallationRepositories lists one page of repositories the installation can access,
+// along with the total count
+func (s *GitHubAppService) GetInstallationRepositories(ctx context.Context, installationID int64, page, perPage int) ([]*GitHubRepository, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 30
+	}
+
+	token, err := s.InstallationToken(ctx, installationID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var resp struct {
+		TotalCount   int                 `json:"total_count"`
+		Repositories []*GitHubRepository `json:"repositories"`
+	}
+	path := fmt.Sprintf("/installation/repositories?page=%d&per_page=%d", page, perPage)
+	if err := s.githubService.doJSON(ctx, "GET", path, token, nil, &resp); err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch repositories from GitHub: %w", err)
+	}
+
+	return resp.Repositories, resp.TotalCount, nil
+}
+
+// appJWT signs a short-lived JWT identifying the app itself
+func (s *GitHubAppService) appJWT() (string, error) {
+	if !s.IsConfigured() {
+		return "", ErrGitHubAppNotConfigured
+	}
+
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		// Backdate to tolerate clock drift, GitHub caps expiry at 10 minutes
+		IssuedAt:  jwt.NewNumericDate(now.Add(-60 * time.Second)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(9 * time.Minute)),
+		Issuer:    strconv.FormatInt(s.appID, 10),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(s.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign app token: %w", err)
+	}
+	return token, nil
+}
+
+// resolveProjectToken returns the token used to reach a project's repository:
+// an installation token when the project came from an app installation,
+// otherwise the token of the account the project is bound to
+func resolveProjectToken(ctx context.Context, store store.Store, appService *GitHubAppService, project *models.Project) (string, error) {
+	if project.InstallationID != 0 {
+		// The installation may have been moved to another user or suspended since the project was created
+		installation, err := store.GetGitHubInstallationByInstallationID(ctx, project.InstallationID)
+		if err != nil {
+			return "", fmt.Errorf("GitHub App installation %d for project %s not found, it may have been uninstalled: %w", project.InstallationID, project.Name, err)
+		}
+		if !installation.IsOwnedBy(project.UserID) {
+			return "", fmt.Errorf("access denied: GitHub App installation %d does not belong to the project owner", project.InstallationID)
+		}
+		if installation.IsSuspended() {
+			return "", fmt.Errorf("GitHub App installation %d for project %s is suspended", project.InstallationID, project.Name)
+		}
+		return appService.InstallationToken(ctx, project.InstallationID)
+	}
+
+	if project.AccountID != "" {
+		account, err := store.GetAccountByID(ctx, project.AccountID)
+		if err != nil {
+			return "", fmt.Errorf("linked account %s for project %s not found, it may have been unlinked: link it again and update the project: %w", project.AccountID, project.Name, err)
+		}
+		if !account.IsOwnedBy(project.UserID) {
+			return "", fmt.Errorf("access denied: linked account %s does not belong to the project owner", project.AccountID)
+		}
+		return account.AccessToken, nil
+	}
+
+	// Projects created before accounts were bound use the owner's first account on the provider
+
+	accounts, err := store.GetAccountsByUserIDWithTokens(ctx, project.UserID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get linked accounts: %w", err)
+	}
+
+	// Use an account on the same provider instance as the repository
+	for _, account := range accounts {
+		if account.ProviderURL == project.ProviderURL {
+			return account.AccessToken, nil
+		}
+	}
+	if project.Provider == "" || project.Provider == models.GIT_PROVIDER_GITHUB {
+		return "", errors.New("no linked GitHub account found")
+	}
+	return "", fmt.Errorf("no linked %s account found for %s", project.Provider, project.ProviderURL)
+}
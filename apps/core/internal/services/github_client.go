@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// GITHUB_CACHE_TTL is how long a cached GET response is served without revalidating it
+	GITHUB_CACHE_TTL = time.Minute
+	// GITHUB_CACHE_MAX_ENTRIES bounds the response cache across all tokens
+	GITHUB_CACHE_MAX_ENTRIES = 2000
+	// GITHUB_MAX_RATE_LIMIT_WAIT is the longest a request waits for a rate limit to reset before failing
+	GITHUB_MAX_RATE_LIMIT_WAIT = 30 * time.Second
+	// GITHUB_MAX_PAGES bounds how many pages Link header pagination follows
+	GITHUB_MAX_PAGES = 50
+	// GITHUB_RATE_LIMIT_WARN_THRESHOLD logs a warning once a token has this few requests left
+	GITHUB_RATE_LIMIT_WARN_THRESHOLD = 100
+)
+
+// githubResponse is a successful GitHub API response, either fresh or from the cache
+type githubResponse struct {
+	StatusCode int
+	Body       []byte
+	Link       string
+}
+
+type githubCacheEntry struct {
+	etag      string
+	body      []byte
+	link      string
+	fetchedAt time.Time
+}
+
+// githubResponseCache holds GET responses keyed by token and URL. Fresh entries are
+// served directly; stale ones are revalidated with If-None-Match, and GitHub doesn't
+// count the resulting 304s against the rate limit.
+type githubResponseCache struct {
+	mu      sync.Mutex
+	entries map[string]*githubCacheEntry
+}
+
+func newGitHubResponseCache() *githubResponseCache {
+	return &githubResponseCache{
+		entries: make(map[string]*githubCacheEntry),
+	}
+}
+
+// get returns a copy of an entry, since touch updates the stored one under the lock
+func (c *githubResponseCache) get(key string) *githubCacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	copied := *entry
+	return &copied
+}
+
+func (c *githubResponseCache) set(key string, entry *githubCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Evict the oldest entry when full
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= GITHUB_CACHE_MAX_ENTRIES {
+		var oldestKey string
+		var oldest time.Time
+		for k, e := range c.entries {
+			if oldestKey == "" || e.fetchedAt.Before(oldest) {
+				oldestKey, oldest = k, e.fetchedAt
+			}
+		}
+		delete(c.entries, oldestKey)
+	}
+
+	c.entries[key] = entry
+}
+
+// touch marks an entry as revalidated
+func (c *githubResponseCache) touch(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[key]; ok {
+		entry.fetchedAt = time.Now()
+	}
+}
+
+type githubRateLimit struct {
+	remaining int
+	resetAt   time.Time
+	// retryAt is set from Retry-After when GitHub applies a secondary rate limit
+	retryAt time.Time
+}
+
+// githubRateLimiter tracks X-RateLimit-* headers per token and holds requests back
+// until a token's limit resets
+type githubRateLimiter struct {
+	mu     sync.Mutex
+	limits map[string]*githubRateLimit
+}
+
+func newGitHubRateLimiter() *githubRateLimiter {
+	return &githubRateLimiter{
+		limits: make(map[string]*githubRateLimit),
+	}
+}
+
+// wait blocks until the token may make another request. It fails straight away when
+// that is further off than GITHUB_MAX_RATE_LIMIT_WAIT.
+func (r *githubRateLimiter) wait(ctx context.Context, tokenKey string) error {
+	r.mu.Lock()
+	var until time.Time
+	if limit, ok := r.limits[tokenKey]; ok {
+		if limit.remaining == 0 && limit.resetAt.After(until) {
+			until = limit.resetAt
+		}
+		if limit.retryAt.After(until) {
+			until = limit.retryAt
+		}
+	}
+	r.mu.Unlock()
+
+	delay := time.Until(until)
+	if delay <= 0 {
+		return nil
+	}
+	if delay > GITHUB_MAX_RATE_LIMIT_WAIT {
+		return fmt.Errorf("github API rate limit exceeded, resets at %s", until.Format(time.RFC3339))
+	}
+
+	log.Printf("⏳ GitHub rate limit reached, waiting %s", delay.Round(time.Second))
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// update records the rate limit headers of a response
+func (r *githubRateLimiter) update(tokenKey string, resp *http.Response) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	limit, ok := r.limits[tokenKey]
+	if !ok {
+		limit = &githubRateLimit{remaining: -1}
+		r.limits[tokenKey] = limit
+	}
+
+	if remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil {
+		limit.remaining = remaining
+		if remaining > 0 && remaining <= GITHUB_RATE_LIMIT_WARN_THRESHOLD && remaining%25 == 0 {
+			log.Printf("⚠️  GitHub rate limit low: %d requests remaining", remaining)
+		}
+	}
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		limit.resetAt = time.Unix(reset, 0)
+	}
+	if retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		limit.retryAt = time.Now().Add(time.Duration(retryAfter) * time.Second)
+	}
+}
+
+// isRateLimited reports whether a 403 or 429 response was caused by a rate limit
+// rather than missing permissions
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return resp.StatusCode == http.StatusForbidden &&
+		(resp.Header.Get("X-RateLimit-Remaining") == "0" || resp.Header.Get("Retry-After") != "")
+}
+
+// githubTokenKey identifies a token in the cache and rate limiter without keeping the token itself
+func githubTokenKey(accessToken string) string {
+	if accessToken == "" {
+		return "anonymous"
+	}
+	sum := sha256.Sum256([]byte(accessToken))
+	return hex.EncodeToString(sum[:16])
+}
+
+// nextPageURL returns the rel="next" URL of a Link header, or "" on the last page
+func nextPageURL(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		segments := strings.Split(part, ";")
+		if len(segments) < 2 {
+			continue
+		}
+		target := strings.Trim(strings.TrimSpace(segments[0]), "<>")
+		for _, param := range segments[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return target
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestNextPageURL(t *testing.T) {
+	tests := []struct {
+		name string
+		link string
+		want string
+	}{
+		{
+			name: "empty header",
+			link: "",
+			want: "",
+		},
+		{
+			name: "next and last",
+			link: `<https://api.github.com/user/repos?page=2>; rel="next", <https://api.github.com/user/repos?page=5>; rel="last"`,
+			want: "https://api.github.com/user/repos?page=2",
+		},
+		{
+			name: "next after prev",
+			link: `<https://api.github.com/user/repos?page=1>; rel="prev", <https://api.github.com/user/repos?page=3>; rel="next"`,
+			want: "https://api.github.com/user/repos?page=3",
+		},
+		{
+			name: "last page",
+			link: `<https://api.github.com/user/repos?page=1>; rel="first", <https://api.github.com/user/repos?page=4>; rel="prev"`,
+			want: "",
+		},
+		{
+			name: "malformed part",
+			link: `<https://api.github.com/user/repos?page=2>, <https://api.github.com/user/repos?page=3>; rel="next"`,
+			want: "https://api.github.com/user/repos?page=3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextPageURL(tt.link); got != tt.want {
+				t.Errorf("nextPageURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitHubResponseCacheEvictsOldest(t *testing.T) {
+	cache := newGitHubResponseCache()
+	start := time.Now().Add(-24 * time.Hour)
+	for i := 0; i < GITHUB_CACHE_MAX_ENTRIES; i++ {
+		cache.set(fmt.Sprintf("key-%d", i), &githubCacheEntry{fetchedAt: start.Add(time.Duration(i) * time.Second)})
+	}
+
+	// Revalidating the oldest entry makes key-1 the oldest
+	cache.touch("key-0")
+
+	// Replacing an entry that is already cached doesn't evict anything
+	cache.set("key-5", &githubCacheEntry{etag: "updated", fetchedAt: start.Add(5 * time.Second)})
+	if len(cache.entries) != GITHUB_CACHE_MAX_ENTRIES {
+		t.Fatalf("cache has %d entries after replacing one, want %d", len(cache.entries), GITHUB_CACHE_MAX_ENTRIES)
+	}
+
+	cache.set("new", &githubCacheEntry{fetchedAt: time.Now()})
+	if len(cache.entries) != GITHUB_CACHE_MAX_ENTRIES {
+		t.Fatalf("cache has %d entries, want %d", len(cache.entries), GITHUB_CACHE_MAX_ENTRIES)
+	}
+	if cache.get("key-1") != nil {
+		t.Error("oldest entry key-1 was not evicted")
+	}
+	for _, key := range []string{"key-0", "key-2", "new"} {
+		if cache.get(key) == nil {
+			t.Errorf("entry %s was evicted", key)
+		}
+	}
+	if entry := cache.get("key-5"); entry == nil || entry.etag != "updated" {
+		t.Errorf("key-5 = %+v, want the replaced entry", entry)
+	}
+}
+
+func TestGitHubResponseCacheGetReturnsCopy(t *testing.T) {
+	cache := newGitHubResponseCache()
+	fetchedAt := time.Now().Add(-time.Hour)
+	cache.set("key", &githubCacheEntry{etag: "etag", fetchedAt: fetchedAt})
+
+	entry := cache.get("key")
+	cache.touch("key")
+	if !entry.fetchedAt.Equal(fetchedAt) {
+		t.Error("touch changed an entry returned by get")
+	}
+	if !cache.get("key").fetchedAt.After(fetchedAt) {
+		t.Error("touch didn't mark the entry as revalidated")
+	}
+}
@@ -0,0 +1,322 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store"
+)
+
+const (
+	// OAUTH_CALLBACK_PATH is where GitHub redirects after authorization, relative to the public URL
+	OAUTH_CALLBACK_PATH = "/api/v1/oauth/github/callback"
+	OAUTH_STATE_TTL     = 10 * time.Minute
+	OAUTH_SCOPES        = "repo admin:repo_hook read:user"
+
+	TOKEN_REFRESH_INTERVAL = 5 * time.Minute
+	TOKEN_REFRESH_WINDOW   = 15 * time.Minute
+)
+
+var (
+	ErrOAuthNotConfigured   = errors.New("github oauth not configured")
+	ErrAuthorizationPending = errors.New("authorization pending")
+	ErrSlowDown             = errors.New("slow down")
+)
+
+// GitHubDeviceCode is returned when starting the device flow. The user enters
+// UserCode at VerificationURI while the client polls with DeviceCode.
+type GitHubDeviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+}
+
+type githubTokenResponse struct {
+	AccessToken           string `json:"access_token"`
+	RefreshToken          string `json:"refresh_token"`
+	ExpiresIn             int    `json:"expires_in"`
+	RefreshTokenExpiresIn int    `json:"refresh_token_expires_in"`
+	Error                 string `json:"error"`
+	ErrorDescription      string `json:"error_description"`
+}
+
+// GitHubOAuthService runs the GitHub OAuth web and device flows and keeps
+// expiring user tokens fresh in the background
+type GitHubOAuthService struct {
+	accountStore store.AccountStore
+	client       *http.Client
+	baseURL      string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	publicURL    string
+	wg           sync.WaitGroup
+	ctx          context.Context
+	cancel       context.CancelFunc
+}
+
+func NewGitHubOAuthService(accountStore store.AccountStore, clientID, clientSecret, publicURL string) *GitHubOAuthService {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	redirectURL := ""
+	if publicURL != "" {
+		redirectURL = strings.TrimRight(publicURL, "/") + OAUTH_CALLBACK_PATH
+	}
+
+	return &GitHubOAuthService{
+		accountStore: accountStore,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		baseURL:      "https://github.com",
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		publicURL:    publicURL,
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
+
+// IsConfigured reports whether OAuth client credentials are available
+func (s *GitHubOAuthService) IsConfigured() bool {
+	return s.clientID != "" && s.clientSecret != ""
+}
+
+// AuthorizeURL returns the GitHub consent page for the web flow
+func (s *GitHubOAuthService) AuthorizeURL(state string) (string, error) {
+	if !s.IsConfigured() || s.redirectURL == "" {
+		return "", ErrOAuthNotConfigured
+	}
+
+	params := url.Values{}
+	params.Set("client_id", s.clientID)
+	params.Set("redirect_uri", s.redirectURL)
+	params.Set("scope", OAUTH_SCOPES)
+	params.Set("state", state)
+
+	return s.baseURL + "/login/oauth/authorize?" + params.Encode(), nil
+}
+
+// ExchangeCode trades a web flow authorization code for a token
+func (s *GitHubOAuthService) ExchangeCode(ctx context.Context, code string) (*models.OAuthToken, error) {
+	if !s.IsConfigured() {
+		return nil, ErrOAuthNotConfigured
+	}
+
+	form := url.Values{}
+	form.Set("client_id", s.clientID)
+	form.Set("client_secret", s.clientSecret)
+	form.Set("code", code)
+	form.Set("redirect_uri", s.redirectURL)
+
+	return s.requestToken(ctx, form)
+}
+
+// RefreshToken trades a refresh token for a new token pair
+func (s *GitHubOAuthService) RefreshToken(ctx context.Context, refreshToken string) (*models.OAuthToken, error) {
+	if !s.IsConfigured() {
+		return nil, ErrOAuthNotConfigured
+	}
+
+	form := url.Values{}
+	form.Set("client_id", s.clientID)
+	form.Set("client_secret", s.clientSecret)
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", refreshToken)
+
+	return s.requestToken(ctx, form)
+}
+
+// RequestDeviceCode starts the device flow
+func (s *GitHubOAuthService) RequestDeviceCode(ctx context.Context) (*GitHubDeviceCode, error) {
+	if s.clientID == "" {
+		return nil, ErrOAuthNotConfigured
+	}
+
+	form := url.Values{}
+	form.Set("client_id", s.clientID)
+	form.Set("scope", OAUTH_SCOPES)
+
+	var deviceCode GitHubDeviceCode
+	if err := s.postForm(ctx, "/login/device/code", form, &deviceCode); err != nil {
+		return nil, fmt.Errorf("failed to request device code from GitHub: %w", err)
+	}
+	if deviceCode.DeviceCode == "" {
+		return nil, errors.New("failed to request device code from GitHub: empty response")
+	}
+
+	return &deviceCode, nil
+}
+
+// PollDeviceToken checks whether the user has approved a device code. It returns
+// ErrAuthorizationPending or ErrSlowDown while the user hasn't finished.
+func (s *GitHubOAuthService) PollDeviceToken(ctx context.Context, deviceCode string) (*models.OAuthToken, error) {
+	if s.clientID == "" {
+		return nil, ErrOAuthNotConfigured
+	}
+
+	form := url.Values{}
+	form.Set("client_id", s.clientID)
+	form.Set("device_code", deviceCode)
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:device_code")
+
+	return s.requestToken(ctx, form)
+}
+
+// EnsureFreshToken refreshes an account's token when it is about to expire
+func (s *GitHubOAuthService) EnsureFreshToken(ctx context.Context, account *models.Account) (*models.Account, error) {
+	if !account.TokenExpiresWithin(TOKEN_REFRESH_WINDOW) || !account.CanRefresh() || !s.IsConfigured() {
+		return account, nil
+	}
+	return s.RefreshAccount(ctx, account)
+}
+
+// RefreshAccount renews an account's token with its refresh token and stores the result
+func (s *GitHubOAuthService) RefreshAccount(ctx context.Context, account *models.Account) (*models.Account, error) {
+	if !account.CanRefresh() {
+		return nil, errors.New("account has no usable refresh token")
+	}
+
+	token, err := s.RefreshToken(ctx, account.RefreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	updated, err := s.accountStore.UpdateAccountOAuthTokens(ctx, account.ID, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store refreshed token: %w", err)
+	}
+
+	log.Printf("🔑 Refreshed GitHub token for account: %s", account.GithubUsername)
+	return updated, nil
+}
+
+// Start runs the background refresher for expiring tokens
+func (s *GitHubOAuthService) Start() {
+	if !s.IsConfigured() {
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(TOKEN_REFRESH_INTERVAL)
+		defer ticker.Stop()
+
+		for {
+			s.refreshExpiringTokens()
+
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	log.Println("🔑 GitHub token refresher started")
+}
+
+func (s *GitHubOAuthService) refreshExpiringTokens() {
+	accounts, err := s.accountStore.GetAccountsWithExpiringTokens(s.ctx, time.Now().Add(TOKEN_REFRESH_WINDOW))
+	if err != nil {
+		log.Printf("⚠️  Failed to load expiring GitHub tokens: %v", err)
+		return
+	}
+
+	for _, account := range accounts {
+		if !account.CanRefresh() {
+			continue
+		}
+		if _, err := s.RefreshAccount(s.ctx, account); err != nil {
+			log.Printf("⚠️  Failed to refresh GitHub token for %s: %v", account.GithubUsername, err)
+		}
+	}
+}
+
+// Shutdown stops the background refresher
+func (s *GitHubOAuthService) Shutdown() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *GitHubOAuthService) requestToken(ctx context.Context, form url.Values) (*models.OAuthToken, error) {
+	var resp githubTokenResponse
+	if err := s.postForm(ctx, "/login/oauth/access_token", form, &resp); err != nil {
+		return nil, fmt.Errorf("failed to request token from GitHub: %w", err)
+	}
+
+	switch resp.Error {
+	case "":
+		// Success, continue to build the token
+	case "authorization_pending":
+		return nil, ErrAuthorizationPending
+	case "slow_down":
+		return nil, ErrSlowDown
+	case "expired_token", "token_expired":
+		return nil, errors.New("device code expired")
+	case "access_denied":
+		return nil, errors.New("authorization denied by user")
+	default:
+		return nil, fmt.Errorf("github oauth error: %s (%s)", resp.Error, resp.ErrorDescription)
+	}
+
+	if resp.AccessToken == "" {
+		return nil, errors.New("github oauth error: empty access token")
+	}
+
+	token := &models.OAuthToken{
+		AccessToken:  resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+	}
+	now := time.Now()
+	if resp.ExpiresIn > 0 {
+		expiresAt := now.Add(time.Duration(resp.ExpiresIn) * time.Second)
+		token.ExpiresAt = &expiresAt
+	}
+	if resp.RefreshTokenExpiresIn > 0 {
+		refreshExpiresAt := now.Add(time.Duration(resp.RefreshTokenExpiresIn) * time.Second)
+		token.RefreshTokenExpiresAt = &refreshExpiresAt
+	}
+
+	return token, nil
+}
+
+func (s *GitHubOAuthService) postForm(ctx context.Context, path string, form url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", s.baseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", "Kova-App")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("github returned status %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/dopeCape/kova/internal/models"
+)
+
+// DEPLOY_HOOK_PATH is where CI triggers a deploy of a project with its hook token
+const DEPLOY_HOOK_PATH = "/api/v1/hooks/projects/%s/deploy"
+
+// validateImageSource checks the image and registry credentials of an image project
+func validateImageSource(image, registryUsername, registryPassword string) error {
+	if !models.IsImageReference(image) {
+		return fmt.Errorf("invalid image reference %q", image)
+	}
+	if (registryUsername == "") != (registryPassword == "") {
+		return errors.New("registry_username and registry_password must be set together")
+	}
+	return nil
+}
+
+// pullProjectImage pulls the image of an image project and tags it as the project's
+// image, so it's deployed like a built one. Returns the digest that was pulled.
+func (bs *BuildService) pullProjectImage(project *models.Project) (string, error) {
+	// Credentials go to a throwaway docker config and never end up in the host's
+	configDir, err := os.MkdirTemp("", "kova-registry-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create docker config: %w", err)
+	}
+	defer os.RemoveAll(configDir)
+
+	if project.RegistryUsername != "" {
+		args := []string{"--config", configDir, "login", "--username", project.RegistryUsername, "--password-stdin"}
+		if registry := models.ImageRegistry(project.Image); registry != "" {
+			args = append(args, registry)
+		}
+		cmd := exec.Command("docker", args...)
+		cmd.Stdin = strings.NewReader(project.RegistryPassword)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return "", fmt.Errorf("registry login failed: %w, output: %s", err,
+				scrubSecrets(strings.TrimSpace(string(output)), project.RegistryPassword))
+		}
+	}
+
+	// Pulling by tag resolves it again, so a tag pushed over is picked up
+	log.Printf("📥 Pulling %s", project.Image)
+	output, err := exec.Command("docker", "--config", configDir, "pull", project.Image).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("docker pull failed: %w, output: %s", err,
+			scrubSecrets(strings.TrimSpace(string(output)), project.RegistryPassword))
+	}
+
+	digests, err := runDocker("image", "inspect", "--format", `{{join .RepoDigests "\n"}}`, project.Image)
+	if err != nil {
+		return "", err
+	}
+	digest := ""
+	repository := models.ImageRepository(project.Image)
+	for _, repoDigest := range strings.Fields(digests) {
+		name, sum, _ := strings.Cut(repoDigest, "@")
+		if digest == "" || strings.HasSuffix(repository, name) {
+			digest = sum
+		}
+	}
+
+	if _, err := runDocker("tag", project.Image, project.ID+":latest"); err != nil {
+		return "", err
+	}
+
+	// Only the project's tags are garbage collected, the registry tag would keep
+	// every pulled image around
+	if _, err := runDocker("rmi", project.Image); err != nil {
+		log.Printf("⚠️  Failed to untag %s: %v", project.Image, err)
+	}
+	return digest, nil
+}
+
+// imageContainerPort returns the port an image project is routed to: the configured
+// one, else the lowest TCP port its image exposes
+func imageContainerPort(project *models.Project) int {
+	if project.ContainerPort > 0 {
+		return project.ContainerPort
+	}
+
+	output, err := runDocker("image", "inspect", "--format", `{{range $port, $_ := .Config.ExposedPorts}}{{$port}} {{end}}`, project.ID+":latest")
+	if err != nil {
+		log.Printf("⚠️  Failed to read the exposed ports of %s, routing to port %d: %v", project.Image, DEFAULT_CONTAINER_PORT, err)
+		return DEFAULT_CONTAINER_PORT
+	}
+
+	port := 0
+	for _, exposed := range strings.Fields(output) {
+		number, protocol, _ := strings.Cut(exposed, "/")
+		if protocol != "" && protocol != "tcp" {
+			continue
+		}
+		// Port ranges like 8000-8010 are skipped
+		n, err := strconv.Atoi(number)
+		if err != nil || n < 1 || n > 65535 {
+			continue
+		}
+		if port == 0 || n < port {
+			port = n
+		}
+	}
+
+	if port == 0 {
+		log.Printf("⚠️  %s exposes no TCP port, routing to port %d", project.Image, DEFAULT_CONTAINER_PORT)
+		return DEFAULT_CONTAINER_PORT
+	}
+	return port
+}
+
+// generateDeployHookToken returns a new deploy hook token and the hash stored for it
+func generateDeployHookToken() (string, string, error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+	return token, hashDeployHookToken(token), nil
+}
+
+func hashDeployHookToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// checkDeployHookToken reports whether a token opens a project's deploy hook
+func checkDeployHookToken(project *models.Project, token string) bool {
+	if project.DeployHookHash == "" || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashDeployHookToken(token)), []byte(project.DeployHookHash)) == 1
+}
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store"
+	"github.com/go-playground/validator/v10"
+)
+
+// InstallationRepository is a repository reachable through a GitHub App installation
+type InstallationRepository struct {
+	*GitRepository
+	InstallationID int64 `json:"installation_id"`
+}
+
+type InstallationService struct {
+	store      store.Store
+	validator  *validator.Validate
+	appService *GitHubAppService
+}
+
+func NewInstallationService(store store.Store, appService *GitHubAppService) *InstallationService {
+	return &InstallationService{
+		store:      store,
+		validator:  validator.New(),
+		appService: appService,
+	}
+}
+
+// GetInstallURL returns the GitHub page where the user installs the app. GitHub
+// passes the single-use state in the URL back to the setup URL along with the
+// installation ID, and linking the installation requires it.
+func (s *InstallationService) GetInstallURL(ctx context.Context, userID string) (string, error) {
+	_, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("user not found: %w", err)
+	}
+
+	stateBytes := make([]byte, 32)
+	if _, err := rand.Read(stateBytes); err != nil {
+		return "", fmt.Errorf("failed to generate state: %w", err)
+	}
+
+	state := &models.OAuthState{
+		State:     hex.EncodeToString(stateBytes),
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(OAUTH_STATE_TTL),
+	}
+
+	installURL, err := s.appService.InstallURL(state.State)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.store.DeleteExpiredOAuthStates(ctx, time.Now()); err != nil {
+		return "", fmt.Errorf("failed to clean up install states: %w", err)
+	}
+	if err := s.store.CreateOAuthState(ctx, state); err != nil {
+		return "", fmt.Errorf("failed to save install state: %w", err)
+	}
+
+	return installURL, nil
+}
+
+// LinkInstallation verifies an installation with GitHub and links it to the user.
+// The state must be one issued to the same user by GetInstallURL, so only the user
+// who went through the install flow can link the installation.
+func (s *InstallationService) LinkInstallation(ctx context.Context, userID string, req *models.CreateGitHubInstallationRequest) (*models.GitHubInstallation, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if !s.appService.IsConfigured() {
+		return nil, ErrGitHubAppNotConfigured
+	}
+
+	_, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	state, err := s.store.ConsumeOAuthState(ctx, req.State)
+	if err != nil || state.UserID != userID {
+		return nil, errors.New("access denied: invalid installation state, start the install from Kova again")
+	}
+	if state.IsExpired() {
+		return nil, errors.New("access denied: installation state expired, start the install from Kova again")
+	}
+
+	exists, err := s.store.GitHubInstallationExists(ctx, req.InstallationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check installation existence: %w", err)
+	}
+	if exists {
+		return nil, errors.New("this installation is already linked")
+	}
+
+	githubInstallation, err := s.appService.GetInstallation(ctx, req.InstallationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify installation with GitHub: %w", err)
+	}
+
+	installation := &models.GitHubInstallation{
+		UserID:         userID,
+		InstallationID: githubInstallation.ID,
+		AccountLogin:   githubInstallation.Account.Login,
+		AccountType:    githubInstallation.Account.Type,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	if err := s.store.CreateGitHubInstallation(ctx, installation); err != nil {
+		return nil, fmt.Errorf("failed to create installation: %w", err)
+	}
+
+	return installation, nil
+}
+
+// GetInstallations lists the installations linked by a user
+func (s *InstallationService) GetInstallations(ctx context.Context, userID string) ([]*models.GitHubInstallation, error) {
+	_, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	installations, err := s.store.GetGitHubInstallationsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installations: %w", err)
+	}
+
+	return installations, nil
+}
+
+// DeleteInstallation unlinks an installation. The app stays installed on GitHub.
+func (s *InstallationService) DeleteInstallation(ctx context.Context, userID, id string) error {
+	installation, err := s.getOwnedInstallation(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.DeleteGitHubInstallation(ctx, installation.ID); err != nil {
+		return fmt.Errorf("failed to delete installation: %w", err)
+	}
+	s.appService.InvalidateInstallation(installation.InstallationID)
+
+	return nil
+}
+
+// GetInstallationRepositories lists one page of repositories for a single installation
+func (s *InstallationService) GetInstallationRepositories(ctx context.Context, userID, id string, page, perPage int) ([]*InstallationRepository, int, error) {
+	installation, err := s.getOwnedInstallation(ctx, userID, id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if installation.IsSuspended() {
+		return nil, 0, fmt.Errorf("validation failed: installation on %s is suspended on GitHub", installation.AccountLogin)
+	}
+
+	repositories, total, err := s.appService.GetInstallationRepositories(ctx, installation.InstallationID, page, perPage)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return toInstallationRepositories(installation.InstallationID, repositories), total, nil
+}
+
+// GetAllRepositories lists every repository across all of the user's installations,
+// covering each organization the app is installed on
+func (s *InstallationService) GetAllRepositories(ctx context.Context, userID string) ([]*InstallationRepository, error) {
+	installations, err := s.GetInstallations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	repositories := []*InstallationRepository{}
+	for _, installation := range installations {
+		// GitHub refuses tokens for suspended installations
+		if installation.IsSuspended() {
+			continue
+		}
+		for page := 1; ; page++ {
+			repos, total, err := s.appService.GetInstallationRepositories(ctx, installation.InstallationID, page, 100)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch repositories for %s: %w", installation.AccountLogin, err)
+			}
+			repositories = append(repositories, toInstallationRepositories(installation.InstallationID, repos)...)
+
+			if len(repos) == 0 || page*100 >= total {
+				break
+			}
+		}
+	}
+
+	return repositories, nil
+}
+
+// HandleInstallationEvent keeps linked installations in sync with GitHub
+func (s *InstallationService) HandleInstallationEvent(ctx context.Context, action string, installationID int64) error {
+	switch action {
+	case "deleted":
+		log.Printf("🔌 GitHub App installation %d deleted, unlinking", installationID)
+		s.appService.InvalidateInstallation(installationID)
+		if err := s.store.DeleteGitHubInstallationByInstallationID(ctx, installationID); err != nil {
+			return fmt.Errorf("failed to delete installation: %w", err)
+		}
+	case "suspend":
+		// A suspension can be lifted, so the link and the projects using it are kept
+		log.Printf("🔌 GitHub App installation %d suspended", installationID)
+		s.appService.InvalidateInstallation(installationID)
+		suspendedAt := time.Now()
+		if err := s.store.SetGitHubInstallationSuspendedAt(ctx, installationID, &suspendedAt); err != nil {
+			return fmt.Errorf("failed to suspend installation: %w", err)
+		}
+	case "unsuspend":
+		log.Printf("🔌 GitHub App installation %d unsuspended", installationID)
+		if err := s.store.SetGitHubInstallationSuspendedAt(ctx, installationID, nil); err != nil {
+			return fmt.Errorf("failed to unsuspend installation: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *InstallationService) getOwnedInstallation(ctx context.Context, userID, id string) (*models.GitHubInstallation, error) {
+	installation, err := s.store.GetGitHubInstallationByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("installation not found: %w", err)
+	}
+
+	if !installation.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: installation does not belong to user")
+	}
+
+	return installation, nil
+}
+
+func toInstallationRepositories(installationID int64, repositories []*GitHubRepository) []*InstallationRepository {
+	result := make([]*InstallationRepository, len(repositories))
+	for i, repo := range repositories {
+		result[i] = &InstallationRepository{
+			GitRepository:  repo.toGitRepository(),
+			InstallationID: installationID,
+		}
+	}
+	return result
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store"
+	"github.com/go-playground/validator/v10"
+)
+
+const MAX_LOG_LINE_SIZE = 1024 * 1024
+
+type LogService struct {
+	store     store.Store
+	validator *validator.Validate
+}
+
+func NewLogService(store store.Store) *LogService {
+	return &LogService{
+		store:     store,
+		validator: validator.New(),
+	}
+}
+
+// LogStream delivers the lines of a running `docker service logs`
+type LogStream struct {
+	Lines <-chan *models.LogLine
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+// Err returns why the stream ended early, once Lines is closed
+func (l *LogStream) Err() error {
+	<-l.done
+	return l.err
+}
+
+// Close stops reading logs and waits for the docker command to exit
+func (l *LogStream) Close() {
+	l.cancel()
+	<-l.done
+}
+
+// OpenLogs starts streaming the runtime logs of a project's app service. The
+// stream outlives ctx and must be closed by the caller.
+func (s *LogService) OpenLogs(ctx context.Context, userID, projectID string, req *models.LogsRequest) (*LogStream, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if req.Since != "" && !validLogSince(req.Since) {
+		return nil, errors.New("validation failed: since must be an RFC 3339 timestamp or a duration")
+	}
+
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+
+	args := []string{"service", "logs", "--timestamps", "--details", "--no-trunc"}
+	if req.Tail > 0 {
+		args = append(args, "--tail", strconv.Itoa(req.Tail))
+	}
+	if req.Since != "" {
+		args = append(args, "--since", req.Since)
+	}
+	if req.Follow {
+		args = append(args, "--follow")
+	}
+	if req.Process != "" && !processNamePattern.MatchString(req.Process) {
+		return nil, errors.New("validation failed: invalid process name")
+	}
+	args = append(args, processServiceName(project.ID, req.Process))
+
+	streamCtx, cancel := context.WithCancel(context.Background())
+	cmd := exec.CommandContext(streamCtx, "docker", args...)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to read logs: %w", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to read logs: %w", err)
+	}
+
+	log.Printf("📜 Streaming logs: docker %s", strings.Join(args, " "))
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to read logs: %w", err)
+	}
+
+	lines := make(chan *models.LogLine, 64)
+	stream := &LogStream{
+		Lines:  lines,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	// docker writes what the app printed to stdout and stderr to its own stdout and
+	// stderr. Anything on stderr that isn't a log line is an error from the CLI.
+	var (
+		wg        sync.WaitGroup
+		cliOutput []string
+	)
+	read := func(r io.Reader, name string) {
+		defer wg.Done()
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), MAX_LOG_LINE_SIZE)
+		for scanner.Scan() {
+			line, ok := parseLogLine(scanner.Text(), name)
+			if !ok {
+				if name == models.LOG_STREAM_STDERR {
+					cliOutput = append(cliOutput, strings.TrimSpace(scanner.Text()))
+				}
+				continue
+			}
+			if req.Replica != 0 && line.Replica != req.Replica {
+				continue
+			}
+
+			select {
+			case lines <- line:
+			case <-streamCtx.Done():
+				return
+			}
+		}
+	}
+
+	wg.Add(2)
+	go read(stdout, models.LOG_STREAM_STDOUT)
+	go read(stderr, models.LOG_STREAM_STDERR)
+
+	go func() {
+		wg.Wait()
+		err := cmd.Wait()
+		// Closing the stream kills docker, which isn't a failure
+		if streamCtx.Err() == nil {
+			if len(cliOutput) > 0 {
+				stream.err = fmt.Errorf("failed to read logs: %s", strings.Join(cliOutput, "; "))
+			} else if err != nil {
+				stream.err = fmt.Errorf("failed to read logs: %w", err)
+			}
+		}
+		close(lines)
+		close(stream.done)
+	}()
+
+	return stream, nil
+}
+
+// appServiceName returns the swarm service running a stack's app
+func appServiceName(stackName string) string {
+	return stackName + "_" + models.DEFAULT_PROCESS_NAME
+}
+
+// processServiceName returns the swarm service running a process of a stack. Without
+// a process it is the exposed one, or the only one of a stack without any exposed.
+func processServiceName(stackName, process string) string {
+	if process != "" {
+		return stackName + "_" + process
+	}
+
+	output, err := runDocker("service", "ls", "--filter", "label="+STACK_NAMESPACE_LABEL+"="+stackName, "--filter", "label="+PROCESS_EXPOSED_LABEL+"=true", "--format", "{{.Name}}")
+	if names := strings.Fields(output); err == nil && len(names) == 1 {
+		return names[0]
+	}
+	if services, err := stackServices(stackName); err == nil && len(services) == 1 {
+		return services[0]
+	}
+	// Stacks deployed before process types run a single app service
+	return appServiceName(stackName)
+}
+
+// validLogSince checks a since filter the way `docker service logs` accepts it
+func validLogSince(since string) bool {
+	if _, err := time.ParseDuration(since); err == nil {
+		return true
+	}
+	_, err := time.Parse(time.RFC3339Nano, since)
+	return err == nil
+}
+
+// parseLogLine parses a line of `docker service logs --timestamps --details --no-trunc`:
+//
+//	2024-01-02T15:04:05.123456789Z <stack>_app.<slot>.<task id>@<node>    | kova.deployment=<id> message
+//
+// The details are empty for containers started without deployment labels.
+func parseLogLine(raw, stream string) (*models.LogLine, bool) {
+	timestamp, rest, ok := strings.Cut(raw, " ")
+	if !ok {
+		return nil, false
+	}
+	parsedTime, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return nil, false
+	}
+
+	source, message, ok := strings.Cut(rest, " | ")
+	if !ok {
+		return nil, false
+	}
+
+	task, node, _ := strings.Cut(strings.TrimSpace(source), "@")
+	parts := strings.Split(task, ".")
+	if len(parts) < 3 {
+		return nil, false
+	}
+	replica, err := strconv.Atoi(parts[len(parts)-2])
+	if err != nil {
+		return nil, false
+	}
+
+	line := &models.LogLine{
+		Timestamp: parsedTime,
+		Replica:   replica,
+		TaskID:    parts[len(parts)-1],
+		Node:      node,
+		Stream:    stream,
+	}
+
+	details, text, _ := strings.Cut(message, " ")
+	for _, detail := range strings.Split(details, ",") {
+		if key, value, ok := strings.Cut(detail, "="); ok && key == DEPLOYMENT_LABEL {
+			line.Deployment = value
+		}
+	}
+	line.Message = text
+
+	return line, true
+}
@@ -0,0 +1,296 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store"
+)
+
+const (
+	METRICS_SAMPLE_INTERVAL   = time.Minute
+	METRICS_STATS_CONCURRENCY = 8 // Stats readings in flight, each takes about a second
+	DEFAULT_METRICS_RANGE     = "1h"
+)
+
+// previewStackPattern matches preview stacks, named <project id>-pr-<number>
+var previewStackPattern = regexp.MustCompile(`^(.+)-pr-\d+$`)
+
+// MetricsOptions configures the resource usage collector
+type MetricsOptions struct {
+	Enabled         bool
+	MinuteRetention time.Duration // How long per minute samples are kept
+	HourRetention   time.Duration // How long hourly rollups are kept
+}
+
+type MetricsService struct {
+	store   store.Store
+	docker  *DockerClient
+	options MetricsOptions
+
+	// Collector state, only touched by the collector goroutine
+	lastSample time.Time
+	network    map[string]networkReading // container ID -> last network counters
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type networkReading struct {
+	rx, tx uint64
+	at     time.Time
+}
+
+// serviceUsage accumulates the readings of a swarm service's replicas
+type serviceUsage struct {
+	mu     sync.Mutex
+	sample models.MetricSample
+}
+
+func NewMetricsService(store store.Store, docker *DockerClient, options MetricsOptions) *MetricsService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &MetricsService{
+		store:   store,
+		docker:  docker,
+		options: options,
+		network: make(map[string]networkReading),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Start runs the background collector
+func (s *MetricsService) Start() {
+	if !s.options.Enabled {
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(METRICS_SAMPLE_INTERVAL)
+		defer ticker.Stop()
+
+		for {
+			s.collect()
+			s.compact()
+
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	log.Println("📊 Metrics collector started")
+}
+
+// Shutdown stops the background collector
+func (s *MetricsService) Shutdown() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// GetProjectMetrics returns the resource usage history of a project's services over
+// a range. Ranges up to a day are served per minute, longer ones per hour.
+func (s *MetricsService) GetProjectMetrics(ctx context.Context, userID, projectID, rangeName string) (*models.ProjectMetrics, error) {
+	if rangeName == "" {
+		rangeName = DEFAULT_METRICS_RANGE
+	}
+	duration, ok := models.MetricsRanges[rangeName]
+	if !ok {
+		return nil, fmt.Errorf("validation failed: unsupported range %q", rangeName)
+	}
+
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+
+	resolution := models.METRICS_RESOLUTION_MINUTE
+	if duration > 24*time.Hour {
+		resolution = models.METRICS_RESOLUTION_HOUR
+	}
+
+	samples, err := s.store.GetProjectMetrics(ctx, projectID, resolution, time.Now().Add(-duration))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metrics: %w", err)
+	}
+
+	metrics := &models.ProjectMetrics{
+		ProjectID:         projectID,
+		Range:             rangeName,
+		ResolutionSeconds: resolution,
+		Series:            []*models.MetricSeries{},
+	}
+
+	// Samples are ordered by service, so each service is one run
+	var series *models.MetricSeries
+	for _, sample := range samples {
+		if series == nil || series.Service != sample.Service {
+			series = &models.MetricSeries{Service: sample.Service}
+			metrics.Series = append(metrics.Series, series)
+		}
+		series.Samples = append(series.Samples, sample)
+	}
+
+	return metrics, nil
+}
+
+// collect takes one reading of every service deployed by Kova
+func (s *MetricsService) collect() {
+	now := time.Now()
+
+	services, err := s.docker.listServices(s.ctx, STACK_NAMESPACE_LABEL)
+	if err != nil {
+		log.Printf("⚠️  Failed to list services for metrics: %v", err)
+		return
+	}
+
+	usages := make(map[string]*serviceUsage, len(services))
+	projects := make(map[string]bool)
+	serviceIDs := make([]string, 0, len(services))
+	for _, service := range services {
+		projectID := projectIDForStack(service.Spec.Labels[STACK_NAMESPACE_LABEL])
+
+		// Stacks of other tools, and of deleted projects, aren't recorded
+		known, checked := projects[projectID]
+		if !checked {
+			_, err := s.store.GetProjectByID(s.ctx, projectID)
+			known = err == nil
+			projects[projectID] = known
+		}
+		if !known {
+			continue
+		}
+
+		usages[service.ID] = &serviceUsage{sample: models.MetricSample{
+			ProjectID:         projectID,
+			Service:           service.Spec.Name,
+			ResolutionSeconds: models.METRICS_RESOLUTION_MINUTE,
+			Timestamp:         now.Truncate(time.Minute),
+		}}
+		serviceIDs = append(serviceIDs, service.ID)
+	}
+
+	tasks, err := s.docker.listTasks(s.ctx, serviceIDs)
+	if err != nil {
+		log.Printf("⚠️  Failed to list tasks for metrics: %v", err)
+		return
+	}
+
+	type container struct {
+		id    string
+		usage *serviceUsage
+	}
+	var containers []container
+	for _, task := range tasks {
+		usage := usages[task.ServiceID]
+		if usage == nil {
+			continue
+		}
+		switch task.Status.State {
+		case "running":
+			if task.Status.ContainerStatus.ContainerID != "" {
+				containers = append(containers, container{task.Status.ContainerStatus.ContainerID, usage})
+			}
+		case "failed", "complete", "rejected":
+			// Swarm replaces tasks that exit, so each one ending since the last reading is a restart
+			if !s.lastSample.IsZero() && task.Status.Timestamp.After(s.lastSample) {
+				usage.sample.Restarts++
+			}
+		}
+	}
+
+	// Read the containers in parallel, each reading blocks for about a second
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		network = make(map[string]networkReading, len(containers))
+		limit   = make(chan struct{}, METRICS_STATS_CONCURRENCY)
+	)
+	for _, c := range containers {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(c container) {
+			defer wg.Done()
+			defer func() { <-limit }()
+
+			stats, err := s.docker.containerStats(s.ctx, c.id)
+			if err != nil {
+				// Containers on other nodes can't be read from this daemon
+				if !isDockerNotFound(err) {
+					log.Printf("⚠️  Failed to read stats of container %s: %v", c.id, err)
+				}
+				return
+			}
+
+			rx, tx := stats.NetworkBytes()
+			reading := networkReading{rx: rx, tx: tx, at: time.Now()}
+
+			mu.Lock()
+			previous, seen := s.network[c.id]
+			network[c.id] = reading
+			mu.Unlock()
+
+			c.usage.mu.Lock()
+			defer c.usage.mu.Unlock()
+			c.usage.sample.CPUPercent += stats.CPUPercent()
+			c.usage.sample.MemoryBytes += int64(stats.MemoryUsage())
+			c.usage.sample.MemoryLimitBytes += int64(stats.MemoryStats.Limit)
+			// Counters start over when a container restarts, which leaves no rate to report
+			if elapsed := reading.at.Sub(previous.at).Seconds(); seen && elapsed > 0 && rx >= previous.rx && tx >= previous.tx {
+				c.usage.sample.NetworkRxRate += float64(rx-previous.rx) / elapsed
+				c.usage.sample.NetworkTxRate += float64(tx-previous.tx) / elapsed
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	// Containers that are gone are dropped with the old readings
+	s.network = network
+	s.lastSample = now
+
+	for _, usage := range usages {
+		if err := s.store.RecordProjectMetric(s.ctx, &usage.sample); err != nil {
+			log.Printf("⚠️  Failed to record metrics of service %s: %v", usage.sample.Service, err)
+		}
+	}
+}
+
+// compact rolls the minute samples of the current and previous hour up into hourly
+// buckets, and drops samples past their retention
+func (s *MetricsService) compact() {
+	now := time.Now()
+
+	if err := s.store.RollupProjectMetrics(s.ctx, now.Add(-time.Hour).Truncate(time.Hour)); err != nil {
+		log.Printf("⚠️  Failed to roll up metrics: %v", err)
+	}
+
+	if err := s.store.DeleteProjectMetricsBefore(s.ctx, models.METRICS_RESOLUTION_MINUTE, now.Add(-s.options.MinuteRetention)); err != nil {
+		log.Printf("⚠️  Failed to delete expired minute metrics: %v", err)
+	}
+	if err := s.store.DeleteProjectMetricsBefore(s.ctx, models.METRICS_RESOLUTION_HOUR, now.Add(-s.options.HourRetention)); err != nil {
+		log.Printf("⚠️  Failed to delete expired hourly metrics: %v", err)
+	}
+}
+
+// projectIDForStack returns the project a stack was deployed for, previews included
+func projectIDForStack(stackName string) string {
+	if match := previewStackPattern.FindStringSubmatch(stackName); match != nil {
+		return match[1]
+	}
+	return stackName
+}
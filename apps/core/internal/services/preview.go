@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store"
+	"github.com/go-playground/validator/v10"
+)
+
+// WEBHOOK_PATH is where GitHub delivers repository webhooks, relative to the public URL
+const WEBHOOK_PATH = "/api/v1/webhooks/github"
+
+type PreviewService struct {
+	store         store.Store
+	validator     *validator.Validate
+	githubService *GitHubService
+	appService    *GitHubAppService
+	buildService  *BuildService
+	publicURL     string
+	webhookSecret string
+}
+
+func NewPreviewService(store store.Store, githubService *GitHubService, appService *GitHubAppService, buildService *BuildService, publicURL, webhookSecret string) *PreviewService {
+	return &PreviewService{
+		store:         store,
+		validator:     validator.New(),
+		githubService: githubService,
+		appService:    appService,
+		buildService:  buildService,
+		publicURL:     strings.TrimRight(publicURL, "/"),
+		webhookSecret: webhookSecret,
+	}
+}
+
+// WebhookSecret returns the secret GitHub signs webhook deliveries with
+func (s *PreviewService) WebhookSecret() string {
+	return s.webhookSecret
+}
+
+// UpdatePreviewSettings enables or disables pull request previews for a project,
+// registering or removing the repository webhook as needed
+func (s *PreviewService) UpdatePreviewSettings(ctx context.Context, userID, projectID string, req *models.UpdatePreviewSettingsRequest) (*models.Project, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := models.ValidateEnvVariables(req.EnvVariables); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+
+	if req.EnvVariables == nil {
+		req.EnvVariables = []models.EnvironmentVariable{}
+	}
+
+	// The webhook receiver only understands github.com pull_request deliveries
+	if req.Enabled && project.ProviderURL != models.DefaultProviderURL(models.GIT_PROVIDER_GITHUB) {
+		return nil, errors.New("validation failed: pull request previews are only supported for github.com repositories")
+	}
+
+	webhookID := project.WebhookID
+	if req.Enabled && webhookID == 0 {
+		if s.publicURL == "" || s.webhookSecret == "" {
+			return nil, errors.New("previews unavailable: PUBLIC_URL and GITHUB_WEBHOOK_SECRET must be configured")
+		}
+
+		token, err := resolveProjectToken(ctx, s.store, s.appService, project)
+		if err != nil {
+			return nil, err
+		}
+
+		webhookID, err = s.githubService.CreateRepositoryWebhook(ctx, token, project.RepoFullName, s.publicURL+WEBHOOK_PATH, s.webhookSecret, []string{"pull_request"})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create repository webhook: %w", err)
+		}
+	}
+
+	if !req.Enabled && webhookID != 0 {
+		token, err := resolveProjectToken(ctx, s.store, s.appService, project)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.githubService.DeleteRepositoryWebhook(ctx, token, project.RepoFullName, webhookID); err != nil {
+			return nil, fmt.Errorf("failed to delete repository webhook: %w", err)
+		}
+		webhookID = 0
+	}
+
+	updatedProject, err := s.store.UpdateProjectPreviewSettings(ctx, projectID, req.Enabled, req.EnvVariables, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update preview settings: %w", err)
+	}
+
+	return updatedProject.ToPublic(), nil
+}
+
+// GetPreviews lists the pull request previews of a project
+func (s *PreviewService) GetPreviews(ctx context.Context, userID, projectID string) ([]*models.Preview, error) {
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+
+	previews, err := s.store.GetPreviewsByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get previews: %w", err)
+	}
+
+	return previews, nil
+}
+
+// HandlePullRequestEvent builds, rebuilds or tears down previews for every
+// project with previews enabled on the event's repository
+func (s *PreviewService) HandlePullRequestEvent(ctx context.Context, event *GitHubPullRequestEvent) error {
+	// Forks can't be cloned with the project owner's token
+	if event.PullRequest.Head.Repo.ID != event.Repository.ID {
+		log.Printf("⏭️  Ignoring PR #%d on %s: head is a fork", event.Number, event.Repository.FullName)
+		return nil
+	}
+
+	projects, err := s.store.GetProjectsByRepoID(ctx, event.Repository.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	for _, project := range projects {
+		if !project.PreviewsEnabled || !project.IsActive() || project.Provider != models.GIT_PROVIDER_GITHUB {
+			continue
+		}
+		// Preview hosts are subdomains of the project's primary domain
+		if project.Domain == "" {
+			log.Printf("⏭️  Skipping previews of project %s: it has no domain", project.ID)
+			continue
+		}
+
+		switch event.Action {
+		case "opened", "reopened", "synchronize":
+			preview := &models.Preview{
+				ProjectID:  project.ID,
+				PRNumber:   event.Number,
+				PRTitle:    event.PullRequest.Title,
+				HeadBranch: event.PullRequest.Head.Ref,
+				HeadSHA:    event.PullRequest.Head.SHA,
+				Domain:     models.PreviewDomain(project.Domain, event.Number),
+			}
+			if err := s.store.UpsertPreview(ctx, preview); err != nil {
+				return fmt.Errorf("failed to save preview: %w", err)
+			}
+			s.buildService.EnqueuePreview(project.ID, project.UserID, preview.ID)
+
+		case "closed":
+			preview, err := s.store.GetPreviewByProjectIDAndNumber(ctx, project.ID, event.Number)
+			if err != nil {
+				continue
+			}
+			s.buildService.EnqueuePreviewTeardown(project.ID, project.UserID, preview.ID)
+		}
+	}
+
+	return nil
+}
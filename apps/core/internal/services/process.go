@@ -0,0 +1,156 @@
+package services
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/dopeCape/kova/internal/models"
+)
+
+const (
+	PROCFILE_NAME          = "Procfile"
+	PROCFILE_WEB_PROCESS   = "web"                       // The Procfile process routed to the project's domains
+	PROCESS_LABEL          = "kova.process"              // Set on each service to the process it runs
+	PROCESS_EXPOSED_LABEL  = PROCESS_LABEL + ".exposed"  // Set on the service routed to the project's domains
+	PROCESS_REPLICAS_LABEL = PROCESS_LABEL + ".replicas" // The configured replicas, which a stopped service is started with
+)
+
+// processNamePattern matches process names, which become part of swarm service names
+var processNamePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// buildProcesses turns a process update into the stored process types
+func buildProcesses(req *models.UpdateProcessesRequest) ([]models.Process, error) {
+	processes := make([]models.Process, 0, len(req.Processes))
+	for _, p := range req.Processes {
+		replicas := 1
+		if p.Replicas != nil {
+			replicas = *p.Replicas
+		}
+		processes = append(processes, models.Process{
+			Name:     p.Name,
+			Command:  strings.TrimSpace(p.Command),
+			Replicas: replicas,
+			Exposed:  p.Exposed,
+		})
+	}
+
+	if err := validateProcesses(processes); err != nil {
+		return nil, err
+	}
+	return processes, nil
+}
+
+// validateProcesses checks process names are usable and unique, and that at most
+// one process is exposed, since the project's domains route to a single service
+func validateProcesses(processes []models.Process) error {
+	names := make(map[string]bool, len(processes))
+	exposed := ""
+	for _, p := range processes {
+		if !processNamePattern.MatchString(p.Name) {
+			return fmt.Errorf("invalid process name %q, use lowercase letters, digits and dashes", p.Name)
+		}
+		if len(p.Name) > models.MAX_PROCESS_NAME_LENGTH {
+			return fmt.Errorf("process name %q is longer than %d characters", p.Name, models.MAX_PROCESS_NAME_LENGTH)
+		}
+		if names[p.Name] {
+			return fmt.Errorf("duplicate process %q", p.Name)
+		}
+		names[p.Name] = true
+
+		if p.Exposed {
+			if exposed != "" {
+				return fmt.Errorf("only one process can be exposed, both %s and %s are", exposed, p.Name)
+			}
+			exposed = p.Name
+		}
+	}
+	return nil
+}
+
+// resolveProcesses returns the processes to deploy a checkout of a project with:
+// its configured processes, else the Procfile of the checkout, else the default
+// single process
+func resolveProcesses(project *models.Project, repoPath string) ([]models.Process, error) {
+	if len(project.Processes) > 0 {
+		return project.Processes, nil
+	}
+
+	content, err := os.ReadFile(filepath.Join(repoPath, PROCFILE_NAME))
+	if errors.Is(err, os.ErrNotExist) {
+		return models.DefaultProcesses(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Procfile: %w", err)
+	}
+
+	processes, err := parseProcfile(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Procfile: %w", err)
+	}
+	if len(processes) == 0 {
+		return models.DefaultProcesses(), nil
+	}
+
+	log.Printf("📄 Using %d processes from Procfile", len(processes))
+	return processes, nil
+}
+
+// parseProcfile parses "name: command" lines. The web process is exposed and every
+// process runs one replica.
+func parseProcfile(content string) ([]models.Process, error) {
+	var processes []models.Process
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, command, ok := strings.Cut(line, ":")
+		name, command = strings.TrimSpace(name), strings.TrimSpace(command)
+		if !ok || name == "" || command == "" {
+			return nil, fmt.Errorf("line %d: expected \"name: command\"", lineNumber)
+		}
+
+		processes = append(processes, models.Process{
+			Name:     strings.ToLower(name),
+			Command:  command,
+			Replicas: 1,
+			Exposed:  strings.EqualFold(name, PROCFILE_WEB_PROCESS),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateProcesses(processes); err != nil {
+		return nil, err
+	}
+	return processes, nil
+}
+
+// deployProcess is a process as rendered into a stack's docker-compose file
+type deployProcess struct {
+	models.Process
+}
+
+// CommandJSON returns the command as a JSON string, which is also valid YAML
+func (p deployProcess) CommandJSON() string {
+	encoded, _ := json.Marshal(p.Command)
+	return string(encoded)
+}
+
+func toDeployProcesses(processes []models.Process) []deployProcess {
+	deployProcesses := make([]deployProcess, len(processes))
+	for i, p := range processes {
+		deployProcesses[i] = deployProcess{p}
+	}
+	return deployProcesses
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dopeCape/kova/internal/models"
+)
+
+func TestParseProcfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []models.Process
+		wantErr string
+	}{
+		{
+			name: "web and worker",
+			content: "# Processes\n" +
+				"web: bundle exec puma -C config/puma.rb\n" +
+				"\n" +
+				"  worker :  bundle exec sidekiq  \n",
+			want: []models.Process{
+				{Name: "web", Command: "bundle exec puma -C config/puma.rb", Replicas: 1, Exposed: true},
+				{Name: "worker", Command: "bundle exec sidekiq", Replicas: 1},
+			},
+		},
+		{
+			name:    "names are lowercased",
+			content: "Web: node server.js\r\nRelease: npm run migrate\r\n",
+			want: []models.Process{
+				{Name: "web", Command: "node server.js", Replicas: 1, Exposed: true},
+				{Name: "release", Command: "npm run migrate", Replicas: 1},
+			},
+		},
+		{
+			name:    "colons in the command",
+			content: "web: gunicorn app:app --bind 0.0.0.0:$PORT",
+			want: []models.Process{
+				{Name: "web", Command: "gunicorn app:app --bind 0.0.0.0:$PORT", Replicas: 1, Exposed: true},
+			},
+		},
+		{
+			name:    "only comments",
+			content: "# nothing here\n\n",
+		},
+		{
+			name:    "missing colon",
+			content: "web: node server.js\nworker node worker.js\n",
+			wantErr: "line 2",
+		},
+		{
+			name:    "missing command",
+			content: "web:\n",
+			wantErr: "line 1",
+		},
+		{
+			name:    "invalid name",
+			content: "web_1: node server.js\n",
+			wantErr: "invalid process name",
+		},
+		{
+			name:    "duplicate name",
+			content: "web: node a.js\nWEB: node b.js\n",
+			wantErr: "duplicate process",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseProcfile(tt.content)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseProcfile() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseProcfile() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseProcfile() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateProcesses(t *testing.T) {
+	tests := []struct {
+		name      string
+		processes []models.Process
+		wantErr   string
+	}{
+		{
+			name: "valid",
+			processes: []models.Process{
+				{Name: "web", Exposed: true},
+				{Name: "worker-2"},
+			},
+		},
+		{
+			name: "nothing exposed",
+			processes: []models.Process{
+				{Name: "worker"},
+			},
+		},
+		{
+			name:      "uppercase name",
+			processes: []models.Process{{Name: "Web"}},
+			wantErr:   "invalid process name",
+		},
+		{
+			name:      "leading digit",
+			processes: []models.Process{{Name: "1web"}},
+			wantErr:   "invalid process name",
+		},
+		{
+			name:      "too long",
+			processes: []models.Process{{Name: strings.Repeat("a", models.MAX_PROCESS_NAME_LENGTH+1)}},
+			wantErr:   "longer than",
+		},
+		{
+			name:      "duplicate",
+			processes: []models.Process{{Name: "web"}, {Name: "web"}},
+			wantErr:   "duplicate process",
+		},
+		{
+			name: "two exposed",
+			processes: []models.Process{
+				{Name: "web", Exposed: true},
+				{Name: "api", Exposed: true},
+			},
+			wantErr: "only one process can be exposed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateProcesses(tt.processes)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateProcesses() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateProcesses() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"strings"
 	"time"
 
 	"github.com/dopeCape/kova/internal/models"
@@ -15,28 +17,51 @@ type ProjectService struct {
 	store        store.Store
 	validator    *validator.Validate
 	buildService *BuildService
+	appService   *GitHubAppService
+	providers    *GitProviders
+	domains      *DomainService
+	teardowns    *TeardownService
+	publicURL    string
 }
 
-func NewProjectService(store store.Store, buildService *BuildService) *ProjectService {
+func NewProjectService(store store.Store, buildService *BuildService, appService *GitHubAppService, providers *GitProviders, domains *DomainService, teardowns *TeardownService, publicURL string) *ProjectService {
 	return &ProjectService{
 		store:        store,
 		validator:    validator.New(),
 		buildService: buildService,
+		appService:   appService,
+		providers:    providers,
+		domains:      domains,
+		teardowns:    teardowns,
+		publicURL:    strings.TrimRight(publicURL, "/"),
 	}
 }
 
 func (s *ProjectService) CreateProject(ctx context.Context, userID string, req *models.CreateProjectRequest) (*models.Project, error) {
+	req.Domain = models.NormalizeDomain(req.Domain)
 	if err := s.validator.Struct(req); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
-	_, err := s.store.GetUserByID(ctx, userID)
+	user, err := s.store.GetUserByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
 
 	req.SetDefaults()
 
+	if err := validateProjectSource(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := models.ValidateEnvVariables(req.EnvVariables); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	accountID, err := s.bindAccount(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
 	if req.EnvVariables == nil {
 		req.EnvVariables = []models.EnvironmentVariable{}
 	}
@@ -49,6 +74,31 @@ func (s *ProjectService) CreateProject(ctx context.Context, userID string, req *
 		return nil, errors.New("project with this name already exists for this user")
 	}
 
+	// Projects without a domain are served under the installation's base domain
+	generatedDomain := false
+	if req.Domain != "" {
+		if err := s.domains.CheckAvailable(ctx, req.Domain); err != nil {
+			return nil, err
+		}
+	} else if s.domains.HasBaseDomain() {
+		req.Domain, err = s.domains.GenerateDomain(ctx, req.Name, user.Username)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate domain: %w", err)
+		}
+		generatedDomain = true
+	}
+
+	// Projects cloned through the GitHub App must use one of the user's installations
+	if req.InstallationID != 0 {
+		installation, err := s.store.GetGitHubInstallationByInstallationID(ctx, req.InstallationID)
+		if err != nil {
+			return nil, fmt.Errorf("installation not found: %w", err)
+		}
+		if !installation.IsOwnedBy(userID) {
+			return nil, errors.New("access denied: installation does not belong to user")
+		}
+	}
+
 	project := &models.Project{
 		Name:             req.Name,
 		UserID:           userID,
@@ -61,20 +111,633 @@ func (s *ProjectService) CreateProject(ctx context.Context, userID string, req *
 		DeploymentStatus: "pending",
 		Domain:           req.Domain,
 		EnvVariables:     req.EnvVariables,
+		InstallationID:   req.InstallationID,
+		AccountID:        accountID,
+		Provider:         req.Provider,
+		ProviderURL:      req.ProviderURL,
+		SourceType:       req.SourceType,
+		Image:            req.Image,
+		RegistryUsername: req.RegistryUsername,
+		RegistryPassword: req.RegistryPassword,
+		ContainerPort:    req.ContainerPort,
 		CreatedAt:        time.Now(),
 		UpdatedAt:        time.Now(),
 	}
 
+	// Plain git projects get their own deploy key to add to the repository
+	if project.Provider == models.GIT_PROVIDER_GIT && !project.IsImage() {
+		publicKey, privateKey, err := generateDeployKey(deployKeyComment(project))
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate deploy key: %w", err)
+		}
+		project.DeployPublicKey = publicKey
+		project.DeployPrivateKey = privateKey
+	}
+
+	// An SSH remote can't be reached until the new deploy key has been added to it.
+	// Image projects have no branch to resolve.
+	if project.IsImage() {
+		project.RepoBranch = ""
+	} else if project.UsesDeployKey() {
+		if project.RepoBranch == "" {
+			return nil, errors.New("validation failed: repo_branch is required for ssh remotes")
+		}
+	} else {
+		branch, err := s.resolveBranch(ctx, project, project.RepoBranch)
+		if err != nil {
+			return nil, err
+		}
+		project.RepoBranch = branch
+	}
+
 	if err := s.store.CreateProject(ctx, project); err != nil {
 		return nil, fmt.Errorf("failed to create project: %w", err)
 	}
 
-	// Enqueue build job
+	// A custom domain stays unrouted until its ownership has been verified
+	if project.Domain != "" {
+		claimDomain := s.domains.ClaimDomain
+		if generatedDomain {
+			claimDomain = s.domains.ClaimGeneratedDomain
+		}
+		if _, err := claimDomain(ctx, project.ID, project.Domain); err != nil {
+			if delErr := s.store.DeleteProject(ctx, project.ID); delErr != nil {
+				log.Printf("⚠️  Failed to remove project %s after its domain was rejected: %v", project.ID, delErr)
+			}
+			return nil, err
+		}
+	}
+
+	// Enqueue build job. SSH remotes can't be cloned until the deploy key has been
+	// added to the repository, so those wait for an explicit deploy.
+	if s.buildService != nil && !project.UsesDeployKey() {
+		s.buildService.Enqueue(project.ID, userID)
+	}
+
+	return s.toPublic(project), nil
+}
+
+// toPublic returns the public view of a project along with the URL it is served at
+func (s *ProjectService) toPublic(project *models.Project) *models.Project {
+	public := project.ToPublic()
+	if public.Domain != "" && s.buildService != nil {
+		public.URL = s.buildService.SiteURL(public.Domain)
+	}
+	return public
+}
+
+// validateProjectSource checks the repository fields against the project's provider
+func validateProjectSource(req *models.CreateProjectRequest) error {
+	if req.SourceType == models.PROJECT_SOURCE_IMAGE {
+		if req.InstallationID != 0 {
+			return errors.New("installation_id is only supported for github")
+		}
+		return validateImageSource(req.Image, req.RegistryUsername, req.RegistryPassword)
+	}
+	if req.ContainerPort != 0 {
+		return errors.New("container_port is only supported for image projects")
+	}
+
+	if req.Provider == models.GIT_PROVIDER_GIT {
+		if !models.IsGitURL(req.RepoURL) {
+			return errors.New("repo_url must be an http(s), ssh or git URL")
+		}
+		if req.InstallationID != 0 {
+			return errors.New("installation_id is only supported for github")
+		}
+		return nil
+	}
+
+	if req.ProviderURL == "" {
+		return fmt.Errorf("provider_url is required for %s", req.Provider)
+	}
+	if req.RepoID == 0 || req.RepoName == "" || req.RepoFullName == "" {
+		return fmt.Errorf("repo_id, repo_name and repo_full_name are required for %s", req.Provider)
+	}
+	if !strings.HasPrefix(req.RepoURL, req.ProviderURL+"/") {
+		return fmt.Errorf("repo_url must be on %s", req.ProviderURL)
+	}
+	if req.InstallationID != 0 && req.Provider != models.GIT_PROVIDER_GITHUB {
+		return errors.New("installation_id is only supported for github")
+	}
+	return nil
+}
+
+// bindAccount returns the linked account a project clones, analyzes and reports with.
+// Without an explicit account_id the user's only account on the provider is used.
+func (s *ProjectService) bindAccount(ctx context.Context, userID string, req *models.CreateProjectRequest) (string, error) {
+	// Plain git remotes and app installations don't use an account token
+	if req.Provider == models.GIT_PROVIDER_GIT || req.InstallationID != 0 {
+		if req.AccountID != "" {
+			return "", errors.New("validation failed: account_id can't be combined with installation_id or a plain git repository")
+		}
+		return "", nil
+	}
+
+	if req.AccountID != "" {
+		account, err := s.store.GetAccountByID(ctx, req.AccountID)
+		if err != nil {
+			return "", fmt.Errorf("account not found: %w", err)
+		}
+		if !account.IsOwnedBy(userID) {
+			return "", errors.New("access denied: account does not belong to user")
+		}
+		if account.ProviderURL != req.ProviderURL {
+			return "", fmt.Errorf("validation failed: account %s is linked to %s, not %s", account.GithubUsername, account.ProviderURL, req.ProviderURL)
+		}
+		return account.ID, nil
+	}
+
+	accounts, err := s.store.GetAccountsByUserID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get linked accounts: %w", err)
+	}
+
+	var matching []*models.Account
+	for _, account := range accounts {
+		if account.ProviderURL == req.ProviderURL {
+			matching = append(matching, account)
+		}
+	}
+
+	switch len(matching) {
+	case 0:
+		return "", fmt.Errorf("validation failed: no linked account found for %s", req.ProviderURL)
+	case 1:
+		return matching[0].ID, nil
+	default:
+		return "", fmt.Errorf("validation failed: account_id is required when several accounts are linked for %s", req.ProviderURL)
+	}
+}
+
+// resolveBranch checks that branch exists in the project's repository. An empty
+// branch resolves to the repository's default branch.
+func (s *ProjectService) resolveBranch(ctx context.Context, project *models.Project, branch string) (string, error) {
+	if project.Provider == models.GIT_PROVIDER_GIT {
+		return s.resolveRemoteBranch(ctx, project, branch)
+	}
+
+	token, err := resolveProjectToken(ctx, s.store, s.appService, project)
+	if err != nil {
+		return "", fmt.Errorf("validation failed: %w", err)
+	}
+
+	provider, err := s.providers.ForProject(project)
+	if err != nil {
+		return "", fmt.Errorf("validation failed: %w", err)
+	}
+
+	if branch == "" {
+		repo, err := provider.GetRepository(ctx, token, project.RepoFullName)
+		if err != nil {
+			if errors.Is(err, errResourceNotFound) {
+				return "", fmt.Errorf("validation failed: repository %s not found", project.RepoFullName)
+			}
+			return "", fmt.Errorf("failed to fetch repository from git provider: %w", err)
+		}
+		return repo.DefaultBranch, nil
+	}
+
+	if _, err := provider.GetBranch(ctx, token, project.RepoFullName, branch); err != nil {
+		if errors.Is(err, errResourceNotFound) {
+			return "", fmt.Errorf("validation failed: branch %q not found in %s", branch, project.RepoFullName)
+		}
+		return "", fmt.Errorf("failed to fetch branch from git provider: %w", err)
+	}
+	return branch, nil
+}
+
+// resolveRemoteBranch is resolveBranch for plain git remotes, which are queried with git ls-remote
+func (s *ProjectService) resolveRemoteBranch(ctx context.Context, project *models.Project, branch string) (string, error) {
+	env, cleanup, err := gitRemoteEnv(project)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	if branch == "" {
+		defaultBranch, err := remoteDefaultBranch(ctx, project.RepoURL, env)
+		if err != nil {
+			return "", fmt.Errorf("validation failed: failed to read default branch of %s: %w", project.RepoURL, err)
+		}
+		return defaultBranch, nil
+	}
+
+	exists, err := remoteBranchExists(ctx, project.RepoURL, branch, env)
+	if err != nil {
+		return "", fmt.Errorf("validation failed: failed to list branches of %s: %w", project.RepoURL, err)
+	}
+	if !exists {
+		return "", fmt.Errorf("validation failed: branch %q not found in %s", branch, project.RepoURL)
+	}
+	return branch, nil
+}
+
+// deployKeyComment labels a project's deploy key so it can be recognised in the repository settings
+func deployKeyComment(project *models.Project) string {
+	return "kova-" + project.Name
+}
+
+// GetDeployKey returns the public deploy key of a plain git project
+func (s *ProjectService) GetDeployKey(ctx context.Context, userID, projectID string) (string, error) {
+	project, err := s.getDeployKeyProject(ctx, userID, projectID)
+	if err != nil {
+		return "", err
+	}
+
+	return project.DeployPublicKey, nil
+}
+
+// RegenerateDeployKey replaces the deploy key of a plain git project. The new public
+// key must be added to the repository before the next deploy.
+func (s *ProjectService) RegenerateDeployKey(ctx context.Context, userID, projectID string) (string, error) {
+	project, err := s.getDeployKeyProject(ctx, userID, projectID)
+	if err != nil {
+		return "", err
+	}
+
+	publicKey, privateKey, err := generateDeployKey(deployKeyComment(project))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate deploy key: %w", err)
+	}
+
+	updatedProject, err := s.store.UpdateProjectDeployKey(ctx, projectID, publicKey, privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to update deploy key: %w", err)
+	}
+
+	return updatedProject.DeployPublicKey, nil
+}
+
+func (s *ProjectService) getDeployKeyProject(ctx context.Context, userID, projectID string) (*models.Project, error) {
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+
+	if project.Provider != models.GIT_PROVIDER_GIT || project.IsImage() {
+		return nil, errors.New("validation failed: deploy keys are only used by plain git projects")
+	}
+
+	return project, nil
+}
+
+// UpdateTLSSettings changes whether a project forces HTTPS and uploads its custom
+// certificate. A deployed project is redeployed to apply the change.
+func (s *ProjectService) UpdateTLSSettings(ctx context.Context, userID, projectID string, req *models.UpdateTLSSettingsRequest) (*models.Project, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+
+	forceHTTPS := project.ForceHTTPS
+	if req.ForceHTTPS != nil {
+		forceHTTPS = *req.ForceHTTPS
+	}
+
+	certificate, privateKey := project.TLSCertificate, project.TLSPrivateKey
+	if req.Certificate != "" {
+		domains, err := s.store.GetProjectDomainsByProjectID(ctx, project.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get domains: %w", err)
+		}
+		var verifiedDomains []string
+		for _, domain := range domains {
+			if domain.IsVerified() {
+				verifiedDomains = append(verifiedDomains, domain.Domain)
+			}
+		}
+
+		if err := validateCertificate(req.Certificate, req.PrivateKey, verifiedDomains); err != nil {
+			return nil, fmt.Errorf("validation failed: %w", err)
+		}
+		certificate, privateKey = req.Certificate, req.PrivateKey
+	}
+
+	return s.saveTLSSettings(ctx, project, forceHTTPS, certificate, privateKey)
+}
+
+// RemoveCertificate drops the custom certificate of a project, which goes back to ACME certificates
+func (s *ProjectService) RemoveCertificate(ctx context.Context, userID, projectID string) (*models.Project, error) {
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+
+	return s.saveTLSSettings(ctx, project, project.ForceHTTPS, "", "")
+}
+
+func (s *ProjectService) saveTLSSettings(ctx context.Context, project *models.Project, forceHTTPS bool, certificate, privateKey string) (*models.Project, error) {
+	updatedProject, err := s.store.UpdateProjectTLSSettings(ctx, project.ID, forceHTTPS, certificate, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update TLS settings: %w", err)
+	}
+
+	if s.buildService != nil && updatedProject.DeploymentStatus == "deployed" {
+		s.buildService.Enqueue(updatedProject.ID, updatedProject.UserID)
+	}
+
+	return s.toPublic(updatedProject), nil
+}
+
+// UpdateRoutingPolicy replaces the Traefik middlewares of a project. A deployed
+// project is redeployed to apply them.
+func (s *ProjectService) UpdateRoutingPolicy(ctx context.Context, userID, projectID string, req *models.UpdateRoutingPolicyRequest) (*models.Project, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+
+	policy, err := buildRoutingPolicy(project.RoutingPolicy, req)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	updatedProject, err := s.store.UpdateProjectRoutingPolicy(ctx, projectID, policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update routing policy: %w", err)
+	}
+
+	if s.buildService != nil && updatedProject.DeploymentStatus == "deployed" {
+		s.buildService.Enqueue(updatedProject.ID, updatedProject.UserID)
+	}
+
+	return s.toPublic(updatedProject), nil
+}
+
+// UpdateProcesses replaces the process types of a project. An empty list falls back
+// to the repository's Procfile. A deployed project is redeployed to apply them.
+func (s *ProjectService) UpdateProcesses(ctx context.Context, userID, projectID string, req *models.UpdateProcessesRequest) (*models.Project, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+
+	processes, err := buildProcesses(req)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	updatedProject, err := s.store.UpdateProjectProcesses(ctx, projectID, processes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update processes: %w", err)
+	}
+
+	if s.buildService != nil && updatedProject.DeploymentStatus == "deployed" {
+		s.buildService.Enqueue(updatedProject.ID, updatedProject.UserID)
+	}
+
+	return s.toPublic(updatedProject), nil
+}
+
+// UpdateVolumes replaces the volumes mounted into every process of a project. A
+// deployed project is redeployed to apply them. Removed volumes keep their data
+// until the project is deleted with its volumes.
+func (s *ProjectService) UpdateVolumes(ctx context.Context, userID, projectID string, req *models.UpdateVolumesRequest) (*models.Project, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+
+	volumes, err := buildVolumes(req)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	updatedProject, err := s.store.UpdateProjectVolumes(ctx, projectID, volumes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update volumes: %w", err)
+	}
+
+	if s.buildService != nil && updatedProject.DeploymentStatus == "deployed" {
+		s.buildService.Enqueue(updatedProject.ID, updatedProject.UserID)
+	}
+
+	return s.toPublic(updatedProject), nil
+}
+
+// UpdateStaticSite switches a project between running a container and being served
+// as static files. A deployed project is redeployed to apply the change.
+func (s *ProjectService) UpdateStaticSite(ctx context.Context, userID, projectID string, req *models.UpdateStaticSiteRequest) (*models.Project, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+
+	site, err := buildStaticSite(req)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	updatedProject, err := s.store.UpdateProjectStaticSite(ctx, projectID, site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update static site: %w", err)
+	}
+
+	if s.buildService != nil && updatedProject.DeploymentStatus == "deployed" {
+		s.buildService.Enqueue(updatedProject.ID, updatedProject.UserID)
+	}
+
+	return s.toPublic(updatedProject), nil
+}
+
+// DeployProject queues a build of the project's current branch. An image project
+// can be switched to another tag of its image with the same request.
+func (s *ProjectService) DeployProject(ctx context.Context, userID, projectID string, req *models.DeployProjectRequest) (*models.Project, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+
+	return s.deployTag(ctx, project, userID, req.Tag)
+}
+
+// TriggerDeployHook queues a deploy of a project for CI, authenticated by the
+// project's deploy hook token rather than a user session
+func (s *ProjectService) TriggerDeployHook(ctx context.Context, projectID, token string, req *models.DeployProjectRequest) (*models.Project, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	// An unknown project is reported like a bad token, so the hook can't be used to probe project IDs
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil || !checkDeployHookToken(project, token) {
+		return nil, errors.New("access denied: invalid deploy hook token")
+	}
+
+	return s.deployTag(ctx, project, project.UserID, req.Tag)
+}
+
+// deployTag points an image project at tag, when one is given, and queues a deploy
+func (s *ProjectService) deployTag(ctx context.Context, project *models.Project, userID, tag string) (*models.Project, error) {
+	if project.Status == "archived" {
+		return nil, errors.New("validation failed: project is archived, activate it first")
+	}
+
+	if tag != "" {
+		if !project.IsImage() {
+			return nil, errors.New("validation failed: tag is only supported for image projects")
+		}
+		if !models.IsImageTag(tag) {
+			return nil, fmt.Errorf("validation failed: invalid tag %q", tag)
+		}
+
+		var err error
+		project, err = s.store.UpdateProjectImageSource(ctx, project.ID, models.ImageWithTag(project.Image, tag), project.RegistryUsername, project.RegistryPassword, project.ContainerPort)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update image: %w", err)
+		}
+	}
+
 	if s.buildService != nil {
 		s.buildService.Enqueue(project.ID, userID)
 	}
 
-	return project.ToPublic(), nil
+	return s.toPublic(project), nil
+}
+
+// UpdateImageSource changes the image and registry credentials of an image project.
+// A deployed project is redeployed with the new image.
+func (s *ProjectService) UpdateImageSource(ctx context.Context, userID, projectID string, req *models.UpdateImageSourceRequest) (*models.Project, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+
+	if !project.IsImage() {
+		return nil, errors.New("validation failed: project is built from a repository, not an image")
+	}
+
+	// The stored password is kept when none is sent for the same registry user
+	password := req.RegistryPassword
+	if password == "" && req.RegistryUsername != "" && req.RegistryUsername == project.RegistryUsername {
+		password = project.RegistryPassword
+	}
+
+	if err := validateImageSource(req.Image, req.RegistryUsername, password); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	updatedProject, err := s.store.UpdateProjectImageSource(ctx, projectID, req.Image, req.RegistryUsername, password, req.ContainerPort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update image: %w", err)
+	}
+
+	if s.buildService != nil && updatedProject.DeploymentStatus == "deployed" {
+		s.buildService.Enqueue(updatedProject.ID, userID)
+	}
+
+	return s.toPublic(updatedProject), nil
+}
+
+// RegenerateDeployHook creates a new deploy hook token for a project, replacing
+// any previous one. The token is only returned here, just its hash is stored.
+func (s *ProjectService) RegenerateDeployHook(ctx context.Context, userID, projectID string) (*models.DeployHook, error) {
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+
+	token, hash, err := generateDeployHookToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate deploy hook: %w", err)
+	}
+
+	if _, err := s.store.UpdateProjectDeployHookToken(ctx, projectID, hash); err != nil {
+		return nil, fmt.Errorf("failed to update deploy hook: %w", err)
+	}
+
+	return &models.DeployHook{
+		URL:   s.publicURL + fmt.Sprintf(DEPLOY_HOOK_PATH, project.ID),
+		Token: token,
+	}, nil
+}
+
+// DisableDeployHook revokes a project's deploy hook token
+func (s *ProjectService) DisableDeployHook(ctx context.Context, userID, projectID string) (*models.Project, error) {
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+
+	updatedProject, err := s.store.UpdateProjectDeployHookToken(ctx, projectID, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to update deploy hook: %w", err)
+	}
+
+	return s.toPublic(updatedProject), nil
 }
 
 // GetProject retrieves a project by ID and verifies ownership
@@ -89,7 +752,7 @@ func (s *ProjectService) GetProject(ctx context.Context, userID, projectID strin
 		return nil, errors.New("access denied: project does not belong to user")
 	}
 
-	return project.ToPublic(), nil
+	return s.toPublic(project), nil
 }
 
 // GetProjectsByUser retrieves all projects for a user
@@ -106,7 +769,7 @@ func (s *ProjectService) GetProjectsByUser(ctx context.Context, userID string) (
 
 	publicProjects := make([]*models.Project, len(projects))
 	for i, project := range projects {
-		publicProjects[i] = project.ToPublic()
+		publicProjects[i] = s.toPublic(project)
 	}
 
 	return publicProjects, nil
@@ -126,7 +789,7 @@ func (s *ProjectService) GetActiveProjectsByUser(ctx context.Context, userID str
 
 	publicProjects := make([]*models.Project, len(projects))
 	for i, project := range projects {
-		publicProjects[i] = project.ToPublic()
+		publicProjects[i] = s.toPublic(project)
 	}
 
 	return publicProjects, nil
@@ -134,6 +797,7 @@ func (s *ProjectService) GetActiveProjectsByUser(ctx context.Context, userID str
 
 // UpdateProject updates a project
 func (s *ProjectService) UpdateProject(ctx context.Context, userID, projectID string, req *models.UpdateProjectRequest) (*models.Project, error) {
+	req.Domain = models.NormalizeDomain(req.Domain)
 	if err := s.validator.Struct(req); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
@@ -157,15 +821,40 @@ func (s *ProjectService) UpdateProject(ctx context.Context, userID, projectID st
 		}
 	}
 
+	// Archiving stops the app and activating starts it, which only their own endpoints do
+	if req.Status != "" && req.Status != project.Status && (req.Status == "archived" || project.Status == "archived") {
+		return nil, errors.New("validation failed: archive or activate the project to change whether it is archived")
+	}
+
+	if req.RepoBranch != "" && req.RepoBranch != project.RepoBranch {
+		if project.IsImage() {
+			return nil, errors.New("validation failed: image projects have no branch, update their image instead")
+		}
+		if _, err := s.resolveBranch(ctx, project, req.RepoBranch); err != nil {
+			return nil, err
+		}
+	}
+
+	// A new primary domain is claimed like any other and routed once verified
+	if req.Domain != "" && req.Domain != project.Domain {
+		existing, err := s.store.GetProjectDomainByDomain(ctx, req.Domain)
+		if err != nil || existing.ProjectID != project.ID {
+			if _, err := s.domains.ClaimDomain(ctx, project.ID, req.Domain); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	updatedProject, err := s.store.UpdateProject(ctx, projectID, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update project: %w", err)
 	}
 
-	return updatedProject.ToPublic(), nil
+	return s.toPublic(updatedProject), nil
 }
 
-// UpdateProjectStatus updates only the project status
+// UpdateProjectStatus updates only the project status. Archiving and activating go
+// through ArchiveProject and ActivateProject, so the app is stopped and started too.
 func (s *ProjectService) UpdateProjectStatus(ctx context.Context, userID, projectID, status string) (*models.Project, error) {
 	validStatuses := []string{"active", "inactive", "archived"}
 	isValid := false
@@ -188,12 +877,23 @@ func (s *ProjectService) UpdateProjectStatus(ctx context.Context, userID, projec
 		return nil, errors.New("access denied: project does not belong to user")
 	}
 
+	switch {
+	case status == project.Status:
+		return s.toPublic(project), nil
+	case status == "archived":
+		return s.ArchiveProject(ctx, userID, projectID)
+	case project.Status == "archived" && status == "active":
+		return s.ActivateProject(ctx, userID, projectID)
+	case project.Status == "archived":
+		return nil, errors.New("validation failed: project is archived, activate it first")
+	}
+
 	updatedProject, err := s.store.UpdateProjectStatus(ctx, projectID, status)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update project status: %w", err)
 	}
 
-	return updatedProject.ToPublic(), nil
+	return s.toPublic(updatedProject), nil
 }
 
 // ArchiveProject archives a project
@@ -207,12 +907,22 @@ func (s *ProjectService) ArchiveProject(ctx context.Context, userID, projectID s
 		return nil, errors.New("access denied: project does not belong to user")
 	}
 
+	// The app is stopped first, an archived project shouldn't keep serving traffic
+	if s.buildService != nil && project.DeploymentStatus == "deployed" {
+		err := s.stopApp(project)
+		if err != nil && !errors.Is(err, errStackNotDeployed) {
+			recordDeploymentEvent(s.store, models.NewDeploymentEvent(projectID, userID, models.DEPLOYMENT_ACTION_ARCHIVE, err))
+			return nil, fmt.Errorf("failed to stop project: %w", err)
+		}
+	}
+
 	archivedProject, err := s.store.ArchiveProject(ctx, projectID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to archive project: %w", err)
 	}
+	recordDeploymentEvent(s.store, models.NewDeploymentEvent(projectID, userID, models.DEPLOYMENT_ACTION_ARCHIVE, nil))
 
-	return archivedProject.ToPublic(), nil
+	return s.toPublic(archivedProject), nil
 }
 
 // ActivateProject activates a project
@@ -231,25 +941,164 @@ func (s *ProjectService) ActivateProject(ctx context.Context, userID, projectID
 		return nil, fmt.Errorf("failed to activate project: %w", err)
 	}
 
-	return activatedProject.ToPublic(), nil
+	// An app stopped by archiving comes back with the project, unless its owner had stopped it
+	var startErr error
+	if s.buildService != nil && project.Status == "archived" && project.DeploymentStatus == "deployed" && !project.Stopped {
+		startErr = s.startApp(ctx, project)
+		if errors.Is(startErr, errStackNotDeployed) {
+			startErr = nil
+		}
+	}
+	recordDeploymentEvent(s.store, models.NewDeploymentEvent(projectID, userID, models.DEPLOYMENT_ACTION_ACTIVATE, startErr))
+	if startErr != nil {
+		return nil, fmt.Errorf("failed to start project: %w", startErr)
+	}
+
+	return s.toPublic(activatedProject), nil
+}
+
+// StopProject scales a deployed project's app down to zero replicas
+func (s *ProjectService) StopProject(ctx context.Context, userID, projectID string) (*models.Project, error) {
+	return s.controlStack(ctx, userID, projectID, models.DEPLOYMENT_ACTION_STOP)
 }
 
-// DeleteProject deletes a project
-func (s *ProjectService) DeleteProject(ctx context.Context, userID, projectID string) error {
+// StartProject brings a stopped project's app back up
+func (s *ProjectService) StartProject(ctx context.Context, userID, projectID string) (*models.Project, error) {
+	return s.controlStack(ctx, userID, projectID, models.DEPLOYMENT_ACTION_START)
+}
+
+// RestartProject replaces the containers of a deployed project's app
+func (s *ProjectService) RestartProject(ctx context.Context, userID, projectID string) (*models.Project, error) {
+	return s.controlStack(ctx, userID, projectID, models.DEPLOYMENT_ACTION_RESTART)
+}
+
+// controlStack runs a runtime action on a project's stack and records it on the
+// deployment timeline, whether it succeeded or not
+func (s *ProjectService) controlStack(ctx context.Context, userID, projectID, action string) (*models.Project, error) {
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+
+	if s.buildService == nil || project.DeploymentStatus != "deployed" {
+		return nil, errStackNotDeployed
+	}
+	if project.Status == "archived" && action != models.DEPLOYMENT_ACTION_STOP {
+		return nil, errors.New("validation failed: project is archived, activate it first")
+	}
+
+	// The flag is saved first, so a redeploy racing the action already renders the
+	// new state. It is restored when the action fails.
+	stopped := action == models.DEPLOYMENT_ACTION_STOP
+	flagChanged := action != models.DEPLOYMENT_ACTION_RESTART && stopped != project.Stopped
+	if flagChanged {
+		if project, err = s.store.UpdateProjectStopped(ctx, projectID, stopped); err != nil {
+			return nil, fmt.Errorf("failed to update project: %w", err)
+		}
+	}
+
+	switch action {
+	case models.DEPLOYMENT_ACTION_STOP:
+		err = s.stopApp(project)
+	case models.DEPLOYMENT_ACTION_START:
+		err = s.startApp(ctx, project)
+	case models.DEPLOYMENT_ACTION_RESTART:
+		if project.StaticSite.Enabled {
+			// The static file server has nothing to restart per site, its config is applied again
+			if !project.Stopped {
+				err = s.buildService.StartStaticSite(ctx, project)
+			}
+		} else {
+			err = s.buildService.RestartStack(projectID)
+		}
+	}
+	if err != nil && flagChanged {
+		if _, restoreErr := s.store.UpdateProjectStopped(ctx, projectID, !stopped); restoreErr != nil {
+			log.Printf("⚠️  Failed to restore the stopped state of project %s: %v", projectID, restoreErr)
+		}
+	}
+	if errors.Is(err, errStackNotDeployed) {
+		return nil, err
+	}
+
+	recordDeploymentEvent(s.store, models.NewDeploymentEvent(projectID, userID, action, err))
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s project: %w", action, err)
+	}
+
+	return s.toPublic(project), nil
+}
+
+// stopApp takes a deployed project's app down. Static sites have no stack, they
+// are taken off the static file server instead.
+func (s *ProjectService) stopApp(project *models.Project) error {
+	if project.StaticSite.Enabled {
+		return s.buildService.StopStaticSite(project.ID)
+	}
+	return s.buildService.StopStack(project.ID)
+}
+
+// startApp brings a deployed project's app back up
+func (s *ProjectService) startApp(ctx context.Context, project *models.Project) error {
+	if project.StaticSite.Enabled {
+		return s.buildService.StartStaticSite(ctx, project)
+	}
+	return s.buildService.StartStack(project.ID)
+}
+
+// GetTimeline returns a page of a project's deployment timeline, newest first,
+// with the total number of entries
+func (s *ProjectService) GetTimeline(ctx context.Context, userID, projectID string, limit, offset int) ([]*models.DeploymentEvent, int64, error) {
 	project, err := s.store.GetProjectByID(ctx, projectID)
 	if err != nil {
-		return fmt.Errorf("project not found: %w", err)
+		return nil, 0, fmt.Errorf("project not found: %w", err)
 	}
 
 	if !project.IsOwnedBy(userID) {
-		return errors.New("access denied: project does not belong to user")
+		return nil, 0, errors.New("access denied: project does not belong to user")
+	}
+
+	events, err := s.store.GetDeploymentEventsByProjectID(ctx, projectID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get timeline: %w", err)
+	}
+
+	total, err := s.store.CountDeploymentEventsByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count timeline: %w", err)
+	}
+
+	return events, total, nil
+}
+
+// DeleteProject deletes a project and queues the teardown of what it left on the
+// host. Volumes are only removed when asked for.
+func (s *ProjectService) DeleteProject(ctx context.Context, userID, projectID string, removeVolumes bool) (*models.Teardown, error) {
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+
+	teardown, err := s.teardowns.Prepare(ctx, project, removeVolumes)
+	if err != nil {
+		return nil, err
 	}
 
 	if err := s.store.DeleteProject(ctx, projectID); err != nil {
-		return fmt.Errorf("failed to delete project: %w", err)
+		s.teardowns.Discard(context.Background(), teardown)
+		return nil, fmt.Errorf("failed to delete project: %w", err)
 	}
 
-	return nil
+	s.teardowns.Run(teardown)
+	return teardown, nil
 }
 
 // SearchProjectsByUser searches projects for a specific user
@@ -273,7 +1122,7 @@ func (s *ProjectService) SearchProjectsByUser(ctx context.Context, userID, query
 
 	publicProjects := make([]*models.Project, len(projects))
 	for i, project := range projects {
-		publicProjects[i] = project.ToPublic()
+		publicProjects[i] = s.toPublic(project)
 	}
 
 	return publicProjects, nil
@@ -316,7 +1165,7 @@ func (s *ProjectService) ListProjectsByUser(ctx context.Context, userID string,
 
 	publicProjects := make([]*models.Project, len(paginatedProjects))
 	for i, project := range paginatedProjects {
-		publicProjects[i] = project.ToPublic()
+		publicProjects[i] = s.toPublic(project)
 	}
 
 	return publicProjects, total, nil
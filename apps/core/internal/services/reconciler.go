@@ -0,0 +1,281 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store"
+)
+
+const (
+	RECONCILE_INTERVAL  = 30 * time.Second
+	RECONCILE_PAGE_SIZE = 100
+)
+
+// kovaStackPattern matches the stacks Kova deploys, named after a project ID with
+// an optional preview suffix. Other stacks on the swarm are left alone.
+var kovaStackPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}(-pr-\d+)?$`)
+
+// ReconcilerService keeps each project's runtime status in line with what the
+// swarm is actually running, since containers crash and stacks get removed behind
+// Kova's back
+type ReconcilerService struct {
+	store  store.Store
+	docker *DockerClient
+	wsHub  *WebSocketHub
+
+	mu       sync.RWMutex
+	replicas map[string][]models.ServiceReplicas // project ID -> replicas of its stack at the last pass
+	orphans  map[string]*models.OrphanedStack    // stack name -> orphan
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// ProjectRuntime is what the swarm was running for a project at the last pass
+type ProjectRuntime struct {
+	Status   string                   `json:"status"`
+	Services []models.ServiceReplicas `json:"services"`
+}
+
+func NewReconcilerService(store store.Store, docker *DockerClient, wsHub *WebSocketHub) *ReconcilerService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ReconcilerService{
+		store:    store,
+		docker:   docker,
+		wsHub:    wsHub,
+		replicas: make(map[string][]models.ServiceReplicas),
+		orphans:  make(map[string]*models.OrphanedStack),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Start runs the background reconciler
+func (s *ReconcilerService) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(RECONCILE_INTERVAL)
+		defer ticker.Stop()
+
+		for {
+			s.reconcile()
+
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	log.Println("🔁 Reconciler started")
+}
+
+// Shutdown stops the background reconciler
+func (s *ReconcilerService) Shutdown() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// GetProjectRuntime returns the runtime status of a project and the replicas of
+// its services
+func (s *ReconcilerService) GetProjectRuntime(ctx context.Context, userID, projectID string) (*ProjectRuntime, error) {
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if !project.IsOwnedBy(userID) {
+		return nil, errors.New("access denied: project does not belong to user")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	runtime := &ProjectRuntime{
+		Status:   project.RuntimeStatus,
+		Services: s.replicas[projectID],
+	}
+	if runtime.Services == nil {
+		runtime.Services = []models.ServiceReplicas{}
+	}
+	return runtime, nil
+}
+
+// GetOrphanedStacks returns the Kova stacks left running without a project
+func (s *ReconcilerService) GetOrphanedStacks() []*models.OrphanedStack {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	orphans := make([]*models.OrphanedStack, 0, len(s.orphans))
+	for _, orphan := range s.orphans {
+		orphans = append(orphans, orphan)
+	}
+	sort.Slice(orphans, func(i, j int) bool {
+		return orphans[i].StackName < orphans[j].StackName
+	})
+	return orphans
+}
+
+// reconcile compares every project with the swarm state and records the changes
+func (s *ReconcilerService) reconcile() {
+	stacks, err := s.inspectStacks()
+	if err != nil {
+		// Without the swarm state every project would look missing
+		log.Printf("⚠️  Failed to inspect swarm for reconciliation: %v", err)
+		return
+	}
+
+	replicas := make(map[string][]models.ServiceReplicas)
+	seen := make(map[string]bool)
+	for offset := 0; ; offset += RECONCILE_PAGE_SIZE {
+		projects, err := s.store.ListProjects(s.ctx, RECONCILE_PAGE_SIZE, offset)
+		if err != nil {
+			log.Printf("⚠️  Failed to list projects for reconciliation: %v", err)
+			return
+		}
+
+		for _, project := range projects {
+			seen[project.ID] = true
+
+			// Static sites are up when the shared static file server is
+			projectReplicas := stacks[project.ID]
+			if project.StaticSite.Enabled && len(projectReplicas) == 0 {
+				projectReplicas = stacks[STATIC_STACK_NAME]
+			}
+			replicas[project.ID] = projectReplicas
+
+			status := runtimeStatus(project, projectReplicas)
+			if status == project.RuntimeStatus {
+				continue
+			}
+
+			if err := s.store.UpdateProjectRuntimeStatus(s.ctx, project.ID, status); err != nil {
+				log.Printf("⚠️  Failed to update runtime status of project %s: %v", project.ID, err)
+				continue
+			}
+			log.Printf("🔁 Project %s is now %s (was %s)", project.ID, status, project.RuntimeStatus)
+			s.broadcastRuntimeStatus(project.ID, status, projectReplicas)
+		}
+
+		if len(projects) < RECONCILE_PAGE_SIZE {
+			break
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.replicas = replicas
+
+	orphans := make(map[string]*models.OrphanedStack)
+	for stackName, stackReplicas := range stacks {
+		if !kovaStackPattern.MatchString(stackName) || seen[projectIDForStack(stackName)] {
+			continue
+		}
+
+		// Orphans handed out earlier may still be read, so each pass builds new ones
+		orphan := &models.OrphanedStack{StackName: stackName, FirstSeen: time.Now()}
+		if previous, known := s.orphans[stackName]; known {
+			orphan.FirstSeen = previous.FirstSeen
+		} else {
+			log.Printf("⚠️  Stack %s has no matching project", stackName)
+		}
+		for _, service := range stackReplicas {
+			orphan.Services = append(orphan.Services, service.Service)
+		}
+		orphans[stackName] = orphan
+	}
+	s.orphans = orphans
+}
+
+// inspectStacks counts the running and desired replicas of every service, grouped by stack
+func (s *ReconcilerService) inspectStacks() (map[string][]models.ServiceReplicas, error) {
+	services, err := s.docker.listServices(s.ctx, STACK_NAMESPACE_LABEL)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceIDs := make([]string, len(services))
+	for i, service := range services {
+		serviceIDs[i] = service.ID
+	}
+
+	tasks, err := s.docker.listTasks(s.ctx, serviceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tasks the swarm wants running are the desired replicas, whatever their state
+	running := make(map[string]int)
+	desired := make(map[string]int)
+	for _, task := range tasks {
+		if task.DesiredState != "running" {
+			continue
+		}
+		desired[task.ServiceID]++
+		if task.Status.State == "running" {
+			running[task.ServiceID]++
+		}
+	}
+
+	stacks := make(map[string][]models.ServiceReplicas)
+	for _, service := range services {
+		stackName := service.Spec.Labels[STACK_NAMESPACE_LABEL]
+		stacks[stackName] = append(stacks[stackName], models.ServiceReplicas{
+			Service: service.Spec.Name,
+			Running: running[service.ID],
+			Desired: desired[service.ID],
+		})
+	}
+	return stacks, nil
+}
+
+func (s *ReconcilerService) broadcastRuntimeStatus(projectID, status string, replicas []models.ServiceReplicas) {
+	if s.wsHub == nil {
+		return
+	}
+	if replicas == nil {
+		replicas = []models.ServiceReplicas{}
+	}
+	s.wsHub.BroadcastToProject(projectID, map[string]interface{}{
+		"type":     "runtime_status",
+		"status":   status,
+		"services": replicas,
+	})
+}
+
+// runtimeStatus derives a project's runtime status from the replicas of its stack
+func runtimeStatus(project *models.Project, replicas []models.ServiceReplicas) string {
+	if len(replicas) == 0 {
+		if project.DeploymentStatus == "deployed" {
+			return models.RUNTIME_STATUS_MISSING
+		}
+		return models.RUNTIME_STATUS_STOPPED
+	}
+
+	running, desired := 0, 0
+	for _, service := range replicas {
+		running += service.Running
+		desired += service.Desired
+	}
+
+	switch {
+	case desired == 0:
+		return models.RUNTIME_STATUS_STOPPED
+	case running >= desired:
+		return models.RUNTIME_STATUS_RUNNING
+	default:
+		return models.RUNTIME_STATUS_DEGRADED
+	}
+}
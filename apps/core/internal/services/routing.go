@@ -0,0 +1,181 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dopeCape/kova/internal/models"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var headerNamePattern = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+// buildRoutingPolicy turns a policy update into the stored policy. Passwords are
+// hashed, and users sent without a password keep the hash stored for them.
+func buildRoutingPolicy(current models.RoutingPolicy, req *models.UpdateRoutingPolicyRequest) (models.RoutingPolicy, error) {
+	existingHashes := make(map[string]string, len(current.BasicAuth))
+	for _, user := range current.BasicAuth {
+		existingHashes[user.Username] = user.PasswordHash
+	}
+
+	policy := models.RoutingPolicy{
+		IPAllowList: req.IPAllowList,
+		Headers:     req.Headers,
+		RateLimit:   req.RateLimit,
+		Redirect:    req.Redirect,
+	}
+
+	seen := make(map[string]bool, len(req.BasicAuth))
+	for _, credentials := range req.BasicAuth {
+		if seen[credentials.Username] {
+			return models.RoutingPolicy{}, fmt.Errorf("duplicate basic auth user %q", credentials.Username)
+		}
+		seen[credentials.Username] = true
+
+		hash := existingHashes[credentials.Username]
+		if credentials.Password != "" {
+			hashed, err := bcrypt.GenerateFromPassword([]byte(credentials.Password), bcrypt.DefaultCost)
+			if err != nil {
+				return models.RoutingPolicy{}, fmt.Errorf("failed to hash password: %w", err)
+			}
+			hash = string(hashed)
+		}
+		if hash == "" {
+			return models.RoutingPolicy{}, fmt.Errorf("password is required for new basic auth user %q", credentials.Username)
+		}
+
+		policy.BasicAuth = append(policy.BasicAuth, models.BasicAuthUser{
+			Username:     credentials.Username,
+			PasswordHash: hash,
+		})
+	}
+
+	for name, value := range policy.Headers {
+		if !headerNamePattern.MatchString(name) {
+			return models.RoutingPolicy{}, fmt.Errorf("invalid header name %q", name)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return models.RoutingPolicy{}, fmt.Errorf("header %s must not contain line breaks", name)
+		}
+	}
+
+	if policy.RateLimit != nil && policy.RateLimit.Burst != 0 && policy.RateLimit.Burst < policy.RateLimit.Average {
+		return models.RoutingPolicy{}, errors.New("rate limit burst must be at least the average")
+	}
+
+	return policy, nil
+}
+
+// middlewareOption is a single setting of a Traefik middleware, like the
+// ratelimit.average of the middleware named <stack>-ratelimit
+type middlewareOption struct {
+	Middleware string
+	Key        string
+	Value      string
+}
+
+// routingMiddlewares renders a routing policy into Traefik middleware labels for a
+// stack, and returns them with the middleware chain its routers should use
+func routingMiddlewares(stackName string, policy models.RoutingPolicy, domains []string) ([]string, []string) {
+	options, chain := routingMiddlewareOptions(stackName, policy, domains)
+	labels := make([]string, len(options))
+	for i, option := range options {
+		labels[i] = composeLabel("traefik.http.middlewares."+option.Middleware+"."+option.Key, option.Value)
+	}
+	return labels, chain
+}
+
+// routingMiddlewareOptions renders a routing policy into the options of Traefik
+// middlewares, and returns them with the middleware chain routers should use
+func routingMiddlewareOptions(stackName string, policy models.RoutingPolicy, domains []string) ([]middlewareOption, []string) {
+	var options []middlewareOption
+	var chain []string
+	add := func(middleware string, settings ...string) {
+		name := stackName + "-" + middleware
+		for i := 0; i < len(settings); i += 2 {
+			options = append(options, middlewareOption{Middleware: name, Key: settings[i], Value: settings[i+1]})
+		}
+		chain = append(chain, name)
+	}
+
+	// Rejected clients are turned away before anything else runs
+	if len(policy.IPAllowList) > 0 {
+		add("ipallowlist", "ipallowlist.sourcerange", strings.Join(policy.IPAllowList, ","))
+	}
+
+	// Redirect before authenticating so credentials are asked for on the final host
+	switch policy.Redirect {
+	case models.REDIRECT_WWW_TO_APEX:
+		add("redirect",
+			"redirectregex.regex", `^(https?)://www\.(.*)$`,
+			"redirectregex.replacement", "${1}://${2}",
+			"redirectregex.permanent", "true")
+	case models.REDIRECT_APEX_TO_WWW:
+		if apexes := apexDomains(domains); len(apexes) > 0 {
+			add("redirect",
+				"redirectregex.regex", `^(https?)://(`+strings.Join(apexes, "|")+`)(.*)$`,
+				"redirectregex.replacement", "${1}://www.${2}${3}",
+				"redirectregex.permanent", "true")
+		}
+	}
+
+	if policy.RateLimit != nil {
+		burst := policy.RateLimit.Burst
+		if burst == 0 {
+			burst = policy.RateLimit.Average
+		}
+		add("ratelimit",
+			"ratelimit.average", strconv.Itoa(policy.RateLimit.Average),
+			"ratelimit.burst", strconv.Itoa(burst))
+	}
+
+	if len(policy.BasicAuth) > 0 {
+		users := make([]string, len(policy.BasicAuth))
+		for i, user := range policy.BasicAuth {
+			users[i] = user.Username + ":" + user.PasswordHash
+		}
+		add("basicauth", "basicauth.users", strings.Join(users, ","))
+	}
+
+	if len(policy.Headers) > 0 {
+		names := make([]string, 0, len(policy.Headers))
+		for name := range policy.Headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		settings := make([]string, 0, len(names)*2)
+		for _, name := range names {
+			settings = append(settings, "headers.customresponseheaders."+name, policy.Headers[name])
+		}
+		add("headers", settings...)
+	}
+
+	return options, chain
+}
+
+// apexDomains returns the quoted domains whose www subdomain is routed too
+func apexDomains(domains []string) []string {
+	routed := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		routed[domain] = true
+	}
+
+	var apexes []string
+	for _, domain := range domains {
+		if !strings.HasPrefix(domain, "www.") && routed["www."+domain] {
+			apexes = append(apexes, regexp.QuoteMeta(domain))
+		}
+	}
+	return apexes
+}
+
+// composeLabel renders a label as a quoted YAML string, escaping $ so docker
+// doesn't interpolate bcrypt hashes and regex replacements
+func composeLabel(key, value string) string {
+	return strconv.Quote(strings.ReplaceAll(key+"="+value, "$", "$$"))
+}
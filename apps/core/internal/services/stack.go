@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store"
+)
+
+var errStackNotDeployed = errors.New("project is not deployed")
+
+// StopStack scales every service of a stack down to zero replicas. The services
+// are kept, so the stack can be started again without a rebuild.
+func (bs *BuildService) StopStack(stackName string) error {
+	services, err := stackServices(stackName)
+	if err != nil {
+		return err
+	}
+
+	args := []string{"service", "scale", "--detach"}
+	for _, service := range services {
+		args = append(args, service+"=0")
+	}
+
+	log.Printf("⏹️  Stopping stack %s", stackName)
+	output, err := exec.Command("docker", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("docker service scale failed: %w, output: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// StartStack scales every service of a stack back to the replicas of its process.
+// The docker-compose file of a stopped project deploys it with zero replicas, so
+// the configured count is read from the services.
+func (bs *BuildService) StartStack(stackName string) error {
+	services, err := stackServices(stackName)
+	if err != nil {
+		return err
+	}
+
+	args := []string{"service", "scale", "--detach"}
+	for _, service := range services {
+		replicas, err := runDocker("service", "inspect", "--format", `{{index .Spec.Labels "`+PROCESS_REPLICAS_LABEL+`"}}`, service)
+		if err != nil {
+			return err
+		}
+		// Stacks deployed before the count was recorded define it in their docker-compose file
+		if replicas == "" || replicas == "<no value>" {
+			return bs.redeployStack(stackName)
+		}
+		args = append(args, service+"="+replicas)
+	}
+
+	log.Printf("▶️  Starting stack %s", stackName)
+	output, err := exec.Command("docker", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("docker service scale failed: %w, output: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// redeployStack deploys a stack again from its docker-compose file, which brings its
+// services back to the replicas it defines
+func (bs *BuildService) redeployStack(stackName string) error {
+	composePath := filepath.Join(SERVICES_BASE_PATH, stackName, "docker-compose.yml")
+	if _, err := os.Stat(composePath); os.IsNotExist(err) {
+		return errStackNotDeployed
+	}
+
+	log.Printf("▶️  Starting stack %s", stackName)
+	return bs.deployWithSwarm(deploySpec{StackName: stackName})
+}
+
+// RestartStack replaces the containers of every service of a stack, keeping the
+// image and configuration they run with
+func (bs *BuildService) RestartStack(stackName string) error {
+	services, err := stackServices(stackName)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("🔄 Restarting stack %s", stackName)
+	for _, service := range services {
+		output, err := exec.Command("docker", "service", "update", "--force", "--detach", service).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("docker service update of %s failed: %w, output: %s", service, err, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
+
+// stackServices returns the names of the services of a deployed stack
+func stackServices(stackName string) ([]string, error) {
+	output, err := exec.Command("docker", "stack", "services", stackName, "--format", "{{.Name}}").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("docker stack services failed: %w, output: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	// A missing stack isn't an error, the CLI only says nothing was found
+	var services []string
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.Contains(line, " ") {
+			continue
+		}
+		services = append(services, line)
+	}
+
+	if len(services) == 0 {
+		return nil, errStackNotDeployed
+	}
+	return services, nil
+}
+
+// waitForStackContainers waits until the containers of a removed stack have
+// stopped, so its images, volumes and networks are no longer in use
+func waitForStackContainers(ctx context.Context, stackName string, deadline time.Time) error {
+	for {
+		output, err := runDocker("ps", "--quiet", "--filter", "label="+STACK_NAMESPACE_LABEL+"="+stackName)
+		if err != nil {
+			return err
+		}
+		if output == "" {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("containers of stack %s did not stop in time", stackName)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(TEARDOWN_POLL_INTERVAL):
+		}
+	}
+}
+
+// recordDeploymentEvent adds an action to a project's timeline. The action has
+// already happened by then, so a failure to record it is only logged.
+func recordDeploymentEvent(store store.Store, event *models.DeploymentEvent) {
+	if err := store.CreateDeploymentEvent(context.Background(), event); err != nil {
+		log.Printf("⚠️  Failed to record %s of project %s: %v", event.Action, event.ProjectID, err)
+	}
+}
@@ -0,0 +1,504 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/dopeCape/kova/internal/models"
+)
+
+const (
+	STATIC_BASE_PATH    = "/data/kova/static" // Site files and server configs of the shared static file server
+	STATIC_STACK_NAME   = "kova-static"       // Also the Traefik service the sites are routed to
+	STATIC_SERVICE      = "web"
+	STATIC_SERVER_IMAGE = "nginx:1.27-alpine"
+	STATIC_APP_DIR      = "/app"       // Where railpack puts the app in the images it builds
+	STATIC_SITES_MOUNT  = "/srv/sites" // Where the server sees the sites directory
+)
+
+// staticOutputDirs are the usual build output directories of Vite, Astro, Create
+// React App, Next.js static exports and Hugo, tried in order when none is set
+var staticOutputDirs = []string{"dist", "build", "out", "public"}
+
+// staticPathPattern matches the paths allowed in static site settings, which end
+// up in the server's config
+var staticPathPattern = regexp.MustCompile(`^[A-Za-z0-9._/-]+$`)
+
+// buildStaticSite turns a static site update into the stored settings
+func buildStaticSite(req *models.UpdateStaticSiteRequest) (models.StaticSite, error) {
+	site := models.StaticSite{
+		Enabled:      req.Enabled,
+		OutputDir:    strings.Trim(req.OutputDir, "/"),
+		SPA:          req.SPA,
+		NotFoundPage: strings.TrimPrefix(req.NotFoundPage, "/"),
+	}
+
+	for name, value := range map[string]string{"output directory": site.OutputDir, "404 page": site.NotFoundPage} {
+		if value == "" {
+			continue
+		}
+		if !staticPathPattern.MatchString(value) || !filepath.IsLocal(value) {
+			return models.StaticSite{}, fmt.Errorf("invalid %s %q, use a relative path of letters, digits, dots, dashes and underscores", name, value)
+		}
+	}
+	return site, nil
+}
+
+// staticSiteDir returns the directory holding the deployments of a static site
+func staticSiteDir(projectID string) string {
+	return filepath.Join(STATIC_BASE_PATH, "sites", projectID)
+}
+
+// staticServerConfigPath returns the path of a server config of the static file server
+func staticServerConfigPath(name string) string {
+	return filepath.Join(STATIC_BASE_PATH, "conf.d", name+".conf")
+}
+
+// staticRoutesConfigPath returns the Traefik config routing a site's domains to the server
+func staticRoutesConfigPath(tlsOptions TLSOptions, projectID string) string {
+	return filepath.Join(tlsOptions.DynamicConfigDir, "static-"+projectID+".yml")
+}
+
+// deployStaticSite publishes the build output of a project's image on the shared
+// static file server and routes the project's domains to it. The previous
+// deployment keeps being served until the new one is in place.
+func (bs *BuildService) deployStaticSite(project *models.Project, deploymentID string, domains []string) error {
+	log.Printf("🔨 [6/7] Publishing static site...")
+	if err := bs.ensureStaticServer(); err != nil {
+		return fmt.Errorf("failed to start static file server: %w", err)
+	}
+
+	siteDir := staticSiteDir(project.ID)
+	outputDir, err := extractStaticSite(project.ID+":latest", project.StaticSite.OutputDir, filepath.Join(siteDir, deploymentID))
+	if err != nil {
+		return err
+	}
+	log.Printf("✅ Extracted %s from image %s", outputDir, project.ID)
+
+	site := project.StaticSite
+	if site.NotFoundPage != "" {
+		if _, err := os.Stat(filepath.Join(siteDir, deploymentID, site.NotFoundPage)); err != nil {
+			return fmt.Errorf("404 page %s not found in %s", site.NotFoundPage, outputDir)
+		}
+	}
+
+	// A stopped site is deployed but not served until it is started
+	if project.Stopped || project.Status == "archived" {
+		err = bs.StopStaticSite(project.ID)
+	} else {
+		err = bs.publishStaticSite(project, deploymentID, domains)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Swap done, earlier deployments are no longer served
+	entries, _ := os.ReadDir(siteDir)
+	for _, entry := range entries {
+		if entry.Name() != deploymentID {
+			os.RemoveAll(filepath.Join(siteDir, entry.Name()))
+		}
+	}
+
+	// A project that ran as a container before now has its domains routed here
+	if _, err := stackServices(project.ID); err == nil {
+		log.Printf("🧹 Removing stack %s, the project is now a static site", project.ID)
+		if _, err := runDocker("stack", "rm", project.ID); err != nil {
+			log.Printf("⚠️  Failed to remove stack %s: %v", project.ID, err)
+		}
+	}
+
+	log.Printf("✅ Static site published")
+	return nil
+}
+
+// publishStaticSite serves a deployment of a static site on its domains
+func (bs *BuildService) publishStaticSite(project *models.Project, deploymentID string, domains []string) error {
+	if len(domains) == 0 {
+		// Nothing to route, the files are kept for when a domain is verified
+		return bs.StopStaticSite(project.ID)
+	}
+
+	config, err := staticServerConfig(project.ID, deploymentID, project.StaticSite, domains)
+	if err != nil {
+		return err
+	}
+	if err := applyStaticServerConfig(project.ID, config); err != nil {
+		return err
+	}
+	return writeStaticRoutes(bs.tlsOptions, project, domains)
+}
+
+// StopStaticSite takes a static site off the static file server and its routes,
+// keeping its files so it can be started again without a rebuild
+func (bs *BuildService) StopStaticSite(projectID string) error {
+	if err := removeStaticRoutes(bs.tlsOptions, projectID); err != nil {
+		return err
+	}
+	return removeStaticServerConfig(projectID)
+}
+
+// StartStaticSite serves the deployed files of a static site again
+func (bs *BuildService) StartStaticSite(ctx context.Context, project *models.Project) error {
+	entries, err := os.ReadDir(staticSiteDir(project.ID))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read static site files: %w", err)
+	}
+
+	// Deployment IDs start with when they were built, the newest is served
+	deploymentID := ""
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() > deploymentID {
+			deploymentID = entry.Name()
+		}
+	}
+	if deploymentID == "" {
+		return errStackNotDeployed
+	}
+
+	domains, err := bs.verifiedDomains(ctx, project.ID)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("▶️  Serving static site %s", project.ID)
+	return bs.publishStaticSite(project, deploymentID, domains)
+}
+
+// extractStaticSite copies the build output directory out of an image into dest,
+// detecting the directory when none is configured, and returns the directory used
+func extractStaticSite(image, outputDir, dest string) (string, error) {
+	containerID, err := runDocker("create", image)
+	if err != nil {
+		return "", fmt.Errorf("failed to create container from %s: %w", image, err)
+	}
+	defer runDocker("rm", "--force", containerID)
+
+	candidates := staticOutputDirs
+	if outputDir != "" {
+		candidates = []string{outputDir}
+	}
+
+	// Copied next to dest and renamed, so a failed copy never looks like a deployment
+	partial := filepath.Join(filepath.Dir(dest), "."+filepath.Base(dest))
+	for _, candidate := range candidates {
+		os.RemoveAll(partial)
+		if err := os.MkdirAll(partial, 0755); err != nil {
+			return "", fmt.Errorf("failed to create site directory: %w", err)
+		}
+
+		source := path.Join(STATIC_APP_DIR, candidate)
+		if _, err := runDocker("cp", containerID+":"+source+"/.", partial); err != nil {
+			continue
+		}
+		if err := os.Rename(partial, dest); err != nil {
+			os.RemoveAll(partial)
+			return "", fmt.Errorf("failed to create site directory: %w", err)
+		}
+		return source, nil
+	}
+
+	os.RemoveAll(partial)
+	if outputDir != "" {
+		return "", fmt.Errorf("output directory %s not found in image %s", path.Join(STATIC_APP_DIR, outputDir), image)
+	}
+	return "", fmt.Errorf("no build output found in image %s, looked for %s; set the output directory of the static site",
+		image, strings.Join(staticOutputDirs, ", "))
+}
+
+// staticServerTemplate serves a site's files with a fallback to index.html for
+// SPAs. Bundlers put content hashes in the names of the files under their asset
+// directories, so those are cached for good, while everything else is revalidated.
+var staticServerTemplate = template.Must(template.New("static-site").Parse(`# Static site of project {{.ProjectID}}, written on each deployment
+server {
+    listen 80;
+    server_name{{range .Domains}} {{.}}{{end}};
+    root {{.Root}};
+    index index.html;
+    absolute_redirect off;
+
+    location ~ ^/(?:assets|_astro|_next/static|static)/ {
+        add_header Cache-Control "public, max-age=31536000, immutable";
+        try_files $uri =404;
+    }
+
+    location / {
+        add_header Cache-Control "no-cache";
+        try_files $uri $uri.html $uri/ {{if .SPA}}/index.html{{else}}=404{{end}};
+    }
+{{- if .NotFoundPage}}
+
+    error_page 404 /{{.NotFoundPage}};
+{{- end}}
+}
+`))
+
+// staticServerConfig renders the server config of a static site deployment
+func staticServerConfig(projectID, deploymentID string, site models.StaticSite, domains []string) (string, error) {
+	var config bytes.Buffer
+	err := staticServerTemplate.Execute(&config, map[string]any{
+		"ProjectID":    projectID,
+		"Domains":      domains,
+		"Root":         path.Join(STATIC_SITES_MOUNT, projectID, deploymentID),
+		"SPA":          site.SPA,
+		"NotFoundPage": site.NotFoundPage,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to render static site config: %w", err)
+	}
+	return config.String(), nil
+}
+
+// applyStaticServerConfig installs a site's server config and reloads the server,
+// putting the previous config back when the server rejects it
+func applyStaticServerConfig(projectID, config string) error {
+	configPath := staticServerConfigPath(projectID)
+	previous, readErr := os.ReadFile(configPath)
+
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		return fmt.Errorf("failed to write static site config: %w", err)
+	}
+
+	if err := reloadStaticServer(); err != nil {
+		if readErr == nil {
+			os.WriteFile(configPath, previous, 0644)
+		} else {
+			os.Remove(configPath)
+		}
+		return err
+	}
+	return nil
+}
+
+// reloadStaticServer checks the server's config and makes it reload it. A server
+// that isn't running yet reads the config when it starts.
+func reloadStaticServer() error {
+	output, err := runDocker("ps", "--quiet", "--filter", "label=com.docker.swarm.service.name="+STATIC_STACK_NAME+"_"+STATIC_SERVICE)
+	if err != nil {
+		return err
+	}
+	containers := strings.Fields(output)
+	if len(containers) == 0 {
+		return nil
+	}
+
+	if _, err := runDocker("exec", containers[0], "nginx", "-t"); err != nil {
+		return fmt.Errorf("static file server rejected the config: %w", err)
+	}
+	if _, err := runDocker("exec", containers[0], "nginx", "-s", "reload"); err != nil {
+		return fmt.Errorf("failed to reload static file server: %w", err)
+	}
+	return nil
+}
+
+// ensureStaticServer deploys the shared static file server unless it's running,
+// along with the Traefik service the routes of the sites point at
+func (bs *BuildService) ensureStaticServer() error {
+	for _, dir := range []string{filepath.Join(STATIC_BASE_PATH, "sites"), filepath.Join(STATIC_BASE_PATH, "conf.d")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create static file server directory: %w", err)
+		}
+	}
+
+	// Hosts no site serves get a plain 404 instead of the image's welcome page
+	defaultConfig := "server {\n    listen 80 default_server;\n    return 404;\n}\n"
+	if err := os.WriteFile(staticServerConfigPath("default"), []byte(defaultConfig), 0644); err != nil {
+		return fmt.Errorf("failed to write default config: %w", err)
+	}
+
+	routes, err := dynamicConfigYAML(map[string]any{
+		"http": map[string]any{
+			"services": map[string]any{
+				STATIC_STACK_NAME: map[string]any{
+					"loadBalancer": map[string]any{
+						"servers": []any{map[string]any{"url": "http://" + STATIC_STACK_NAME + "_" + STATIC_SERVICE + ":80"}},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if err := bs.tlsOptions.writeDynamicConfig(filepath.Join(bs.tlsOptions.DynamicConfigDir, STATIC_STACK_NAME+".yml"), routes); err != nil {
+		return fmt.Errorf("failed to write static file server route: %w", err)
+	}
+
+	if _, err := stackServices(STATIC_STACK_NAME); err == nil {
+		return nil
+	} else if !errors.Is(err, errStackNotDeployed) {
+		return err
+	}
+
+	log.Printf("🚀 Deploying static file server %s", STATIC_STACK_NAME)
+	if err := bs.ensureNetworkExists(); err != nil {
+		return fmt.Errorf("failed to ensure network exists: %w", err)
+	}
+
+	compose := fmt.Sprintf(`version: '3.8'
+services:
+  %s:
+    image: %s
+    volumes:
+      - %s:%s:ro
+      - %s:/etc/nginx/conf.d:ro
+    networks:
+      - proxy
+    deploy:
+      replicas: 1
+      restart_policy:
+        condition: any
+      labels:
+        - "traefik.enable=false"
+
+networks:
+  proxy:
+    external: true
+    name: %s
+`, STATIC_SERVICE, STATIC_SERVER_IMAGE,
+		filepath.Join(STATIC_BASE_PATH, "sites"), STATIC_SITES_MOUNT,
+		filepath.Join(STATIC_BASE_PATH, "conf.d"), NETWORK_NAME)
+
+	servicePath := filepath.Join(SERVICES_BASE_PATH, STATIC_STACK_NAME)
+	if err := os.MkdirAll(servicePath, 0755); err != nil {
+		return fmt.Errorf("failed to create service directory: %w", err)
+	}
+	composePath := filepath.Join(servicePath, "docker-compose.yml")
+	if err := os.WriteFile(composePath, []byte(compose), 0644); err != nil {
+		return fmt.Errorf("failed to write docker-compose file: %w", err)
+	}
+
+	if _, err := runDocker("stack", "deploy", "-c", composePath, STATIC_STACK_NAME); err != nil {
+		return err
+	}
+	return nil
+}
+
+// writeStaticRoutes routes a static site's domains to the shared static file
+// server through Traefik's file provider, with the same TLS settings and routing
+// policy middlewares a project's stack gets from its labels
+func writeStaticRoutes(tlsOptions TLSOptions, project *models.Project, domains []string) error {
+	spec := projectDeploySpec(project, domains, tlsOptions)
+	options, chain := routingMiddlewareOptions(spec.StackName, project.RoutingPolicy, spec.Domains)
+
+	middlewares := make(map[string]any)
+	for _, option := range options {
+		middleware, _ := middlewares[option.Middleware].(map[string]any)
+		if middleware == nil {
+			middleware = make(map[string]any)
+			middlewares[option.Middleware] = middleware
+		}
+		setDynamicConfigOption(middleware, strings.Split(option.Key, "."), option.Value)
+	}
+
+	httpChain := chain
+	if spec.ForceHTTPS {
+		redirect := spec.StackName + "-https-redirect"
+		middlewares[redirect] = map[string]any{
+			"redirectScheme": map[string]any{"scheme": "https", "permanent": true},
+		}
+		httpChain = []string{redirect}
+	}
+
+	router := func(entryPoint string, chain []string) map[string]any {
+		r := map[string]any{
+			"rule":        spec.HostRule(),
+			"entryPoints": []string{entryPoint},
+			"service":     STATIC_STACK_NAME,
+		}
+		if len(chain) > 0 {
+			r["middlewares"] = chain
+		}
+		return r
+	}
+
+	routers := map[string]any{spec.StackName: router("web", httpChain)}
+	if spec.TLS {
+		secure := router("websecure", chain)
+		secure["tls"] = map[string]any{"certResolver": spec.CertResolver}
+		routers[spec.StackName+"-secure"] = secure
+	}
+
+	httpConfig := map[string]any{"routers": routers}
+	if len(middlewares) > 0 {
+		httpConfig["middlewares"] = middlewares
+	}
+
+	config, err := dynamicConfigYAML(map[string]any{"http": httpConfig})
+	if err != nil {
+		return err
+	}
+	if err := tlsOptions.writeDynamicConfig(staticRoutesConfigPath(tlsOptions, project.ID), config); err != nil {
+		return fmt.Errorf("failed to write static site routes: %w", err)
+	}
+	return nil
+}
+
+// setDynamicConfigOption sets a dotted middleware option like
+// headers.customresponseheaders.X-Frame-Options in a nested config
+func setDynamicConfigOption(config map[string]any, keys []string, value string) {
+	for _, key := range keys[:len(keys)-1] {
+		next, _ := config[key].(map[string]any)
+		if next == nil {
+			next = make(map[string]any)
+			config[key] = next
+		}
+		config = next
+	}
+	config[keys[len(keys)-1]] = value
+}
+
+// dynamicConfigYAML renders a Traefik dynamic config. JSON is valid YAML, and
+// template actions are escaped since Traefik runs its files through text/template.
+func dynamicConfigYAML(config map[string]any) (string, error) {
+	encoded, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to render dynamic config: %w", err)
+	}
+	return strings.ReplaceAll(string(encoded), "{{", "{{`{{`}}") + "\n", nil
+}
+
+// removeStaticRoutes stops routing a static site's domains
+func removeStaticRoutes(tlsOptions TLSOptions, projectID string) error {
+	if err := os.Remove(staticRoutesConfigPath(tlsOptions, projectID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove static site routes: %w", err)
+	}
+	return nil
+}
+
+// removeStaticServerConfig stops the static file server from serving a site
+func removeStaticServerConfig(projectID string) error {
+	err := os.Remove(staticServerConfigPath(projectID))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove static site config: %w", err)
+	}
+	return reloadStaticServer()
+}
+
+// removeStaticSite takes a project's site off the static file server and deletes
+// its files. Projects that were never static sites have nothing to remove.
+func removeStaticSite(tlsOptions TLSOptions, projectID string) error {
+	if err := removeStaticRoutes(tlsOptions, projectID); err != nil {
+		return err
+	}
+	if err := removeStaticServerConfig(projectID); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(staticSiteDir(projectID)); err != nil {
+		return fmt.Errorf("failed to remove static site files: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,385 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store"
+	"github.com/dopeCape/kova/internal/store/postgres/repository"
+)
+
+const (
+	TEARDOWN_STOP_TIMEOUT  = 2 * time.Minute // How long removed stacks get to stop their containers
+	TEARDOWN_POLL_INTERVAL = 2 * time.Second
+	TEARDOWN_HISTORY_LIMIT = 50
+)
+
+// TeardownService removes what deleted projects leave on the host: stacks,
+// images, volumes, directories and repository webhooks. Teardowns are queued in
+// the database and run one at a time in the background, so they are resumed
+// after a restart.
+type TeardownService struct {
+	store      store.Store
+	providers  *GitProviders
+	appService *GitHubAppService
+	tlsOptions TLSOptions
+
+	wake   chan struct{} // Signals the worker that a teardown was queued
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewTeardownService(store store.Store, providers *GitProviders, appService *GitHubAppService, tlsOptions TLSOptions) *TeardownService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &TeardownService{
+		store:      store,
+		providers:  providers,
+		appService: appService,
+		tlsOptions: tlsOptions,
+		wake:       make(chan struct{}, 1),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Start runs the teardown worker, first resuming the teardowns a restart interrupted
+func (s *TeardownService) Start() {
+	s.settlePrepared()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		for {
+			s.processQueued()
+
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-s.wake:
+			}
+		}
+	}()
+	log.Println("🧹 Teardown worker started")
+}
+
+// settlePrepared resolves the teardowns a restart left prepared: the ones whose
+// project is gone are queued, the others belong to a deletion that never happened
+func (s *TeardownService) settlePrepared() {
+	unfinished, err := s.store.GetUnfinishedTeardowns(s.ctx)
+	if err != nil {
+		log.Printf("⚠️  Failed to load unfinished teardowns: %v", err)
+		return
+	}
+
+	for _, teardown := range unfinished {
+		if teardown.Status != models.TEARDOWN_STATUS_PREPARED {
+			continue
+		}
+		_, err := s.store.GetProjectByID(s.ctx, teardown.ProjectID)
+		if err == nil {
+			s.Discard(s.ctx, teardown)
+			continue
+		}
+		if !errors.Is(err, repository.ErrProjectNotFound) {
+			// Settled on the next start, once the project can be looked up
+			log.Printf("⚠️  Failed to look up project %s of a prepared teardown: %v", teardown.ProjectID, err)
+			continue
+		}
+		teardown.Status = models.TEARDOWN_STATUS_PENDING
+		s.save(teardown)
+	}
+}
+
+// processQueued runs every queued teardown, oldest first. Teardowns queued while
+// it runs wake the worker again.
+func (s *TeardownService) processQueued() {
+	unfinished, err := s.store.GetUnfinishedTeardowns(s.ctx)
+	if err != nil {
+		log.Printf("⚠️  Failed to load unfinished teardowns: %v", err)
+		return
+	}
+
+	for _, teardown := range unfinished {
+		if s.ctx.Err() != nil {
+			return
+		}
+		if teardown.Status == models.TEARDOWN_STATUS_PREPARED {
+			continue
+		}
+		if teardown.Status == models.TEARDOWN_STATUS_RUNNING {
+			log.Printf("🧹 Resuming teardown of project %s", teardown.ProjectID)
+		}
+		s.process(teardown)
+	}
+}
+
+// Shutdown stops the teardown worker once the current step is done. Teardowns
+// left unfinished are resumed on the next start.
+func (s *TeardownService) Shutdown() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// Prepare records the teardown of a project that is about to be deleted. It isn't
+// run until Run queues it, once the project is gone.
+func (s *TeardownService) Prepare(ctx context.Context, project *models.Project, removeVolumes bool) (*models.Teardown, error) {
+	teardown := models.NewTeardown(project, removeVolumes)
+	if err := s.store.CreateTeardown(ctx, teardown); err != nil {
+		return nil, fmt.Errorf("failed to create teardown: %w", err)
+	}
+	return teardown, nil
+}
+
+// Discard drops a prepared teardown whose project couldn't be deleted
+func (s *TeardownService) Discard(ctx context.Context, teardown *models.Teardown) {
+	if err := s.store.DeleteTeardown(ctx, teardown.ID); err != nil {
+		log.Printf("⚠️  Failed to discard teardown %s: %v", teardown.ID, err)
+	}
+}
+
+// Run queues a prepared teardown once its project has been deleted. It never
+// blocks, the worker picks the teardown up from the database.
+func (s *TeardownService) Run(teardown *models.Teardown) {
+	teardown.Status = models.TEARDOWN_STATUS_PENDING
+	s.save(teardown)
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+		// The worker is already woken up and will see this teardown
+	}
+	log.Printf("📦 Teardown enqueued for project: %s", teardown.ProjectID)
+}
+
+// GetTeardowns returns the latest teardowns of a user's projects, newest first
+func (s *TeardownService) GetTeardowns(ctx context.Context, userID string) ([]*models.Teardown, error) {
+	teardowns, err := s.store.GetTeardownsByUserID(ctx, userID, TEARDOWN_HISTORY_LIMIT)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teardowns: %w", err)
+	}
+	return teardowns, nil
+}
+
+// GetTeardown returns a teardown and the progress of its steps
+func (s *TeardownService) GetTeardown(ctx context.Context, userID, teardownID string) (*models.Teardown, error) {
+	teardown, err := s.store.GetTeardownByID(ctx, teardownID)
+	if err != nil {
+		return nil, fmt.Errorf("teardown not found: %w", err)
+	}
+
+	if teardown.UserID != userID {
+		return nil, errors.New("access denied: teardown does not belong to user")
+	}
+
+	return teardown, nil
+}
+
+// process runs the pending steps of a teardown, saving its progress after each one
+func (s *TeardownService) process(teardown *models.Teardown) {
+	log.Printf("🧹 Tearing down project %s (%s)", teardown.ProjectName, teardown.ProjectID)
+
+	teardown.Status = models.TEARDOWN_STATUS_RUNNING
+	s.save(teardown)
+
+	failed := false
+	for _, step := range teardown.Steps {
+		if step.Status == models.TEARDOWN_STEP_FAILED {
+			failed = true
+		}
+		if step.Status != models.TEARDOWN_STEP_PENDING {
+			continue
+		}
+		if s.ctx.Err() != nil {
+			return
+		}
+
+		err := s.runStep(teardown, step.Name)
+		if s.ctx.Err() != nil {
+			// Interrupted by shutdown, the step runs again on the next start
+			return
+		}
+		if err != nil {
+			log.Printf("⚠️  Teardown of project %s: %s failed: %v", teardown.ProjectID, step.Name, err)
+			step.Status = models.TEARDOWN_STEP_FAILED
+			step.Error = err.Error()
+			failed = true
+		} else {
+			step.Status = models.TEARDOWN_STEP_SUCCEEDED
+		}
+		s.save(teardown)
+	}
+
+	now := time.Now()
+	teardown.FinishedAt = &now
+	teardown.Status = models.TEARDOWN_STATUS_COMPLETED
+	if failed {
+		teardown.Status = models.TEARDOWN_STATUS_FAILED
+	}
+	s.save(teardown)
+
+	log.Printf("✅ Teardown of project %s %s", teardown.ProjectID, teardown.Status)
+}
+
+func (s *TeardownService) runStep(teardown *models.Teardown, name string) error {
+	switch name {
+	case models.TEARDOWN_STEP_STACKS:
+		return s.removeStacks(teardown.ProjectID)
+	case models.TEARDOWN_STEP_WEBHOOK:
+		return s.removeWebhook(teardown)
+	case models.TEARDOWN_STEP_IMAGES:
+		return removeProjectImages(teardown.ProjectID)
+	case models.TEARDOWN_STEP_VOLUMES:
+		return removeProjectVolumes(teardown.ProjectID)
+	case models.TEARDOWN_STEP_DIRECTORIES:
+		return s.removeDirectories(teardown.ProjectID)
+	default:
+		return fmt.Errorf("unknown teardown step %q", name)
+	}
+}
+
+// removeStacks removes the project's stack and its previews, and waits for their
+// containers to stop so their images and volumes are no longer in use
+func (s *TeardownService) removeStacks(projectID string) error {
+	output, err := runDocker("stack", "ls", "--format", "{{.Name}}")
+	if err != nil {
+		return err
+	}
+
+	var stacks []string
+	for _, stackName := range strings.Fields(output) {
+		if projectIDForStack(stackName) == projectID {
+			stacks = append(stacks, stackName)
+		}
+	}
+
+	for _, stackName := range stacks {
+		log.Printf("🧹 Removing stack: %s", stackName)
+		if _, err := runDocker("stack", "rm", stackName); err != nil {
+			return err
+		}
+	}
+
+	deadline := time.Now().Add(TEARDOWN_STOP_TIMEOUT)
+	for _, stackName := range stacks {
+		if err := waitForStackContainers(s.ctx, stackName, deadline); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeWebhook removes the repository webhook with the credentials the project
+// was bound to. They are resolved now, so a resumed teardown finds them too.
+func (s *TeardownService) removeWebhook(teardown *models.Teardown) error {
+	repository := teardown.Repository()
+
+	provider, err := s.providers.ForProject(repository)
+	if err != nil {
+		return err
+	}
+
+	token, err := resolveProjectToken(s.ctx, s.store, s.appService, repository)
+	if err != nil {
+		return fmt.Errorf("no repository credentials left to remove it with, delete webhook %d from %s by hand: %w", teardown.WebhookID, teardown.RepoFullName, err)
+	}
+
+	if err := provider.DeleteWebhook(s.ctx, token, teardown.RepoFullName, teardown.WebhookID); err != nil {
+		return fmt.Errorf("failed to delete repository webhook: %w", err)
+	}
+	return nil
+}
+
+// removeDirectories removes the cloned repositories and compose files of the
+// project and its previews, and its custom certificate
+func (s *TeardownService) removeDirectories(projectID string) error {
+	var errs []error
+	for _, basePath := range []string{REPO_BASE_PATH, SERVICES_BASE_PATH} {
+		previews, _ := filepath.Glob(filepath.Join(basePath, projectID+"-pr-*"))
+		for _, path := range append([]string{filepath.Join(basePath, projectID)}, previews...) {
+			if err := os.RemoveAll(path); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if err := s.tlsOptions.removeCertificateConfig(projectID); err != nil {
+		errs = append(errs, err)
+	}
+	if err := removeStaticSite(s.tlsOptions, projectID); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// removeProjectImages removes the images built for the project and its previews
+func removeProjectImages(projectID string) error {
+	output, err := runDocker("image", "ls", "--quiet", "--filter", "reference="+projectID, "--filter", "reference="+projectID+"-pr-*")
+	if err != nil {
+		return err
+	}
+
+	// An image tagged more than once is listed once per tag
+	seen := make(map[string]bool)
+	args := []string{"image", "rm", "--force"}
+	for _, imageID := range strings.Fields(output) {
+		if !seen[imageID] {
+			seen[imageID] = true
+			args = append(args, imageID)
+		}
+	}
+	if len(seen) == 0 {
+		return nil
+	}
+
+	_, err = runDocker(args...)
+	return err
+}
+
+// removeProjectVolumes removes the volumes created by the stacks of the project
+// and its previews
+func removeProjectVolumes(projectID string) error {
+	output, err := runDocker("volume", "ls", "--filter", "label="+STACK_NAMESPACE_LABEL, "--format", `{{.Name}} {{.Label "`+STACK_NAMESPACE_LABEL+`"}}`)
+	if err != nil {
+		return err
+	}
+
+	args := []string{"volume", "rm"}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && projectIDForStack(fields[1]) == projectID {
+			args = append(args, fields[0])
+		}
+	}
+	if len(args) == 2 {
+		return nil
+	}
+
+	_, err = runDocker(args...)
+	return err
+}
+
+// runDocker runs a docker CLI command and returns its trimmed output
+func runDocker(args ...string) (string, error) {
+	output, err := exec.Command("docker", args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("docker %s failed: %w, output: %s", strings.Join(args[:2], " "), err, strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+func (s *TeardownService) save(teardown *models.Teardown) {
+	if err := s.store.UpdateTeardownProgress(context.Background(), teardown); err != nil {
+		log.Printf("⚠️  Failed to save progress of teardown %s: %v", teardown.ID, err)
+	}
+}
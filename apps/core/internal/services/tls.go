@@ -0,0 +1,116 @@
+package services
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// TLSOptions configures HTTPS for deployed apps
+type TLSOptions struct {
+	Enabled          bool
+	CertResolver     string // Traefik ACME resolver requesting certificates for routed domains
+	DynamicConfigDir string // Directory watched by Traefik's file provider, holds custom certificates
+}
+
+// siteURL returns the URL a deployed domain is served at
+func (o TLSOptions) siteURL(domain string) string {
+	if o.Enabled {
+		return "https://" + domain
+	}
+	return "http://" + domain
+}
+
+// validateCertificate checks that a PEM certificate chain and private key belong
+// together, that the certificate hasn't expired and that it only covers domains in
+// verifiedDomains. Traefik serves custom certificates from a store shared by every
+// project, so a certificate for any other name would hijack that name's TLS.
+func validateCertificate(certificate, privateKey string, verifiedDomains []string) error {
+	pair, err := tls.X509KeyPair([]byte(certificate), []byte(privateKey))
+	if err != nil {
+		return fmt.Errorf("invalid certificate or private key: %w", err)
+	}
+
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("invalid certificate: %w", err)
+	}
+	if time.Now().After(leaf.NotAfter) {
+		return fmt.Errorf("certificate expired on %s", leaf.NotAfter.Format(time.RFC3339))
+	}
+	if len(leaf.DNSNames) == 0 {
+		return errors.New("certificate has no DNS names")
+	}
+
+	for _, name := range leaf.DNSNames {
+		if !slices.Contains(verifiedDomains, strings.ToLower(name)) {
+			return fmt.Errorf("certificate covers %s, which is not a verified domain of this project", name)
+		}
+		if err := leaf.VerifyHostname(name); err != nil {
+			return fmt.Errorf("certificate doesn't cover %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// certificateConfigPath returns the Traefik dynamic configuration file of a stack's certificate
+func (o TLSOptions) certificateConfigPath(stackName string) string {
+	return filepath.Join(o.DynamicConfigDir, stackName+".yml")
+}
+
+// writeCertificateConfig adds a custom certificate to Traefik's certificate store.
+// Traefik serves it for the names it covers and only asks the ACME resolver for the rest.
+func (o TLSOptions) writeCertificateConfig(stackName, certificate, privateKey string) error {
+	var config strings.Builder
+	config.WriteString("tls:\n  certificates:\n")
+	config.WriteString("    - certFile: |\n")
+	writeIndentedPEM(&config, certificate)
+	config.WriteString("      keyFile: |\n")
+	writeIndentedPEM(&config, privateKey)
+
+	if err := o.writeDynamicConfig(o.certificateConfigPath(stackName), config.String()); err != nil {
+		return fmt.Errorf("failed to write certificate config: %w", err)
+	}
+	return nil
+}
+
+// writeDynamicConfig writes a file for Traefik's file provider
+func (o TLSOptions) writeDynamicConfig(path, config string) error {
+	if err := os.MkdirAll(o.DynamicConfigDir, 0755); err != nil {
+		return fmt.Errorf("failed to create dynamic config directory: %w", err)
+	}
+
+	// Write then rename so Traefik's watcher never reads a partial file
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(config), 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// removeCertificateConfig drops a stack's custom certificate from Traefik
+func (o TLSOptions) removeCertificateConfig(stackName string) error {
+	if err := os.Remove(o.certificateConfigPath(stackName)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove certificate config: %w", err)
+	}
+	return nil
+}
+
+func writeIndentedPEM(b *strings.Builder, pem string) {
+	for _, line := range strings.Split(strings.TrimSpace(pem), "\n") {
+		b.WriteString("        ")
+		b.WriteString(strings.TrimRight(line, "\r"))
+		b.WriteString("\n")
+	}
+}
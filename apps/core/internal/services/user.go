@@ -15,12 +15,14 @@ import (
 type UserService struct {
 	store     store.Store
 	validator *validator.Validate
+	teardowns *TeardownService
 }
 
-func NewUserService(store store.Store) *UserService {
+func NewUserService(store store.Store, teardowns *TeardownService) *UserService {
 	return &UserService{
 		store:     store,
 		validator: validator.New(),
+		teardowns: teardowns,
 	}
 }
 
@@ -197,18 +199,47 @@ func (s *UserService) ChangePassword(ctx context.Context, userID string, req *mo
 }
 
 // DeleteUser soft deletes a user
-func (s *UserService) DeleteUser(ctx context.Context, userID string) error {
+func (s *UserService) DeleteUser(ctx context.Context, userID string, removeVolumes bool) ([]*models.Teardown, error) {
 	// Check if user exists
 	_, err := s.store.GetUserByID(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("user not found: %w", err)
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	// Projects are deleted with the user, so their teardowns are prepared first
+	teardowns := []*models.Teardown{}
+	if s.teardowns != nil {
+		projects, err := s.store.GetProjectsByUserID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get projects: %w", err)
+		}
+
+		for _, project := range projects {
+			teardown, err := s.teardowns.Prepare(ctx, project, removeVolumes)
+			if err != nil {
+				s.discardTeardowns(teardowns)
+				return nil, err
+			}
+			teardowns = append(teardowns, teardown)
+		}
 	}
 
 	if err := s.store.DeleteUser(ctx, userID); err != nil {
-		return fmt.Errorf("failed to delete user: %w", err)
+		s.discardTeardowns(teardowns)
+		return nil, fmt.Errorf("failed to delete user: %w", err)
 	}
 
-	return nil
+	for _, teardown := range teardowns {
+		s.teardowns.Run(teardown)
+	}
+
+	return teardowns, nil
+}
+
+func (s *UserService) discardTeardowns(teardowns []*models.Teardown) {
+	for _, teardown := range teardowns {
+		s.teardowns.Discard(context.Background(), teardown)
+	}
 }
 
 // ListUsers retrieves a paginated list of users
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/dopeCape/kova/internal/models"
+)
+
+// volumeNamePattern matches volume names, which become part of docker volume names
+var volumeNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// buildVolumes turns a volume update into the stored volumes
+func buildVolumes(req *models.UpdateVolumesRequest) ([]models.Volume, error) {
+	volumes := make([]models.Volume, 0, len(req.Volumes))
+	names := make(map[string]bool, len(req.Volumes))
+	paths := make(map[string]bool, len(req.Volumes))
+	for _, v := range req.Volumes {
+		if !volumeNamePattern.MatchString(v.Name) {
+			return nil, fmt.Errorf("invalid volume name %q, use lowercase letters, digits, dashes and underscores", v.Name)
+		}
+		if names[v.Name] {
+			return nil, fmt.Errorf("duplicate volume %q", v.Name)
+		}
+		names[v.Name] = true
+
+		mountPath := path.Clean(strings.TrimSpace(v.Path))
+		if !path.IsAbs(mountPath) || mountPath == "/" {
+			return nil, fmt.Errorf("volume %s must be mounted at an absolute path below /", v.Name)
+		}
+		if strings.ContainsAny(mountPath, ":,\"") {
+			return nil, fmt.Errorf("invalid mount path %q for volume %s", v.Path, v.Name)
+		}
+		if paths[mountPath] {
+			return nil, fmt.Errorf("more than one volume is mounted at %s", mountPath)
+		}
+		paths[mountPath] = true
+
+		volumes = append(volumes, models.Volume{Name: v.Name, Path: mountPath})
+	}
+	return volumes, nil
+}
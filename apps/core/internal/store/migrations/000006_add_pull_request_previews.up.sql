@@ -0,0 +1,39 @@
+ALTER TABLE projects
+ADD COLUMN previews_enabled BOOLEAN NOT NULL DEFAULT false,
+ADD COLUMN preview_env_variables JSONB NOT NULL DEFAULT '[]'::jsonb,
+ADD COLUMN webhook_id BIGINT;
+
+CREATE TABLE IF NOT EXISTS previews (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
+    project_id TEXT NOT NULL,
+    pr_number INTEGER NOT NULL,
+    pr_title TEXT,
+    head_branch VARCHAR(255) NOT NULL,
+    head_sha VARCHAR(64),
+    domain TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    comment_id BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_previews_project_id
+        FOREIGN KEY (project_id)
+        REFERENCES projects(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT unique_project_pr_number
+        UNIQUE(project_id, pr_number),
+
+    CONSTRAINT previews_pr_number_positive
+        CHECK (pr_number > 0),
+    CONSTRAINT previews_status_valid
+        CHECK (status IN ('pending', 'building', 'deploying', 'deployed', 'failed', 'closed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_previews_project_id ON previews(project_id);
+CREATE INDEX IF NOT EXISTS idx_previews_status ON previews(status);
+
+CREATE TRIGGER update_previews_updated_at
+    BEFORE UPDATE ON previews
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS github_installations (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
+    user_id TEXT NOT NULL,
+    installation_id BIGINT UNIQUE NOT NULL,
+    account_login VARCHAR(255) NOT NULL,
+    account_type VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_github_installations_user_id
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT github_installations_installation_id_positive
+        CHECK (installation_id > 0),
+    CONSTRAINT github_installations_account_type_valid
+        CHECK (account_type IN ('User', 'Organization'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_github_installations_user_id ON github_installations(user_id);
+
+CREATE TRIGGER update_github_installations_updated_at
+    BEFORE UPDATE ON github_installations
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Projects created from an app installation clone with installation tokens
+ALTER TABLE projects
+ADD COLUMN installation_id BIGINT;
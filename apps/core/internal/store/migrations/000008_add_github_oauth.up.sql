@@ -0,0 +1,23 @@
+ALTER TABLE accounts
+ADD COLUMN refresh_token TEXT,
+ADD COLUMN token_expires_at TIMESTAMP WITH TIME ZONE,
+ADD COLUMN refresh_token_expires_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_accounts_token_expires_at ON accounts(token_expires_at)
+    WHERE refresh_token IS NOT NULL;
+
+-- Pending OAuth authorizations, the state doubles as the CSRF token
+CREATE TABLE IF NOT EXISTS oauth_states (
+    state TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    redirect_to TEXT,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_oauth_states_user_id
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_oauth_states_expires_at ON oauth_states(expires_at);
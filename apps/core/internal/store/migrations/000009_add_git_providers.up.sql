@@ -0,0 +1,42 @@
+-- Accounts and projects can point at GitHub, GitLab or Gitea. provider_url is the
+-- web URL of the instance so self-hosted GitLab and Gitea servers can be linked.
+ALTER TABLE accounts
+ADD COLUMN provider VARCHAR(20) NOT NULL DEFAULT 'github',
+ADD COLUMN provider_url TEXT NOT NULL DEFAULT 'https://github.com';
+
+ALTER TABLE accounts
+ADD CONSTRAINT accounts_provider_valid
+    CHECK (provider IN ('github', 'gitlab', 'gitea')),
+ADD CONSTRAINT accounts_provider_url_format
+    CHECK (provider_url ~ '^https?://');
+
+-- User IDs are only unique within a single provider instance
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_github_id_key;
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS unique_user_github_account;
+ALTER TABLE accounts
+ADD CONSTRAINT unique_provider_account
+    UNIQUE(provider_url, github_id);
+
+-- GitLab usernames may contain dots and underscores, and its tokens are shorter than GitHub's
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_github_username_format;
+ALTER TABLE accounts
+ADD CONSTRAINT accounts_github_username_format
+    CHECK (github_username ~ '^[a-zA-Z0-9._-]+$');
+
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_access_token_length;
+ALTER TABLE accounts
+ADD CONSTRAINT accounts_access_token_length
+    CHECK (char_length(access_token) >= 20);
+
+ALTER TABLE projects
+ADD COLUMN provider VARCHAR(20) NOT NULL DEFAULT 'github',
+ADD COLUMN provider_url TEXT NOT NULL DEFAULT 'https://github.com';
+
+ALTER TABLE projects DROP CONSTRAINT IF EXISTS projects_repo_url_format;
+ALTER TABLE projects
+ADD CONSTRAINT projects_repo_url_format
+    CHECK (repo_url ~ '^https?://'),
+ADD CONSTRAINT projects_provider_valid
+    CHECK (provider IN ('github', 'gitlab', 'gitea'));
+
+CREATE INDEX IF NOT EXISTS idx_projects_provider_repo_id ON projects(provider_url, repo_id);
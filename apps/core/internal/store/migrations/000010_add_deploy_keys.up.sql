@@ -0,0 +1,22 @@
+-- Projects can be deployed from any git URL. Those projects have no linked account
+-- and are cloned over SSH with a per-project ed25519 deploy key.
+ALTER TABLE projects
+ADD COLUMN deploy_public_key TEXT,
+ADD COLUMN deploy_private_key TEXT;
+
+ALTER TABLE projects DROP CONSTRAINT IF EXISTS projects_provider_valid;
+ALTER TABLE projects
+ADD CONSTRAINT projects_provider_valid
+    CHECK (provider IN ('github', 'gitlab', 'gitea', 'git'));
+
+-- Accept ssh://, git:// and scp-style (git@host:owner/repo.git) URLs
+ALTER TABLE projects DROP CONSTRAINT IF EXISTS projects_repo_url_format;
+ALTER TABLE projects
+ADD CONSTRAINT projects_repo_url_format
+    CHECK (repo_url ~ '^(https?://|ssh://|git://|[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:)');
+
+-- Plain git repositories have no provider repository ID
+ALTER TABLE projects DROP CONSTRAINT IF EXISTS projects_repo_id_positive;
+ALTER TABLE projects
+ADD CONSTRAINT projects_repo_id_positive
+    CHECK (repo_id >= 0);
@@ -0,0 +1,18 @@
+-- Projects clone, analyze and report with one specific linked account. There is no
+-- foreign key, so unlinking an account leaves the ID behind and builds fail with a
+-- clear error instead of silently switching to another account.
+ALTER TABLE projects
+ADD COLUMN account_id TEXT;
+
+-- Bind existing projects to the account they have been cloning with: the owner's
+-- oldest account on the repository's provider instance
+UPDATE projects p
+SET account_id = (
+    SELECT a.id FROM accounts a
+    WHERE a.user_id = p.user_id AND a.provider_url = p.provider_url
+    ORDER BY a.created_at ASC
+    LIMIT 1
+)
+WHERE p.installation_id IS NULL AND p.provider <> 'git';
+
+CREATE INDEX IF NOT EXISTS idx_projects_account_id ON projects(account_id);
@@ -0,0 +1,44 @@
+-- Projects can serve several domains. A domain is only routed once its owner has
+-- proven control of it through DNS, and each domain can belong to one project only.
+CREATE TABLE IF NOT EXISTS project_domains (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
+    project_id TEXT NOT NULL,
+    domain VARCHAR(253) NOT NULL,
+    verification_token TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    verification_error TEXT,
+    verified_at TIMESTAMP WITH TIME ZONE,
+    last_checked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_project_domains_project_id
+        FOREIGN KEY (project_id)
+        REFERENCES projects(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT unique_project_domains_domain
+        UNIQUE(domain),
+
+    CONSTRAINT project_domains_domain_lowercase
+        CHECK (domain = lower(domain)),
+    CONSTRAINT project_domains_status_valid
+        CHECK (status IN ('pending', 'verified', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_domains_project_id ON project_domains(project_id);
+
+CREATE TRIGGER update_project_domains_updated_at
+    BEFORE UPDATE ON project_domains
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Existing domains are already routed, so they are carried over as verified. When
+-- several projects claimed the same domain only the oldest keeps it; the others
+-- stop being routed until the domain is released and verified again.
+INSERT INTO project_domains (project_id, domain, verification_token, status, verified_at)
+SELECT id, lower(domain), md5(random()::text || id), 'verified', CURRENT_TIMESTAMP
+FROM projects
+WHERE domain IS NOT NULL AND domain <> ''
+ORDER BY created_at ASC
+ON CONFLICT (domain) DO NOTHING;
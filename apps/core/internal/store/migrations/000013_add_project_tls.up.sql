@@ -0,0 +1,7 @@
+-- HTTPS settings of deployed apps. When the installation has TLS enabled, apps are
+-- served over HTTPS with a certificate from the ACME resolver, or with their own
+-- certificate when one is uploaded, and plain HTTP redirects unless force_https is off.
+ALTER TABLE projects
+ADD COLUMN force_https BOOLEAN NOT NULL DEFAULT true,
+ADD COLUMN tls_certificate TEXT,
+ADD COLUMN tls_private_key TEXT;
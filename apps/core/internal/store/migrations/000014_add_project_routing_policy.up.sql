@@ -0,0 +1,4 @@
+-- Per-project Traefik middlewares: basic auth (bcrypt hashed), IP allowlist,
+-- custom response headers, rate limiting and www/apex redirects
+ALTER TABLE projects
+ADD COLUMN routing_policy JSONB NOT NULL DEFAULT '{}'::jsonb;
@@ -0,0 +1,24 @@
+-- Resource usage of the swarm services of each project. Samples are stored per
+-- minute and rolled up into hourly buckets, each resolution with its own retention.
+CREATE TABLE IF NOT EXISTS project_metrics (
+    project_id TEXT NOT NULL,
+    service_name VARCHAR(255) NOT NULL,
+    resolution_seconds INTEGER NOT NULL,
+    bucket_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    cpu_percent DOUBLE PRECISION NOT NULL DEFAULT 0,
+    memory_bytes BIGINT NOT NULL DEFAULT 0,
+    memory_limit_bytes BIGINT NOT NULL DEFAULT 0,
+    network_rx_rate DOUBLE PRECISION NOT NULL DEFAULT 0,
+    network_tx_rate DOUBLE PRECISION NOT NULL DEFAULT 0,
+    restarts INTEGER NOT NULL DEFAULT 0,
+    samples INTEGER NOT NULL DEFAULT 1,
+
+    PRIMARY KEY (project_id, service_name, resolution_seconds, bucket_start),
+
+    CONSTRAINT fk_project_metrics_project_id
+        FOREIGN KEY (project_id)
+        REFERENCES projects(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_metrics_retention ON project_metrics(resolution_seconds, bucket_start);
@@ -0,0 +1,8 @@
+-- What is actually running for a project, kept by the reconciler from the swarm
+-- state. deployment_status only describes the last build.
+ALTER TABLE projects
+ADD COLUMN runtime_status VARCHAR(20) NOT NULL DEFAULT 'unknown';
+
+ALTER TABLE projects
+ADD CONSTRAINT projects_runtime_status_valid
+    CHECK (runtime_status IN ('unknown', 'running', 'degraded', 'stopped', 'missing'));
@@ -0,0 +1,29 @@
+-- Timeline of what happened to each project's app: deploys, and the start, stop,
+-- restart, archive and activate actions that change what is running
+CREATE TABLE IF NOT EXISTS deployment_events (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
+    project_id TEXT NOT NULL,
+    user_id TEXT,
+    action VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    deployment VARCHAR(64) NOT NULL DEFAULT '',
+    message TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_deployment_events_project_id
+        FOREIGN KEY (project_id)
+        REFERENCES projects(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_deployment_events_user_id
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT deployment_events_action_valid
+        CHECK (action IN ('deploy', 'start', 'stop', 'restart', 'archive', 'activate')),
+    CONSTRAINT deployment_events_status_valid
+        CHECK (status IN ('succeeded', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_deployment_events_project_id ON deployment_events(project_id, created_at DESC);
@@ -0,0 +1,56 @@
+-- Cron jobs run a command on a schedule in a one-off container from the
+-- project's current image. Each run is kept with its exit code and output.
+CREATE TABLE IF NOT EXISTS cron_jobs (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
+    project_id TEXT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    schedule VARCHAR(100) NOT NULL,
+    command TEXT NOT NULL,
+    timeout_seconds INTEGER NOT NULL DEFAULT 3600,
+    concurrency_policy VARCHAR(20) NOT NULL DEFAULT 'forbid',
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    last_scheduled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_cron_jobs_project_id
+        FOREIGN KEY (project_id)
+        REFERENCES projects(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT cron_jobs_project_name_unique UNIQUE (project_id, name),
+    CONSTRAINT cron_jobs_timeout_positive CHECK (timeout_seconds > 0),
+    CONSTRAINT cron_jobs_concurrency_policy_valid
+        CHECK (concurrency_policy IN ('allow', 'forbid', 'replace'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_cron_jobs_enabled ON cron_jobs(enabled) WHERE enabled = true;
+
+CREATE TABLE IF NOT EXISTS cron_job_runs (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
+    cron_job_id TEXT NOT NULL,
+    project_id TEXT NOT NULL,
+    trigger VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'running',
+    exit_code INTEGER,
+    logs TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT fk_cron_job_runs_cron_job_id
+        FOREIGN KEY (cron_job_id)
+        REFERENCES cron_jobs(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_cron_job_runs_project_id
+        FOREIGN KEY (project_id)
+        REFERENCES projects(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT cron_job_runs_trigger_valid
+        CHECK (trigger IN ('schedule', 'manual')),
+    CONSTRAINT cron_job_runs_status_valid
+        CHECK (status IN ('running', 'succeeded', 'failed', 'timed_out', 'skipped', 'canceled'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_cron_job_runs_cron_job_id ON cron_job_runs(cron_job_id, started_at DESC);
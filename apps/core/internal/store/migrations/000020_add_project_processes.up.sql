@@ -0,0 +1,4 @@
+-- Process types of a project, each run as its own swarm service from the same
+-- image. Empty falls back to the repository's Procfile, or a single web process.
+ALTER TABLE projects
+ADD COLUMN processes JSONB NOT NULL DEFAULT '[]'::jsonb;
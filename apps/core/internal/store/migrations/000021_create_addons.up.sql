@@ -0,0 +1,53 @@
+-- Managed database add-ons run as their own stacks with a persistent volume on a
+-- private network. Attached projects join the network and get its URL in their
+-- runtime environment.
+CREATE TABLE IF NOT EXISTS addons (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
+    user_id TEXT NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    version VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'provisioning',
+    error TEXT NOT NULL DEFAULT '',
+    username VARCHAR(63) NOT NULL,
+    password TEXT NOT NULL,
+    database_name VARCHAR(63) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_addons_user_id
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT addons_user_name_unique UNIQUE (user_id, name),
+    CONSTRAINT addons_type_valid
+        CHECK (type IN ('postgres', 'redis', 'mysql')),
+    CONSTRAINT addons_status_valid
+        CHECK (status IN ('provisioning', 'running', 'failed', 'deleting'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_addons_user_id ON addons(user_id);
+
+CREATE TABLE IF NOT EXISTS addon_attachments (
+    addon_id TEXT NOT NULL,
+    project_id TEXT NOT NULL,
+    env_var VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (addon_id, project_id),
+
+    CONSTRAINT fk_addon_attachments_addon_id
+        FOREIGN KEY (addon_id)
+        REFERENCES addons(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_addon_attachments_project_id
+        FOREIGN KEY (project_id)
+        REFERENCES projects(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT addon_attachments_project_env_var_unique UNIQUE (project_id, env_var)
+);
+
+CREATE INDEX IF NOT EXISTS idx_addon_attachments_project_id ON addon_attachments(project_id);
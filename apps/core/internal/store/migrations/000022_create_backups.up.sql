@@ -0,0 +1,80 @@
+-- Backup policies snapshot an add-on's database or a project volume on a
+-- schedule, to a local directory or an S3-compatible bucket. Every backup and
+-- restore is kept in the history.
+CREATE TABLE IF NOT EXISTS backup_policies (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
+    user_id TEXT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    target_type VARCHAR(20) NOT NULL,
+    addon_id TEXT,
+    project_id TEXT,
+    volume_name VARCHAR(255) NOT NULL DEFAULT '',
+    schedule VARCHAR(100) NOT NULL DEFAULT '',
+    retention INTEGER NOT NULL DEFAULT 7,
+    destination JSONB NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    last_scheduled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_backup_policies_user_id
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_backup_policies_addon_id
+        FOREIGN KEY (addon_id)
+        REFERENCES addons(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_backup_policies_project_id
+        FOREIGN KEY (project_id)
+        REFERENCES projects(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT backup_policies_user_name_unique UNIQUE (user_id, name),
+    CONSTRAINT backup_policies_retention_positive CHECK (retention > 0),
+    CONSTRAINT backup_policies_target_valid CHECK (
+        (target_type = 'addon' AND addon_id IS NOT NULL) OR
+        (target_type = 'volume' AND project_id IS NOT NULL AND volume_name <> '')
+    )
+);
+
+CREATE INDEX IF NOT EXISTS idx_backup_policies_user_id ON backup_policies(user_id);
+
+CREATE TABLE IF NOT EXISTS backups (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
+    policy_id TEXT NOT NULL,
+    operation VARCHAR(20) NOT NULL DEFAULT 'backup',
+    trigger VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'running',
+    object_key TEXT NOT NULL DEFAULT '',
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    restored_from TEXT,
+    error TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT fk_backups_policy_id
+        FOREIGN KEY (policy_id)
+        REFERENCES backup_policies(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_backups_restored_from
+        FOREIGN KEY (restored_from)
+        REFERENCES backups(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT backups_operation_valid
+        CHECK (operation IN ('backup', 'restore')),
+    CONSTRAINT backups_trigger_valid
+        CHECK (trigger IN ('schedule', 'manual')),
+    CONSTRAINT backups_status_valid
+        CHECK (status IN ('running', 'succeeded', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_backups_policy_id ON backups(policy_id, started_at DESC);
+
+-- Volume backups snapshot the named volumes a project mounts into its processes
+ALTER TABLE projects
+ADD COLUMN volumes JSONB NOT NULL DEFAULT '[]'::jsonb;
@@ -0,0 +1,4 @@
+-- Static site settings of a project. Static projects have their build output
+-- served by the shared static file server instead of running a container.
+ALTER TABLE projects
+ADD COLUMN static_site JSONB NOT NULL DEFAULT '{}'::jsonb;
@@ -0,0 +1,20 @@
+-- Projects can deploy a prebuilt image from a registry instead of cloning and
+-- building a repository. The registry credentials are only used to pull it.
+ALTER TABLE projects
+ADD COLUMN source_type VARCHAR(10) NOT NULL DEFAULT 'git',
+ADD COLUMN image TEXT,
+ADD COLUMN registry_username TEXT,
+ADD COLUMN registry_password TEXT,
+ADD COLUMN deploy_hook_token_hash TEXT;
+
+ALTER TABLE projects
+ADD CONSTRAINT projects_source_type_valid
+    CHECK (source_type IN ('git', 'image')),
+ADD CONSTRAINT projects_image_source_complete
+    CHECK (source_type = 'git' OR image IS NOT NULL);
+
+-- Image projects have no repository URL
+ALTER TABLE projects DROP CONSTRAINT IF EXISTS projects_repo_url_format;
+ALTER TABLE projects
+ADD CONSTRAINT projects_repo_url_format
+    CHECK (repo_url = '' OR repo_url ~ '^(https?://|ssh://|git://|[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:)');
@@ -0,0 +1,4 @@
+-- A suspended installation stays linked, GitHub only refuses its tokens until
+-- it is unsuspended
+ALTER TABLE github_installations
+ADD COLUMN suspended_at TIMESTAMP WITH TIME ZONE;
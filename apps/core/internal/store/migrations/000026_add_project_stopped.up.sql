@@ -0,0 +1,4 @@
+-- A project stopped by its owner stays scaled to zero across redeploys until it
+-- is started again
+ALTER TABLE projects
+ADD COLUMN stopped BOOLEAN NOT NULL DEFAULT FALSE;
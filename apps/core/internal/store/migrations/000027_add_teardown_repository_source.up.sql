@@ -0,0 +1,13 @@
+-- Teardowns keep where the deleted project's repository lives, so its webhook
+-- credentials can be resolved when the step runs, even after a restart
+ALTER TABLE teardowns
+ADD COLUMN provider VARCHAR(20) NOT NULL DEFAULT 'github',
+ADD COLUMN provider_url TEXT NOT NULL DEFAULT 'https://github.com',
+ADD COLUMN account_id TEXT NOT NULL DEFAULT '',
+ADD COLUMN installation_id BIGINT NOT NULL DEFAULT 0;
+
+-- A prepared teardown waits for its project to be deleted before it is queued
+ALTER TABLE teardowns DROP CONSTRAINT IF EXISTS teardowns_status_valid;
+ALTER TABLE teardowns
+ADD CONSTRAINT teardowns_status_valid
+    CHECK (status IN ('prepared', 'pending', 'running', 'completed', 'failed'));
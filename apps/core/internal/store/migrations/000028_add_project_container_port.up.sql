@@ -0,0 +1,8 @@
+-- The port image projects serve on inside their containers, which Traefik routes
+-- to. Empty falls back to the port the image exposes.
+ALTER TABLE projects
+ADD COLUMN container_port INTEGER;
+
+ALTER TABLE projects
+ADD CONSTRAINT projects_container_port_valid
+    CHECK (container_port IS NULL OR (container_port >= 1 AND container_port <= 65535));
@@ -89,17 +89,22 @@ func (q *Queries) CountAccountsByUserID(ctx context.Context, userID string) (int
 }
 
 const createAccount = `-- name: CreateAccount :one
-INSERT INTO accounts (user_id, github_username, github_id, avatar_url, access_token)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, user_id, github_username, github_id, avatar_url, created_at, updated_at
+INSERT INTO accounts (user_id, github_username, github_id, provider, provider_url, avatar_url, access_token, refresh_token, token_expires_at, refresh_token_expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id, user_id, github_username, github_id, provider, provider_url, avatar_url, created_at, updated_at
 `
 
 type CreateAccountParams struct {
-	UserID         string      `json:"user_id"`
-	GithubUsername string      `json:"github_username"`
-	GithubID       int64       `json:"github_id"`
-	AvatarUrl      pgtype.Text `json:"avatar_url"`
-	AccessToken    string      `json:"access_token"`
+	UserID                string             `json:"user_id"`
+	GithubUsername        string             `json:"github_username"`
+	GithubID              int64              `json:"github_id"`
+	Provider              string             `json:"provider"`
+	ProviderUrl           string             `json:"provider_url"`
+	AvatarUrl             pgtype.Text        `json:"avatar_url"`
+	AccessToken           string             `json:"access_token"`
+	RefreshToken          pgtype.Text        `json:"refresh_token"`
+	TokenExpiresAt        pgtype.Timestamptz `json:"token_expires_at"`
+	RefreshTokenExpiresAt pgtype.Timestamptz `json:"refresh_token_expires_at"`
 }
 
 type CreateAccountRow struct {
@@ -107,6 +112,8 @@ type CreateAccountRow struct {
 	UserID         string      `json:"user_id"`
 	GithubUsername string      `json:"github_username"`
 	GithubID       int64       `json:"github_id"`
+	Provider       string      `json:"provider"`
+	ProviderUrl    string      `json:"provider_url"`
 	AvatarUrl      pgtype.Text `json:"avatar_url"`
 	CreatedAt      time.Time   `json:"created_at"`
 	UpdatedAt      time.Time   `json:"updated_at"`
@@ -117,8 +124,13 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (C
 		arg.UserID,
 		arg.GithubUsername,
 		arg.GithubID,
+		arg.Provider,
+		arg.ProviderUrl,
 		arg.AvatarUrl,
 		arg.AccessToken,
+		arg.RefreshToken,
+		arg.TokenExpiresAt,
+		arg.RefreshTokenExpiresAt,
 	)
 	var i CreateAccountRow
 	err := row.Scan(
@@ -126,6 +138,8 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (C
 		&i.UserID,
 		&i.GithubUsername,
 		&i.GithubID,
+		&i.Provider,
+		&i.ProviderUrl,
 		&i.AvatarUrl,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -164,7 +178,7 @@ func (q *Queries) DeleteAccountsByUserID(ctx context.Context, userID string) err
 }
 
 const getAccountByGithubID = `-- name: GetAccountByGithubID :one
-SELECT id, user_id, github_username, github_id, avatar_url, access_token, created_at, updated_at
+SELECT id, user_id, github_username, github_id, provider, provider_url, avatar_url, access_token, refresh_token, token_expires_at, refresh_token_expires_at, created_at, updated_at
 FROM accounts
 WHERE github_id = $1
 `
@@ -177,8 +191,13 @@ func (q *Queries) GetAccountByGithubID(ctx context.Context, githubID int64) (Acc
 		&i.UserID,
 		&i.GithubUsername,
 		&i.GithubID,
+		&i.Provider,
+		&i.ProviderUrl,
 		&i.AvatarUrl,
 		&i.AccessToken,
+		&i.RefreshToken,
+		&i.TokenExpiresAt,
+		&i.RefreshTokenExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -186,7 +205,7 @@ func (q *Queries) GetAccountByGithubID(ctx context.Context, githubID int64) (Acc
 }
 
 const getAccountByGithubUsername = `-- name: GetAccountByGithubUsername :one
-SELECT id, user_id, github_username, github_id, avatar_url, access_token, created_at, updated_at
+SELECT id, user_id, github_username, github_id, provider, provider_url, avatar_url, access_token, refresh_token, token_expires_at, refresh_token_expires_at, created_at, updated_at
 FROM accounts
 WHERE github_username = $1
 `
@@ -199,8 +218,13 @@ func (q *Queries) GetAccountByGithubUsername(ctx context.Context, githubUsername
 		&i.UserID,
 		&i.GithubUsername,
 		&i.GithubID,
+		&i.Provider,
+		&i.ProviderUrl,
 		&i.AvatarUrl,
 		&i.AccessToken,
+		&i.RefreshToken,
+		&i.TokenExpiresAt,
+		&i.RefreshTokenExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -208,7 +232,7 @@ func (q *Queries) GetAccountByGithubUsername(ctx context.Context, githubUsername
 }
 
 const getAccountByID = `-- name: GetAccountByID :one
-SELECT id, user_id, github_username, github_id, avatar_url, access_token, created_at, updated_at
+SELECT id, user_id, github_username, github_id, provider, provider_url, avatar_url, access_token, refresh_token, token_expires_at, refresh_token_expires_at, created_at, updated_at
 FROM accounts
 WHERE id = $1
 `
@@ -221,8 +245,45 @@ func (q *Queries) GetAccountByID(ctx context.Context, id string) (Account, error
 		&i.UserID,
 		&i.GithubUsername,
 		&i.GithubID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.AvatarUrl,
+		&i.AccessToken,
+		&i.RefreshToken,
+		&i.TokenExpiresAt,
+		&i.RefreshTokenExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getAccountByProviderID = `-- name: GetAccountByProviderID :one
+SELECT id, user_id, github_username, github_id, provider, provider_url, avatar_url, access_token, refresh_token, token_expires_at, refresh_token_expires_at, created_at, updated_at
+FROM accounts
+WHERE provider_url = $1 AND github_id = $2
+`
+
+type GetAccountByProviderIDParams struct {
+	ProviderUrl string `json:"provider_url"`
+	GithubID    int64  `json:"github_id"`
+}
+
+func (q *Queries) GetAccountByProviderID(ctx context.Context, arg GetAccountByProviderIDParams) (Account, error) {
+	row := q.db.QueryRow(ctx, getAccountByProviderID, arg.ProviderUrl, arg.GithubID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.GithubUsername,
+		&i.GithubID,
+		&i.Provider,
+		&i.ProviderUrl,
 		&i.AvatarUrl,
 		&i.AccessToken,
+		&i.RefreshToken,
+		&i.TokenExpiresAt,
+		&i.RefreshTokenExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -235,6 +296,8 @@ SELECT
     a.user_id,
     a.github_username,
     a.github_id,
+    a.provider,
+    a.provider_url,
     a.avatar_url,
     a.access_token,
     a.created_at,
@@ -251,6 +314,8 @@ type GetAccountWithUserRow struct {
 	UserID         string      `json:"user_id"`
 	GithubUsername string      `json:"github_username"`
 	GithubID       int64       `json:"github_id"`
+	Provider       string      `json:"provider"`
+	ProviderUrl    string      `json:"provider_url"`
 	AvatarUrl      pgtype.Text `json:"avatar_url"`
 	AccessToken    string      `json:"access_token"`
 	CreatedAt      time.Time   `json:"created_at"`
@@ -267,6 +332,8 @@ func (q *Queries) GetAccountWithUser(ctx context.Context, id string) (GetAccount
 		&i.UserID,
 		&i.GithubUsername,
 		&i.GithubID,
+		&i.Provider,
+		&i.ProviderUrl,
 		&i.AvatarUrl,
 		&i.AccessToken,
 		&i.CreatedAt,
@@ -278,7 +345,7 @@ func (q *Queries) GetAccountWithUser(ctx context.Context, id string) (GetAccount
 }
 
 const getAccountsByUserID = `-- name: GetAccountsByUserID :many
-SELECT id, user_id, github_username, github_id, avatar_url, created_at, updated_at
+SELECT id, user_id, github_username, github_id, provider, provider_url, avatar_url, created_at, updated_at
 FROM accounts
 WHERE user_id = $1
 ORDER BY created_at DESC
@@ -289,6 +356,8 @@ type GetAccountsByUserIDRow struct {
 	UserID         string      `json:"user_id"`
 	GithubUsername string      `json:"github_username"`
 	GithubID       int64       `json:"github_id"`
+	Provider       string      `json:"provider"`
+	ProviderUrl    string      `json:"provider_url"`
 	AvatarUrl      pgtype.Text `json:"avatar_url"`
 	CreatedAt      time.Time   `json:"created_at"`
 	UpdatedAt      time.Time   `json:"updated_at"`
@@ -308,6 +377,8 @@ func (q *Queries) GetAccountsByUserID(ctx context.Context, userID string) ([]Get
 			&i.UserID,
 			&i.GithubUsername,
 			&i.GithubID,
+			&i.Provider,
+			&i.ProviderUrl,
 			&i.AvatarUrl,
 			&i.CreatedAt,
 			&i.UpdatedAt,
@@ -323,7 +394,7 @@ func (q *Queries) GetAccountsByUserID(ctx context.Context, userID string) ([]Get
 }
 
 const getAccountsByUserIDWithTokens = `-- name: GetAccountsByUserIDWithTokens :many
-SELECT id, user_id, github_username, github_id, avatar_url, access_token, created_at, updated_at
+SELECT id, user_id, github_username, github_id, provider, provider_url, avatar_url, access_token, refresh_token, token_expires_at, refresh_token_expires_at, created_at, updated_at
 FROM accounts
 WHERE user_id = $1
 ORDER BY created_at DESC
@@ -343,8 +414,54 @@ func (q *Queries) GetAccountsByUserIDWithTokens(ctx context.Context, userID stri
 			&i.UserID,
 			&i.GithubUsername,
 			&i.GithubID,
+			&i.Provider,
+			&i.ProviderUrl,
 			&i.AvatarUrl,
 			&i.AccessToken,
+			&i.RefreshToken,
+			&i.TokenExpiresAt,
+			&i.RefreshTokenExpiresAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAccountsWithExpiringTokens = `-- name: GetAccountsWithExpiringTokens :many
+SELECT id, user_id, github_username, github_id, provider, provider_url, avatar_url, access_token, refresh_token, token_expires_at, refresh_token_expires_at, created_at, updated_at
+FROM accounts
+WHERE refresh_token IS NOT NULL AND token_expires_at < $1
+ORDER BY token_expires_at ASC
+`
+
+func (q *Queries) GetAccountsWithExpiringTokens(ctx context.Context, tokenExpiresAt pgtype.Timestamptz) ([]Account, error) {
+	rows, err := q.db.Query(ctx, getAccountsWithExpiringTokens, tokenExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.GithubUsername,
+			&i.GithubID,
+			&i.Provider,
+			&i.ProviderUrl,
+			&i.AvatarUrl,
+			&i.AccessToken,
+			&i.RefreshToken,
+			&i.TokenExpiresAt,
+			&i.RefreshTokenExpiresAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -359,7 +476,7 @@ func (q *Queries) GetAccountsByUserIDWithTokens(ctx context.Context, userID stri
 }
 
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, user_id, github_username, github_id, avatar_url, created_at, updated_at
+SELECT id, user_id, github_username, github_id, provider, provider_url, avatar_url, created_at, updated_at
 FROM accounts
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
@@ -375,6 +492,8 @@ type ListAccountsRow struct {
 	UserID         string      `json:"user_id"`
 	GithubUsername string      `json:"github_username"`
 	GithubID       int64       `json:"github_id"`
+	Provider       string      `json:"provider"`
+	ProviderUrl    string      `json:"provider_url"`
 	AvatarUrl      pgtype.Text `json:"avatar_url"`
 	CreatedAt      time.Time   `json:"created_at"`
 	UpdatedAt      time.Time   `json:"updated_at"`
@@ -394,6 +513,8 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]L
 			&i.UserID,
 			&i.GithubUsername,
 			&i.GithubID,
+			&i.Provider,
+			&i.ProviderUrl,
 			&i.AvatarUrl,
 			&i.CreatedAt,
 			&i.UpdatedAt,
@@ -409,7 +530,7 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]L
 }
 
 const searchAccounts = `-- name: SearchAccounts :many
-SELECT id, user_id, github_username, github_id, avatar_url, created_at, updated_at
+SELECT id, user_id, github_username, github_id, provider, provider_url, avatar_url, created_at, updated_at
 FROM accounts
 WHERE github_username ILIKE '%' || $1 || '%'
 ORDER BY created_at DESC
@@ -427,6 +548,8 @@ type SearchAccountsRow struct {
 	UserID         string      `json:"user_id"`
 	GithubUsername string      `json:"github_username"`
 	GithubID       int64       `json:"github_id"`
+	Provider       string      `json:"provider"`
+	ProviderUrl    string      `json:"provider_url"`
 	AvatarUrl      pgtype.Text `json:"avatar_url"`
 	CreatedAt      time.Time   `json:"created_at"`
 	UpdatedAt      time.Time   `json:"updated_at"`
@@ -446,6 +569,8 @@ func (q *Queries) SearchAccounts(ctx context.Context, arg SearchAccountsParams)
 			&i.UserID,
 			&i.GithubUsername,
 			&i.GithubID,
+			&i.Provider,
+			&i.ProviderUrl,
 			&i.AvatarUrl,
 			&i.CreatedAt,
 			&i.UpdatedAt,
@@ -461,7 +586,7 @@ func (q *Queries) SearchAccounts(ctx context.Context, arg SearchAccountsParams)
 }
 
 const searchAccountsByUserID = `-- name: SearchAccountsByUserID :many
-SELECT id, user_id, github_username, github_id, avatar_url, created_at, updated_at
+SELECT id, user_id, github_username, github_id, provider, provider_url, avatar_url, created_at, updated_at
 FROM accounts
 WHERE user_id = $1 AND github_username ILIKE '%' || $2 || '%'
 ORDER BY created_at DESC
@@ -480,6 +605,8 @@ type SearchAccountsByUserIDRow struct {
 	UserID         string      `json:"user_id"`
 	GithubUsername string      `json:"github_username"`
 	GithubID       int64       `json:"github_id"`
+	Provider       string      `json:"provider"`
+	ProviderUrl    string      `json:"provider_url"`
 	AvatarUrl      pgtype.Text `json:"avatar_url"`
 	CreatedAt      time.Time   `json:"created_at"`
 	UpdatedAt      time.Time   `json:"updated_at"`
@@ -504,6 +631,8 @@ func (q *Queries) SearchAccountsByUserID(ctx context.Context, arg SearchAccounts
 			&i.UserID,
 			&i.GithubUsername,
 			&i.GithubID,
+			&i.Provider,
+			&i.ProviderUrl,
 			&i.AvatarUrl,
 			&i.CreatedAt,
 			&i.UpdatedAt,
@@ -522,7 +651,7 @@ const updateAccount = `-- name: UpdateAccount :one
 UPDATE accounts
 SET github_username = $2, avatar_url = $3, updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, user_id, github_username, github_id, avatar_url, created_at, updated_at
+RETURNING id, user_id, github_username, github_id, provider, provider_url, avatar_url, created_at, updated_at
 `
 
 type UpdateAccountParams struct {
@@ -536,6 +665,8 @@ type UpdateAccountRow struct {
 	UserID         string      `json:"user_id"`
 	GithubUsername string      `json:"github_username"`
 	GithubID       int64       `json:"github_id"`
+	Provider       string      `json:"provider"`
+	ProviderUrl    string      `json:"provider_url"`
 	AvatarUrl      pgtype.Text `json:"avatar_url"`
 	CreatedAt      time.Time   `json:"created_at"`
 	UpdatedAt      time.Time   `json:"updated_at"`
@@ -549,6 +680,8 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (U
 		&i.UserID,
 		&i.GithubUsername,
 		&i.GithubID,
+		&i.Provider,
+		&i.ProviderUrl,
 		&i.AvatarUrl,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -560,7 +693,7 @@ const updateAccountByGithubID = `-- name: UpdateAccountByGithubID :one
 UPDATE accounts
 SET github_username = $2, avatar_url = $3, access_token = $4, updated_at = CURRENT_TIMESTAMP
 WHERE github_id = $1
-RETURNING id, user_id, github_username, github_id, avatar_url, created_at, updated_at
+RETURNING id, user_id, github_username, github_id, provider, provider_url, avatar_url, created_at, updated_at
 `
 
 type UpdateAccountByGithubIDParams struct {
@@ -575,6 +708,8 @@ type UpdateAccountByGithubIDRow struct {
 	UserID         string      `json:"user_id"`
 	GithubUsername string      `json:"github_username"`
 	GithubID       int64       `json:"github_id"`
+	Provider       string      `json:"provider"`
+	ProviderUrl    string      `json:"provider_url"`
 	AvatarUrl      pgtype.Text `json:"avatar_url"`
 	CreatedAt      time.Time   `json:"created_at"`
 	UpdatedAt      time.Time   `json:"updated_at"`
@@ -593,6 +728,58 @@ func (q *Queries) UpdateAccountByGithubID(ctx context.Context, arg UpdateAccount
 		&i.UserID,
 		&i.GithubUsername,
 		&i.GithubID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.AvatarUrl,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateAccountOAuthTokens = `-- name: UpdateAccountOAuthTokens :one
+UPDATE accounts
+SET access_token = $2, refresh_token = $3, token_expires_at = $4, refresh_token_expires_at = $5, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, user_id, github_username, github_id, provider, provider_url, avatar_url, created_at, updated_at
+`
+
+type UpdateAccountOAuthTokensParams struct {
+	ID                    string             `json:"id"`
+	AccessToken           string             `json:"access_token"`
+	RefreshToken          pgtype.Text        `json:"refresh_token"`
+	TokenExpiresAt        pgtype.Timestamptz `json:"token_expires_at"`
+	RefreshTokenExpiresAt pgtype.Timestamptz `json:"refresh_token_expires_at"`
+}
+
+type UpdateAccountOAuthTokensRow struct {
+	ID             string      `json:"id"`
+	UserID         string      `json:"user_id"`
+	GithubUsername string      `json:"github_username"`
+	GithubID       int64       `json:"github_id"`
+	Provider       string      `json:"provider"`
+	ProviderUrl    string      `json:"provider_url"`
+	AvatarUrl      pgtype.Text `json:"avatar_url"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+func (q *Queries) UpdateAccountOAuthTokens(ctx context.Context, arg UpdateAccountOAuthTokensParams) (UpdateAccountOAuthTokensRow, error) {
+	row := q.db.QueryRow(ctx, updateAccountOAuthTokens,
+		arg.ID,
+		arg.AccessToken,
+		arg.RefreshToken,
+		arg.TokenExpiresAt,
+		arg.RefreshTokenExpiresAt,
+	)
+	var i UpdateAccountOAuthTokensRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.GithubUsername,
+		&i.GithubID,
+		&i.Provider,
+		&i.ProviderUrl,
 		&i.AvatarUrl,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -604,7 +791,7 @@ const updateAccountToken = `-- name: UpdateAccountToken :one
 UPDATE accounts
 SET access_token = $2, updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, user_id, github_username, github_id, avatar_url, created_at, updated_at
+RETURNING id, user_id, github_username, github_id, provider, provider_url, avatar_url, created_at, updated_at
 `
 
 type UpdateAccountTokenParams struct {
@@ -617,6 +804,8 @@ type UpdateAccountTokenRow struct {
 	UserID         string      `json:"user_id"`
 	GithubUsername string      `json:"github_username"`
 	GithubID       int64       `json:"github_id"`
+	Provider       string      `json:"provider"`
+	ProviderUrl    string      `json:"provider_url"`
 	AvatarUrl      pgtype.Text `json:"avatar_url"`
 	CreatedAt      time.Time   `json:"created_at"`
 	UpdatedAt      time.Time   `json:"updated_at"`
@@ -630,6 +819,8 @@ func (q *Queries) UpdateAccountToken(ctx context.Context, arg UpdateAccountToken
 		&i.UserID,
 		&i.GithubUsername,
 		&i.GithubID,
+		&i.Provider,
+		&i.ProviderUrl,
 		&i.AvatarUrl,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -11,32 +11,230 @@ import (
 )
 
 type Account struct {
-	ID             string      `json:"id"`
-	UserID         string      `json:"user_id"`
-	GithubUsername string      `json:"github_username"`
-	GithubID       int64       `json:"github_id"`
-	AvatarUrl      pgtype.Text `json:"avatar_url"`
-	AccessToken    string      `json:"access_token"`
-	CreatedAt      time.Time   `json:"created_at"`
-	UpdatedAt      time.Time   `json:"updated_at"`
+	ID                    string             `json:"id"`
+	UserID                string             `json:"user_id"`
+	GithubUsername        string             `json:"github_username"`
+	GithubID              int64              `json:"github_id"`
+	Provider              string             `json:"provider"`
+	ProviderUrl           string             `json:"provider_url"`
+	AvatarUrl             pgtype.Text        `json:"avatar_url"`
+	AccessToken           string             `json:"access_token"`
+	RefreshToken          pgtype.Text        `json:"refresh_token"`
+	TokenExpiresAt        pgtype.Timestamptz `json:"token_expires_at"`
+	RefreshTokenExpiresAt pgtype.Timestamptz `json:"refresh_token_expires_at"`
+	CreatedAt             time.Time          `json:"created_at"`
+	UpdatedAt             time.Time          `json:"updated_at"`
+}
+
+type Addon struct {
+	ID           string    `json:"id"`
+	UserID       string    `json:"user_id"`
+	Name         string    `json:"name"`
+	Type         string    `json:"type"`
+	Version      string    `json:"version"`
+	Status       string    `json:"status"`
+	Error        string    `json:"error"`
+	Username     string    `json:"username"`
+	Password     string    `json:"password"`
+	DatabaseName string    `json:"database_name"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type AddonAttachment struct {
+	AddonID   string    `json:"addon_id"`
+	ProjectID string    `json:"project_id"`
+	EnvVar    string    `json:"env_var"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Backup struct {
+	ID           string             `json:"id"`
+	PolicyID     string             `json:"policy_id"`
+	Operation    string             `json:"operation"`
+	Trigger      string             `json:"trigger"`
+	Status       string             `json:"status"`
+	ObjectKey    string             `json:"object_key"`
+	SizeBytes    int64              `json:"size_bytes"`
+	RestoredFrom pgtype.Text        `json:"restored_from"`
+	Error        string             `json:"error"`
+	StartedAt    time.Time          `json:"started_at"`
+	FinishedAt   pgtype.Timestamptz `json:"finished_at"`
+}
+
+type BackupPolicy struct {
+	ID              string             `json:"id"`
+	UserID          string             `json:"user_id"`
+	Name            string             `json:"name"`
+	TargetType      string             `json:"target_type"`
+	AddonID         pgtype.Text        `json:"addon_id"`
+	ProjectID       pgtype.Text        `json:"project_id"`
+	VolumeName      string             `json:"volume_name"`
+	Schedule        string             `json:"schedule"`
+	Retention       int32              `json:"retention"`
+	Destination     []byte             `json:"destination"`
+	Enabled         bool               `json:"enabled"`
+	LastScheduledAt pgtype.Timestamptz `json:"last_scheduled_at"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+}
+
+type CronJob struct {
+	ID                string             `json:"id"`
+	ProjectID         string             `json:"project_id"`
+	Name              string             `json:"name"`
+	Schedule          string             `json:"schedule"`
+	Command           string             `json:"command"`
+	TimeoutSeconds    int32              `json:"timeout_seconds"`
+	ConcurrencyPolicy string             `json:"concurrency_policy"`
+	Enabled           bool               `json:"enabled"`
+	LastScheduledAt   pgtype.Timestamptz `json:"last_scheduled_at"`
+	CreatedAt         time.Time          `json:"created_at"`
+	UpdatedAt         time.Time          `json:"updated_at"`
+}
+
+type CronJobRun struct {
+	ID         string             `json:"id"`
+	CronJobID  string             `json:"cron_job_id"`
+	ProjectID  string             `json:"project_id"`
+	Trigger    string             `json:"trigger"`
+	Status     string             `json:"status"`
+	ExitCode   pgtype.Int4        `json:"exit_code"`
+	Logs       string             `json:"logs"`
+	StartedAt  time.Time          `json:"started_at"`
+	FinishedAt pgtype.Timestamptz `json:"finished_at"`
+}
+
+type DeploymentEvent struct {
+	ID         string      `json:"id"`
+	ProjectID  string      `json:"project_id"`
+	UserID     pgtype.Text `json:"user_id"`
+	Action     string      `json:"action"`
+	Status     string      `json:"status"`
+	Deployment string      `json:"deployment"`
+	Message    string      `json:"message"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+type GithubInstallation struct {
+	ID             string             `json:"id"`
+	UserID         string             `json:"user_id"`
+	InstallationID int64              `json:"installation_id"`
+	AccountLogin   string             `json:"account_login"`
+	AccountType    string             `json:"account_type"`
+	SuspendedAt    pgtype.Timestamptz `json:"suspended_at"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
+type OauthState struct {
+	State      string      `json:"state"`
+	UserID     string      `json:"user_id"`
+	RedirectTo pgtype.Text `json:"redirect_to"`
+	ExpiresAt  time.Time   `json:"expires_at"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+type Preview struct {
+	ID         string      `json:"id"`
+	ProjectID  string      `json:"project_id"`
+	PrNumber   int32       `json:"pr_number"`
+	PrTitle    pgtype.Text `json:"pr_title"`
+	HeadBranch string      `json:"head_branch"`
+	HeadSha    pgtype.Text `json:"head_sha"`
+	Domain     string      `json:"domain"`
+	Status     string      `json:"status"`
+	CommentID  pgtype.Int8 `json:"comment_id"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
 }
 
 type Project struct {
-	ID               string      `json:"id"`
-	Name             string      `json:"name"`
-	UserID           string      `json:"user_id"`
-	RepoID           int64       `json:"repo_id"`
-	RepoName         string      `json:"repo_name"`
-	RepoFullName     string      `json:"repo_full_name"`
-	RepoUrl          string      `json:"repo_url"`
-	RepoBranch       string      `json:"repo_branch"`
-	Status           string      `json:"status"`
-	EnvVariables     []byte      `json:"env_variables"`
-	DeploymentStatus string      `json:"deployment_status"`
-	Domain           pgtype.Text `json:"domain"`
-	Port             pgtype.Int4 `json:"port"`
-	CreatedAt        time.Time   `json:"created_at"`
-	UpdatedAt        time.Time   `json:"updated_at"`
+	ID                  string      `json:"id"`
+	Name                string      `json:"name"`
+	UserID              string      `json:"user_id"`
+	RepoID              int64       `json:"repo_id"`
+	RepoName            string      `json:"repo_name"`
+	RepoFullName        string      `json:"repo_full_name"`
+	RepoUrl             string      `json:"repo_url"`
+	RepoBranch          string      `json:"repo_branch"`
+	Status              string      `json:"status"`
+	EnvVariables        []byte      `json:"env_variables"`
+	DeploymentStatus    string      `json:"deployment_status"`
+	Domain              pgtype.Text `json:"domain"`
+	Port                pgtype.Int4 `json:"port"`
+	PreviewsEnabled     bool        `json:"previews_enabled"`
+	PreviewEnvVariables []byte      `json:"preview_env_variables"`
+	WebhookID           pgtype.Int8 `json:"webhook_id"`
+	InstallationID      pgtype.Int8 `json:"installation_id"`
+	Provider            string      `json:"provider"`
+	ProviderUrl         string      `json:"provider_url"`
+	DeployPublicKey     pgtype.Text `json:"deploy_public_key"`
+	DeployPrivateKey    pgtype.Text `json:"deploy_private_key"`
+	AccountID           pgtype.Text `json:"account_id"`
+	ForceHttps          bool        `json:"force_https"`
+	TlsCertificate      pgtype.Text `json:"tls_certificate"`
+	TlsPrivateKey       pgtype.Text `json:"tls_private_key"`
+	RoutingPolicy       []byte      `json:"routing_policy"`
+	RuntimeStatus       string      `json:"runtime_status"`
+	Processes           []byte      `json:"processes"`
+	Volumes             []byte      `json:"volumes"`
+	StaticSite          []byte      `json:"static_site"`
+	SourceType          string      `json:"source_type"`
+	Image               pgtype.Text `json:"image"`
+	RegistryUsername    pgtype.Text `json:"registry_username"`
+	RegistryPassword    pgtype.Text `json:"registry_password"`
+	DeployHookTokenHash pgtype.Text `json:"deploy_hook_token_hash"`
+	Stopped             bool        `json:"stopped"`
+	ContainerPort       pgtype.Int4 `json:"container_port"`
+	CreatedAt           time.Time   `json:"created_at"`
+	UpdatedAt           time.Time   `json:"updated_at"`
+}
+
+type ProjectDomain struct {
+	ID                string             `json:"id"`
+	ProjectID         string             `json:"project_id"`
+	Domain            string             `json:"domain"`
+	VerificationToken string             `json:"verification_token"`
+	Status            string             `json:"status"`
+	VerificationError pgtype.Text        `json:"verification_error"`
+	VerifiedAt        pgtype.Timestamptz `json:"verified_at"`
+	LastCheckedAt     pgtype.Timestamptz `json:"last_checked_at"`
+	CreatedAt         time.Time          `json:"created_at"`
+	UpdatedAt         time.Time          `json:"updated_at"`
+}
+
+type ProjectMetric struct {
+	ProjectID         string    `json:"project_id"`
+	ServiceName       string    `json:"service_name"`
+	ResolutionSeconds int32     `json:"resolution_seconds"`
+	BucketStart       time.Time `json:"bucket_start"`
+	CpuPercent        float64   `json:"cpu_percent"`
+	MemoryBytes       int64     `json:"memory_bytes"`
+	MemoryLimitBytes  int64     `json:"memory_limit_bytes"`
+	NetworkRxRate     float64   `json:"network_rx_rate"`
+	NetworkTxRate     float64   `json:"network_tx_rate"`
+	Restarts          int32     `json:"restarts"`
+	Samples           int32     `json:"samples"`
+}
+
+type Teardown struct {
+	ID             string             `json:"id"`
+	UserID         string             `json:"user_id"`
+	ProjectID      string             `json:"project_id"`
+	ProjectName    string             `json:"project_name"`
+	RepoFullName   string             `json:"repo_full_name"`
+	WebhookID      int64              `json:"webhook_id"`
+	Provider       string             `json:"provider"`
+	ProviderUrl    string             `json:"provider_url"`
+	AccountID      string             `json:"account_id"`
+	InstallationID int64              `json:"installation_id"`
+	RemoveVolumes  bool               `json:"remove_volumes"`
+	Status         string             `json:"status"`
+	Steps          []byte             `json:"steps"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+	FinishedAt     pgtype.Timestamptz `json:"finished_at"`
 }
 
 type User struct {
@@ -15,7 +15,7 @@ const activateProject = `-- name: ActivateProject :one
 UPDATE projects
 SET status = 'active', updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 `
 
 func (q *Queries) ActivateProject(ctx context.Context, id string) (Project, error) {
@@ -35,6 +35,30 @@ func (q *Queries) ActivateProject(ctx context.Context, id string) (Project, erro
 		&i.DeploymentStatus,
 		&i.Domain,
 		&i.Port,
+		&i.PreviewsEnabled,
+		&i.PreviewEnvVariables,
+		&i.WebhookID,
+		&i.InstallationID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.DeployPublicKey,
+		&i.DeployPrivateKey,
+		&i.AccountID,
+		&i.ForceHttps,
+		&i.TlsCertificate,
+		&i.TlsPrivateKey,
+		&i.RoutingPolicy,
+		&i.RuntimeStatus,
+		&i.Processes,
+		&i.Volumes,
+		&i.StaticSite,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryUsername,
+		&i.RegistryPassword,
+		&i.DeployHookTokenHash,
+		&i.Stopped,
+		&i.ContainerPort,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -45,7 +69,7 @@ const archiveProject = `-- name: ArchiveProject :one
 UPDATE projects
 SET status = 'archived', updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 `
 
 func (q *Queries) ArchiveProject(ctx context.Context, id string) (Project, error) {
@@ -65,6 +89,30 @@ func (q *Queries) ArchiveProject(ctx context.Context, id string) (Project, error
 		&i.DeploymentStatus,
 		&i.Domain,
 		&i.Port,
+		&i.PreviewsEnabled,
+		&i.PreviewEnvVariables,
+		&i.WebhookID,
+		&i.InstallationID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.DeployPublicKey,
+		&i.DeployPrivateKey,
+		&i.AccountID,
+		&i.ForceHttps,
+		&i.TlsCertificate,
+		&i.TlsPrivateKey,
+		&i.RoutingPolicy,
+		&i.RuntimeStatus,
+		&i.Processes,
+		&i.Volumes,
+		&i.StaticSite,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryUsername,
+		&i.RegistryPassword,
+		&i.DeployHookTokenHash,
+		&i.Stopped,
+		&i.ContainerPort,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -105,22 +153,33 @@ func (q *Queries) CountProjectsByUserID(ctx context.Context, userID string) (int
 }
 
 const createProject = `-- name: CreateProject :one
-INSERT INTO projects (name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port)
-VALUES ($1, $2, $3, $4, $5, $6, $7, 'active', $8, 'pending', $9, $10)
-RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+INSERT INTO projects (name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, source_type, image, registry_username, registry_password, container_port)
+VALUES ($1, $2, $3, $4, $5, $6, $7, 'active', $8, 'pending', $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 `
 
 type CreateProjectParams struct {
-	Name         string      `json:"name"`
-	UserID       string      `json:"user_id"`
-	RepoID       int64       `json:"repo_id"`
-	RepoName     string      `json:"repo_name"`
-	RepoFullName string      `json:"repo_full_name"`
-	RepoUrl      string      `json:"repo_url"`
-	RepoBranch   string      `json:"repo_branch"`
-	EnvVariables []byte      `json:"env_variables"`
-	Domain       pgtype.Text `json:"domain"`
-	Port         pgtype.Int4 `json:"port"`
+	Name             string      `json:"name"`
+	UserID           string      `json:"user_id"`
+	RepoID           int64       `json:"repo_id"`
+	RepoName         string      `json:"repo_name"`
+	RepoFullName     string      `json:"repo_full_name"`
+	RepoUrl          string      `json:"repo_url"`
+	RepoBranch       string      `json:"repo_branch"`
+	EnvVariables     []byte      `json:"env_variables"`
+	Domain           pgtype.Text `json:"domain"`
+	Port             pgtype.Int4 `json:"port"`
+	InstallationID   pgtype.Int8 `json:"installation_id"`
+	Provider         string      `json:"provider"`
+	ProviderUrl      string      `json:"provider_url"`
+	DeployPublicKey  pgtype.Text `json:"deploy_public_key"`
+	DeployPrivateKey pgtype.Text `json:"deploy_private_key"`
+	AccountID        pgtype.Text `json:"account_id"`
+	SourceType       string      `json:"source_type"`
+	Image            pgtype.Text `json:"image"`
+	RegistryUsername pgtype.Text `json:"registry_username"`
+	RegistryPassword pgtype.Text `json:"registry_password"`
+	ContainerPort    pgtype.Int4 `json:"container_port"`
 }
 
 func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) (Project, error) {
@@ -135,6 +194,17 @@ func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) (P
 		arg.EnvVariables,
 		arg.Domain,
 		arg.Port,
+		arg.InstallationID,
+		arg.Provider,
+		arg.ProviderUrl,
+		arg.DeployPublicKey,
+		arg.DeployPrivateKey,
+		arg.AccountID,
+		arg.SourceType,
+		arg.Image,
+		arg.RegistryUsername,
+		arg.RegistryPassword,
+		arg.ContainerPort,
 	)
 	var i Project
 	err := row.Scan(
@@ -151,6 +221,30 @@ func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) (P
 		&i.DeploymentStatus,
 		&i.Domain,
 		&i.Port,
+		&i.PreviewsEnabled,
+		&i.PreviewEnvVariables,
+		&i.WebhookID,
+		&i.InstallationID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.DeployPublicKey,
+		&i.DeployPrivateKey,
+		&i.AccountID,
+		&i.ForceHttps,
+		&i.TlsCertificate,
+		&i.TlsPrivateKey,
+		&i.RoutingPolicy,
+		&i.RuntimeStatus,
+		&i.Processes,
+		&i.Volumes,
+		&i.StaticSite,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryUsername,
+		&i.RegistryPassword,
+		&i.DeployHookTokenHash,
+		&i.Stopped,
+		&i.ContainerPort,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -178,7 +272,7 @@ func (q *Queries) DeleteProjectsByUserID(ctx context.Context, userID string) err
 }
 
 const getActiveProjectsByUserID = `-- name: GetActiveProjectsByUserID :many
-SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 FROM projects
 WHERE user_id = $1 AND status = 'active'
 ORDER BY created_at DESC
@@ -207,6 +301,30 @@ func (q *Queries) GetActiveProjectsByUserID(ctx context.Context, userID string)
 			&i.DeploymentStatus,
 			&i.Domain,
 			&i.Port,
+			&i.PreviewsEnabled,
+			&i.PreviewEnvVariables,
+			&i.WebhookID,
+			&i.InstallationID,
+			&i.Provider,
+			&i.ProviderUrl,
+			&i.DeployPublicKey,
+			&i.DeployPrivateKey,
+			&i.AccountID,
+			&i.ForceHttps,
+			&i.TlsCertificate,
+			&i.TlsPrivateKey,
+			&i.RoutingPolicy,
+			&i.RuntimeStatus,
+			&i.Processes,
+			&i.Volumes,
+			&i.StaticSite,
+			&i.SourceType,
+			&i.Image,
+			&i.RegistryUsername,
+			&i.RegistryPassword,
+			&i.DeployHookTokenHash,
+			&i.Stopped,
+			&i.ContainerPort,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -221,7 +339,7 @@ func (q *Queries) GetActiveProjectsByUserID(ctx context.Context, userID string)
 }
 
 const getProjectByID = `-- name: GetProjectByID :one
-SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 FROM projects
 WHERE id = $1
 `
@@ -243,6 +361,30 @@ func (q *Queries) GetProjectByID(ctx context.Context, id string) (Project, error
 		&i.DeploymentStatus,
 		&i.Domain,
 		&i.Port,
+		&i.PreviewsEnabled,
+		&i.PreviewEnvVariables,
+		&i.WebhookID,
+		&i.InstallationID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.DeployPublicKey,
+		&i.DeployPrivateKey,
+		&i.AccountID,
+		&i.ForceHttps,
+		&i.TlsCertificate,
+		&i.TlsPrivateKey,
+		&i.RoutingPolicy,
+		&i.RuntimeStatus,
+		&i.Processes,
+		&i.Volumes,
+		&i.StaticSite,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryUsername,
+		&i.RegistryPassword,
+		&i.DeployHookTokenHash,
+		&i.Stopped,
+		&i.ContainerPort,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -250,7 +392,7 @@ func (q *Queries) GetProjectByID(ctx context.Context, id string) (Project, error
 }
 
 const getProjectByUserIDAndName = `-- name: GetProjectByUserIDAndName :one
-SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 FROM projects
 WHERE user_id = $1 AND name = $2
 `
@@ -277,6 +419,30 @@ func (q *Queries) GetProjectByUserIDAndName(ctx context.Context, arg GetProjectB
 		&i.DeploymentStatus,
 		&i.Domain,
 		&i.Port,
+		&i.PreviewsEnabled,
+		&i.PreviewEnvVariables,
+		&i.WebhookID,
+		&i.InstallationID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.DeployPublicKey,
+		&i.DeployPrivateKey,
+		&i.AccountID,
+		&i.ForceHttps,
+		&i.TlsCertificate,
+		&i.TlsPrivateKey,
+		&i.RoutingPolicy,
+		&i.RuntimeStatus,
+		&i.Processes,
+		&i.Volumes,
+		&i.StaticSite,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryUsername,
+		&i.RegistryPassword,
+		&i.DeployHookTokenHash,
+		&i.Stopped,
+		&i.ContainerPort,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -284,7 +450,7 @@ func (q *Queries) GetProjectByUserIDAndName(ctx context.Context, arg GetProjectB
 }
 
 const getProjectsByRepoID = `-- name: GetProjectsByRepoID :many
-SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 FROM projects
 WHERE repo_id = $1
 ORDER BY created_at DESC
@@ -313,6 +479,30 @@ func (q *Queries) GetProjectsByRepoID(ctx context.Context, repoID int64) ([]Proj
 			&i.DeploymentStatus,
 			&i.Domain,
 			&i.Port,
+			&i.PreviewsEnabled,
+			&i.PreviewEnvVariables,
+			&i.WebhookID,
+			&i.InstallationID,
+			&i.Provider,
+			&i.ProviderUrl,
+			&i.DeployPublicKey,
+			&i.DeployPrivateKey,
+			&i.AccountID,
+			&i.ForceHttps,
+			&i.TlsCertificate,
+			&i.TlsPrivateKey,
+			&i.RoutingPolicy,
+			&i.RuntimeStatus,
+			&i.Processes,
+			&i.Volumes,
+			&i.StaticSite,
+			&i.SourceType,
+			&i.Image,
+			&i.RegistryUsername,
+			&i.RegistryPassword,
+			&i.DeployHookTokenHash,
+			&i.Stopped,
+			&i.ContainerPort,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -327,7 +517,7 @@ func (q *Queries) GetProjectsByRepoID(ctx context.Context, repoID int64) ([]Proj
 }
 
 const getProjectsByUserID = `-- name: GetProjectsByUserID :many
-SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 FROM projects
 WHERE user_id = $1
 ORDER BY created_at DESC
@@ -356,6 +546,30 @@ func (q *Queries) GetProjectsByUserID(ctx context.Context, userID string) ([]Pro
 			&i.DeploymentStatus,
 			&i.Domain,
 			&i.Port,
+			&i.PreviewsEnabled,
+			&i.PreviewEnvVariables,
+			&i.WebhookID,
+			&i.InstallationID,
+			&i.Provider,
+			&i.ProviderUrl,
+			&i.DeployPublicKey,
+			&i.DeployPrivateKey,
+			&i.AccountID,
+			&i.ForceHttps,
+			&i.TlsCertificate,
+			&i.TlsPrivateKey,
+			&i.RoutingPolicy,
+			&i.RuntimeStatus,
+			&i.Processes,
+			&i.Volumes,
+			&i.StaticSite,
+			&i.SourceType,
+			&i.Image,
+			&i.RegistryUsername,
+			&i.RegistryPassword,
+			&i.DeployHookTokenHash,
+			&i.Stopped,
+			&i.ContainerPort,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -370,7 +584,7 @@ func (q *Queries) GetProjectsByUserID(ctx context.Context, userID string) ([]Pro
 }
 
 const getProjectsByUserIDAndStatus = `-- name: GetProjectsByUserIDAndStatus :many
-SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 FROM projects
 WHERE user_id = $1 AND status = $2
 ORDER BY created_at DESC
@@ -404,6 +618,30 @@ func (q *Queries) GetProjectsByUserIDAndStatus(ctx context.Context, arg GetProje
 			&i.DeploymentStatus,
 			&i.Domain,
 			&i.Port,
+			&i.PreviewsEnabled,
+			&i.PreviewEnvVariables,
+			&i.WebhookID,
+			&i.InstallationID,
+			&i.Provider,
+			&i.ProviderUrl,
+			&i.DeployPublicKey,
+			&i.DeployPrivateKey,
+			&i.AccountID,
+			&i.ForceHttps,
+			&i.TlsCertificate,
+			&i.TlsPrivateKey,
+			&i.RoutingPolicy,
+			&i.RuntimeStatus,
+			&i.Processes,
+			&i.Volumes,
+			&i.StaticSite,
+			&i.SourceType,
+			&i.Image,
+			&i.RegistryUsername,
+			&i.RegistryPassword,
+			&i.DeployHookTokenHash,
+			&i.Stopped,
+			&i.ContainerPort,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -442,7 +680,7 @@ func (q *Queries) GetUsedPorts(ctx context.Context) ([]pgtype.Int4, error) {
 }
 
 const listProjects = `-- name: ListProjects :many
-SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 FROM projects
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
@@ -476,6 +714,30 @@ func (q *Queries) ListProjects(ctx context.Context, arg ListProjectsParams) ([]P
 			&i.DeploymentStatus,
 			&i.Domain,
 			&i.Port,
+			&i.PreviewsEnabled,
+			&i.PreviewEnvVariables,
+			&i.WebhookID,
+			&i.InstallationID,
+			&i.Provider,
+			&i.ProviderUrl,
+			&i.DeployPublicKey,
+			&i.DeployPrivateKey,
+			&i.AccountID,
+			&i.ForceHttps,
+			&i.TlsCertificate,
+			&i.TlsPrivateKey,
+			&i.RoutingPolicy,
+			&i.RuntimeStatus,
+			&i.Processes,
+			&i.Volumes,
+			&i.StaticSite,
+			&i.SourceType,
+			&i.Image,
+			&i.RegistryUsername,
+			&i.RegistryPassword,
+			&i.DeployHookTokenHash,
+			&i.Stopped,
+			&i.ContainerPort,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -490,7 +752,7 @@ func (q *Queries) ListProjects(ctx context.Context, arg ListProjectsParams) ([]P
 }
 
 const listProjectsByStatus = `-- name: ListProjectsByStatus :many
-SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 FROM projects
 WHERE status = $1
 ORDER BY created_at DESC
@@ -526,6 +788,30 @@ func (q *Queries) ListProjectsByStatus(ctx context.Context, arg ListProjectsBySt
 			&i.DeploymentStatus,
 			&i.Domain,
 			&i.Port,
+			&i.PreviewsEnabled,
+			&i.PreviewEnvVariables,
+			&i.WebhookID,
+			&i.InstallationID,
+			&i.Provider,
+			&i.ProviderUrl,
+			&i.DeployPublicKey,
+			&i.DeployPrivateKey,
+			&i.AccountID,
+			&i.ForceHttps,
+			&i.TlsCertificate,
+			&i.TlsPrivateKey,
+			&i.RoutingPolicy,
+			&i.RuntimeStatus,
+			&i.Processes,
+			&i.Volumes,
+			&i.StaticSite,
+			&i.SourceType,
+			&i.Image,
+			&i.RegistryUsername,
+			&i.RegistryPassword,
+			&i.DeployHookTokenHash,
+			&i.Stopped,
+			&i.ContainerPort,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -567,7 +853,7 @@ func (q *Queries) ProjectExistsByUserIDAndName(ctx context.Context, arg ProjectE
 }
 
 const searchProjects = `-- name: SearchProjects :many
-SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 FROM projects
 WHERE name ILIKE '%' || $1 || '%' 
    OR repo_name ILIKE '%' || $1 || '%'
@@ -605,6 +891,30 @@ func (q *Queries) SearchProjects(ctx context.Context, arg SearchProjectsParams)
 			&i.DeploymentStatus,
 			&i.Domain,
 			&i.Port,
+			&i.PreviewsEnabled,
+			&i.PreviewEnvVariables,
+			&i.WebhookID,
+			&i.InstallationID,
+			&i.Provider,
+			&i.ProviderUrl,
+			&i.DeployPublicKey,
+			&i.DeployPrivateKey,
+			&i.AccountID,
+			&i.ForceHttps,
+			&i.TlsCertificate,
+			&i.TlsPrivateKey,
+			&i.RoutingPolicy,
+			&i.RuntimeStatus,
+			&i.Processes,
+			&i.Volumes,
+			&i.StaticSite,
+			&i.SourceType,
+			&i.Image,
+			&i.RegistryUsername,
+			&i.RegistryPassword,
+			&i.DeployHookTokenHash,
+			&i.Stopped,
+			&i.ContainerPort,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -619,7 +929,7 @@ func (q *Queries) SearchProjects(ctx context.Context, arg SearchProjectsParams)
 }
 
 const searchProjectsByUserID = `-- name: SearchProjectsByUserID :many
-SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 FROM projects
 WHERE user_id = $1 AND (
     name ILIKE '%' || $2 || '%' 
@@ -665,6 +975,30 @@ func (q *Queries) SearchProjectsByUserID(ctx context.Context, arg SearchProjects
 			&i.DeploymentStatus,
 			&i.Domain,
 			&i.Port,
+			&i.PreviewsEnabled,
+			&i.PreviewEnvVariables,
+			&i.WebhookID,
+			&i.InstallationID,
+			&i.Provider,
+			&i.ProviderUrl,
+			&i.DeployPublicKey,
+			&i.DeployPrivateKey,
+			&i.AccountID,
+			&i.ForceHttps,
+			&i.TlsCertificate,
+			&i.TlsPrivateKey,
+			&i.RoutingPolicy,
+			&i.RuntimeStatus,
+			&i.Processes,
+			&i.Volumes,
+			&i.StaticSite,
+			&i.SourceType,
+			&i.Image,
+			&i.RegistryUsername,
+			&i.RegistryPassword,
+			&i.DeployHookTokenHash,
+			&i.Stopped,
+			&i.ContainerPort,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -682,7 +1016,7 @@ const updateProject = `-- name: UpdateProject :one
 UPDATE projects
 SET name = $2, repo_branch = $3, status = $4, domain = $5, updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 `
 
 type UpdateProjectParams struct {
@@ -716,6 +1050,30 @@ func (q *Queries) UpdateProject(ctx context.Context, arg UpdateProjectParams) (P
 		&i.DeploymentStatus,
 		&i.Domain,
 		&i.Port,
+		&i.PreviewsEnabled,
+		&i.PreviewEnvVariables,
+		&i.WebhookID,
+		&i.InstallationID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.DeployPublicKey,
+		&i.DeployPrivateKey,
+		&i.AccountID,
+		&i.ForceHttps,
+		&i.TlsCertificate,
+		&i.TlsPrivateKey,
+		&i.RoutingPolicy,
+		&i.RuntimeStatus,
+		&i.Processes,
+		&i.Volumes,
+		&i.StaticSite,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryUsername,
+		&i.RegistryPassword,
+		&i.DeployHookTokenHash,
+		&i.Stopped,
+		&i.ContainerPort,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -726,7 +1084,7 @@ const updateProjectBranch = `-- name: UpdateProjectBranch :one
 UPDATE projects
 SET repo_branch = $2, updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 `
 
 type UpdateProjectBranchParams struct {
@@ -751,6 +1109,149 @@ func (q *Queries) UpdateProjectBranch(ctx context.Context, arg UpdateProjectBran
 		&i.DeploymentStatus,
 		&i.Domain,
 		&i.Port,
+		&i.PreviewsEnabled,
+		&i.PreviewEnvVariables,
+		&i.WebhookID,
+		&i.InstallationID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.DeployPublicKey,
+		&i.DeployPrivateKey,
+		&i.AccountID,
+		&i.ForceHttps,
+		&i.TlsCertificate,
+		&i.TlsPrivateKey,
+		&i.RoutingPolicy,
+		&i.RuntimeStatus,
+		&i.Processes,
+		&i.Volumes,
+		&i.StaticSite,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryUsername,
+		&i.RegistryPassword,
+		&i.DeployHookTokenHash,
+		&i.Stopped,
+		&i.ContainerPort,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateProjectDeployHookToken = `-- name: UpdateProjectDeployHookToken :one
+UPDATE projects
+SET deploy_hook_token_hash = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
+`
+
+type UpdateProjectDeployHookTokenParams struct {
+	ID                  string      `json:"id"`
+	DeployHookTokenHash pgtype.Text `json:"deploy_hook_token_hash"`
+}
+
+func (q *Queries) UpdateProjectDeployHookToken(ctx context.Context, arg UpdateProjectDeployHookTokenParams) (Project, error) {
+	row := q.db.QueryRow(ctx, updateProjectDeployHookToken, arg.ID, arg.DeployHookTokenHash)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.RepoID,
+		&i.RepoName,
+		&i.RepoFullName,
+		&i.RepoUrl,
+		&i.RepoBranch,
+		&i.Status,
+		&i.EnvVariables,
+		&i.DeploymentStatus,
+		&i.Domain,
+		&i.Port,
+		&i.PreviewsEnabled,
+		&i.PreviewEnvVariables,
+		&i.WebhookID,
+		&i.InstallationID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.DeployPublicKey,
+		&i.DeployPrivateKey,
+		&i.AccountID,
+		&i.ForceHttps,
+		&i.TlsCertificate,
+		&i.TlsPrivateKey,
+		&i.RoutingPolicy,
+		&i.RuntimeStatus,
+		&i.Processes,
+		&i.Volumes,
+		&i.StaticSite,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryUsername,
+		&i.RegistryPassword,
+		&i.DeployHookTokenHash,
+		&i.Stopped,
+		&i.ContainerPort,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateProjectDeployKey = `-- name: UpdateProjectDeployKey :one
+UPDATE projects
+SET deploy_public_key = $2, deploy_private_key = $3, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
+`
+
+type UpdateProjectDeployKeyParams struct {
+	ID               string      `json:"id"`
+	DeployPublicKey  pgtype.Text `json:"deploy_public_key"`
+	DeployPrivateKey pgtype.Text `json:"deploy_private_key"`
+}
+
+func (q *Queries) UpdateProjectDeployKey(ctx context.Context, arg UpdateProjectDeployKeyParams) (Project, error) {
+	row := q.db.QueryRow(ctx, updateProjectDeployKey, arg.ID, arg.DeployPublicKey, arg.DeployPrivateKey)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.RepoID,
+		&i.RepoName,
+		&i.RepoFullName,
+		&i.RepoUrl,
+		&i.RepoBranch,
+		&i.Status,
+		&i.EnvVariables,
+		&i.DeploymentStatus,
+		&i.Domain,
+		&i.Port,
+		&i.PreviewsEnabled,
+		&i.PreviewEnvVariables,
+		&i.WebhookID,
+		&i.InstallationID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.DeployPublicKey,
+		&i.DeployPrivateKey,
+		&i.AccountID,
+		&i.ForceHttps,
+		&i.TlsCertificate,
+		&i.TlsPrivateKey,
+		&i.RoutingPolicy,
+		&i.RuntimeStatus,
+		&i.Processes,
+		&i.Volumes,
+		&i.StaticSite,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryUsername,
+		&i.RegistryPassword,
+		&i.DeployHookTokenHash,
+		&i.Stopped,
+		&i.ContainerPort,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -761,7 +1262,7 @@ const updateProjectDeploymentStatus = `-- name: UpdateProjectDeploymentStatus :o
 UPDATE projects
 SET deployment_status = $2, updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 `
 
 type UpdateProjectDeploymentStatusParams struct {
@@ -786,6 +1287,157 @@ func (q *Queries) UpdateProjectDeploymentStatus(ctx context.Context, arg UpdateP
 		&i.DeploymentStatus,
 		&i.Domain,
 		&i.Port,
+		&i.PreviewsEnabled,
+		&i.PreviewEnvVariables,
+		&i.WebhookID,
+		&i.InstallationID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.DeployPublicKey,
+		&i.DeployPrivateKey,
+		&i.AccountID,
+		&i.ForceHttps,
+		&i.TlsCertificate,
+		&i.TlsPrivateKey,
+		&i.RoutingPolicy,
+		&i.RuntimeStatus,
+		&i.Processes,
+		&i.Volumes,
+		&i.StaticSite,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryUsername,
+		&i.RegistryPassword,
+		&i.DeployHookTokenHash,
+		&i.Stopped,
+		&i.ContainerPort,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateProjectDomain = `-- name: UpdateProjectDomain :one
+UPDATE projects
+SET domain = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
+`
+
+type UpdateProjectDomainParams struct {
+	ID     string      `json:"id"`
+	Domain pgtype.Text `json:"domain"`
+}
+
+func (q *Queries) UpdateProjectDomain(ctx context.Context, arg UpdateProjectDomainParams) (Project, error) {
+	row := q.db.QueryRow(ctx, updateProjectDomain, arg.ID, arg.Domain)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.RepoID,
+		&i.RepoName,
+		&i.RepoFullName,
+		&i.RepoUrl,
+		&i.RepoBranch,
+		&i.Status,
+		&i.EnvVariables,
+		&i.DeploymentStatus,
+		&i.Domain,
+		&i.Port,
+		&i.PreviewsEnabled,
+		&i.PreviewEnvVariables,
+		&i.WebhookID,
+		&i.InstallationID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.DeployPublicKey,
+		&i.DeployPrivateKey,
+		&i.AccountID,
+		&i.ForceHttps,
+		&i.TlsCertificate,
+		&i.TlsPrivateKey,
+		&i.RoutingPolicy,
+		&i.RuntimeStatus,
+		&i.Processes,
+		&i.Volumes,
+		&i.StaticSite,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryUsername,
+		&i.RegistryPassword,
+		&i.DeployHookTokenHash,
+		&i.Stopped,
+		&i.ContainerPort,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateProjectImageSource = `-- name: UpdateProjectImageSource :one
+UPDATE projects
+SET image = $2, registry_username = $3, registry_password = $4, container_port = $5, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
+`
+
+type UpdateProjectImageSourceParams struct {
+	ID               string      `json:"id"`
+	Image            pgtype.Text `json:"image"`
+	RegistryUsername pgtype.Text `json:"registry_username"`
+	RegistryPassword pgtype.Text `json:"registry_password"`
+	ContainerPort    pgtype.Int4 `json:"container_port"`
+}
+
+func (q *Queries) UpdateProjectImageSource(ctx context.Context, arg UpdateProjectImageSourceParams) (Project, error) {
+	row := q.db.QueryRow(ctx, updateProjectImageSource,
+		arg.ID,
+		arg.Image,
+		arg.RegistryUsername,
+		arg.RegistryPassword,
+		arg.ContainerPort,
+	)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.RepoID,
+		&i.RepoName,
+		&i.RepoFullName,
+		&i.RepoUrl,
+		&i.RepoBranch,
+		&i.Status,
+		&i.EnvVariables,
+		&i.DeploymentStatus,
+		&i.Domain,
+		&i.Port,
+		&i.PreviewsEnabled,
+		&i.PreviewEnvVariables,
+		&i.WebhookID,
+		&i.InstallationID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.DeployPublicKey,
+		&i.DeployPrivateKey,
+		&i.AccountID,
+		&i.ForceHttps,
+		&i.TlsCertificate,
+		&i.TlsPrivateKey,
+		&i.RoutingPolicy,
+		&i.RuntimeStatus,
+		&i.Processes,
+		&i.Volumes,
+		&i.StaticSite,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryUsername,
+		&i.RegistryPassword,
+		&i.DeployHookTokenHash,
+		&i.Stopped,
+		&i.ContainerPort,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -808,11 +1460,270 @@ func (q *Queries) UpdateProjectPort(ctx context.Context, arg UpdateProjectPortPa
 	return err
 }
 
+const updateProjectPreviewSettings = `-- name: UpdateProjectPreviewSettings :one
+UPDATE projects
+SET previews_enabled = $2, preview_env_variables = $3, webhook_id = $4, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
+`
+
+type UpdateProjectPreviewSettingsParams struct {
+	ID                  string      `json:"id"`
+	PreviewsEnabled     bool        `json:"previews_enabled"`
+	PreviewEnvVariables []byte      `json:"preview_env_variables"`
+	WebhookID           pgtype.Int8 `json:"webhook_id"`
+}
+
+func (q *Queries) UpdateProjectPreviewSettings(ctx context.Context, arg UpdateProjectPreviewSettingsParams) (Project, error) {
+	row := q.db.QueryRow(ctx, updateProjectPreviewSettings,
+		arg.ID,
+		arg.PreviewsEnabled,
+		arg.PreviewEnvVariables,
+		arg.WebhookID,
+	)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.RepoID,
+		&i.RepoName,
+		&i.RepoFullName,
+		&i.RepoUrl,
+		&i.RepoBranch,
+		&i.Status,
+		&i.EnvVariables,
+		&i.DeploymentStatus,
+		&i.Domain,
+		&i.Port,
+		&i.PreviewsEnabled,
+		&i.PreviewEnvVariables,
+		&i.WebhookID,
+		&i.InstallationID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.DeployPublicKey,
+		&i.DeployPrivateKey,
+		&i.AccountID,
+		&i.ForceHttps,
+		&i.TlsCertificate,
+		&i.TlsPrivateKey,
+		&i.RoutingPolicy,
+		&i.RuntimeStatus,
+		&i.Processes,
+		&i.Volumes,
+		&i.StaticSite,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryUsername,
+		&i.RegistryPassword,
+		&i.DeployHookTokenHash,
+		&i.Stopped,
+		&i.ContainerPort,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateProjectProcesses = `-- name: UpdateProjectProcesses :one
+UPDATE projects
+SET processes = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
+`
+
+type UpdateProjectProcessesParams struct {
+	ID        string `json:"id"`
+	Processes []byte `json:"processes"`
+}
+
+func (q *Queries) UpdateProjectProcesses(ctx context.Context, arg UpdateProjectProcessesParams) (Project, error) {
+	row := q.db.QueryRow(ctx, updateProjectProcesses, arg.ID, arg.Processes)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.RepoID,
+		&i.RepoName,
+		&i.RepoFullName,
+		&i.RepoUrl,
+		&i.RepoBranch,
+		&i.Status,
+		&i.EnvVariables,
+		&i.DeploymentStatus,
+		&i.Domain,
+		&i.Port,
+		&i.PreviewsEnabled,
+		&i.PreviewEnvVariables,
+		&i.WebhookID,
+		&i.InstallationID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.DeployPublicKey,
+		&i.DeployPrivateKey,
+		&i.AccountID,
+		&i.ForceHttps,
+		&i.TlsCertificate,
+		&i.TlsPrivateKey,
+		&i.RoutingPolicy,
+		&i.RuntimeStatus,
+		&i.Processes,
+		&i.Volumes,
+		&i.StaticSite,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryUsername,
+		&i.RegistryPassword,
+		&i.DeployHookTokenHash,
+		&i.Stopped,
+		&i.ContainerPort,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateProjectRoutingPolicy = `-- name: UpdateProjectRoutingPolicy :one
+UPDATE projects
+SET routing_policy = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
+`
+
+type UpdateProjectRoutingPolicyParams struct {
+	ID            string `json:"id"`
+	RoutingPolicy []byte `json:"routing_policy"`
+}
+
+func (q *Queries) UpdateProjectRoutingPolicy(ctx context.Context, arg UpdateProjectRoutingPolicyParams) (Project, error) {
+	row := q.db.QueryRow(ctx, updateProjectRoutingPolicy, arg.ID, arg.RoutingPolicy)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.RepoID,
+		&i.RepoName,
+		&i.RepoFullName,
+		&i.RepoUrl,
+		&i.RepoBranch,
+		&i.Status,
+		&i.EnvVariables,
+		&i.DeploymentStatus,
+		&i.Domain,
+		&i.Port,
+		&i.PreviewsEnabled,
+		&i.PreviewEnvVariables,
+		&i.WebhookID,
+		&i.InstallationID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.DeployPublicKey,
+		&i.DeployPrivateKey,
+		&i.AccountID,
+		&i.ForceHttps,
+		&i.TlsCertificate,
+		&i.TlsPrivateKey,
+		&i.RoutingPolicy,
+		&i.RuntimeStatus,
+		&i.Processes,
+		&i.Volumes,
+		&i.StaticSite,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryUsername,
+		&i.RegistryPassword,
+		&i.DeployHookTokenHash,
+		&i.Stopped,
+		&i.ContainerPort,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateProjectRuntimeStatus = `-- name: UpdateProjectRuntimeStatus :exec
+UPDATE projects
+SET runtime_status = $2
+WHERE id = $1
+`
+
+type UpdateProjectRuntimeStatusParams struct {
+	ID            string `json:"id"`
+	RuntimeStatus string `json:"runtime_status"`
+}
+
+func (q *Queries) UpdateProjectRuntimeStatus(ctx context.Context, arg UpdateProjectRuntimeStatusParams) error {
+	_, err := q.db.Exec(ctx, updateProjectRuntimeStatus, arg.ID, arg.RuntimeStatus)
+	return err
+}
+
+const updateProjectStaticSite = `-- name: UpdateProjectStaticSite :one
+UPDATE projects
+SET static_site = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
+`
+
+type UpdateProjectStaticSiteParams struct {
+	ID         string `json:"id"`
+	StaticSite []byte `json:"static_site"`
+}
+
+func (q *Queries) UpdateProjectStaticSite(ctx context.Context, arg UpdateProjectStaticSiteParams) (Project, error) {
+	row := q.db.QueryRow(ctx, updateProjectStaticSite, arg.ID, arg.StaticSite)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.RepoID,
+		&i.RepoName,
+		&i.RepoFullName,
+		&i.RepoUrl,
+		&i.RepoBranch,
+		&i.Status,
+		&i.EnvVariables,
+		&i.DeploymentStatus,
+		&i.Domain,
+		&i.Port,
+		&i.PreviewsEnabled,
+		&i.PreviewEnvVariables,
+		&i.WebhookID,
+		&i.InstallationID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.DeployPublicKey,
+		&i.DeployPrivateKey,
+		&i.AccountID,
+		&i.ForceHttps,
+		&i.TlsCertificate,
+		&i.TlsPrivateKey,
+		&i.RoutingPolicy,
+		&i.RuntimeStatus,
+		&i.Processes,
+		&i.Volumes,
+		&i.StaticSite,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryUsername,
+		&i.RegistryPassword,
+		&i.DeployHookTokenHash,
+		&i.Stopped,
+		&i.ContainerPort,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateProjectStatus = `-- name: UpdateProjectStatus :one
 UPDATE projects
 SET status = $2, updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 `
 
 type UpdateProjectStatusParams struct {
@@ -837,6 +1748,214 @@ func (q *Queries) UpdateProjectStatus(ctx context.Context, arg UpdateProjectStat
 		&i.DeploymentStatus,
 		&i.Domain,
 		&i.Port,
+		&i.PreviewsEnabled,
+		&i.PreviewEnvVariables,
+		&i.WebhookID,
+		&i.InstallationID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.DeployPublicKey,
+		&i.DeployPrivateKey,
+		&i.AccountID,
+		&i.ForceHttps,
+		&i.TlsCertificate,
+		&i.TlsPrivateKey,
+		&i.RoutingPolicy,
+		&i.RuntimeStatus,
+		&i.Processes,
+		&i.Volumes,
+		&i.StaticSite,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryUsername,
+		&i.RegistryPassword,
+		&i.DeployHookTokenHash,
+		&i.Stopped,
+		&i.ContainerPort,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateProjectStopped = `-- name: UpdateProjectStopped :one
+UPDATE projects
+SET stopped = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
+`
+
+type UpdateProjectStoppedParams struct {
+	ID      string `json:"id"`
+	Stopped bool   `json:"stopped"`
+}
+
+func (q *Queries) UpdateProjectStopped(ctx context.Context, arg UpdateProjectStoppedParams) (Project, error) {
+	row := q.db.QueryRow(ctx, updateProjectStopped, arg.ID, arg.Stopped)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.RepoID,
+		&i.RepoName,
+		&i.RepoFullName,
+		&i.RepoUrl,
+		&i.RepoBranch,
+		&i.Status,
+		&i.EnvVariables,
+		&i.DeploymentStatus,
+		&i.Domain,
+		&i.Port,
+		&i.PreviewsEnabled,
+		&i.PreviewEnvVariables,
+		&i.WebhookID,
+		&i.InstallationID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.DeployPublicKey,
+		&i.DeployPrivateKey,
+		&i.AccountID,
+		&i.ForceHttps,
+		&i.TlsCertificate,
+		&i.TlsPrivateKey,
+		&i.RoutingPolicy,
+		&i.RuntimeStatus,
+		&i.Processes,
+		&i.Volumes,
+		&i.StaticSite,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryUsername,
+		&i.RegistryPassword,
+		&i.DeployHookTokenHash,
+		&i.Stopped,
+		&i.ContainerPort,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateProjectTLSSettings = `-- name: UpdateProjectTLSSettings :one
+UPDATE projects
+SET force_https = $2, tls_certificate = $3, tls_private_key = $4, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
+`
+
+type UpdateProjectTLSSettingsParams struct {
+	ID             string      `json:"id"`
+	ForceHttps     bool        `json:"force_https"`
+	TlsCertificate pgtype.Text `json:"tls_certificate"`
+	TlsPrivateKey  pgtype.Text `json:"tls_private_key"`
+}
+
+func (q *Queries) UpdateProjectTLSSettings(ctx context.Context, arg UpdateProjectTLSSettingsParams) (Project, error) {
+	row := q.db.QueryRow(ctx, updateProjectTLSSettings,
+		arg.ID,
+		arg.ForceHttps,
+		arg.TlsCertificate,
+		arg.TlsPrivateKey,
+	)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.RepoID,
+		&i.RepoName,
+		&i.RepoFullName,
+		&i.RepoUrl,
+		&i.RepoBranch,
+		&i.Status,
+		&i.EnvVariables,
+		&i.DeploymentStatus,
+		&i.Domain,
+		&i.Port,
+		&i.PreviewsEnabled,
+		&i.PreviewEnvVariables,
+		&i.WebhookID,
+		&i.InstallationID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.DeployPublicKey,
+		&i.DeployPrivateKey,
+		&i.AccountID,
+		&i.ForceHttps,
+		&i.TlsCertificate,
+		&i.TlsPrivateKey,
+		&i.RoutingPolicy,
+		&i.RuntimeStatus,
+		&i.Processes,
+		&i.Volumes,
+		&i.StaticSite,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryUsername,
+		&i.RegistryPassword,
+		&i.DeployHookTokenHash,
+		&i.Stopped,
+		&i.ContainerPort,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateProjectVolumes = `-- name: UpdateProjectVolumes :one
+UPDATE projects
+SET volumes = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
+`
+
+type UpdateProjectVolumesParams struct {
+	ID      string `json:"id"`
+	Volumes []byte `json:"volumes"`
+}
+
+func (q *Queries) UpdateProjectVolumes(ctx context.Context, arg UpdateProjectVolumesParams) (Project, error) {
+	row := q.db.QueryRow(ctx, updateProjectVolumes, arg.ID, arg.Volumes)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.RepoID,
+		&i.RepoName,
+		&i.RepoFullName,
+		&i.RepoUrl,
+		&i.RepoBranch,
+		&i.Status,
+		&i.EnvVariables,
+		&i.DeploymentStatus,
+		&i.Domain,
+		&i.Port,
+		&i.PreviewsEnabled,
+		&i.PreviewEnvVariables,
+		&i.WebhookID,
+		&i.InstallationID,
+		&i.Provider,
+		&i.ProviderUrl,
+		&i.DeployPublicKey,
+		&i.DeployPrivateKey,
+		&i.AccountID,
+		&i.ForceHttps,
+		&i.TlsCertificate,
+		&i.TlsPrivateKey,
+		&i.RoutingPolicy,
+		&i.RuntimeStatus,
+		&i.Processes,
+		&i.Volumes,
+		&i.StaticSite,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryUsername,
+		&i.RegistryPassword,
+		&i.DeployHookTokenHash,
+		&i.Stopped,
+		&i.ContainerPort,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
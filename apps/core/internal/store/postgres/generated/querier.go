@@ -6,6 +6,7 @@ package generated
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 )
@@ -16,60 +17,157 @@ type Querier interface {
 	AccountExistsByUserIDAndGithubID(ctx context.Context, arg AccountExistsByUserIDAndGithubIDParams) (bool, error)
 	AccountExistsForUser(ctx context.Context, arg AccountExistsForUserParams) (bool, error)
 	ActivateProject(ctx context.Context, id string) (Project, error)
+	AddonExists(ctx context.Context, id string) (bool, error)
+	AddonExistsByUserIDAndName(ctx context.Context, arg AddonExistsByUserIDAndNameParams) (bool, error)
 	ArchiveProject(ctx context.Context, id string) (Project, error)
+	BackupPolicyExistsByUserIDAndName(ctx context.Context, arg BackupPolicyExistsByUserIDAndNameParams) (bool, error)
+	ConsumeOAuthState(ctx context.Context, state string) (OauthState, error)
 	CountAccounts(ctx context.Context) (int64, error)
 	CountAccountsByUserID(ctx context.Context, userID string) (int64, error)
+	CountBackupsByPolicyID(ctx context.Context, policyID string) (int64, error)
+	CountCronJobRunsByCronJobID(ctx context.Context, cronJobID string) (int64, error)
+	CountDeploymentEventsByProjectID(ctx context.Context, projectID string) (int64, error)
 	CountProjects(ctx context.Context) (int64, error)
 	CountProjectsByStatus(ctx context.Context, status string) (int64, error)
 	CountProjectsByUserID(ctx context.Context, userID string) (int64, error)
 	CountUsers(ctx context.Context) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (CreateAccountRow, error)
+	CreateAddon(ctx context.Context, arg CreateAddonParams) (Addon, error)
+	CreateAddonAttachment(ctx context.Context, arg CreateAddonAttachmentParams) (AddonAttachment, error)
+	CreateBackup(ctx context.Context, arg CreateBackupParams) (Backup, error)
+	CreateBackupPolicy(ctx context.Context, arg CreateBackupPolicyParams) (BackupPolicy, error)
+	CreateCronJob(ctx context.Context, arg CreateCronJobParams) (CronJob, error)
+	CreateCronJobRun(ctx context.Context, arg CreateCronJobRunParams) (CronJobRun, error)
+	CreateDeploymentEvent(ctx context.Context, arg CreateDeploymentEventParams) (DeploymentEvent, error)
+	CreateGitHubInstallation(ctx context.Context, arg CreateGitHubInstallationParams) (GithubInstallation, error)
+	CreateOAuthState(ctx context.Context, arg CreateOAuthStateParams) error
 	CreateProject(ctx context.Context, arg CreateProjectParams) (Project, error)
+	CreateProjectDomain(ctx context.Context, arg CreateProjectDomainParams) (ProjectDomain, error)
+	CreateTeardown(ctx context.Context, arg CreateTeardownParams) (Teardown, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (CreateUserRow, error)
+	CronJobExistsByProjectIDAndName(ctx context.Context, arg CronJobExistsByProjectIDAndNameParams) (bool, error)
 	DeleteAccount(ctx context.Context, id string) error
 	DeleteAccountByGithubID(ctx context.Context, githubID int64) error
 	DeleteAccountsByUserID(ctx context.Context, userID string) error
+	DeleteAddon(ctx context.Context, id string) error
+	DeleteAddonAttachment(ctx context.Context, arg DeleteAddonAttachmentParams) error
+	DeleteBackup(ctx context.Context, id string) error
+	DeleteBackupPolicy(ctx context.Context, id string) error
+	DeleteCronJob(ctx context.Context, id string) error
+	DeleteExpiredOAuthStates(ctx context.Context, expiresAt time.Time) error
+	DeleteGitHubInstallation(ctx context.Context, id string) error
+	DeleteGitHubInstallationByInstallationID(ctx context.Context, installationID int64) error
+	DeletePreview(ctx context.Context, id string) error
 	DeleteProject(ctx context.Context, id string) error
+	DeleteProjectDomain(ctx context.Context, id string) error
+	DeleteProjectMetricsBefore(ctx context.Context, arg DeleteProjectMetricsBeforeParams) error
 	DeleteProjectsByUserID(ctx context.Context, userID string) error
+	DeleteTeardown(ctx context.Context, id string) error
 	DeleteUser(ctx context.Context, id string) error
+	FailRunningBackups(ctx context.Context, error string) error
+	FailRunningCronJobRuns(ctx context.Context, logs string) error
+	FinishBackup(ctx context.Context, arg FinishBackupParams) error
+	FinishCronJobRun(ctx context.Context, arg FinishCronJobRunParams) error
 	GetAccountByGithubID(ctx context.Context, githubID int64) (Account, error)
 	GetAccountByGithubUsername(ctx context.Context, githubUsername string) (Account, error)
 	GetAccountByID(ctx context.Context, id string) (Account, error)
+	GetAccountByProviderID(ctx context.Context, arg GetAccountByProviderIDParams) (Account, error)
 	GetAccountWithUser(ctx context.Context, id string) (GetAccountWithUserRow, error)
 	GetAccountsByUserID(ctx context.Context, userID string) ([]GetAccountsByUserIDRow, error)
 	GetAccountsByUserIDWithTokens(ctx context.Context, userID string) ([]Account, error)
+	GetAccountsWithExpiringTokens(ctx context.Context, tokenExpiresAt pgtype.Timestamptz) ([]Account, error)
 	GetActiveProjectsByUserID(ctx context.Context, userID string) ([]Project, error)
+	GetAddonAttachmentsByAddonID(ctx context.Context, addonID string) ([]AddonAttachment, error)
+	GetAddonAttachmentsByProjectID(ctx context.Context, projectID string) ([]AddonAttachment, error)
+	GetAddonByID(ctx context.Context, id string) (Addon, error)
+	GetAddonsByStatus(ctx context.Context, status string) ([]Addon, error)
+	GetAddonsByUserID(ctx context.Context, userID string) ([]Addon, error)
+	GetBackupByID(ctx context.Context, id string) (Backup, error)
+	GetBackupPoliciesByUserID(ctx context.Context, userID string) ([]BackupPolicy, error)
+	GetBackupPolicyByID(ctx context.Context, id string) (BackupPolicy, error)
+	GetBackupsByPolicyID(ctx context.Context, arg GetBackupsByPolicyIDParams) ([]Backup, error)
+	GetCronJobByID(ctx context.Context, id string) (CronJob, error)
+	GetCronJobRunByID(ctx context.Context, id string) (CronJobRun, error)
+	GetCronJobRunsByCronJobID(ctx context.Context, arg GetCronJobRunsByCronJobIDParams) ([]CronJobRun, error)
+	GetCronJobsByProjectID(ctx context.Context, projectID string) ([]CronJob, error)
+	GetDeploymentEventsByProjectID(ctx context.Context, arg GetDeploymentEventsByProjectIDParams) ([]DeploymentEvent, error)
+	GetEnabledCronJobs(ctx context.Context) ([]CronJob, error)
+	GetExpiredBackups(ctx context.Context, arg GetExpiredBackupsParams) ([]Backup, error)
+	GetGitHubInstallationByID(ctx context.Context, id string) (GithubInstallation, error)
+	GetGitHubInstallationByInstallationID(ctx context.Context, installationID int64) (GithubInstallation, error)
+	GetGitHubInstallationsByUserID(ctx context.Context, userID string) ([]GithubInstallation, error)
+	GetPreviewByID(ctx context.Context, id string) (Preview, error)
+	GetPreviewByProjectIDAndNumber(ctx context.Context, arg GetPreviewByProjectIDAndNumberParams) (Preview, error)
+	GetPreviewsByProjectID(ctx context.Context, projectID string) ([]Preview, error)
 	GetProjectByID(ctx context.Context, id string) (Project, error)
 	GetProjectByUserIDAndName(ctx context.Context, arg GetProjectByUserIDAndNameParams) (Project, error)
+	GetProjectDomainByDomain(ctx context.Context, domain string) (ProjectDomain, error)
+	GetProjectDomainByID(ctx context.Context, id string) (ProjectDomain, error)
+	GetProjectDomainsByProjectID(ctx context.Context, projectID string) ([]ProjectDomain, error)
+	GetProjectMetrics(ctx context.Context, arg GetProjectMetricsParams) ([]ProjectMetric, error)
 	GetProjectsByRepoID(ctx context.Context, repoID int64) ([]Project, error)
 	GetProjectsByUserID(ctx context.Context, userID string) ([]Project, error)
 	GetProjectsByUserIDAndStatus(ctx context.Context, arg GetProjectsByUserIDAndStatusParams) ([]Project, error)
+	GetScheduledBackupPolicies(ctx context.Context) ([]BackupPolicy, error)
+	GetTeardownByID(ctx context.Context, id string) (Teardown, error)
+	GetTeardownsByUserID(ctx context.Context, arg GetTeardownsByUserIDParams) ([]Teardown, error)
+	GetUnfinishedTeardowns(ctx context.Context) ([]Teardown, error)
 	GetUsedPorts(ctx context.Context) ([]pgtype.Int4, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByEmailOrUsername(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id string) (User, error)
 	GetUserByUsername(ctx context.Context, username string) (User, error)
+	GetVerifiedProjectDomainsByProjectID(ctx context.Context, projectID string) ([]ProjectDomain, error)
+	GitHubInstallationExists(ctx context.Context, installationID int64) (bool, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]ListAccountsRow, error)
 	ListProjects(ctx context.Context, arg ListProjectsParams) ([]Project, error)
 	ListProjectsByStatus(ctx context.Context, arg ListProjectsByStatusParams) ([]Project, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]ListUsersRow, error)
+	ProjectDomainExists(ctx context.Context, domain string) (bool, error)
 	ProjectExistsByID(ctx context.Context, id string) (bool, error)
 	ProjectExistsByUserIDAndName(ctx context.Context, arg ProjectExistsByUserIDAndNameParams) (bool, error)
+	RollupProjectMetrics(ctx context.Context, bucketStart time.Time) error
 	SearchAccounts(ctx context.Context, arg SearchAccountsParams) ([]SearchAccountsRow, error)
 	SearchAccountsByUserID(ctx context.Context, arg SearchAccountsByUserIDParams) ([]SearchAccountsByUserIDRow, error)
 	SearchProjects(ctx context.Context, arg SearchProjectsParams) ([]Project, error)
 	SearchProjectsByUserID(ctx context.Context, arg SearchProjectsByUserIDParams) ([]Project, error)
 	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]SearchUsersRow, error)
+	SetGitHubInstallationSuspendedAt(ctx context.Context, arg SetGitHubInstallationSuspendedAtParams) error
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (UpdateAccountRow, error)
 	UpdateAccountByGithubID(ctx context.Context, arg UpdateAccountByGithubIDParams) (UpdateAccountByGithubIDRow, error)
+	UpdateAccountOAuthTokens(ctx context.Context, arg UpdateAccountOAuthTokensParams) (UpdateAccountOAuthTokensRow, error)
 	UpdateAccountToken(ctx context.Context, arg UpdateAccountTokenParams) (UpdateAccountTokenRow, error)
+	UpdateAddonPassword(ctx context.Context, arg UpdateAddonPasswordParams) (Addon, error)
+	UpdateAddonStatus(ctx context.Context, arg UpdateAddonStatusParams) (Addon, error)
+	UpdateBackupPolicy(ctx context.Context, arg UpdateBackupPolicyParams) (BackupPolicy, error)
+	UpdateBackupPolicyLastScheduledAt(ctx context.Context, arg UpdateBackupPolicyLastScheduledAtParams) error
+	UpdateCronJob(ctx context.Context, arg UpdateCronJobParams) (CronJob, error)
+	UpdateCronJobLastScheduledAt(ctx context.Context, arg UpdateCronJobLastScheduledAtParams) error
+	UpdatePreviewCommentID(ctx context.Context, arg UpdatePreviewCommentIDParams) error
+	UpdatePreviewStatus(ctx context.Context, arg UpdatePreviewStatusParams) (Preview, error)
 	UpdateProject(ctx context.Context, arg UpdateProjectParams) (Project, error)
 	UpdateProjectBranch(ctx context.Context, arg UpdateProjectBranchParams) (Project, error)
+	UpdateProjectDeployHookToken(ctx context.Context, arg UpdateProjectDeployHookTokenParams) (Project, error)
+	UpdateProjectDeployKey(ctx context.Context, arg UpdateProjectDeployKeyParams) (Project, error)
 	UpdateProjectDeploymentStatus(ctx context.Context, arg UpdateProjectDeploymentStatusParams) (Project, error)
+	UpdateProjectDomain(ctx context.Context, arg UpdateProjectDomainParams) (Project, error)
+	UpdateProjectDomainVerification(ctx context.Context, arg UpdateProjectDomainVerificationParams) (ProjectDomain, error)
+	UpdateProjectImageSource(ctx context.Context, arg UpdateProjectImageSourceParams) (Project, error)
 	UpdateProjectPort(ctx context.Context, arg UpdateProjectPortParams) error
+	UpdateProjectPreviewSettings(ctx context.Context, arg UpdateProjectPreviewSettingsParams) (Project, error)
+	UpdateProjectProcesses(ctx context.Context, arg UpdateProjectProcessesParams) (Project, error)
+	UpdateProjectRoutingPolicy(ctx context.Context, arg UpdateProjectRoutingPolicyParams) (Project, error)
+	UpdateProjectRuntimeStatus(ctx context.Context, arg UpdateProjectRuntimeStatusParams) error
+	UpdateProjectStaticSite(ctx context.Context, arg UpdateProjectStaticSiteParams) (Project, error)
 	UpdateProjectStatus(ctx context.Context, arg UpdateProjectStatusParams) (Project, error)
+	UpdateProjectStopped(ctx context.Context, arg UpdateProjectStoppedParams) (Project, error)
+	UpdateProjectTLSSettings(ctx context.Context, arg UpdateProjectTLSSettingsParams) (Project, error)
+	UpdateProjectVolumes(ctx context.Context, arg UpdateProjectVolumesParams) (Project, error)
+	UpdateTeardownProgress(ctx context.Context, arg UpdateTeardownProgressParams) error
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateUserRow, error)
 	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (UpdateUserPasswordRow, error)
+	UpsertPreview(ctx context.Context, arg UpsertPreviewParams) (Preview, error)
+	UpsertProjectMetric(ctx context.Context, arg UpsertProjectMetricParams) error
 	UserExistsByEmail(ctx context.Context, email string) (bool, error)
 	UserExistsByUsername(ctx context.Context, username string) (bool, error)
 }
@@ -1,31 +1,36 @@
 -- name: CreateAccount :one
-INSERT INTO accounts (user_id, github_username, github_id, avatar_url, access_token)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, user_id, github_username, github_id, avatar_url, created_at, updated_at;
+INSERT INTO accounts (user_id, github_username, github_id, provider, provider_url, avatar_url, access_token, refresh_token, token_expires_at, refresh_token_expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id, user_id, github_username, github_id, provider, provider_url, avatar_url, created_at, updated_at;
 
 -- name: GetAccountByID :one
-SELECT id, user_id, github_username, github_id, avatar_url, access_token, created_at, updated_at
+SELECT id, user_id, github_username, github_id, provider, provider_url, avatar_url, access_token, refresh_token, token_expires_at, refresh_token_expires_at, created_at, updated_at
 FROM accounts
 WHERE id = $1;
 
 -- name: GetAccountByGithubID :one
-SELECT id, user_id, github_username, github_id, avatar_url, access_token, created_at, updated_at
+SELECT id, user_id, github_username, github_id, provider, provider_url, avatar_url, access_token, refresh_token, token_expires_at, refresh_token_expires_at, created_at, updated_at
 FROM accounts
 WHERE github_id = $1;
 
+-- name: GetAccountByProviderID :one
+SELECT id, user_id, github_username, github_id, provider, provider_url, avatar_url, access_token, refresh_token, token_expires_at, refresh_token_expires_at, created_at, updated_at
+FROM accounts
+WHERE provider_url = $1 AND github_id = $2;
+
 -- name: GetAccountByGithubUsername :one
-SELECT id, user_id, github_username, github_id, avatar_url, access_token, created_at, updated_at
+SELECT id, user_id, github_username, github_id, provider, provider_url, avatar_url, access_token, refresh_token, token_expires_at, refresh_token_expires_at, created_at, updated_at
 FROM accounts
 WHERE github_username = $1;
 
 -- name: GetAccountsByUserID :many
-SELECT id, user_id, github_username, github_id, avatar_url, created_at, updated_at
+SELECT id, user_id, github_username, github_id, provider, provider_url, avatar_url, created_at, updated_at
 FROM accounts
 WHERE user_id = $1
 ORDER BY created_at DESC;
 
 -- name: GetAccountsByUserIDWithTokens :many
-SELECT id, user_id, github_username, github_id, avatar_url, access_token, created_at, updated_at
+SELECT id, user_id, github_username, github_id, provider, provider_url, avatar_url, access_token, refresh_token, token_expires_at, refresh_token_expires_at, created_at, updated_at
 FROM accounts
 WHERE user_id = $1
 ORDER BY created_at DESC;
@@ -34,19 +39,31 @@ ORDER BY created_at DESC;
 UPDATE accounts
 SET github_username = $2, avatar_url = $3, updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, user_id, github_username, github_id, avatar_url, created_at, updated_at;
+RETURNING id, user_id, github_username, github_id, provider, provider_url, avatar_url, created_at, updated_at;
 
 -- name: UpdateAccountToken :one
 UPDATE accounts
 SET access_token = $2, updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, user_id, github_username, github_id, avatar_url, created_at, updated_at;
+RETURNING id, user_id, github_username, github_id, provider, provider_url, avatar_url, created_at, updated_at;
+
+-- name: UpdateAccountOAuthTokens :one
+UPDATE accounts
+SET access_token = $2, refresh_token = $3, token_expires_at = $4, refresh_token_expires_at = $5, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, user_id, github_username, github_id, provider, provider_url, avatar_url, created_at, updated_at;
+
+-- name: GetAccountsWithExpiringTokens :many
+SELECT id, user_id, github_username, github_id, provider, provider_url, avatar_url, access_token, refresh_token, token_expires_at, refresh_token_expires_at, created_at, updated_at
+FROM accounts
+WHERE refresh_token IS NOT NULL AND token_expires_at < $1
+ORDER BY token_expires_at ASC;
 
 -- name: UpdateAccountByGithubID :one
 UPDATE accounts
 SET github_username = $2, avatar_url = $3, access_token = $4, updated_at = CURRENT_TIMESTAMP
 WHERE github_id = $1
-RETURNING id, user_id, github_username, github_id, avatar_url, created_at, updated_at;
+RETURNING id, user_id, github_username, github_id, provider, provider_url, avatar_url, created_at, updated_at;
 
 -- name: DeleteAccount :exec
 DELETE FROM accounts
@@ -61,7 +78,7 @@ DELETE FROM accounts
 WHERE user_id = $1;
 
 -- name: ListAccounts :many
-SELECT id, user_id, github_username, github_id, avatar_url, created_at, updated_at
+SELECT id, user_id, github_username, github_id, provider, provider_url, avatar_url, created_at, updated_at
 FROM accounts
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2;
@@ -85,14 +102,14 @@ SELECT EXISTS(SELECT 1 FROM accounts WHERE user_id = $1 AND github_id = $2);
 SELECT EXISTS(SELECT 1 FROM accounts WHERE user_id = $1 AND github_id = $2);
 
 -- name: SearchAccounts :many
-SELECT id, user_id, github_username, github_id, avatar_url, created_at, updated_at
+SELECT id, user_id, github_username, github_id, provider, provider_url, avatar_url, created_at, updated_at
 FROM accounts
 WHERE github_username ILIKE '%' || $1 || '%'
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
 -- name: SearchAccountsByUserID :many
-SELECT id, user_id, github_username, github_id, avatar_url, created_at, updated_at
+SELECT id, user_id, github_username, github_id, provider, provider_url, avatar_url, created_at, updated_at
 FROM accounts
 WHERE user_id = $1 AND github_username ILIKE '%' || $2 || '%'
 ORDER BY created_at DESC
@@ -104,6 +121,8 @@ SELECT
     a.user_id,
     a.github_username,
     a.github_id,
+    a.provider,
+    a.provider_url,
     a.avatar_url,
     a.access_token,
     a.created_at,
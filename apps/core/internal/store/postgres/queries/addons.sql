@@ -0,0 +1,63 @@
+-- name: CreateAddon :one
+INSERT INTO addons (user_id, name, type, version, username, password, database_name)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, user_id, name, type, version, status, error, username, password, database_name, created_at, updated_at;
+
+-- name: GetAddonByID :one
+SELECT id, user_id, name, type, version, status, error, username, password, database_name, created_at, updated_at
+FROM addons
+WHERE id = $1;
+
+-- name: GetAddonsByUserID :many
+SELECT id, user_id, name, type, version, status, error, username, password, database_name, created_at, updated_at
+FROM addons
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: GetAddonsByStatus :many
+SELECT id, user_id, name, type, version, status, error, username, password, database_name, created_at, updated_at
+FROM addons
+WHERE status = $1;
+
+-- name: AddonExists :one
+SELECT EXISTS(SELECT 1 FROM addons WHERE id = $1);
+
+-- name: AddonExistsByUserIDAndName :one
+SELECT EXISTS(SELECT 1 FROM addons WHERE user_id = $1 AND name = $2);
+
+-- name: UpdateAddonStatus :one
+UPDATE addons
+SET status = $2, error = $3, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, user_id, name, type, version, status, error, username, password, database_name, created_at, updated_at;
+
+-- name: UpdateAddonPassword :one
+UPDATE addons
+SET password = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, user_id, name, type, version, status, error, username, password, database_name, created_at, updated_at;
+
+-- name: DeleteAddon :exec
+DELETE FROM addons
+WHERE id = $1;
+
+-- name: CreateAddonAttachment :one
+INSERT INTO addon_attachments (addon_id, project_id, env_var)
+VALUES ($1, $2, $3)
+RETURNING addon_id, project_id, env_var, created_at;
+
+-- name: GetAddonAttachmentsByAddonID :many
+SELECT addon_id, project_id, env_var, created_at
+FROM addon_attachments
+WHERE addon_id = $1
+ORDER BY created_at;
+
+-- name: GetAddonAttachmentsByProjectID :many
+SELECT addon_id, project_id, env_var, created_at
+FROM addon_attachments
+WHERE project_id = $1
+ORDER BY created_at;
+
+-- name: DeleteAddonAttachment :exec
+DELETE FROM addon_attachments
+WHERE addon_id = $1 AND project_id = $2;
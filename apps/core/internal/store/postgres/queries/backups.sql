@@ -0,0 +1,80 @@
+-- name: CreateBackupPolicy :one
+INSERT INTO backup_policies (user_id, name, target_type, addon_id, project_id, volume_name, schedule, retention, destination, enabled)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id, user_id, name, target_type, addon_id, project_id, volume_name, schedule, retention, destination, enabled, last_scheduled_at, created_at, updated_at;
+
+-- name: GetBackupPolicyByID :one
+SELECT id, user_id, name, target_type, addon_id, project_id, volume_name, schedule, retention, destination, enabled, last_scheduled_at, created_at, updated_at
+FROM backup_policies
+WHERE id = $1;
+
+-- name: GetBackupPoliciesByUserID :many
+SELECT id, user_id, name, target_type, addon_id, project_id, volume_name, schedule, retention, destination, enabled, last_scheduled_at, created_at, updated_at
+FROM backup_policies
+WHERE user_id = $1
+ORDER BY name;
+
+-- name: GetScheduledBackupPolicies :many
+SELECT id, user_id, name, target_type, addon_id, project_id, volume_name, schedule, retention, destination, enabled, last_scheduled_at, created_at, updated_at
+FROM backup_policies
+WHERE enabled = true AND schedule <> '';
+
+-- name: BackupPolicyExistsByUserIDAndName :one
+SELECT EXISTS(SELECT 1 FROM backup_policies WHERE user_id = $1 AND name = $2);
+
+-- name: UpdateBackupPolicy :one
+UPDATE backup_policies
+SET name = $2, schedule = $3, retention = $4, destination = $5, enabled = $6, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, user_id, name, target_type, addon_id, project_id, volume_name, schedule, retention, destination, enabled, last_scheduled_at, created_at, updated_at;
+
+-- name: UpdateBackupPolicyLastScheduledAt :exec
+UPDATE backup_policies
+SET last_scheduled_at = $2
+WHERE id = $1;
+
+-- name: DeleteBackupPolicy :exec
+DELETE FROM backup_policies
+WHERE id = $1;
+
+-- name: CreateBackup :one
+INSERT INTO backups (policy_id, operation, trigger, restored_from)
+VALUES ($1, $2, $3, $4)
+RETURNING id, policy_id, operation, trigger, status, object_key, size_bytes, restored_from, error, started_at, finished_at;
+
+-- name: FinishBackup :exec
+UPDATE backups
+SET status = $2, object_key = $3, size_bytes = $4, error = $5, finished_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: FailRunningBackups :exec
+UPDATE backups
+SET status = 'failed', error = $1, finished_at = CURRENT_TIMESTAMP
+WHERE status = 'running';
+
+-- name: GetBackupByID :one
+SELECT id, policy_id, operation, trigger, status, object_key, size_bytes, restored_from, error, started_at, finished_at
+FROM backups
+WHERE id = $1;
+
+-- name: GetBackupsByPolicyID :many
+SELECT id, policy_id, operation, trigger, status, object_key, size_bytes, restored_from, error, started_at, finished_at
+FROM backups
+WHERE policy_id = $1
+ORDER BY started_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountBackupsByPolicyID :one
+SELECT COUNT(*) FROM backups
+WHERE policy_id = $1;
+
+-- name: GetExpiredBackups :many
+SELECT id, policy_id, operation, trigger, status, object_key, size_bytes, restored_from, error, started_at, finished_at
+FROM backups
+WHERE policy_id = $1 AND operation = 'backup' AND status = 'succeeded'
+ORDER BY started_at DESC
+OFFSET $2;
+
+-- name: DeleteBackup :exec
+DELETE FROM backups
+WHERE id = $1;
@@ -0,0 +1,69 @@
+-- name: CreateCronJob :one
+INSERT INTO cron_jobs (project_id, name, schedule, command, timeout_seconds, concurrency_policy, enabled)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, project_id, name, schedule, command, timeout_seconds, concurrency_policy, enabled, last_scheduled_at, created_at, updated_at;
+
+-- name: GetCronJobByID :one
+SELECT id, project_id, name, schedule, command, timeout_seconds, concurrency_policy, enabled, last_scheduled_at, created_at, updated_at
+FROM cron_jobs
+WHERE id = $1;
+
+-- name: GetCronJobsByProjectID :many
+SELECT id, project_id, name, schedule, command, timeout_seconds, concurrency_policy, enabled, last_scheduled_at, created_at, updated_at
+FROM cron_jobs
+WHERE project_id = $1
+ORDER BY name;
+
+-- name: GetEnabledCronJobs :many
+SELECT id, project_id, name, schedule, command, timeout_seconds, concurrency_policy, enabled, last_scheduled_at, created_at, updated_at
+FROM cron_jobs
+WHERE enabled = true;
+
+-- name: CronJobExistsByProjectIDAndName :one
+SELECT EXISTS(SELECT 1 FROM cron_jobs WHERE project_id = $1 AND name = $2);
+
+-- name: UpdateCronJob :one
+UPDATE cron_jobs
+SET name = $2, schedule = $3, command = $4, timeout_seconds = $5, concurrency_policy = $6, enabled = $7, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, project_id, name, schedule, command, timeout_seconds, concurrency_policy, enabled, last_scheduled_at, created_at, updated_at;
+
+-- name: UpdateCronJobLastScheduledAt :exec
+UPDATE cron_jobs
+SET last_scheduled_at = $2
+WHERE id = $1;
+
+-- name: DeleteCronJob :exec
+DELETE FROM cron_jobs
+WHERE id = $1;
+
+-- name: CreateCronJobRun :one
+INSERT INTO cron_job_runs (cron_job_id, project_id, trigger, status)
+VALUES ($1, $2, $3, $4)
+RETURNING id, cron_job_id, project_id, trigger, status, exit_code, logs, started_at, finished_at;
+
+-- name: FinishCronJobRun :exec
+UPDATE cron_job_runs
+SET status = $2, exit_code = $3, logs = $4, finished_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: FailRunningCronJobRuns :exec
+UPDATE cron_job_runs
+SET status = 'failed', logs = $1, finished_at = CURRENT_TIMESTAMP
+WHERE status = 'running';
+
+-- name: GetCronJobRunByID :one
+SELECT id, cron_job_id, project_id, trigger, status, exit_code, logs, started_at, finished_at
+FROM cron_job_runs
+WHERE id = $1;
+
+-- name: GetCronJobRunsByCronJobID :many
+SELECT id, cron_job_id, project_id, trigger, status, exit_code, logs, started_at, finished_at
+FROM cron_job_runs
+WHERE cron_job_id = $1
+ORDER BY started_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountCronJobRunsByCronJobID :one
+SELECT COUNT(*) FROM cron_job_runs
+WHERE cron_job_id = $1;
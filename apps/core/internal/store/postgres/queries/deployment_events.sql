@@ -0,0 +1,15 @@
+-- name: CreateDeploymentEvent :one
+INSERT INTO deployment_events (project_id, user_id, action, status, deployment, message)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, project_id, user_id, action, status, deployment, message, created_at;
+
+-- name: GetDeploymentEventsByProjectID :many
+SELECT id, project_id, user_id, action, status, deployment, message, created_at
+FROM deployment_events
+WHERE project_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountDeploymentEventsByProjectID :one
+SELECT COUNT(*) FROM deployment_events
+WHERE project_id = $1;
@@ -0,0 +1,36 @@
+-- name: CreateGitHubInstallation :one
+INSERT INTO github_installations (user_id, installation_id, account_login, account_type)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, installation_id, account_login, account_type, suspended_at, created_at, updated_at;
+
+-- name: GetGitHubInstallationByID :one
+SELECT id, user_id, installation_id, account_login, account_type, suspended_at, created_at, updated_at
+FROM github_installations
+WHERE id = $1;
+
+-- name: GetGitHubInstallationByInstallationID :one
+SELECT id, user_id, installation_id, account_login, account_type, suspended_at, created_at, updated_at
+FROM github_installations
+WHERE installation_id = $1;
+
+-- name: GetGitHubInstallationsByUserID :many
+SELECT id, user_id, installation_id, account_login, account_type, suspended_at, created_at, updated_at
+FROM github_installations
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: GitHubInstallationExists :one
+SELECT EXISTS(SELECT 1 FROM github_installations WHERE installation_id = $1);
+
+-- name: DeleteGitHubInstallation :exec
+DELETE FROM github_installations
+WHERE id = $1;
+
+-- name: DeleteGitHubInstallationByInstallationID :exec
+DELETE FROM github_installations
+WHERE installation_id = $1;
+
+-- name: SetGitHubInstallationSuspendedAt :exec
+UPDATE github_installations
+SET suspended_at = $2, updated_at = CURRENT_TIMESTAMP
+WHERE installation_id = $1;
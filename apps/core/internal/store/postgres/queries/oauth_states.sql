@@ -0,0 +1,12 @@
+-- name: CreateOAuthState :exec
+INSERT INTO oauth_states (state, user_id, redirect_to, expires_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: ConsumeOAuthState :one
+DELETE FROM oauth_states
+WHERE state = $1
+RETURNING state, user_id, redirect_to, expires_at, created_at;
+
+-- name: DeleteExpiredOAuthStates :exec
+DELETE FROM oauth_states
+WHERE expires_at < $1;
@@ -0,0 +1,37 @@
+-- name: UpsertPreview :one
+INSERT INTO previews (project_id, pr_number, pr_title, head_branch, head_sha, domain, status)
+VALUES ($1, $2, $3, $4, $5, $6, 'pending')
+ON CONFLICT (project_id, pr_number) DO UPDATE
+SET pr_title = EXCLUDED.pr_title, head_branch = EXCLUDED.head_branch, head_sha = EXCLUDED.head_sha, status = 'pending', updated_at = CURRENT_TIMESTAMP
+RETURNING id, project_id, pr_number, pr_title, head_branch, head_sha, domain, status, comment_id, created_at, updated_at;
+
+-- name: GetPreviewByID :one
+SELECT id, project_id, pr_number, pr_title, head_branch, head_sha, domain, status, comment_id, created_at, updated_at
+FROM previews
+WHERE id = $1;
+
+-- name: GetPreviewByProjectIDAndNumber :one
+SELECT id, project_id, pr_number, pr_title, head_branch, head_sha, domain, status, comment_id, created_at, updated_at
+FROM previews
+WHERE project_id = $1 AND pr_number = $2;
+
+-- name: GetPreviewsByProjectID :many
+SELECT id, project_id, pr_number, pr_title, head_branch, head_sha, domain, status, comment_id, created_at, updated_at
+FROM previews
+WHERE project_id = $1
+ORDER BY pr_number DESC;
+
+-- name: UpdatePreviewStatus :one
+UPDATE previews
+SET status = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, project_id, pr_number, pr_title, head_branch, head_sha, domain, status, comment_id, created_at, updated_at;
+
+-- name: UpdatePreviewCommentID :exec
+UPDATE previews
+SET comment_id = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: DeletePreview :exec
+DELETE FROM previews
+WHERE id = $1;
@@ -0,0 +1,39 @@
+-- name: CreateProjectDomain :one
+INSERT INTO project_domains (project_id, domain, verification_token, status, verified_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, project_id, domain, verification_token, status, verification_error, verified_at, last_checked_at, created_at, updated_at;
+
+-- name: GetProjectDomainByID :one
+SELECT id, project_id, domain, verification_token, status, verification_error, verified_at, last_checked_at, created_at, updated_at
+FROM project_domains
+WHERE id = $1;
+
+-- name: GetProjectDomainByDomain :one
+SELECT id, project_id, domain, verification_token, status, verification_error, verified_at, last_checked_at, created_at, updated_at
+FROM project_domains
+WHERE domain = $1;
+
+-- name: GetProjectDomainsByProjectID :many
+SELECT id, project_id, domain, verification_token, status, verification_error, verified_at, last_checked_at, created_at, updated_at
+FROM project_domains
+WHERE project_id = $1
+ORDER BY created_at ASC;
+
+-- name: GetVerifiedProjectDomainsByProjectID :many
+SELECT id, project_id, domain, verification_token, status, verification_error, verified_at, last_checked_at, created_at, updated_at
+FROM project_domains
+WHERE project_id = $1 AND status = 'verified'
+ORDER BY created_at ASC;
+
+-- name: UpdateProjectDomainVerification :one
+UPDATE project_domains
+SET status = $2, verification_error = $3, verified_at = $4, last_checked_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, project_id, domain, verification_token, status, verification_error, verified_at, last_checked_at, created_at, updated_at;
+
+-- name: ProjectDomainExists :one
+SELECT EXISTS(SELECT 1 FROM project_domains WHERE domain = $1);
+
+-- name: DeleteProjectDomain :exec
+DELETE FROM project_domains
+WHERE id = $1;
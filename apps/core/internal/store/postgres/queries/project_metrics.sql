@@ -0,0 +1,35 @@
+-- name: UpsertProjectMetric :exec
+INSERT INTO project_metrics (project_id, service_name, resolution_seconds, bucket_start, cpu_percent, memory_bytes, memory_limit_bytes, network_rx_rate, network_tx_rate, restarts)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (project_id, service_name, resolution_seconds, bucket_start) DO UPDATE
+SET cpu_percent = EXCLUDED.cpu_percent,
+    memory_bytes = EXCLUDED.memory_bytes,
+    memory_limit_bytes = EXCLUDED.memory_limit_bytes,
+    network_rx_rate = EXCLUDED.network_rx_rate,
+    network_tx_rate = EXCLUDED.network_tx_rate,
+    restarts = project_metrics.restarts + EXCLUDED.restarts;
+
+-- name: RollupProjectMetrics :exec
+INSERT INTO project_metrics (project_id, service_name, resolution_seconds, bucket_start, cpu_percent, memory_bytes, memory_limit_bytes, network_rx_rate, network_tx_rate, restarts, samples)
+SELECT project_id, service_name, 3600, date_trunc('hour', bucket_start), AVG(cpu_percent), AVG(memory_bytes)::BIGINT, MAX(memory_limit_bytes), AVG(network_rx_rate), AVG(network_tx_rate), SUM(restarts)::INTEGER, COUNT(*)::INTEGER
+FROM project_metrics
+WHERE resolution_seconds = 60 AND bucket_start >= $1
+GROUP BY project_id, service_name, date_trunc('hour', bucket_start)
+ON CONFLICT (project_id, service_name, resolution_seconds, bucket_start) DO UPDATE
+SET cpu_percent = EXCLUDED.cpu_percent,
+    memory_bytes = EXCLUDED.memory_bytes,
+    memory_limit_bytes = EXCLUDED.memory_limit_bytes,
+    network_rx_rate = EXCLUDED.network_rx_rate,
+    network_tx_rate = EXCLUDED.network_tx_rate,
+    restarts = EXCLUDED.restarts,
+    samples = EXCLUDED.samples;
+
+-- name: DeleteProjectMetricsBefore :exec
+DELETE FROM project_metrics
+WHERE resolution_seconds = $1 AND bucket_start < $2;
+
+-- name: GetProjectMetrics :many
+SELECT project_id, service_name, resolution_seconds, bucket_start, cpu_percent, memory_bytes, memory_limit_bytes, network_rx_rate, network_tx_rate, restarts, samples
+FROM project_metrics
+WHERE project_id = $1 AND resolution_seconds = $2 AND bucket_start >= $3
+ORDER BY service_name ASC, bucket_start ASC;
@@ -1,32 +1,32 @@
 -- name: CreateProject :one
-INSERT INTO projects (name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port)
-VALUES ($1, $2, $3, $4, $5, $6, $7, 'active', $8, 'pending', $9, $10)
-RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at;
+INSERT INTO projects (name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, source_type, image, registry_username, registry_password, container_port)
+VALUES ($1, $2, $3, $4, $5, $6, $7, 'active', $8, 'pending', $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at;
 
 -- name: GetProjectByID :one
-SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 FROM projects
 WHERE id = $1;
 
 -- name: GetProjectByUserIDAndName :one
-SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 FROM projects
 WHERE user_id = $1 AND name = $2;
 
 -- name: GetProjectsByUserID :many
-SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 FROM projects
 WHERE user_id = $1
 ORDER BY created_at DESC;
 
 -- name: GetProjectsByUserIDAndStatus :many
-SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 FROM projects
 WHERE user_id = $1 AND status = $2
 ORDER BY created_at DESC;
 
 -- name: GetProjectsByRepoID :many
-SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 FROM projects
 WHERE repo_id = $1
 ORDER BY created_at DESC;
@@ -35,25 +35,25 @@ ORDER BY created_at DESC;
 UPDATE projects
 SET name = $2, repo_branch = $3, status = $4, domain = $5, updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at;
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at;
 
 -- name: UpdateProjectStatus :one
 UPDATE projects
 SET status = $2, updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at;
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at;
 
 -- name: UpdateProjectDeploymentStatus :one
 UPDATE projects
 SET deployment_status = $2, updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at;
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at;
 
 -- name: UpdateProjectBranch :one
 UPDATE projects
 SET repo_branch = $2, updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at;
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at;
 
 -- name: DeleteProject :exec
 DELETE FROM projects
@@ -64,13 +64,13 @@ DELETE FROM projects
 WHERE user_id = $1;
 
 -- name: ListProjects :many
-SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 FROM projects
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2;
 
 -- name: ListProjectsByStatus :many
-SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 FROM projects
 WHERE status = $1
 ORDER BY created_at DESC
@@ -92,7 +92,7 @@ SELECT EXISTS(SELECT 1 FROM projects WHERE user_id = $1 AND name = $2);
 SELECT EXISTS(SELECT 1 FROM projects WHERE id = $1);
 
 -- name: SearchProjectsByUserID :many
-SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 FROM projects
 WHERE user_id = $1 AND (
     name ILIKE '%' || $2 || '%' 
@@ -103,7 +103,7 @@ ORDER BY created_at DESC
 LIMIT $3 OFFSET $4;
 
 -- name: SearchProjects :many
-SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 FROM projects
 WHERE name ILIKE '%' || $1 || '%' 
    OR repo_name ILIKE '%' || $1 || '%'
@@ -112,7 +112,7 @@ ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
 -- name: GetActiveProjectsByUserID :many
-SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at
+SELECT id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at
 FROM projects
 WHERE user_id = $1 AND status = 'active'
 ORDER BY created_at DESC;
@@ -121,13 +121,13 @@ ORDER BY created_at DESC;
 UPDATE projects
 SET status = 'archived', updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at;
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at;
 
 -- name: ActivateProject :one
 UPDATE projects
 SET status = 'active', updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, created_at, updated_at;
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at;
 
 -- name: GetUsedPorts :many
 SELECT port FROM projects WHERE port IS NOT NULL ORDER BY port ASC;
@@ -136,3 +136,74 @@ SELECT port FROM projects WHERE port IS NOT NULL ORDER BY port ASC;
 UPDATE projects
 SET port = $2, updated_at = CURRENT_TIMESTAMP
 WHERE id = $1;
+
+-- name: UpdateProjectPreviewSettings :one
+UPDATE projects
+SET previews_enabled = $2, preview_env_variables = $3, webhook_id = $4, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at;
+
+-- name: UpdateProjectDeployKey :one
+UPDATE projects
+SET deploy_public_key = $2, deploy_private_key = $3, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at;
+
+-- name: UpdateProjectDomain :one
+UPDATE projects
+SET domain = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at;
+
+-- name: UpdateProjectTLSSettings :one
+UPDATE projects
+SET force_https = $2, tls_certificate = $3, tls_private_key = $4, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at;
+
+-- name: UpdateProjectRoutingPolicy :one
+UPDATE projects
+SET routing_policy = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at;
+
+-- name: UpdateProjectRuntimeStatus :exec
+UPDATE projects
+SET runtime_status = $2
+WHERE id = $1;
+
+-- name: UpdateProjectProcesses :one
+UPDATE projects
+SET processes = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at;
+
+-- name: UpdateProjectVolumes :one
+UPDATE projects
+SET volumes = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at;
+
+-- name: UpdateProjectStaticSite :one
+UPDATE projects
+SET static_site = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at;
+
+-- name: UpdateProjectImageSource :one
+UPDATE projects
+SET image = $2, registry_username = $3, registry_password = $4, container_port = $5, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at;
+
+-- name: UpdateProjectDeployHookToken :one
+UPDATE projects
+SET deploy_hook_token_hash = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at;
+
+-- name: UpdateProjectStopped :one
+UPDATE projects
+SET stopped = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, user_id, repo_id, repo_name, repo_full_name, repo_url, repo_branch, status, env_variables, deployment_status, domain, port, previews_enabled, preview_env_variables, webhook_id, installation_id, provider, provider_url, deploy_public_key, deploy_private_key, account_id, force_https, tls_certificate, tls_private_key, routing_policy, runtime_status, processes, volumes, static_site, source_type, image, registry_username, registry_password, deploy_hook_token_hash, stopped, container_port, created_at, updated_at;
@@ -0,0 +1,31 @@
+-- name: CreateTeardown :one
+INSERT INTO teardowns (user_id, project_id, project_name, repo_full_name, webhook_id, provider, provider_url, account_id, installation_id, remove_volumes, status, steps)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING id, user_id, project_id, project_name, repo_full_name, webhook_id, provider, provider_url, account_id, installation_id, remove_volumes, status, steps, created_at, updated_at, finished_at;
+
+-- name: GetTeardownByID :one
+SELECT id, user_id, project_id, project_name, repo_full_name, webhook_id, provider, provider_url, account_id, installation_id, remove_volumes, status, steps, created_at, updated_at, finished_at
+FROM teardowns
+WHERE id = $1;
+
+-- name: GetTeardownsByUserID :many
+SELECT id, user_id, project_id, project_name, repo_full_name, webhook_id, provider, provider_url, account_id, installation_id, remove_volumes, status, steps, created_at, updated_at, finished_at
+FROM teardowns
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
+
+-- name: GetUnfinishedTeardowns :many
+SELECT id, user_id, project_id, project_name, repo_full_name, webhook_id, provider, provider_url, account_id, installation_id, remove_volumes, status, steps, created_at, updated_at, finished_at
+FROM teardowns
+WHERE finished_at IS NULL
+ORDER BY created_at;
+
+-- name: UpdateTeardownProgress :exec
+UPDATE teardowns
+SET status = $2, steps = $3, finished_at = $4, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: DeleteTeardown :exec
+DELETE FROM teardowns
+WHERE id = $1;
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/dopeCape/kova/internal/models"
 	"github.com/dopeCape/kova/internal/store/postgres/generated"
@@ -12,11 +13,16 @@ import (
 
 func (s *Store) CreateAccount(ctx context.Context, account *models.Account) error {
 	params := generated.CreateAccountParams{
-		UserID:         account.UserID,
-		GithubUsername: account.GithubUsername,
-		GithubID:       account.GithubID,
-		AvatarUrl:      pgtype.Text{String: account.AvatarURL, Valid: account.AvatarURL != ""},
-		AccessToken:    account.AccessToken,
+		UserID:                account.UserID,
+		GithubUsername:        account.GithubUsername,
+		GithubID:              account.GithubID,
+		Provider:              account.Provider,
+		ProviderUrl:           account.ProviderURL,
+		AvatarUrl:             pgtype.Text{String: account.AvatarURL, Valid: account.AvatarURL != ""},
+		AccessToken:           account.AccessToken,
+		RefreshToken:          pgtype.Text{String: account.RefreshToken, Valid: account.RefreshToken != ""},
+		TokenExpiresAt:        toTimestamptz(account.TokenExpiresAt),
+		RefreshTokenExpiresAt: toTimestamptz(account.RefreshTokenExpiresAt),
 	}
 
 	dbAccount, err := s.queries.CreateAccount(ctx, params)
@@ -25,14 +31,19 @@ func (s *Store) CreateAccount(ctx context.Context, account *models.Account) erro
 	}
 
 	*account = models.Account{
-		ID:             dbAccount.ID,
-		UserID:         dbAccount.UserID,
-		GithubUsername: dbAccount.GithubUsername,
-		GithubID:       dbAccount.GithubID,
-		AvatarURL:      dbAccount.AvatarUrl.String,
-		AccessToken:    account.AccessToken,
-		CreatedAt:      dbAccount.CreatedAt,
-		UpdatedAt:      dbAccount.UpdatedAt,
+		ID:                    dbAccount.ID,
+		UserID:                dbAccount.UserID,
+		GithubUsername:        dbAccount.GithubUsername,
+		GithubID:              dbAccount.GithubID,
+		Provider:              dbAccount.Provider,
+		ProviderURL:           dbAccount.ProviderUrl,
+		AvatarURL:             dbAccount.AvatarUrl.String,
+		AccessToken:           account.AccessToken,
+		RefreshToken:          account.RefreshToken,
+		TokenExpiresAt:        account.TokenExpiresAt,
+		RefreshTokenExpiresAt: account.RefreshTokenExpiresAt,
+		CreatedAt:             dbAccount.CreatedAt,
+		UpdatedAt:             dbAccount.UpdatedAt,
 	}
 
 	return nil
@@ -64,6 +75,23 @@ func (s *Store) GetAccountByGithubID(ctx context.Context, githubID int64) (*mode
 	return &account, nil
 }
 
+// GetAccountByProviderID retrieves an account by its user ID on a provider instance
+func (s *Store) GetAccountByProviderID(ctx context.Context, providerURL string, providerUserID int64) (*models.Account, error) {
+	dbAccount, err := s.queries.GetAccountByProviderID(ctx, generated.GetAccountByProviderIDParams{
+		ProviderUrl: providerURL,
+		GithubID:    providerUserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAccountNotFound
+		}
+		return nil, err
+	}
+
+	account := s.toDomainAccount(dbAccount)
+	return &account, nil
+}
+
 func (s *Store) GetAccountByGithubUsername(ctx context.Context, githubUsername string) (*models.Account, error) {
 	dbAccount, err := s.queries.GetAccountByGithubUsername(ctx, githubUsername)
 	if err != nil {
@@ -90,6 +118,8 @@ func (s *Store) GetAccountsByUserID(ctx context.Context, userID string) ([]*mode
 			UserID:         dbAccount.UserID,
 			GithubUsername: dbAccount.GithubUsername,
 			GithubID:       dbAccount.GithubID,
+			Provider:       dbAccount.Provider,
+			ProviderURL:    dbAccount.ProviderUrl,
 			AvatarURL:      dbAccount.AvatarUrl.String,
 			CreatedAt:      dbAccount.CreatedAt,
 			UpdatedAt:      dbAccount.UpdatedAt,
@@ -133,6 +163,8 @@ func (s *Store) UpdateAccount(ctx context.Context, accountID string, req *models
 		UserID:         dbAccount.UserID,
 		GithubUsername: dbAccount.GithubUsername,
 		GithubID:       dbAccount.GithubID,
+		Provider:       dbAccount.Provider,
+		ProviderURL:    dbAccount.ProviderUrl,
 		AvatarURL:      dbAccount.AvatarUrl.String,
 		CreatedAt:      dbAccount.CreatedAt,
 		UpdatedAt:      dbAccount.UpdatedAt,
@@ -157,6 +189,8 @@ func (s *Store) UpdateAccountToken(ctx context.Context, accountID, accessToken s
 		UserID:         dbAccount.UserID,
 		GithubUsername: dbAccount.GithubUsername,
 		GithubID:       dbAccount.GithubID,
+		Provider:       dbAccount.Provider,
+		ProviderURL:    dbAccount.ProviderUrl,
 		AvatarURL:      dbAccount.AvatarUrl.String,
 		AccessToken:    accessToken,
 		CreatedAt:      dbAccount.CreatedAt,
@@ -166,6 +200,57 @@ func (s *Store) UpdateAccountToken(ctx context.Context, accountID, accessToken s
 	return account, nil
 }
 
+// UpdateAccountOAuthTokens replaces the tokens of an account linked through OAuth
+func (s *Store) UpdateAccountOAuthTokens(ctx context.Context, accountID string, token *models.OAuthToken) (*models.Account, error) {
+	params := generated.UpdateAccountOAuthTokensParams{
+		ID:                    accountID,
+		AccessToken:           token.AccessToken,
+		RefreshToken:          pgtype.Text{String: token.RefreshToken, Valid: token.RefreshToken != ""},
+		TokenExpiresAt:        toTimestamptz(token.ExpiresAt),
+		RefreshTokenExpiresAt: toTimestamptz(token.RefreshTokenExpiresAt),
+	}
+
+	dbAccount, err := s.queries.UpdateAccountOAuthTokens(ctx, params)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAccountNotFound
+		}
+		return nil, err
+	}
+
+	return &models.Account{
+		ID:                    dbAccount.ID,
+		UserID:                dbAccount.UserID,
+		GithubUsername:        dbAccount.GithubUsername,
+		GithubID:              dbAccount.GithubID,
+		Provider:              dbAccount.Provider,
+		ProviderURL:           dbAccount.ProviderUrl,
+		AvatarURL:             dbAccount.AvatarUrl.String,
+		AccessToken:           token.AccessToken,
+		RefreshToken:          token.RefreshToken,
+		TokenExpiresAt:        token.ExpiresAt,
+		RefreshTokenExpiresAt: token.RefreshTokenExpiresAt,
+		CreatedAt:             dbAccount.CreatedAt,
+		UpdatedAt:             dbAccount.UpdatedAt,
+	}, nil
+}
+
+// GetAccountsWithExpiringTokens retrieves refreshable accounts whose token expires before the given time
+func (s *Store) GetAccountsWithExpiringTokens(ctx context.Context, before time.Time) ([]*models.Account, error) {
+	dbAccounts, err := s.queries.GetAccountsWithExpiringTokens(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]*models.Account, len(dbAccounts))
+	for i, dbAccount := range dbAccounts {
+		account := s.toDomainAccount(dbAccount)
+		accounts[i] = &account
+	}
+
+	return accounts, nil
+}
+
 func (s *Store) UpdateAccountByGithubID(ctx context.Context, githubID int64, req *models.UpdateAccountByGithubIDRequest) (*models.Account, error) {
 	params := generated.UpdateAccountByGithubIDParams{
 		GithubID:       githubID,
@@ -184,6 +269,8 @@ func (s *Store) UpdateAccountByGithubID(ctx context.Context, githubID int64, req
 		UserID:         dbAccount.UserID,
 		GithubUsername: dbAccount.GithubUsername,
 		GithubID:       dbAccount.GithubID,
+		Provider:       dbAccount.Provider,
+		ProviderURL:    dbAccount.ProviderUrl,
 		AvatarURL:      dbAccount.AvatarUrl.String,
 		AccessToken:    req.AccessToken,
 		CreatedAt:      dbAccount.CreatedAt,
@@ -223,6 +310,8 @@ func (s *Store) ListAccounts(ctx context.Context, limit, offset int) ([]*models.
 			UserID:         dbAccount.UserID,
 			GithubUsername: dbAccount.GithubUsername,
 			GithubID:       dbAccount.GithubID,
+			Provider:       dbAccount.Provider,
+			ProviderURL:    dbAccount.ProviderUrl,
 			AvatarURL:      dbAccount.AvatarUrl.String,
 			CreatedAt:      dbAccount.CreatedAt,
 			UpdatedAt:      dbAccount.UpdatedAt,
@@ -279,6 +368,8 @@ func (s *Store) SearchAccounts(ctx context.Context, query string, limit, offset
 			UserID:         dbAccount.UserID,
 			GithubUsername: dbAccount.GithubUsername,
 			GithubID:       dbAccount.GithubID,
+			Provider:       dbAccount.Provider,
+			ProviderURL:    dbAccount.ProviderUrl,
 			AvatarURL:      dbAccount.AvatarUrl.String,
 			CreatedAt:      dbAccount.CreatedAt,
 			UpdatedAt:      dbAccount.UpdatedAt,
@@ -309,6 +400,8 @@ func (s *Store) SearchAccountsByUserID(ctx context.Context, userID, query string
 			UserID:         dbAccount.UserID,
 			GithubUsername: dbAccount.GithubUsername,
 			GithubID:       dbAccount.GithubID,
+			Provider:       dbAccount.Provider,
+			ProviderURL:    dbAccount.ProviderUrl,
 			AvatarURL:      dbAccount.AvatarUrl.String,
 			CreatedAt:      dbAccount.CreatedAt,
 			UpdatedAt:      dbAccount.UpdatedAt,
@@ -333,6 +426,8 @@ func (s *Store) GetAccountWithUser(ctx context.Context, accountID string) (*mode
 		UserID:         dbAccountWithUser.UserID,
 		GithubUsername: dbAccountWithUser.GithubUsername,
 		GithubID:       dbAccountWithUser.GithubID,
+		Provider:       dbAccountWithUser.Provider,
+		ProviderURL:    dbAccountWithUser.ProviderUrl,
 		AvatarURL:      dbAccountWithUser.AvatarUrl.String,
 		AccessToken:    dbAccountWithUser.AccessToken,
 		CreatedAt:      dbAccountWithUser.CreatedAt,
@@ -346,15 +441,35 @@ func (s *Store) GetAccountWithUser(ctx context.Context, accountID string) (*mode
 
 func (s *Store) toDomainAccount(dbAccount generated.Account) models.Account {
 	return models.Account{
-		ID:             dbAccount.ID,
-		UserID:         dbAccount.UserID,
-		GithubUsername: dbAccount.GithubUsername,
-		GithubID:       dbAccount.GithubID,
-		AvatarURL:      dbAccount.AvatarUrl.String,
-		AccessToken:    dbAccount.AccessToken,
-		CreatedAt:      dbAccount.CreatedAt,
-		UpdatedAt:      dbAccount.UpdatedAt,
+		ID:                    dbAccount.ID,
+		UserID:                dbAccount.UserID,
+		GithubUsername:        dbAccount.GithubUsername,
+		GithubID:              dbAccount.GithubID,
+		Provider:              dbAccount.Provider,
+		ProviderURL:           dbAccount.ProviderUrl,
+		AvatarURL:             dbAccount.AvatarUrl.String,
+		AccessToken:           dbAccount.AccessToken,
+		RefreshToken:          dbAccount.RefreshToken.String,
+		TokenExpiresAt:        fromTimestamptz(dbAccount.TokenExpiresAt),
+		RefreshTokenExpiresAt: fromTimestamptz(dbAccount.RefreshTokenExpiresAt),
+		CreatedAt:             dbAccount.CreatedAt,
+		UpdatedAt:             dbAccount.UpdatedAt,
+	}
+}
+
+func toTimestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
+
+func fromTimestamptz(ts pgtype.Timestamptz) *time.Time {
+	if !ts.Valid {
+		return nil
 	}
+	t := ts.Time
+	return &t
 }
 
 var (
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store/postgres/generated"
+)
+
+// CreateAddon creates an add-on, provisioning until its stack is deployed
+func (s *Store) CreateAddon(ctx context.Context, addon *models.Addon) error {
+	params := generated.CreateAddonParams{
+		UserID:       addon.UserID,
+		Name:         addon.Name,
+		Type:         addon.Type,
+		Version:      addon.Version,
+		Username:     addon.Username,
+		Password:     addon.Password,
+		DatabaseName: addon.DatabaseName,
+	}
+
+	dbAddon, err := s.queries.CreateAddon(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	*addon = *s.toDomainAddon(dbAddon)
+	return nil
+}
+
+// GetAddonByID retrieves an add-on by ID
+func (s *Store) GetAddonByID(ctx context.Context, id string) (*models.Addon, error) {
+	dbAddon, err := s.queries.GetAddonByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainAddon(dbAddon), nil
+}
+
+// GetAddonsByUserID retrieves the add-ons of a user, newest first
+func (s *Store) GetAddonsByUserID(ctx context.Context, userID string) ([]*models.Addon, error) {
+	dbAddons, err := s.queries.GetAddonsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainAddons(dbAddons), nil
+}
+
+// GetAddonsByStatus retrieves the add-ons of every user in a status
+func (s *Store) GetAddonsByStatus(ctx context.Context, status string) ([]*models.Addon, error) {
+	dbAddons, err := s.queries.GetAddonsByStatus(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainAddons(dbAddons), nil
+}
+
+// AddonExists checks if an add-on exists
+func (s *Store) AddonExists(ctx context.Context, id string) (bool, error) {
+	return s.queries.AddonExists(ctx, id)
+}
+
+// AddonExistsByUserIDAndName checks if a user already has an add-on with a name
+func (s *Store) AddonExistsByUserIDAndName(ctx context.Context, userID, name string) (bool, error) {
+	return s.queries.AddonExistsByUserIDAndName(ctx, generated.AddonExistsByUserIDAndNameParams{
+		UserID: userID,
+		Name:   name,
+	})
+}
+
+// UpdateAddonStatus records the status of an add-on and the error that failed it
+func (s *Store) UpdateAddonStatus(ctx context.Context, id, status, errorMessage string) (*models.Addon, error) {
+	dbAddon, err := s.queries.UpdateAddonStatus(ctx, generated.UpdateAddonStatusParams{
+		ID:     id,
+		Status: status,
+		Error:  errorMessage,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainAddon(dbAddon), nil
+}
+
+// UpdateAddonPassword replaces the password of an add-on
+func (s *Store) UpdateAddonPassword(ctx context.Context, id, password string) (*models.Addon, error) {
+	dbAddon, err := s.queries.UpdateAddonPassword(ctx, generated.UpdateAddonPasswordParams{
+		ID:       id,
+		Password: password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainAddon(dbAddon), nil
+}
+
+// DeleteAddon deletes an add-on and its attachments
+func (s *Store) DeleteAddon(ctx context.Context, id string) error {
+	return s.queries.DeleteAddon(ctx, id)
+}
+
+// CreateAddonAttachment attaches an add-on to a project
+func (s *Store) CreateAddonAttachment(ctx context.Context, attachment *models.AddonAttachment) error {
+	dbAttachment, err := s.queries.CreateAddonAttachment(ctx, generated.CreateAddonAttachmentParams{
+		AddonID:   attachment.AddonID,
+		ProjectID: attachment.ProjectID,
+		EnvVar:    attachment.EnvVar,
+	})
+	if err != nil {
+		return err
+	}
+
+	*attachment = *s.toDomainAddonAttachment(dbAttachment)
+	return nil
+}
+
+// GetAddonAttachmentsByAddonID retrieves the projects an add-on is attached to
+func (s *Store) GetAddonAttachmentsByAddonID(ctx context.Context, addonID string) ([]*models.AddonAttachment, error) {
+	dbAttachments, err := s.queries.GetAddonAttachmentsByAddonID(ctx, addonID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainAddonAttachments(dbAttachments), nil
+}
+
+// GetAddonAttachmentsByProjectID retrieves the add-ons attached to a project
+func (s *Store) GetAddonAttachmentsByProjectID(ctx context.Context, projectID string) ([]*models.AddonAttachment, error) {
+	dbAttachments, err := s.queries.GetAddonAttachmentsByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainAddonAttachments(dbAttachments), nil
+}
+
+// DeleteAddonAttachment detaches an add-on from a project
+func (s *Store) DeleteAddonAttachment(ctx context.Context, addonID, projectID string) error {
+	return s.queries.DeleteAddonAttachment(ctx, generated.DeleteAddonAttachmentParams{
+		AddonID:   addonID,
+		ProjectID: projectID,
+	})
+}
+
+func (s *Store) toDomainAddons(dbAddons []generated.Addon) []*models.Addon {
+	addons := make([]*models.Addon, len(dbAddons))
+	for i, dbAddon := range dbAddons {
+		addons[i] = s.toDomainAddon(dbAddon)
+	}
+	return addons
+}
+
+// toDomainAddon converts a database add-on to a domain model
+func (s *Store) toDomainAddon(dbAddon generated.Addon) *models.Addon {
+	return &models.Addon{
+		ID:           dbAddon.ID,
+		UserID:       dbAddon.UserID,
+		Name:         dbAddon.Name,
+		Type:         dbAddon.Type,
+		Version:      dbAddon.Version,
+		Status:       dbAddon.Status,
+		Error:        dbAddon.Error,
+		Username:     dbAddon.Username,
+		Password:     dbAddon.Password,
+		DatabaseName: dbAddon.DatabaseName,
+		CreatedAt:    dbAddon.CreatedAt,
+		UpdatedAt:    dbAddon.UpdatedAt,
+	}
+}
+
+func (s *Store) toDomainAddonAttachments(dbAttachments []generated.AddonAttachment) []*models.AddonAttachment {
+	attachments := make([]*models.AddonAttachment, len(dbAttachments))
+	for i, dbAttachment := range dbAttachments {
+		attachments[i] = s.toDomainAddonAttachment(dbAttachment)
+	}
+	return attachments
+}
+
+// toDomainAddonAttachment converts a database add-on attachment to a domain model
+func (s *Store) toDomainAddonAttachment(dbAttachment generated.AddonAttachment) *models.AddonAttachment {
+	return &models.AddonAttachment{
+		AddonID:   dbAttachment.AddonID,
+		ProjectID: dbAttachment.ProjectID,
+		EnvVar:    dbAttachment.EnvVar,
+		CreatedAt: dbAttachment.CreatedAt,
+	}
+}
@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store/postgres/generated"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CreateBackupPolicy creates a backup policy for an add-on or a volume
+func (s *Store) CreateBackupPolicy(ctx context.Context, policy *models.BackupPolicy) error {
+	destinationJSON, err := json.Marshal(policy.Destination)
+	if err != nil {
+		return err
+	}
+
+	params := generated.CreateBackupPolicyParams{
+		UserID:      policy.UserID,
+		Name:        policy.Name,
+		TargetType:  policy.TargetType,
+		AddonID:     pgtype.Text{String: policy.AddonID, Valid: policy.AddonID != ""},
+		ProjectID:   pgtype.Text{String: policy.ProjectID, Valid: policy.ProjectID != ""},
+		VolumeName:  policy.VolumeName,
+		Schedule:    policy.Schedule,
+		Retention:   int32(policy.Retention),
+		Destination: destinationJSON,
+		Enabled:     policy.Enabled,
+	}
+
+	dbPolicy, err := s.queries.CreateBackupPolicy(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	*policy = *s.toDomainBackupPolicy(dbPolicy)
+	return nil
+}
+
+// GetBackupPolicyByID retrieves a backup policy by ID
+func (s *Store) GetBackupPolicyByID(ctx context.Context, id string) (*models.BackupPolicy, error) {
+	dbPolicy, err := s.queries.GetBackupPolicyByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainBackupPolicy(dbPolicy), nil
+}
+
+// GetBackupPoliciesByUserID retrieves the backup policies of a user, by name
+func (s *Store) GetBackupPoliciesByUserID(ctx context.Context, userID string) ([]*models.BackupPolicy, error) {
+	dbPolicies, err := s.queries.GetBackupPoliciesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainBackupPolicies(dbPolicies), nil
+}
+
+// GetScheduledBackupPolicies retrieves the enabled backup policies that have a schedule
+func (s *Store) GetScheduledBackupPolicies(ctx context.Context) ([]*models.BackupPolicy, error) {
+	dbPolicies, err := s.queries.GetScheduledBackupPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainBackupPolicies(dbPolicies), nil
+}
+
+// BackupPolicyExistsByUserIDAndName checks if a user already has a backup policy with a name
+func (s *Store) BackupPolicyExistsByUserIDAndName(ctx context.Context, userID, name string) (bool, error) {
+	return s.queries.BackupPolicyExistsByUserIDAndName(ctx, generated.BackupPolicyExistsByUserIDAndNameParams{
+		UserID: userID,
+		Name:   name,
+	})
+}
+
+// UpdateBackupPolicy saves the settings of a backup policy
+func (s *Store) UpdateBackupPolicy(ctx context.Context, policy *models.BackupPolicy) (*models.BackupPolicy, error) {
+	destinationJSON, err := json.Marshal(policy.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	params := generated.UpdateBackupPolicyParams{
+		ID:          policy.ID,
+		Name:        policy.Name,
+		Schedule:    policy.Schedule,
+		Retention:   int32(policy.Retention),
+		Destination: destinationJSON,
+		Enabled:     policy.Enabled,
+	}
+
+	dbPolicy, err := s.queries.UpdateBackupPolicy(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainBackupPolicy(dbPolicy), nil
+}
+
+// UpdateBackupPolicyLastScheduledAt records the schedule time a policy last backed up for
+func (s *Store) UpdateBackupPolicyLastScheduledAt(ctx context.Context, id string, scheduledAt time.Time) error {
+	return s.queries.UpdateBackupPolicyLastScheduledAt(ctx, generated.UpdateBackupPolicyLastScheduledAtParams{
+		ID:              id,
+		LastScheduledAt: toTimestamptz(&scheduledAt),
+	})
+}
+
+// DeleteBackupPolicy deletes a backup policy and its history
+func (s *Store) DeleteBackupPolicy(ctx context.Context, id string) error {
+	return s.queries.DeleteBackupPolicy(ctx, id)
+}
+
+// CreateBackup records the start of a backup or restore
+func (s *Store) CreateBackup(ctx context.Context, backup *models.Backup) error {
+	params := generated.CreateBackupParams{
+		PolicyID:     backup.PolicyID,
+		Operation:    backup.Operation,
+		Trigger:      backup.Trigger,
+		RestoredFrom: pgtype.Text{String: backup.RestoredFrom, Valid: backup.RestoredFrom != ""},
+	}
+
+	dbBackup, err := s.queries.CreateBackup(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	*backup = *s.toDomainBackup(dbBackup)
+	return nil
+}
+
+// FinishBackup records the outcome of a backup or restore
+func (s *Store) FinishBackup(ctx context.Context, backup *models.Backup) error {
+	return s.queries.FinishBackup(ctx, generated.FinishBackupParams{
+		ID:        backup.ID,
+		Status:    backup.Status,
+		ObjectKey: backup.ObjectKey,
+		SizeBytes: backup.SizeBytes,
+		Error:     backup.Error,
+	})
+}
+
+// FailRunningBackups marks every backup and restore still recorded as running as failed
+func (s *Store) FailRunningBackups(ctx context.Context, message string) error {
+	return s.queries.FailRunningBackups(ctx, message)
+}
+
+// GetBackupByID retrieves a backup by ID
+func (s *Store) GetBackupByID(ctx context.Context, id string) (*models.Backup, error) {
+	dbBackup, err := s.queries.GetBackupByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainBackup(dbBackup), nil
+}
+
+// GetBackupsByPolicyID retrieves a page of a policy's backups and restores, newest first
+func (s *Store) GetBackupsByPolicyID(ctx context.Context, policyID string, limit, offset int) ([]*models.Backup, error) {
+	dbBackups, err := s.queries.GetBackupsByPolicyID(ctx, generated.GetBackupsByPolicyIDParams{
+		PolicyID: policyID,
+		Limit:    int32(limit),
+		Offset:   int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainBackups(dbBackups), nil
+}
+
+// CountBackupsByPolicyID counts the backups and restores of a policy
+func (s *Store) CountBackupsByPolicyID(ctx context.Context, policyID string) (int64, error) {
+	return s.queries.CountBackupsByPolicyID(ctx, policyID)
+}
+
+// GetExpiredBackups retrieves the successful backups of a policy beyond the newest keep
+func (s *Store) GetExpiredBackups(ctx context.Context, policyID string, keep int) ([]*models.Backup, error) {
+	dbBackups, err := s.queries.GetExpiredBackups(ctx, generated.GetExpiredBackupsParams{
+		PolicyID: policyID,
+		Offset:   int32(keep),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainBackups(dbBackups), nil
+}
+
+// DeleteBackup deletes the record of a backup
+func (s *Store) DeleteBackup(ctx context.Context, id string) error {
+	return s.queries.DeleteBackup(ctx, id)
+}
+
+func (s *Store) toDomainBackupPolicies(dbPolicies []generated.BackupPolicy) []*models.BackupPolicy {
+	policies := make([]*models.BackupPolicy, len(dbPolicies))
+	for i, dbPolicy := range dbPolicies {
+		policies[i] = s.toDomainBackupPolicy(dbPolicy)
+	}
+	return policies
+}
+
+// toDomainBackupPolicy converts a database backup policy to a domain model
+func (s *Store) toDomainBackupPolicy(dbPolicy generated.BackupPolicy) *models.BackupPolicy {
+	var destination models.BackupDestination
+	json.Unmarshal(dbPolicy.Destination, &destination)
+
+	return &models.BackupPolicy{
+		ID:              dbPolicy.ID,
+		UserID:          dbPolicy.UserID,
+		Name:            dbPolicy.Name,
+		TargetType:      dbPolicy.TargetType,
+		AddonID:         dbPolicy.AddonID.String,
+		ProjectID:       dbPolicy.ProjectID.String,
+		VolumeName:      dbPolicy.VolumeName,
+		Schedule:        dbPolicy.Schedule,
+		Retention:       int(dbPolicy.Retention),
+		Destination:     destination,
+		Enabled:         dbPolicy.Enabled,
+		LastScheduledAt: fromTimestamptz(dbPolicy.LastScheduledAt),
+		CreatedAt:       dbPolicy.CreatedAt,
+		UpdatedAt:       dbPolicy.UpdatedAt,
+	}
+}
+
+func (s *Store) toDomainBackups(dbBackups []generated.Backup) []*models.Backup {
+	backups := make([]*models.Backup, len(dbBackups))
+	for i, dbBackup := range dbBackups {
+		backups[i] = s.toDomainBackup(dbBackup)
+	}
+	return backups
+}
+
+// toDomainBackup converts a database backup to a domain model
+func (s *Store) toDomainBackup(dbBackup generated.Backup) *models.Backup {
+	return &models.Backup{
+		ID:           dbBackup.ID,
+		PolicyID:     dbBackup.PolicyID,
+		Operation:    dbBackup.Operation,
+		Trigger:      dbBackup.Trigger,
+		Status:       dbBackup.Status,
+		ObjectKey:    dbBackup.ObjectKey,
+		SizeBytes:    dbBackup.SizeBytes,
+		RestoredFrom: dbBackup.RestoredFrom.String,
+		Error:        dbBackup.Error,
+		StartedAt:    dbBackup.StartedAt,
+		FinishedAt:   fromTimestamptz(dbBackup.FinishedAt),
+	}
+}
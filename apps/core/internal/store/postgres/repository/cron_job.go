@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store/postgres/generated"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CreateCronJob creates a cron job for a project
+func (s *Store) CreateCronJob(ctx context.Context, job *models.CronJob) error {
+	params := generated.CreateCronJobParams{
+		ProjectID:         job.ProjectID,
+		Name:              job.Name,
+		Schedule:          job.Schedule,
+		Command:           job.Command,
+		TimeoutSeconds:    int32(job.TimeoutSeconds),
+		ConcurrencyPolicy: job.ConcurrencyPolicy,
+		Enabled:           job.Enabled,
+	}
+
+	dbJob, err := s.queries.CreateCronJob(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	*job = *s.toDomainCronJob(dbJob)
+	return nil
+}
+
+// GetCronJobByID retrieves a cron job by ID
+func (s *Store) GetCronJobByID(ctx context.Context, id string) (*models.CronJob, error) {
+	dbJob, err := s.queries.GetCronJobByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainCronJob(dbJob), nil
+}
+
+// GetCronJobsByProjectID retrieves the cron jobs of a project, by name
+func (s *Store) GetCronJobsByProjectID(ctx context.Context, projectID string) ([]*models.CronJob, error) {
+	dbJobs, err := s.queries.GetCronJobsByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainCronJobs(dbJobs), nil
+}
+
+// GetEnabledCronJobs retrieves the cron jobs of every project that are enabled
+func (s *Store) GetEnabledCronJobs(ctx context.Context) ([]*models.CronJob, error) {
+	dbJobs, err := s.queries.GetEnabledCronJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainCronJobs(dbJobs), nil
+}
+
+// CronJobExistsByProjectIDAndName checks if a project already has a cron job with a name
+func (s *Store) CronJobExistsByProjectIDAndName(ctx context.Context, projectID, name string) (bool, error) {
+	return s.queries.CronJobExistsByProjectIDAndName(ctx, generated.CronJobExistsByProjectIDAndNameParams{
+		ProjectID: projectID,
+		Name:      name,
+	})
+}
+
+// UpdateCronJob saves the settings of a cron job
+func (s *Store) UpdateCronJob(ctx context.Context, job *models.CronJob) (*models.CronJob, error) {
+	params := generated.UpdateCronJobParams{
+		ID:                job.ID,
+		Name:              job.Name,
+		Schedule:          job.Schedule,
+		Command:           job.Command,
+		TimeoutSeconds:    int32(job.TimeoutSeconds),
+		ConcurrencyPolicy: job.ConcurrencyPolicy,
+		Enabled:           job.Enabled,
+	}
+
+	dbJob, err := s.queries.UpdateCronJob(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainCronJob(dbJob), nil
+}
+
+// UpdateCronJobLastScheduledAt records the schedule time a cron job last ran for
+func (s *Store) UpdateCronJobLastScheduledAt(ctx context.Context, id string, scheduledAt time.Time) error {
+	return s.queries.UpdateCronJobLastScheduledAt(ctx, generated.UpdateCronJobLastScheduledAtParams{
+		ID:              id,
+		LastScheduledAt: toTimestamptz(&scheduledAt),
+	})
+}
+
+// DeleteCronJob deletes a cron job and its runs
+func (s *Store) DeleteCronJob(ctx context.Context, id string) error {
+	return s.queries.DeleteCronJob(ctx, id)
+}
+
+// CreateCronJobRun records the start of a cron job run
+func (s *Store) CreateCronJobRun(ctx context.Context, run *models.CronJobRun) error {
+	params := generated.CreateCronJobRunParams{
+		CronJobID: run.CronJobID,
+		ProjectID: run.ProjectID,
+		Trigger:   run.Trigger,
+		Status:    run.Status,
+	}
+
+	dbRun, err := s.queries.CreateCronJobRun(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	*run = *s.toDomainCronJobRun(dbRun)
+	return nil
+}
+
+// FinishCronJobRun records the outcome of a cron job run
+func (s *Store) FinishCronJobRun(ctx context.Context, run *models.CronJobRun) error {
+	exitCode := pgtype.Int4{}
+	if run.ExitCode != nil {
+		exitCode = pgtype.Int4{Int32: int32(*run.ExitCode), Valid: true}
+	}
+
+	return s.queries.FinishCronJobRun(ctx, generated.FinishCronJobRunParams{
+		ID:       run.ID,
+		Status:   run.Status,
+		ExitCode: exitCode,
+		Logs:     run.Logs,
+	})
+}
+
+// FailRunningCronJobRuns marks every run still recorded as running as failed
+func (s *Store) FailRunningCronJobRuns(ctx context.Context, message string) error {
+	return s.queries.FailRunningCronJobRuns(ctx, message)
+}
+
+// GetCronJobRunByID retrieves a cron job run by ID
+func (s *Store) GetCronJobRunByID(ctx context.Context, id string) (*models.CronJobRun, error) {
+	dbRun, err := s.queries.GetCronJobRunByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainCronJobRun(dbRun), nil
+}
+
+// GetCronJobRunsByCronJobID retrieves a page of a cron job's runs, newest first
+func (s *Store) GetCronJobRunsByCronJobID(ctx context.Context, cronJobID string, limit, offset int) ([]*models.CronJobRun, error) {
+	dbRuns, err := s.queries.GetCronJobRunsByCronJobID(ctx, generated.GetCronJobRunsByCronJobIDParams{
+		CronJobID: cronJobID,
+		Limit:     int32(limit),
+		Offset:    int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]*models.CronJobRun, len(dbRuns))
+	for i, dbRun := range dbRuns {
+		runs[i] = s.toDomainCronJobRun(dbRun)
+	}
+	return runs, nil
+}
+
+// CountCronJobRunsByCronJobID counts the runs of a cron job
+func (s *Store) CountCronJobRunsByCronJobID(ctx context.Context, cronJobID string) (int64, error) {
+	return s.queries.CountCronJobRunsByCronJobID(ctx, cronJobID)
+}
+
+func (s *Store) toDomainCronJobs(dbJobs []generated.CronJob) []*models.CronJob {
+	jobs := make([]*models.CronJob, len(dbJobs))
+	for i, dbJob := range dbJobs {
+		jobs[i] = s.toDomainCronJob(dbJob)
+	}
+	return jobs
+}
+
+// toDomainCronJob converts a database cron job to a domain model
+func (s *Store) toDomainCronJob(dbJob generated.CronJob) *models.CronJob {
+	return &models.CronJob{
+		ID:                dbJob.ID,
+		ProjectID:         dbJob.ProjectID,
+		Name:              dbJob.Name,
+		Schedule:          dbJob.Schedule,
+		Command:           dbJob.Command,
+		TimeoutSeconds:    int(dbJob.TimeoutSeconds),
+		ConcurrencyPolicy: dbJob.ConcurrencyPolicy,
+		Enabled:           dbJob.Enabled,
+		LastScheduledAt:   fromTimestamptz(dbJob.LastScheduledAt),
+		CreatedAt:         dbJob.CreatedAt,
+		UpdatedAt:         dbJob.UpdatedAt,
+	}
+}
+
+// toDomainCronJobRun converts a database cron job run to a domain model
+func (s *Store) toDomainCronJobRun(dbRun generated.CronJobRun) *models.CronJobRun {
+	run := &models.CronJobRun{
+		ID:         dbRun.ID,
+		CronJobID:  dbRun.CronJobID,
+		ProjectID:  dbRun.ProjectID,
+		Trigger:    dbRun.Trigger,
+		Status:     dbRun.Status,
+		Logs:       dbRun.Logs,
+		StartedAt:  dbRun.StartedAt,
+		FinishedAt: fromTimestamptz(dbRun.FinishedAt),
+	}
+	if dbRun.ExitCode.Valid {
+		exitCode := int(dbRun.ExitCode.Int32)
+		run.ExitCode = &exitCode
+	}
+	return run
+}
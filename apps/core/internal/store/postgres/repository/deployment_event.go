@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store/postgres/generated"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CreateDeploymentEvent adds an entry to a project's deployment timeline
+func (s *Store) CreateDeploymentEvent(ctx context.Context, event *models.DeploymentEvent) error {
+	params := generated.CreateDeploymentEventParams{
+		ProjectID:  event.ProjectID,
+		UserID:     pgtype.Text{String: event.UserID, Valid: event.UserID != ""},
+		Action:     event.Action,
+		Status:     event.Status,
+		Deployment: event.Deployment,
+		Message:    event.Message,
+	}
+
+	dbEvent, err := s.queries.CreateDeploymentEvent(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	*event = s.toDomainDeploymentEvent(dbEvent)
+	return nil
+}
+
+// GetDeploymentEventsByProjectID retrieves a page of a project's timeline, newest first
+func (s *Store) GetDeploymentEventsByProjectID(ctx context.Context, projectID string, limit, offset int) ([]*models.DeploymentEvent, error) {
+	params := generated.GetDeploymentEventsByProjectIDParams{
+		ProjectID: projectID,
+		Limit:     int32(limit),
+		Offset:    int32(offset),
+	}
+
+	dbEvents, err := s.queries.GetDeploymentEventsByProjectID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*models.DeploymentEvent, len(dbEvents))
+	for i, dbEvent := range dbEvents {
+		event := s.toDomainDeploymentEvent(dbEvent)
+		events[i] = &event
+	}
+	return events, nil
+}
+
+// CountDeploymentEventsByProjectID counts the entries of a project's timeline
+func (s *Store) CountDeploymentEventsByProjectID(ctx context.Context, projectID string) (int64, error) {
+	return s.queries.CountDeploymentEventsByProjectID(ctx, projectID)
+}
+
+// toDomainDeploymentEvent converts a database deployment event to a domain model
+func (s *Store) toDomainDeploymentEvent(dbEvent generated.DeploymentEvent) models.DeploymentEvent {
+	return models.DeploymentEvent{
+		ID:         dbEvent.ID,
+		ProjectID:  dbEvent.ProjectID,
+		UserID:     dbEvent.UserID.String,
+		Action:     dbEvent.Action,
+		Status:     dbEvent.Status,
+		Deployment: dbEvent.Deployment,
+		Message:    dbEvent.Message,
+		CreatedAt:  dbEvent.CreatedAt,
+	}
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store/postgres/generated"
+)
+
+// CreateGitHubInstallation records a GitHub App installation for a user
+func (s *Store) CreateGitHubInstallation(ctx context.Context, installation *models.GitHubInstallation) error {
+	params := generated.CreateGitHubInstallationParams{
+		UserID:         installation.UserID,
+		InstallationID: installation.InstallationID,
+		AccountLogin:   installation.AccountLogin,
+		AccountType:    installation.AccountType,
+	}
+
+	dbInstallation, err := s.queries.CreateGitHubInstallation(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	*installation = s.toDomainGitHubInstallation(dbInstallation)
+	return nil
+}
+
+// GetGitHubInstallationByID retrieves an installation by ID
+func (s *Store) GetGitHubInstallationByID(ctx context.Context, id string) (*models.GitHubInstallation, error) {
+	dbInstallation, err := s.queries.GetGitHubInstallationByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrGitHubInstallationNotFound
+		}
+		return nil, err
+	}
+
+	installation := s.toDomainGitHubInstallation(dbInstallation)
+	return &installation, nil
+}
+
+// GetGitHubInstallationByInstallationID retrieves an installation by its GitHub installation ID
+func (s *Store) GetGitHubInstallationByInstallationID(ctx context.Context, installationID int64) (*models.GitHubInstallation, error) {
+	dbInstallation, err := s.queries.GetGitHubInstallationByInstallationID(ctx, installationID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrGitHubInstallationNotFound
+		}
+		return nil, err
+	}
+
+	installation := s.toDomainGitHubInstallation(dbInstallation)
+	return &installation, nil
+}
+
+// GetGitHubInstallationsByUserID retrieves all installations linked by a user
+func (s *Store) GetGitHubInstallationsByUserID(ctx context.Context, userID string) ([]*models.GitHubInstallation, error) {
+	dbInstallations, err := s.queries.GetGitHubInstallationsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	installations := make([]*models.GitHubInstallation, len(dbInstallations))
+	for i, dbInstallation := range dbInstallations {
+		installation := s.toDomainGitHubInstallation(dbInstallation)
+		installations[i] = &installation
+	}
+
+	return installations, nil
+}
+
+// GitHubInstallationExists checks if an installation is already linked
+func (s *Store) GitHubInstallationExists(ctx context.Context, installationID int64) (bool, error) {
+	return s.queries.GitHubInstallationExists(ctx, installationID)
+}
+
+// DeleteGitHubInstallation deletes an installation by ID
+func (s *Store) DeleteGitHubInstallation(ctx context.Context, id string) error {
+	return s.queries.DeleteGitHubInstallation(ctx, id)
+}
+
+// DeleteGitHubInstallationByInstallationID deletes an installation by its GitHub installation ID
+func (s *Store) DeleteGitHubInstallationByInstallationID(ctx context.Context, installationID int64) error {
+	return s.queries.DeleteGitHubInstallationByInstallationID(ctx, installationID)
+}
+
+// SetGitHubInstallationSuspendedAt marks an installation as suspended, or unsuspended when suspendedAt is nil
+func (s *Store) SetGitHubInstallationSuspendedAt(ctx context.Context, installationID int64, suspendedAt *time.Time) error {
+	params := generated.SetGitHubInstallationSuspendedAtParams{
+		InstallationID: installationID,
+		SuspendedAt:    toTimestamptz(suspendedAt),
+	}
+	return s.queries.SetGitHubInstallationSuspendedAt(ctx, params)
+}
+
+// toDomainGitHubInstallation converts a database installation to a domain model
+func (s *Store) toDomainGitHubInstallation(dbInstallation generated.GithubInstallation) models.GitHubInstallation {
+	return models.GitHubInstallation{
+		ID:             dbInstallation.ID,
+		UserID:         dbInstallation.UserID,
+		InstallationID: dbInstallation.InstallationID,
+		AccountLogin:   dbInstallation.AccountLogin,
+		AccountType:    dbInstallation.AccountType,
+		SuspendedAt:    fromTimestamptz(dbInstallation.SuspendedAt),
+		CreatedAt:      dbInstallation.CreatedAt,
+		UpdatedAt:      dbInstallation.UpdatedAt,
+	}
+}
+
+// Error definitions
+var (
+	ErrGitHubInstallationNotFound = errors.New("github installation not found")
+)
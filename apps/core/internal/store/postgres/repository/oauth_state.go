@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store/postgres/generated"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CreateOAuthState stores a pending OAuth authorization
+func (s *Store) CreateOAuthState(ctx context.Context, state *models.OAuthState) error {
+	params := generated.CreateOAuthStateParams{
+		State:      state.State,
+		UserID:     state.UserID,
+		RedirectTo: pgtype.Text{String: state.RedirectTo, Valid: state.RedirectTo != ""},
+		ExpiresAt:  state.ExpiresAt,
+	}
+
+	return s.queries.CreateOAuthState(ctx, params)
+}
+
+// ConsumeOAuthState deletes and returns a pending OAuth authorization so it can only be used once
+func (s *Store) ConsumeOAuthState(ctx context.Context, state string) (*models.OAuthState, error) {
+	dbState, err := s.queries.ConsumeOAuthState(ctx, state)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrOAuthStateNotFound
+		}
+		return nil, err
+	}
+
+	return &models.OAuthState{
+		State:      dbState.State,
+		UserID:     dbState.UserID,
+		RedirectTo: dbState.RedirectTo.String,
+		ExpiresAt:  dbState.ExpiresAt,
+		CreatedAt:  dbState.CreatedAt,
+	}, nil
+}
+
+// DeleteExpiredOAuthStates removes abandoned OAuth authorizations
+func (s *Store) DeleteExpiredOAuthStates(ctx context.Context, before time.Time) error {
+	return s.queries.DeleteExpiredOAuthStates(ctx, before)
+}
+
+// Error definitions
+var (
+	ErrOAuthStateNotFound = errors.New("oauth state not found")
+)
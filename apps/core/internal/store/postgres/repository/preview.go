@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store/postgres/generated"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// UpsertPreview creates a preview for a pull request or resets an existing one to pending
+func (s *Store) UpsertPreview(ctx context.Context, preview *models.Preview) error {
+	params := generated.UpsertPreviewParams{
+		ProjectID:  preview.ProjectID,
+		PrNumber:   int32(preview.PRNumber),
+		PrTitle:    pgtype.Text{String: preview.PRTitle, Valid: preview.PRTitle != ""},
+		HeadBranch: preview.HeadBranch,
+		HeadSha:    pgtype.Text{String: preview.HeadSHA, Valid: preview.HeadSHA != ""},
+		Domain:     preview.Domain,
+	}
+
+	dbPreview, err := s.queries.UpsertPreview(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	*preview = s.toDomainPreview(dbPreview)
+	return nil
+}
+
+// GetPreviewByID retrieves a preview by ID
+func (s *Store) GetPreviewByID(ctx context.Context, id string) (*models.Preview, error) {
+	dbPreview, err := s.queries.GetPreviewByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrPreviewNotFound
+		}
+		return nil, err
+	}
+
+	preview := s.toDomainPreview(dbPreview)
+	return &preview, nil
+}
+
+// GetPreviewByProjectIDAndNumber retrieves the preview of a project for a pull request number
+func (s *Store) GetPreviewByProjectIDAndNumber(ctx context.Context, projectID string, prNumber int) (*models.Preview, error) {
+	params := generated.GetPreviewByProjectIDAndNumberParams{
+		ProjectID: projectID,
+		PrNumber:  int32(prNumber),
+	}
+
+	dbPreview, err := s.queries.GetPreviewByProjectIDAndNumber(ctx, params)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrPreviewNotFound
+		}
+		return nil, err
+	}
+
+	preview := s.toDomainPreview(dbPreview)
+	return &preview, nil
+}
+
+// GetPreviewsByProjectID retrieves all previews for a project
+func (s *Store) GetPreviewsByProjectID(ctx context.Context, projectID string) ([]*models.Preview, error) {
+	dbPreviews, err := s.queries.GetPreviewsByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	previews := make([]*models.Preview, len(dbPreviews))
+	for i, dbPreview := range dbPreviews {
+		preview := s.toDomainPreview(dbPreview)
+		previews[i] = &preview
+	}
+
+	return previews, nil
+}
+
+// UpdatePreviewStatus updates the deployment status of a preview
+func (s *Store) UpdatePreviewStatus(ctx context.Context, id, status string) (*models.Preview, error) {
+	params := generated.UpdatePreviewStatusParams{
+		ID:     id,
+		Status: status,
+	}
+
+	dbPreview, err := s.queries.UpdatePreviewStatus(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := s.toDomainPreview(dbPreview)
+	return &preview, nil
+}
+
+// UpdatePreviewCommentID stores the pull request comment used to report the preview URL
+func (s *Store) UpdatePreviewCommentID(ctx context.Context, id string, commentID int64) error {
+	params := generated.UpdatePreviewCommentIDParams{
+		ID:        id,
+		CommentID: pgtype.Int8{Int64: commentID, Valid: commentID > 0},
+	}
+
+	return s.queries.UpdatePreviewCommentID(ctx, params)
+}
+
+// DeletePreview deletes a preview by ID
+func (s *Store) DeletePreview(ctx context.Context, id string) error {
+	return s.queries.DeletePreview(ctx, id)
+}
+
+// toDomainPreview converts a database preview to a domain model
+func (s *Store) toDomainPreview(dbPreview generated.Preview) models.Preview {
+	return models.Preview{
+		ID:         dbPreview.ID,
+		ProjectID:  dbPreview.ProjectID,
+		PRNumber:   int(dbPreview.PrNumber),
+		PRTitle:    dbPreview.PrTitle.String,
+		HeadBranch: dbPreview.HeadBranch,
+		HeadSHA:    dbPreview.HeadSha.String,
+		Domain:     dbPreview.Domain,
+		Status:     dbPreview.Status,
+		CommentID:  dbPreview.CommentID.Int64,
+		CreatedAt:  dbPreview.CreatedAt,
+		UpdatedAt:  dbPreview.UpdatedAt,
+	}
+}
+
+// Error definitions
+var (
+	ErrPreviewNotFound = errors.New("preview not found")
+)
@@ -24,16 +24,27 @@ func (s *Store) CreateProject(ctx context.Context, project *models.Project) erro
 	port := pgtype.Int4{Int32: int32(project.Port), Valid: project.Port > 0}
 
 	params := generated.CreateProjectParams{
-		Name:         project.Name,
-		UserID:       project.UserID,
-		RepoID:       project.RepoID,
-		RepoName:     project.RepoName,
-		RepoFullName: project.RepoFullName,
-		RepoUrl:      project.RepoURL,
-		RepoBranch:   project.RepoBranch,
-		EnvVariables: envJSON,
-		Domain:       domain,
-		Port:         port,
+		Name:             project.Name,
+		UserID:           project.UserID,
+		RepoID:           project.RepoID,
+		RepoName:         project.RepoName,
+		RepoFullName:     project.RepoFullName,
+		RepoUrl:          project.RepoURL,
+		RepoBranch:       project.RepoBranch,
+		EnvVariables:     envJSON,
+		Domain:           domain,
+		Port:             port,
+		InstallationID:   pgtype.Int8{Int64: project.InstallationID, Valid: project.InstallationID > 0},
+		Provider:         project.Provider,
+		ProviderUrl:      project.ProviderURL,
+		DeployPublicKey:  pgtype.Text{String: project.DeployPublicKey, Valid: project.DeployPublicKey != ""},
+		DeployPrivateKey: pgtype.Text{String: project.DeployPrivateKey, Valid: project.DeployPrivateKey != ""},
+		AccountID:        pgtype.Text{String: project.AccountID, Valid: project.AccountID != ""},
+		SourceType:       project.SourceType,
+		Image:            pgtype.Text{String: project.Image, Valid: project.Image != ""},
+		RegistryUsername: pgtype.Text{String: project.RegistryUsername, Valid: project.RegistryUsername != ""},
+		RegistryPassword: pgtype.Text{String: project.RegistryPassword, Valid: project.RegistryPassword != ""},
+		ContainerPort:    pgtype.Int4{Int32: int32(project.ContainerPort), Valid: project.ContainerPort > 0},
 	}
 
 	dbProject, err := s.queries.CreateProject(ctx, params)
@@ -49,7 +60,7 @@ func (s *Store) CreateProject(ctx context.Context, project *models.Project) erro
 func (s *Store) GetProjectByID(ctx context.Context, id string) (*models.Project, error) {
 	dbProject, err := s.queries.GetProjectByID(ctx, id)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrProjectNotFound
 		}
 		return nil, err
@@ -417,6 +428,226 @@ func (s *Store) GetUsedPorts(ctx context.Context) ([]int, error) {
 	return result, nil
 }
 
+// UpdateProjectPreviewSettings updates pull request preview settings for a project
+func (s *Store) UpdateProjectPreviewSettings(ctx context.Context, projectID string, enabled bool, envVars []models.EnvironmentVariable, webhookID int64) (*models.Project, error) {
+	envJSON, err := json.Marshal(envVars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal preview env variables: %w", err)
+	}
+
+	params := generated.UpdateProjectPreviewSettingsParams{
+		ID:                  projectID,
+		PreviewsEnabled:     enabled,
+		PreviewEnvVariables: envJSON,
+		WebhookID:           pgtype.Int8{Int64: webhookID, Valid: webhookID > 0},
+	}
+
+	dbProject, err := s.queries.UpdateProjectPreviewSettings(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	project := s.toDomainProject(dbProject)
+	return &project, nil
+}
+
+// UpdateProjectRoutingPolicy replaces the Traefik middlewares of a project
+func (s *Store) UpdateProjectRoutingPolicy(ctx context.Context, projectID string, policy models.RoutingPolicy) (*models.Project, error) {
+	policyJSON, err := json.Marshal(policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal routing policy: %w", err)
+	}
+
+	params := generated.UpdateProjectRoutingPolicyParams{
+		ID:            projectID,
+		RoutingPolicy: policyJSON,
+	}
+
+	dbProject, err := s.queries.UpdateProjectRoutingPolicy(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	project := s.toDomainProject(dbProject)
+	return &project, nil
+}
+
+// UpdateProjectRuntimeStatus records what the swarm is running for a project
+func (s *Store) UpdateProjectRuntimeStatus(ctx context.Context, projectID, status string) error {
+	return s.queries.UpdateProjectRuntimeStatus(ctx, generated.UpdateProjectRuntimeStatusParams{
+		ID:            projectID,
+		RuntimeStatus: status,
+	})
+}
+
+// UpdateProjectProcesses replaces the process types of a project
+func (s *Store) UpdateProjectProcesses(ctx context.Context, projectID string, processes []models.Process) (*models.Project, error) {
+	processesJSON, err := json.Marshal(processes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal processes: %w", err)
+	}
+
+	params := generated.UpdateProjectProcessesParams{
+		ID:        projectID,
+		Processes: processesJSON,
+	}
+
+	dbProject, err := s.queries.UpdateProjectProcesses(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	project := s.toDomainProject(dbProject)
+	return &project, nil
+}
+
+// UpdateProjectVolumes replaces the volumes of a project
+func (s *Store) UpdateProjectVolumes(ctx context.Context, projectID string, volumes []models.Volume) (*models.Project, error) {
+	volumesJSON, err := json.Marshal(volumes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal volumes: %w", err)
+	}
+
+	params := generated.UpdateProjectVolumesParams{
+		ID:      projectID,
+		Volumes: volumesJSON,
+	}
+
+	dbProject, err := s.queries.UpdateProjectVolumes(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	project := s.toDomainProject(dbProject)
+	return &project, nil
+}
+
+// UpdateProjectStaticSite replaces the static site settings of a project
+func (s *Store) UpdateProjectStaticSite(ctx context.Context, projectID string, site models.StaticSite) (*models.Project, error) {
+	siteJSON, err := json.Marshal(site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal static site: %w", err)
+	}
+
+	params := generated.UpdateProjectStaticSiteParams{
+		ID:         projectID,
+		StaticSite: siteJSON,
+	}
+
+	dbProject, err := s.queries.UpdateProjectStaticSite(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	project := s.toDomainProject(dbProject)
+	return &project, nil
+}
+
+// UpdateProjectImageSource replaces the image, registry credentials and container
+// port of an image project
+func (s *Store) UpdateProjectImageSource(ctx context.Context, projectID, image, registryUsername, registryPassword string, containerPort int) (*models.Project, error) {
+	params := generated.UpdateProjectImageSourceParams{
+		ID:               projectID,
+		Image:            pgtype.Text{String: image, Valid: image != ""},
+		RegistryUsername: pgtype.Text{String: registryUsername, Valid: registryUsername != ""},
+		RegistryPassword: pgtype.Text{String: registryPassword, Valid: registryPassword != ""},
+		ContainerPort:    pgtype.Int4{Int32: int32(containerPort), Valid: containerPort > 0},
+	}
+
+	dbProject, err := s.queries.UpdateProjectImageSource(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	project := s.toDomainProject(dbProject)
+	return &project, nil
+}
+
+// UpdateProjectDeployHookToken replaces the deploy hook token hash of a project,
+// an empty hash turns the hook off
+func (s *Store) UpdateProjectDeployHookToken(ctx context.Context, projectID, tokenHash string) (*models.Project, error) {
+	params := generated.UpdateProjectDeployHookTokenParams{
+		ID:                  projectID,
+		DeployHookTokenHash: pgtype.Text{String: tokenHash, Valid: tokenHash != ""},
+	}
+
+	dbProject, err := s.queries.UpdateProjectDeployHookToken(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	project := s.toDomainProject(dbProject)
+	return &project, nil
+}
+
+// UpdateProjectStopped records whether a project's owner stopped it
+func (s *Store) UpdateProjectStopped(ctx context.Context, projectID string, stopped bool) (*models.Project, error) {
+	params := generated.UpdateProjectStoppedParams{
+		ID:      projectID,
+		Stopped: stopped,
+	}
+
+	dbProject, err := s.queries.UpdateProjectStopped(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	project := s.toDomainProject(dbProject)
+	return &project, nil
+}
+
+// UpdateProjectDeployKey replaces the SSH deploy key of a project
+func (s *Store) UpdateProjectDeployKey(ctx context.Context, projectID, publicKey, privateKey string) (*models.Project, error) {
+	params := generated.UpdateProjectDeployKeyParams{
+		ID:               projectID,
+		DeployPublicKey:  pgtype.Text{String: publicKey, Valid: publicKey != ""},
+		DeployPrivateKey: pgtype.Text{String: privateKey, Valid: privateKey != ""},
+	}
+
+	dbProject, err := s.queries.UpdateProjectDeployKey(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	project := s.toDomainProject(dbProject)
+	return &project, nil
+}
+
+// UpdateProjectDomain sets the primary domain of a project, an empty domain clears it
+func (s *Store) UpdateProjectDomain(ctx context.Context, projectID, domain string) (*models.Project, error) {
+	params := generated.UpdateProjectDomainParams{
+		ID:     projectID,
+		Domain: pgtype.Text{String: domain, Valid: domain != ""},
+	}
+
+	dbProject, err := s.queries.UpdateProjectDomain(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	project := s.toDomainProject(dbProject)
+	return &project, nil
+}
+
+// UpdateProjectTLSSettings updates the HTTPS settings of a project. Empty certificate
+// and key clear the custom certificate.
+func (s *Store) UpdateProjectTLSSettings(ctx context.Context, projectID string, forceHTTPS bool, certificate, privateKey string) (*models.Project, error) {
+	params := generated.UpdateProjectTLSSettingsParams{
+		ID:             projectID,
+		ForceHttps:     forceHTTPS,
+		TlsCertificate: pgtype.Text{String: certificate, Valid: certificate != ""},
+		TlsPrivateKey:  pgtype.Text{String: privateKey, Valid: privateKey != ""},
+	}
+
+	dbProject, err := s.queries.UpdateProjectTLSSettings(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	project := s.toDomainProject(dbProject)
+	return &project, nil
+}
+
 // toDomainProject converts a database project to a domain model
 func (s *Store) toDomainProject(dbProject generated.Project) models.Project {
 	// Unmarshal env variables from JSON
@@ -435,6 +666,31 @@ func (s *Store) toDomainProject(dbProject generated.Project) models.Project {
 		port = int(dbProject.Port.Int32)
 	}
 
+	var previewEnvVars []models.EnvironmentVariable
+	if err := json.Unmarshal(dbProject.PreviewEnvVariables, &previewEnvVars); err != nil {
+		previewEnvVars = []models.EnvironmentVariable{}
+	}
+
+	var routingPolicy models.RoutingPolicy
+	if err := json.Unmarshal(dbProject.RoutingPolicy, &routingPolicy); err != nil {
+		routingPolicy = models.RoutingPolicy{}
+	}
+
+	var processes []models.Process
+	if err := json.Unmarshal(dbProject.Processes, &processes); err != nil || processes == nil {
+		processes = []models.Process{}
+	}
+
+	var volumes []models.Volume
+	if err := json.Unmarshal(dbProject.Volumes, &volumes); err != nil || volumes == nil {
+		volumes = []models.Volume{}
+	}
+
+	var staticSite models.StaticSite
+	if err := json.Unmarshal(dbProject.StaticSite, &staticSite); err != nil {
+		staticSite = models.StaticSite{}
+	}
+
 	return models.Project{
 		ID:               dbProject.ID,
 		Name:             dbProject.Name,
@@ -448,6 +704,30 @@ func (s *Store) toDomainProject(dbProject generated.Project) models.Project {
 		DeploymentStatus: dbProject.DeploymentStatus,
 		Domain:           domain,
 		Port:             port,
+		PreviewsEnabled:  dbProject.PreviewsEnabled,
+		PreviewEnvVars:   previewEnvVars,
+		WebhookID:        dbProject.WebhookID.Int64,
+		InstallationID:   dbProject.InstallationID.Int64,
+		AccountID:        dbProject.AccountID.String,
+		Provider:         dbProject.Provider,
+		ProviderURL:      dbProject.ProviderUrl,
+		DeployPublicKey:  dbProject.DeployPublicKey.String,
+		DeployPrivateKey: dbProject.DeployPrivateKey.String,
+		ForceHTTPS:       dbProject.ForceHttps,
+		TLSCertificate:   dbProject.TlsCertificate.String,
+		TLSPrivateKey:    dbProject.TlsPrivateKey.String,
+		RoutingPolicy:    routingPolicy,
+		Processes:        processes,
+		Volumes:          volumes,
+		StaticSite:       staticSite,
+		SourceType:       dbProject.SourceType,
+		Image:            dbProject.Image.String,
+		RegistryUsername: dbProject.RegistryUsername.String,
+		RegistryPassword: dbProject.RegistryPassword.String,
+		ContainerPort:    int(dbProject.ContainerPort.Int32),
+		DeployHookHash:   dbProject.DeployHookTokenHash.String,
+		Stopped:          dbProject.Stopped,
+		RuntimeStatus:    dbProject.RuntimeStatus,
 		EnvVariables:     envVars,
 		CreatedAt:        dbProject.CreatedAt,
 		UpdatedAt:        dbProject.UpdatedAt,
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store/postgres/generated"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CreateProjectDomain adds a domain to a project
+func (s *Store) CreateProjectDomain(ctx context.Context, domain *models.ProjectDomain) error {
+	params := generated.CreateProjectDomainParams{
+		ProjectID:         domain.ProjectID,
+		Domain:            domain.Domain,
+		VerificationToken: domain.VerificationToken,
+		Status:            domain.Status,
+		VerifiedAt:        toTimestamptz(domain.VerifiedAt),
+	}
+
+	dbDomain, err := s.queries.CreateProjectDomain(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	*domain = s.toDomainProjectDomain(dbDomain)
+	return nil
+}
+
+// GetProjectDomainByID retrieves a project domain by ID
+func (s *Store) GetProjectDomainByID(ctx context.Context, id string) (*models.ProjectDomain, error) {
+	dbDomain, err := s.queries.GetProjectDomainByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrProjectDomainNotFound
+		}
+		return nil, err
+	}
+
+	domain := s.toDomainProjectDomain(dbDomain)
+	return &domain, nil
+}
+
+// GetProjectDomainByDomain retrieves a project domain by host name
+func (s *Store) GetProjectDomainByDomain(ctx context.Context, domain string) (*models.ProjectDomain, error) {
+	dbDomain, err := s.queries.GetProjectDomainByDomain(ctx, domain)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrProjectDomainNotFound
+		}
+		return nil, err
+	}
+
+	projectDomain := s.toDomainProjectDomain(dbDomain)
+	return &projectDomain, nil
+}
+
+// GetProjectDomainsByProjectID retrieves all domains of a project, oldest first
+func (s *Store) GetProjectDomainsByProjectID(ctx context.Context, projectID string) ([]*models.ProjectDomain, error) {
+	dbDomains, err := s.queries.GetProjectDomainsByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toDomainProjectDomains(dbDomains), nil
+}
+
+// GetVerifiedProjectDomainsByProjectID retrieves the domains of a project that may be routed
+func (s *Store) GetVerifiedProjectDomainsByProjectID(ctx context.Context, projectID string) ([]*models.ProjectDomain, error) {
+	dbDomains, err := s.queries.GetVerifiedProjectDomainsByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toDomainProjectDomains(dbDomains), nil
+}
+
+// UpdateProjectDomainVerification records the outcome of a DNS ownership check
+func (s *Store) UpdateProjectDomainVerification(ctx context.Context, id, status, verificationError string, verifiedAt *time.Time) (*models.ProjectDomain, error) {
+	params := generated.UpdateProjectDomainVerificationParams{
+		ID:                id,
+		Status:            status,
+		VerificationError: pgtype.Text{String: verificationError, Valid: verificationError != ""},
+		VerifiedAt:        toTimestamptz(verifiedAt),
+	}
+
+	dbDomain, err := s.queries.UpdateProjectDomainVerification(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	domain := s.toDomainProjectDomain(dbDomain)
+	return &domain, nil
+}
+
+// ProjectDomainExists checks if a domain is already claimed by any project
+func (s *Store) ProjectDomainExists(ctx context.Context, domain string) (bool, error) {
+	return s.queries.ProjectDomainExists(ctx, domain)
+}
+
+// DeleteProjectDomain deletes a project domain by ID
+func (s *Store) DeleteProjectDomain(ctx context.Context, id string) error {
+	return s.queries.DeleteProjectDomain(ctx, id)
+}
+
+func (s *Store) toDomainProjectDomains(dbDomains []generated.ProjectDomain) []*models.ProjectDomain {
+	domains := make([]*models.ProjectDomain, len(dbDomains))
+	for i, dbDomain := range dbDomains {
+		domain := s.toDomainProjectDomain(dbDomain)
+		domains[i] = &domain
+	}
+	return domains
+}
+
+// toDomainProjectDomain converts a database project domain to a domain model
+func (s *Store) toDomainProjectDomain(dbDomain generated.ProjectDomain) models.ProjectDomain {
+	return models.ProjectDomain{
+		ID:                dbDomain.ID,
+		ProjectID:         dbDomain.ProjectID,
+		Domain:            dbDomain.Domain,
+		VerificationToken: dbDomain.VerificationToken,
+		Status:            dbDomain.Status,
+		VerificationError: dbDomain.VerificationError.String,
+		VerifiedAt:        fromTimestamptz(dbDomain.VerifiedAt),
+		LastCheckedAt:     fromTimestamptz(dbDomain.LastCheckedAt),
+		CreatedAt:         dbDomain.CreatedAt,
+		UpdatedAt:         dbDomain.UpdatedAt,
+	}
+}
+
+// Error definitions
+var (
+	ErrProjectDomainNotFound = errors.New("project domain not found")
+)
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store/postgres/generated"
+)
+
+// RecordProjectMetric stores a sample in its bucket, replacing an earlier sample
+// of the same bucket
+func (s *Store) RecordProjectMetric(ctx context.Context, sample *models.MetricSample) error {
+	params := generated.UpsertProjectMetricParams{
+		ProjectID:         sample.ProjectID,
+		ServiceName:       sample.Service,
+		ResolutionSeconds: int32(sample.ResolutionSeconds),
+		BucketStart:       sample.Timestamp,
+		CpuPercent:        sample.CPUPercent,
+		MemoryBytes:       sample.MemoryBytes,
+		MemoryLimitBytes:  sample.MemoryLimitBytes,
+		NetworkRxRate:     sample.NetworkRxRate,
+		NetworkTxRate:     sample.NetworkTxRate,
+		Restarts:          int32(sample.Restarts),
+	}
+
+	return s.queries.UpsertProjectMetric(ctx, params)
+}
+
+// RollupProjectMetrics recomputes the hourly buckets of the minute samples taken since a time
+func (s *Store) RollupProjectMetrics(ctx context.Context, since time.Time) error {
+	return s.queries.RollupProjectMetrics(ctx, since)
+}
+
+// DeleteProjectMetricsBefore drops the samples of a resolution older than a time
+func (s *Store) DeleteProjectMetricsBefore(ctx context.Context, resolutionSeconds int, before time.Time) error {
+	return s.queries.DeleteProjectMetricsBefore(ctx, generated.DeleteProjectMetricsBeforeParams{
+		ResolutionSeconds: int32(resolutionSeconds),
+		BucketStart:       before,
+	})
+}
+
+// GetProjectMetrics retrieves the samples of a project at a resolution since a time,
+// ordered by service and time
+func (s *Store) GetProjectMetrics(ctx context.Context, projectID string, resolutionSeconds int, since time.Time) ([]*models.MetricSample, error) {
+	dbMetrics, err := s.queries.GetProjectMetrics(ctx, generated.GetProjectMetricsParams{
+		ProjectID:         projectID,
+		ResolutionSeconds: int32(resolutionSeconds),
+		BucketStart:       since,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]*models.MetricSample, len(dbMetrics))
+	for i, dbMetric := range dbMetrics {
+		samples[i] = s.toDomainMetricSample(dbMetric)
+	}
+	return samples, nil
+}
+
+// toDomainMetricSample converts a database metric to a domain model
+func (s *Store) toDomainMetricSample(dbMetric generated.ProjectMetric) *models.MetricSample {
+	return &models.MetricSample{
+		ProjectID:         dbMetric.ProjectID,
+		Service:           dbMetric.ServiceName,
+		ResolutionSeconds: int(dbMetric.ResolutionSeconds),
+		Timestamp:         dbMetric.BucketStart,
+		CPUPercent:        dbMetric.CpuPercent,
+		MemoryBytes:       dbMetric.MemoryBytes,
+		MemoryLimitBytes:  dbMetric.MemoryLimitBytes,
+		NetworkRxRate:     dbMetric.NetworkRxRate,
+		NetworkTxRate:     dbMetric.NetworkTxRate,
+		Restarts:          int(dbMetric.Restarts),
+	}
+}
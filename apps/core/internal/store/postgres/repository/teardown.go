@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dopeCape/kova/internal/models"
+	"github.com/dopeCape/kova/internal/store/postgres/generated"
+)
+
+// CreateTeardown records a planned teardown
+func (s *Store) CreateTeardown(ctx context.Context, teardown *models.Teardown) error {
+	stepsJSON, err := json.Marshal(teardown.Steps)
+	if err != nil {
+		return fmt.Errorf("failed to marshal teardown steps: %w", err)
+	}
+
+	params := generated.CreateTeardownParams{
+		UserID:         teardown.UserID,
+		ProjectID:      teardown.ProjectID,
+		ProjectName:    teardown.ProjectName,
+		RepoFullName:   teardown.RepoFullName,
+		WebhookID:      teardown.WebhookID,
+		Provider:       teardown.Provider,
+		ProviderUrl:    teardown.ProviderURL,
+		AccountID:      teardown.AccountID,
+		InstallationID: teardown.InstallationID,
+		RemoveVolumes:  teardown.RemoveVolumes,
+		Status:         teardown.Status,
+		Steps:          stepsJSON,
+	}
+
+	dbTeardown, err := s.queries.CreateTeardown(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	domainTeardown, err := s.toDomainTeardown(dbTeardown)
+	if err != nil {
+		return err
+	}
+	*teardown = *domainTeardown
+	return nil
+}
+
+// GetTeardownByID retrieves a teardown by ID
+func (s *Store) GetTeardownByID(ctx context.Context, id string) (*models.Teardown, error) {
+	dbTeardown, err := s.queries.GetTeardownByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainTeardown(dbTeardown)
+}
+
+// GetTeardownsByUserID retrieves the latest teardowns of a user's projects, newest first
+func (s *Store) GetTeardownsByUserID(ctx context.Context, userID string, limit int) ([]*models.Teardown, error) {
+	dbTeardowns, err := s.queries.GetTeardownsByUserID(ctx, generated.GetTeardownsByUserIDParams{
+		UserID: userID,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainTeardowns(dbTeardowns)
+}
+
+// GetUnfinishedTeardowns retrieves the teardowns that haven't finished, oldest first
+func (s *Store) GetUnfinishedTeardowns(ctx context.Context) ([]*models.Teardown, error) {
+	dbTeardowns, err := s.queries.GetUnfinishedTeardowns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDomainTeardowns(dbTeardowns)
+}
+
+// UpdateTeardownProgress saves the status and steps of a teardown
+func (s *Store) UpdateTeardownProgress(ctx context.Context, teardown *models.Teardown) error {
+	stepsJSON, err := json.Marshal(teardown.Steps)
+	if err != nil {
+		return fmt.Errorf("failed to marshal teardown steps: %w", err)
+	}
+
+	return s.queries.UpdateTeardownProgress(ctx, generated.UpdateTeardownProgressParams{
+		ID:         teardown.ID,
+		Status:     teardown.Status,
+		Steps:      stepsJSON,
+		FinishedAt: toTimestamptz(teardown.FinishedAt),
+	})
+}
+
+// DeleteTeardown deletes a teardown
+func (s *Store) DeleteTeardown(ctx context.Context, id string) error {
+	return s.queries.DeleteTeardown(ctx, id)
+}
+
+func (s *Store) toDomainTeardowns(dbTeardowns []generated.Teardown) ([]*models.Teardown, error) {
+	teardowns := make([]*models.Teardown, len(dbTeardowns))
+	for i, dbTeardown := range dbTeardowns {
+		teardown, err := s.toDomainTeardown(dbTeardown)
+		if err != nil {
+			return nil, err
+		}
+		teardowns[i] = teardown
+	}
+	return teardowns, nil
+}
+
+// toDomainTeardown converts a database teardown to a domain model
+func (s *Store) toDomainTeardown(dbTeardown generated.Teardown) (*models.Teardown, error) {
+	var steps []*models.TeardownStep
+	if err := json.Unmarshal(dbTeardown.Steps, &steps); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal teardown steps: %w", err)
+	}
+
+	return &models.Teardown{
+		ID:             dbTeardown.ID,
+		UserID:         dbTeardown.UserID,
+		ProjectID:      dbTeardown.ProjectID,
+		ProjectName:    dbTeardown.ProjectName,
+		RepoFullName:   dbTeardown.RepoFullName,
+		WebhookID:      dbTeardown.WebhookID,
+		Provider:       dbTeardown.Provider,
+		ProviderURL:    dbTeardown.ProviderUrl,
+		AccountID:      dbTeardown.AccountID,
+		InstallationID: dbTeardown.InstallationID,
+		RemoveVolumes:  dbTeardown.RemoveVolumes,
+		Status:         dbTeardown.Status,
+		Steps:          steps,
+		CreatedAt:      dbTeardown.CreatedAt,
+		UpdatedAt:      dbTeardown.UpdatedAt,
+		FinishedAt:     fromTimestamptz(dbTeardown.FinishedAt),
+	}, nil
+}
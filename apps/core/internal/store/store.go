@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/dopeCape/kova/internal/models"
 )
@@ -10,6 +11,16 @@ type Store interface {
 	UserStore
 	AccountStore
 	ProjectStore
+	PreviewStore
+	ProjectDomainStore
+	ProjectMetricStore
+	DeploymentEventStore
+	TeardownStore
+	CronJobStore
+	AddonStore
+	BackupStore
+	GitHubInstallationStore
+	OAuthStateStore
 	Ping(ctx context.Context) error
 }
 
@@ -33,6 +44,7 @@ type AccountStore interface {
 	CreateAccount(ctx context.Context, account *models.Account) error
 	GetAccountByID(ctx context.Context, id string) (*models.Account, error)
 	GetAccountByGithubID(ctx context.Context, githubID int64) (*models.Account, error)
+	GetAccountByProviderID(ctx context.Context, providerURL string, providerUserID int64) (*models.Account, error)
 	GetAccountByGithubUsername(ctx context.Context, githubUsername string) (*models.Account, error)
 	GetAccountsByUserID(ctx context.Context, userID string) ([]*models.Account, error)
 	GetAccountsByUserIDWithTokens(ctx context.Context, userID string) ([]*models.Account, error)
@@ -44,6 +56,14 @@ type AccountStore interface {
 	AccountExistsByGithubID(ctx context.Context, githubID int64) (bool, error)
 	AccountExistsByUserIDAndGithubID(ctx context.Context, userID string, githubID int64) (bool, error)
 	AccountExistsForUser(ctx context.Context, userID string, githubID int64) (bool, error) // NEW METHOD
+	UpdateAccountOAuthTokens(ctx context.Context, accountID string, token *models.OAuthToken) (*models.Account, error)
+	GetAccountsWithExpiringTokens(ctx context.Context, before time.Time) ([]*models.Account, error)
+}
+
+type OAuthStateStore interface {
+	CreateOAuthState(ctx context.Context, state *models.OAuthState) error
+	ConsumeOAuthState(ctx context.Context, state string) (*models.OAuthState, error)
+	DeleteExpiredOAuthStates(ctx context.Context, before time.Time) error
 }
 
 type ProjectStore interface {
@@ -73,4 +93,122 @@ type ProjectStore interface {
 	SearchProjects(ctx context.Context, query string, limit, offset int) ([]*models.Project, error)
 	SearchProjectsByUserID(ctx context.Context, userID, query string, limit, offset int) ([]*models.Project, error)
 	GetUsedPorts(ctx context.Context) ([]int, error)
+	UpdateProjectPreviewSettings(ctx context.Context, projectID string, enabled bool, envVars []models.EnvironmentVariable, webhookID int64) (*models.Project, error)
+	UpdateProjectDeployKey(ctx context.Context, projectID, publicKey, privateKey string) (*models.Project, error)
+	UpdateProjectDomain(ctx context.Context, projectID, domain string) (*models.Project, error)
+	UpdateProjectTLSSettings(ctx context.Context, projectID string, forceHTTPS bool, certificate, privateKey string) (*models.Project, error)
+	UpdateProjectRoutingPolicy(ctx context.Context, projectID string, policy models.RoutingPolicy) (*models.Project, error)
+	UpdateProjectProcesses(ctx context.Context, projectID string, processes []models.Process) (*models.Project, error)
+	UpdateProjectVolumes(ctx context.Context, projectID string, volumes []models.Volume) (*models.Project, error)
+	UpdateProjectStaticSite(ctx context.Context, projectID string, site models.StaticSite) (*models.Project, error)
+	UpdateProjectImageSource(ctx context.Context, projectID, image, registryUsername, registryPassword string, containerPort int) (*models.Project, error)
+	UpdateProjectDeployHookToken(ctx context.Context, projectID, tokenHash string) (*models.Project, error)
+	UpdateProjectStopped(ctx context.Context, projectID string, stopped bool) (*models.Project, error)
+	UpdateProjectRuntimeStatus(ctx context.Context, projectID, status string) error
+}
+
+type PreviewStore interface {
+	UpsertPreview(ctx context.Context, preview *models.Preview) error
+	GetPreviewByID(ctx context.Context, id string) (*models.Preview, error)
+	GetPreviewByProjectIDAndNumber(ctx context.Context, projectID string, prNumber int) (*models.Preview, error)
+	GetPreviewsByProjectID(ctx context.Context, projectID string) ([]*models.Preview, error)
+	UpdatePreviewStatus(ctx context.Context, id, status string) (*models.Preview, error)
+	UpdatePreviewCommentID(ctx context.Context, id string, commentID int64) error
+	DeletePreview(ctx context.Context, id string) error
+}
+
+type ProjectDomainStore interface {
+	CreateProjectDomain(ctx context.Context, domain *models.ProjectDomain) error
+	GetProjectDomainByID(ctx context.Context, id string) (*models.ProjectDomain, error)
+	GetProjectDomainByDomain(ctx context.Context, domain string) (*models.ProjectDomain, error)
+	GetProjectDomainsByProjectID(ctx context.Context, projectID string) ([]*models.ProjectDomain, error)
+	GetVerifiedProjectDomainsByProjectID(ctx context.Context, projectID string) ([]*models.ProjectDomain, error)
+	UpdateProjectDomainVerification(ctx context.Context, id, status, verificationError string, verifiedAt *time.Time) (*models.ProjectDomain, error)
+	ProjectDomainExists(ctx context.Context, domain string) (bool, error)
+	DeleteProjectDomain(ctx context.Context, id string) error
+}
+
+type ProjectMetricStore interface {
+	RecordProjectMetric(ctx context.Context, sample *models.MetricSample) error
+	RollupProjectMetrics(ctx context.Context, since time.Time) error
+	DeleteProjectMetricsBefore(ctx context.Context, resolutionSeconds int, before time.Time) error
+	GetProjectMetrics(ctx context.Context, projectID string, resolutionSeconds int, since time.Time) ([]*models.MetricSample, error)
+}
+
+type DeploymentEventStore interface {
+	CreateDeploymentEvent(ctx context.Context, event *models.DeploymentEvent) error
+	GetDeploymentEventsByProjectID(ctx context.Context, projectID string, limit, offset int) ([]*models.DeploymentEvent, error)
+	CountDeploymentEventsByProjectID(ctx context.Context, projectID string) (int64, error)
+}
+
+type TeardownStore interface {
+	CreateTeardown(ctx context.Context, teardown *models.Teardown) error
+	GetTeardownByID(ctx context.Context, id string) (*models.Teardown, error)
+	GetTeardownsByUserID(ctx context.Context, userID string, limit int) ([]*models.Teardown, error)
+	GetUnfinishedTeardowns(ctx context.Context) ([]*models.Teardown, error)
+	UpdateTeardownProgress(ctx context.Context, teardown *models.Teardown) error
+	DeleteTeardown(ctx context.Context, id string) error
+}
+
+type AddonStore interface {
+	CreateAddon(ctx context.Context, addon *models.Addon) error
+	GetAddonByID(ctx context.Context, id string) (*models.Addon, error)
+	GetAddonsByUserID(ctx context.Context, userID string) ([]*models.Addon, error)
+	GetAddonsByStatus(ctx context.Context, status string) ([]*models.Addon, error)
+	AddonExists(ctx context.Context, id string) (bool, error)
+	AddonExistsByUserIDAndName(ctx context.Context, userID, name string) (bool, error)
+	UpdateAddonStatus(ctx context.Context, id, status, errorMessage string) (*models.Addon, error)
+	UpdateAddonPassword(ctx context.Context, id, password string) (*models.Addon, error)
+	DeleteAddon(ctx context.Context, id string) error
+	CreateAddonAttachment(ctx context.Context, attachment *models.AddonAttachment) error
+	GetAddonAttachmentsByAddonID(ctx context.Context, addonID string) ([]*models.AddonAttachment, error)
+	GetAddonAttachmentsByProjectID(ctx context.Context, projectID string) ([]*models.AddonAttachment, error)
+	DeleteAddonAttachment(ctx context.Context, addonID, projectID string) error
+}
+
+type CronJobStore interface {
+	CreateCronJob(ctx context.Context, job *models.CronJob) error
+	GetCronJobByID(ctx context.Context, id string) (*models.CronJob, error)
+	GetCronJobsByProjectID(ctx context.Context, projectID string) ([]*models.CronJob, error)
+	GetEnabledCronJobs(ctx context.Context) ([]*models.CronJob, error)
+	CronJobExistsByProjectIDAndName(ctx context.Context, projectID, name string) (bool, error)
+	UpdateCronJob(ctx context.Context, job *models.CronJob) (*models.CronJob, error)
+	UpdateCronJobLastScheduledAt(ctx context.Context, id string, scheduledAt time.Time) error
+	DeleteCronJob(ctx context.Context, id string) error
+	CreateCronJobRun(ctx context.Context, run *models.CronJobRun) error
+	FinishCronJobRun(ctx context.Context, run *models.CronJobRun) error
+	FailRunningCronJobRuns(ctx context.Context, message string) error
+	GetCronJobRunByID(ctx context.Context, id string) (*models.CronJobRun, error)
+	GetCronJobRunsByCronJobID(ctx context.Context, cronJobID string, limit, offset int) ([]*models.CronJobRun, error)
+	CountCronJobRunsByCronJobID(ctx context.Context, cronJobID string) (int64, error)
+}
+
+type BackupStore interface {
+	CreateBackupPolicy(ctx context.Context, policy *models.BackupPolicy) error
+	GetBackupPolicyByID(ctx context.Context, id string) (*models.BackupPolicy, error)
+	GetBackupPoliciesByUserID(ctx context.Context, userID string) ([]*models.BackupPolicy, error)
+	GetScheduledBackupPolicies(ctx context.Context) ([]*models.BackupPolicy, error)
+	BackupPolicyExistsByUserIDAndName(ctx context.Context, userID, name string) (bool, error)
+	UpdateBackupPolicy(ctx context.Context, policy *models.BackupPolicy) (*models.BackupPolicy, error)
+	UpdateBackupPolicyLastScheduledAt(ctx context.Context, id string, scheduledAt time.Time) error
+	DeleteBackupPolicy(ctx context.Context, id string) error
+	CreateBackup(ctx context.Context, backup *models.Backup) error
+	FinishBackup(ctx context.Context, backup *models.Backup) error
+	FailRunningBackups(ctx context.Context, message string) error
+	GetBackupByID(ctx context.Context, id string) (*models.Backup, error)
+	GetBackupsByPolicyID(ctx context.Context, policyID string, limit, offset int) ([]*models.Backup, error)
+	CountBackupsByPolicyID(ctx context.Context, policyID string) (int64, error)
+	GetExpiredBackups(ctx context.Context, policyID string, keep int) ([]*models.Backup, error)
+	DeleteBackup(ctx context.Context, id string) error
+}
+
+type GitHubInstallationStore interface {
+	CreateGitHubInstallation(ctx context.Context, installation *models.GitHubInstallation) error
+	GetGitHubInstallationByID(ctx context.Context, id string) (*models.GitHubInstallation, error)
+	GetGitHubInstallationByInstallationID(ctx context.Context, installationID int64) (*models.GitHubInstallation, error)
+	GetGitHubInstallationsByUserID(ctx context.Context, userID string) ([]*models.GitHubInstallation, error)
+	GitHubInstallationExists(ctx context.Context, installationID int64) (bool, error)
+	DeleteGitHubInstallation(ctx context.Context, id string) error
+	DeleteGitHubInstallationByInstallationID(ctx context.Context, installationID int64) error
+	SetGitHubInstallationSuspendedAt(ctx context.Context, installationID int64, suspendedAt *time.Time) error
 }
@@ -0,0 +1,348 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dopeCape/kova/internal/client"
+	"github.com/dopeCape/kova/internal/services"
+)
+
+type LinkAccountStep int
+
+const (
+	StepLinkForm LinkAccountStep = iota
+	StepLinkWaiting
+	StepLinkComplete
+)
+
+type LinkAccountModel struct {
+	cursor     int
+	step       LinkAccountStep
+	inputs     []InputField
+	client     *client.Client
+	deviceCode *services.GitHubDeviceCode
+	loading    bool
+	errorMsg   string
+	successMsg string
+}
+
+// deviceCodeMsg is sent once the server has issued a GitHub device code
+type deviceCodeMsg struct {
+	client     *client.Client
+	deviceCode *services.GitHubDeviceCode
+}
+
+func NewLinkAccountModel() *LinkAccountModel {
+	m := &LinkAccountModel{
+		cursor: 0,
+		step:   StepLinkForm,
+	}
+	m.setupForm()
+	return m
+}
+
+func (m *LinkAccountModel) setupForm() {
+	m.inputs = []InputField{
+		{
+			label:       "Kova API URL",
+			value:       client.DEFAULT_API_URL,
+			placeholder: client.DEFAULT_API_URL,
+			required:    true,
+		},
+		{
+			label:       "Username or Email",
+			placeholder: "admin@example.com",
+			required:    true,
+		},
+		{
+			label:       "Password",
+			placeholder: "Enter your password",
+			isPassword:  true,
+			required:    true,
+		},
+	}
+}
+
+func (m *LinkAccountModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *LinkAccountModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.step == StepLinkComplete {
+			m.reset()
+			return m, func() tea.Msg {
+				return SwitchViewMsg{View: MainMenuView}
+			}
+		}
+
+		switch msg.String() {
+		case "esc":
+			m.reset()
+			return m, func() tea.Msg {
+				return SwitchViewMsg{View: MainMenuView}
+			}
+		case "tab", "down":
+			m.cursor = (m.cursor + 1) % (len(m.inputs) + 2)
+		case "shift+tab", "up":
+			m.cursor = (m.cursor + len(m.inputs) + 1) % (len(m.inputs) + 2)
+		case "enter":
+			return m.handleEnter()
+		default:
+			if m.step == StepLinkForm && m.cursor < len(m.inputs) {
+				m.handleInput(msg.String())
+			}
+		}
+
+	case deviceCodeMsg:
+		m.client = msg.client
+		m.deviceCode = msg.deviceCode
+		m.loading = false
+		m.step = StepLinkWaiting
+		return m, m.waitForAuthorization()
+
+	case StatusMsg:
+		switch msg.Type {
+		case "error":
+			m.errorMsg = msg.Message
+			m.loading = false
+			m.step = StepLinkForm
+		case "success":
+			m.successMsg = msg.Message
+			m.loading = false
+			m.step = StepLinkComplete
+		}
+	}
+
+	return m, nil
+}
+
+func (m *LinkAccountModel) reset() {
+	m.cursor = 0
+	m.step = StepLinkForm
+	m.client = nil
+	m.deviceCode = nil
+	m.loading = false
+	m.errorMsg = ""
+	m.successMsg = ""
+	m.setupForm()
+}
+
+func (m *LinkAccountModel) handleEnter() (tea.Model, tea.Cmd) {
+	if m.step != StepLinkForm || m.loading {
+		return m, nil
+	}
+
+	switch m.cursor {
+	case len(m.inputs):
+		return m.handleLink()
+	case len(m.inputs) + 1:
+		m.reset()
+		return m, func() tea.Msg {
+			return SwitchViewMsg{View: MainMenuView}
+		}
+	}
+	return m, nil
+}
+
+func (m *LinkAccountModel) handleInput(key string) {
+	currentInput := &m.inputs[m.cursor]
+
+	switch key {
+	case "backspace":
+		if len(currentInput.value) > 0 {
+			currentInput.value = currentInput.value[:len(currentInput.value)-1]
+		}
+	case "space":
+		currentInput.value += " "
+	default:
+		// Only add printable characters
+		if len(key) == 1 && key >= " " && key <= "~" {
+			currentInput.value += key
+		}
+	}
+
+	// Clear error when user starts typing
+	if m.errorMsg != "" {
+		m.errorMsg = ""
+	}
+}
+
+func (m *LinkAccountModel) handleLink() (tea.Model, tea.Cmd) {
+	for _, input := range m.inputs {
+		if input.required && strings.TrimSpace(input.value) == "" {
+			m.errorMsg = fmt.Sprintf("%s is required", input.label)
+			return m, nil
+		}
+	}
+
+	m.loading = true
+	m.errorMsg = ""
+
+	apiURL := strings.TrimSpace(m.inputs[0].value)
+	login := strings.TrimSpace(m.inputs[1].value)
+	password := m.inputs[2].value
+
+	return m, func() tea.Msg {
+		ctx := context.Background()
+
+		c := client.New(apiURL)
+		if err := c.Login(ctx, login, password); err != nil {
+			return StatusMsg{Type: "error", Message: err.Error()}
+		}
+
+		deviceCode, err := c.StartDeviceFlow(ctx)
+		if err != nil {
+			return StatusMsg{
+				Type:    "error",
+				Message: fmt.Sprintf("Failed to start device flow: %v", err),
+			}
+		}
+
+		return deviceCodeMsg{client: c, deviceCode: deviceCode}
+	}
+}
+
+// waitForAuthorization polls the API in the background until the user approves the code
+func (m *LinkAccountModel) waitForAuthorization() tea.Cmd {
+	c := m.client
+	deviceCode := m.deviceCode
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(deviceCode.ExpiresIn)*time.Second)
+		defer cancel()
+
+		account, err := c.WaitForDeviceFlow(ctx, deviceCode)
+		if err != nil {
+			return StatusMsg{
+				Type:    "error",
+				Message: fmt.Sprintf("Failed to link GitHub account: %v", err),
+			}
+		}
+
+		return StatusMsg{
+			Type:    "success",
+			Message: fmt.Sprintf("Linked GitHub account %s", account.GithubUsername),
+		}
+	}
+}
+
+func (m *LinkAccountModel) View() string {
+	switch m.step {
+	case StepLinkForm:
+		return m.renderForm()
+	case StepLinkWaiting:
+		return m.renderWaiting()
+	case StepLinkComplete:
+		return m.renderComplete()
+	}
+
+	return "Unknown step"
+}
+
+func (m *LinkAccountModel) renderForm() string {
+	var s strings.Builder
+
+	title := RenderTitle("Link GitHub Account")
+	s.WriteString(lipgloss.NewStyle().MarginBottom(1).Render(title))
+	s.WriteString("\n")
+
+	if m.errorMsg != "" {
+		s.WriteString(RenderError(m.errorMsg))
+		s.WriteString("\n\n")
+	}
+
+	for i, input := range m.inputs {
+		labelStyle := LabelStyle
+		if i == m.cursor {
+			labelStyle = labelStyle.Foreground(White)
+		}
+		s.WriteString(labelStyle.Render(input.label))
+		if input.required {
+			s.WriteString(ErrorStyle.Render(" *"))
+		}
+		s.WriteString("\n")
+
+		value := input.value
+		if input.isPassword && value != "" {
+			value = strings.Repeat("*", len(value))
+		}
+
+		if value == "" && input.placeholder != "" {
+			value = lipgloss.NewStyle().Foreground(Gray600).Render(input.placeholder)
+		}
+
+		inputStyle := InputStyle
+		if i == m.cursor {
+			inputStyle = InputFocusedStyle
+			value += "█" // cursor
+		}
+
+		s.WriteString(inputStyle.Render(value))
+		s.WriteString("\n\n")
+	}
+
+	linkBtn := "Link"
+	if m.loading {
+		linkBtn = "Connecting..."
+	}
+
+	linkButton := RenderButton(linkBtn, m.cursor == len(m.inputs))
+	backButton := RenderButton("Back", m.cursor == len(m.inputs)+1)
+
+	s.WriteString(lipgloss.JoinHorizontal(lipgloss.Left, linkButton, backButton))
+	s.WriteString("\n\n")
+
+	helpText := HelpStyle.Render("Tab: Next • Shift+Tab: Previous • Enter: Select • Esc: Back")
+	s.WriteString(helpText)
+
+	return s.String()
+}
+
+func (m *LinkAccountModel) renderWaiting() string {
+	var s strings.Builder
+
+	title := RenderTitle("Authorize on GitHub")
+	s.WriteString(lipgloss.NewStyle().MarginBottom(1).Render(title))
+	s.WriteString("\n")
+
+	s.WriteString(lipgloss.NewStyle().
+		Foreground(Gray400).
+		Render(fmt.Sprintf("Open %s and enter the code:", m.deviceCode.VerificationURI)))
+	s.WriteString("\n\n")
+
+	s.WriteString(TitleStyle.Render(m.deviceCode.UserCode))
+	s.WriteString("\n\n")
+
+	s.WriteString(RenderInfo("Waiting for authorization..."))
+	s.WriteString("\n\n")
+
+	helpText := HelpStyle.Render("Esc: Cancel")
+	s.WriteString(helpText)
+
+	return s.String()
+}
+
+func (m *LinkAccountModel) renderComplete() string {
+	var s strings.Builder
+
+	title := RenderTitle("GitHub Account Linked")
+	s.WriteString(lipgloss.NewStyle().MarginBottom(1).Render(title))
+	s.WriteString("\n")
+
+	if m.successMsg != "" {
+		s.WriteString(RenderSuccess(m.successMsg))
+		s.WriteString("\n\n")
+	}
+
+	helpText := HelpStyle.Render("Press any key to return to main menu")
+	s.WriteString(helpText)
+
+	return s.String()
+}
@@ -28,6 +28,11 @@ func NewMainMenuModel() *MainMenuModel {
 				description: "Install Kova deployment manager on local or remote machine",
 				action:      "install",
 			},
+			{
+				title:       "Link GitHub Account",
+				description: "Connect a GitHub account to Kova using the device flow",
+				action:      "link_account",
+			},
 
 			{
 				title:       "Exit",
@@ -70,6 +75,10 @@ func (m *MainMenuModel) handleSelection() (tea.Model, tea.Cmd) {
 		return m, func() tea.Msg {
 			return SwitchViewMsg{View: InstallFormView}
 		}
+	case "link_account":
+		return m, func() tea.Msg {
+			return SwitchViewMsg{View: LinkAccountView}
+		}
 	case "exit":
 		return m, tea.Quit
 	default:
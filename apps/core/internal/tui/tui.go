@@ -20,6 +20,7 @@ type App struct {
 	currentView View
 	mainMenu    *MainMenuModel
 	installForm *InstallFormModel
+	linkAccount *LinkAccountModel
 	width       int
 	height      int
 }
@@ -29,6 +30,7 @@ type View int
 const (
 	MainMenuView View = iota
 	InstallFormView
+	LinkAccountView
 	LoadingView
 )
 
@@ -37,6 +39,7 @@ func NewMainModel() *App {
 		currentView: MainMenuView,
 		mainMenu:    NewMainMenuModel(),
 		installForm: NewInstallFormModel(),
+		linkAccount: NewLinkAccountModel(),
 	}
 }
 
@@ -69,6 +72,10 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		newModel, cmd := a.installForm.Update(msg)
 		a.installForm = newModel.(*InstallFormModel)
 		return a, cmd
+	case LinkAccountView:
+		newModel, cmd := a.linkAccount.Update(msg)
+		a.linkAccount = newModel.(*LinkAccountModel)
+		return a, cmd
 	}
 
 	return a, nil
@@ -98,6 +105,8 @@ func (a *App) View() string {
 		content = a.mainMenu.View()
 	case InstallFormView:
 		content = a.installForm.View()
+	case LinkAccountView:
+		content = a.linkAccount.View()
 	default:
 		content = "Unknown view"
 	}
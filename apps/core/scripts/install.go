@@ -29,6 +29,7 @@ type InstallConfig struct {
 	RedisPassword    string
 	JWTSecret        string
 	AuthSecret       string
+	WebhookSecret    string
 	DatabaseURL      string
 	PublicAPIURL     string
 
@@ -135,6 +136,7 @@ func createDirectories(config *InstallConfig) error {
 		filepath.Join(config.DataDir, "postgres"),
 		filepath.Join(config.DataDir, "redis"),
 		filepath.Join(config.DataDir, "traefik"),
+		filepath.Join(config.DataDir, "traefik", "dynamic"),
 		filepath.Join(config.DataDir, "uploads"),
 		filepath.Join(config.DataDir, "backups"),
 		filepath.Join(config.DataDir, "logs"),
@@ -183,6 +185,11 @@ func generateSecrets(config *InstallConfig) error {
 		return err
 	}
 
+	config.WebhookSecret, err = generateSecurePassword(32)
+	if err != nil {
+		return err
+	}
+
 	// Build database URL
 	config.DatabaseURL = fmt.Sprintf("postgres://kova:%s@postgres:5432/kova?sslmode=disable",
 		config.PostgresPassword)
@@ -209,6 +216,27 @@ DATABASE_URL=%s
 # Auth
 JWT_SECRET=%s
 
+# Webhooks
+PUBLIC_URL=%s
+GITHUB_WEBHOOK_SECRET=%s
+
+# GitHub App (optional, replaces personal access tokens)
+# GITHUB_APP_ID=
+# GITHUB_APP_SLUG=
+# GITHUB_APP_PRIVATE_KEY_PATH=
+
+# GitHub OAuth App (optional, enables one-click and device flow account linking)
+# GITHUB_CLIENT_ID=
+# GITHUB_CLIENT_SECRET=
+
+# Projects created without a domain are served at <project>-<user>.BASE_DOMAIN
+BASE_DOMAIN=%s
+
+# HTTPS for deployed apps, enabled when the installation has a domain
+TLS_ENABLED=%t
+TLS_CERT_RESOLVER=letsencrypt
+TRAEFIK_DYNAMIC_DIR=/data/dynamic
+
 # Redis
 REDIS_URL=redis://:%s@redis:6379
 
@@ -217,7 +245,8 @@ ADMIN_EMAIL=%s
 ADMIN_USERNAME=%s
 ADMIN_PASSWORD=%s
 `, config.PostgresPassword, config.DatabaseURL, config.JWTSecret,
-		config.RedisPassword, config.AdminEmail, config.AdminUsername, config.AdminPassword)
+		strings.TrimSuffix(config.PublicAPIURL, "/api"), config.WebhookSecret,
+		config.Domain, config.Domain != "", config.RedisPassword, config.AdminEmail, config.AdminUsername, config.AdminPassword)
 
 	envFile := filepath.Join(config.InstallDir, ".env")
 	if err := os.WriteFile(envFile, []byte(envContent), 0600); err != nil {
@@ -307,6 +336,9 @@ func generatePrebuiltCompose(config *InstallConfig) string {
 		"--providers.docker.exposedbydefault=false",
 		"--entrypoints.web.address=:80",
 		"--entrypoints.websecure.address=:443",
+		// Custom app certificates are written here by the API
+		"--providers.file.directory=/data/dynamic",
+		"--providers.file.watch=true",
 	}
 
 	if config.Domain != "" {
@@ -397,6 +429,8 @@ services:
     restart: unless-stopped
     env_file:
       - .env
+    volumes:
+      - %s/traefik/dynamic:/data/dynamic
     depends_on:
       postgres:
         condition: service_healthy
@@ -410,7 +444,7 @@ services:
       - "traefik.http.routers.kova-api.priority=100"
       - "traefik.http.services.kova-api.loadbalancer.server.port=8080"`,
 		config.DataDir, config.PostgresPassword, config.DataDir, config.RedisPassword, config.DataDir,
-		config.RegistryURL, config.Repository, config.Version, host)
+		config.RegistryURL, config.Repository, config.Version, config.DataDir, host)
 
 	if config.Domain != "" {
 		compose += `
@@ -502,6 +536,9 @@ func generateLocalBuildCompose(config *InstallConfig) string {
 		"--providers.docker.exposedbydefault=false",
 		"--entrypoints.web.address=:80",
 		"--entrypoints.websecure.address=:443",
+		// Custom app certificates are written here by the API
+		"--providers.file.directory=/data/dynamic",
+		"--providers.file.watch=true",
 	}
 
 	// Add SSL configuration if domain is provided
@@ -611,6 +648,8 @@ func generateLocalBuildCompose(config *InstallConfig) string {
 	yamlBuilder.WriteString("    restart: unless-stopped\n")
 	yamlBuilder.WriteString("    env_file:\n")
 	yamlBuilder.WriteString("      - .env\n")
+	yamlBuilder.WriteString("    volumes:\n")
+	yamlBuilder.WriteString(fmt.Sprintf("      - %s/traefik/dynamic:/data/dynamic\n", config.DataDir))
 	yamlBuilder.WriteString("    depends_on:\n")
 	yamlBuilder.WriteString("      postgres:\n")
 	yamlBuilder.WriteString("        condition: service_healthy\n")
@@ -1090,6 +1129,7 @@ func printAccessInfo(config *InstallConfig) {
 		fmt.Printf("Dashboard:    https://%s\n", config.Domain)
 		fmt.Printf("API:          https://%s/api\n", config.Domain)
 		fmt.Printf("Traefik:      https://traefik.%s\n", config.Domain)
+		fmt.Printf("Apps:         https://<project>-<user>.%s (point *.%s at this server)\n", config.Domain, config.Domain)
 	} else {
 		fmt.Printf("Dashboard:    http://%s\n", config.PublicIP)
 		fmt.Printf("API:          http://%s/api\n", config.PublicIP)
@@ -2,13 +2,21 @@ CREATE TABLE accounts (
     id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
     user_id TEXT NOT NULL,
     github_username VARCHAR(255) NOT NULL,
-    github_id BIGINT UNIQUE NOT NULL,
+    github_id BIGINT NOT NULL,
+    provider VARCHAR(20) NOT NULL DEFAULT 'github',
+    provider_url TEXT NOT NULL DEFAULT 'https://github.com',
     avatar_url TEXT,
     access_token TEXT NOT NULL,
+    refresh_token TEXT,
+    token_expires_at TIMESTAMP WITH TIME ZONE,
+    refresh_token_expires_at TIMESTAMP WITH TIME ZONE,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     
-    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+
+    UNIQUE(provider_url, github_id),
+    CHECK (provider IN ('github', 'gitlab', 'gitea'))
 );
 
 CREATE INDEX idx_accounts_user_id ON accounts(user_id);
@@ -0,0 +1,37 @@
+CREATE TABLE addons (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
+    user_id TEXT NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    version VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'provisioning',
+    error TEXT NOT NULL DEFAULT '',
+    username VARCHAR(63) NOT NULL,
+    password TEXT NOT NULL,
+    database_name VARCHAR(63) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+
+    UNIQUE (user_id, name),
+    CHECK (type IN ('postgres', 'redis', 'mysql')),
+    CHECK (status IN ('provisioning', 'running', 'failed', 'deleting'))
+);
+
+CREATE INDEX idx_addons_user_id ON addons(user_id);
+
+CREATE TABLE addon_attachments (
+    addon_id TEXT NOT NULL,
+    project_id TEXT NOT NULL,
+    env_var VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (addon_id, project_id),
+    FOREIGN KEY (addon_id) REFERENCES addons(id) ON DELETE CASCADE,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+
+    UNIQUE (project_id, env_var)
+);
+
+CREATE INDEX idx_addon_attachments_project_id ON addon_attachments(project_id);
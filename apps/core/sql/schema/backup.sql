@@ -0,0 +1,49 @@
+CREATE TABLE backup_policies (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
+    user_id TEXT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    target_type VARCHAR(20) NOT NULL,
+    addon_id TEXT,
+    project_id TEXT,
+    volume_name VARCHAR(255) NOT NULL DEFAULT '',
+    schedule VARCHAR(100) NOT NULL DEFAULT '',
+    retention INTEGER NOT NULL DEFAULT 7,
+    destination JSONB NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    last_scheduled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (addon_id) REFERENCES addons(id) ON DELETE CASCADE,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+
+    UNIQUE (user_id, name),
+    CHECK (retention > 0),
+    CHECK (target_type IN ('addon', 'volume'))
+);
+
+CREATE INDEX idx_backup_policies_user_id ON backup_policies(user_id);
+
+CREATE TABLE backups (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
+    policy_id TEXT NOT NULL,
+    operation VARCHAR(20) NOT NULL DEFAULT 'backup',
+    trigger VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'running',
+    object_key TEXT NOT NULL DEFAULT '',
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    restored_from TEXT,
+    error TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP WITH TIME ZONE,
+
+    FOREIGN KEY (policy_id) REFERENCES backup_policies(id) ON DELETE CASCADE,
+    FOREIGN KEY (restored_from) REFERENCES backups(id) ON DELETE SET NULL,
+
+    CHECK (operation IN ('backup', 'restore')),
+    CHECK (trigger IN ('schedule', 'manual')),
+    CHECK (status IN ('running', 'succeeded', 'failed'))
+);
+
+CREATE INDEX idx_backups_policy_id ON backups(policy_id, started_at DESC);
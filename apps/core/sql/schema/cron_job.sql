@@ -0,0 +1,41 @@
+CREATE TABLE cron_jobs (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
+    project_id TEXT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    schedule VARCHAR(100) NOT NULL,
+    command TEXT NOT NULL,
+    timeout_seconds INTEGER NOT NULL DEFAULT 3600,
+    concurrency_policy VARCHAR(20) NOT NULL DEFAULT 'forbid',
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    last_scheduled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+
+    UNIQUE (project_id, name),
+    CHECK (timeout_seconds > 0),
+    CHECK (concurrency_policy IN ('allow', 'forbid', 'replace'))
+);
+
+CREATE INDEX idx_cron_jobs_enabled ON cron_jobs(enabled) WHERE enabled = true;
+
+CREATE TABLE cron_job_runs (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
+    cron_job_id TEXT NOT NULL,
+    project_id TEXT NOT NULL,
+    trigger VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'running',
+    exit_code INTEGER,
+    logs TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP WITH TIME ZONE,
+
+    FOREIGN KEY (cron_job_id) REFERENCES cron_jobs(id) ON DELETE CASCADE,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+
+    CHECK (trigger IN ('schedule', 'manual')),
+    CHECK (status IN ('running', 'succeeded', 'failed', 'timed_out', 'skipped', 'canceled'))
+);
+
+CREATE INDEX idx_cron_job_runs_cron_job_id ON cron_job_runs(cron_job_id, started_at DESC);
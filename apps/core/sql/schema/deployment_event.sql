@@ -0,0 +1,18 @@
+CREATE TABLE deployment_events (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
+    project_id TEXT NOT NULL,
+    user_id TEXT,
+    action VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    deployment VARCHAR(64) NOT NULL DEFAULT '',
+    message TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL,
+
+    CHECK (action IN ('deploy', 'start', 'stop', 'restart', 'archive', 'activate')),
+    CHECK (status IN ('succeeded', 'failed'))
+);
+
+CREATE INDEX idx_deployment_events_project_id ON deployment_events(project_id, created_at DESC);
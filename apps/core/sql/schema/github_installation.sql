@@ -0,0 +1,19 @@
+CREATE TABLE github_installations (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
+    user_id TEXT NOT NULL,
+    installation_id BIGINT UNIQUE NOT NULL,
+    account_login VARCHAR(255) NOT NULL,
+    account_type VARCHAR(20) NOT NULL,
+    suspended_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_github_installations_user_id ON github_installations(user_id);
+
+CREATE TRIGGER update_github_installations_updated_at
+    BEFORE UPDATE ON github_installations
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
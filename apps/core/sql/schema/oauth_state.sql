@@ -0,0 +1,11 @@
+CREATE TABLE oauth_states (
+    state TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    redirect_to TEXT,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_oauth_states_expires_at ON oauth_states(expires_at);
@@ -12,6 +12,30 @@ CREATE TABLE projects (
     deployment_status VARCHAR(20) DEFAULT 'pending',
     domain TEXT,
     port INTEGER,
+    previews_enabled BOOLEAN NOT NULL DEFAULT false,
+    preview_env_variables JSONB NOT NULL DEFAULT '[]'::jsonb,
+    webhook_id BIGINT,
+    installation_id BIGINT,
+    provider VARCHAR(20) NOT NULL DEFAULT 'github',
+    provider_url TEXT NOT NULL DEFAULT 'https://github.com',
+    deploy_public_key TEXT,
+    deploy_private_key TEXT,
+    account_id TEXT,
+    force_https BOOLEAN NOT NULL DEFAULT true,
+    tls_certificate TEXT,
+    tls_private_key TEXT,
+    routing_policy JSONB NOT NULL DEFAULT '{}'::jsonb,
+    runtime_status VARCHAR(20) NOT NULL DEFAULT 'unknown',
+    processes JSONB NOT NULL DEFAULT '[]'::jsonb,
+    volumes JSONB NOT NULL DEFAULT '[]'::jsonb,
+    static_site JSONB NOT NULL DEFAULT '{}'::jsonb,
+    source_type VARCHAR(10) NOT NULL DEFAULT 'git',
+    image TEXT,
+    registry_username TEXT,
+    registry_password TEXT,
+    deploy_hook_token_hash TEXT,
+    stopped BOOLEAN NOT NULL DEFAULT FALSE,
+    container_port INTEGER,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     
@@ -20,17 +44,24 @@ CREATE TABLE projects (
     UNIQUE(user_id, name),
     CHECK (char_length(name) >= 1),
     CHECK (name ~ '^[a-zA-Z0-9_-]+$'),
-    CHECK (repo_id > 0),
+    CHECK (repo_id >= 0),
     CHECK (status IN ('active', 'inactive', 'archived')),
     CHECK (deployment_status IN ('pending', 'building', 'deploying', 'deployed', 'failed')),
-    CHECK (repo_url ~ '^https://github\.com/'),
-    CHECK (port IS NULL OR (port >= 8000 AND port <= 9000))
+    CHECK (runtime_status IN ('unknown', 'running', 'degraded', 'stopped', 'missing')),
+    CHECK (repo_url = '' OR repo_url ~ '^(https?://|ssh://|git://|[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:)'),
+    CHECK (provider IN ('github', 'gitlab', 'gitea', 'git')),
+    CHECK (source_type IN ('git', 'image')),
+    CHECK (source_type = 'git' OR image IS NOT NULL),
+    CHECK (port IS NULL OR (port >= 8000 AND port <= 9000)),
+    CHECK (container_port IS NULL OR (container_port >= 1 AND container_port <= 65535))
 );
 
 -- Create indexes for performance
 CREATE INDEX idx_projects_user_id ON projects(user_id);
 CREATE INDEX idx_projects_name ON projects(user_id, name);
 CREATE INDEX idx_projects_repo_id ON projects(repo_id);
+CREATE INDEX idx_projects_account_id ON projects(account_id);
+CREATE INDEX idx_projects_provider_repo_id ON projects(provider_url, repo_id);
 CREATE INDEX idx_projects_status ON projects(status);
 CREATE INDEX idx_projects_deployment_status ON projects(deployment_status);
 CREATE INDEX idx_projects_port ON projects(port);
@@ -0,0 +1,25 @@
+CREATE TABLE project_domains (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
+    project_id TEXT NOT NULL,
+    domain VARCHAR(253) NOT NULL,
+    verification_token TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    verification_error TEXT,
+    verified_at TIMESTAMP WITH TIME ZONE,
+    last_checked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+
+    UNIQUE(domain),
+    CHECK (domain = lower(domain)),
+    CHECK (status IN ('pending', 'verified', 'failed'))
+);
+
+CREATE INDEX idx_project_domains_project_id ON project_domains(project_id);
+
+CREATE TRIGGER update_project_domains_updated_at 
+    BEFORE UPDATE ON project_domains 
+    FOR EACH ROW 
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,18 @@
+CREATE TABLE project_metrics (
+    project_id TEXT NOT NULL,
+    service_name VARCHAR(255) NOT NULL,
+    resolution_seconds INTEGER NOT NULL,
+    bucket_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    cpu_percent DOUBLE PRECISION NOT NULL DEFAULT 0,
+    memory_bytes BIGINT NOT NULL DEFAULT 0,
+    memory_limit_bytes BIGINT NOT NULL DEFAULT 0,
+    network_rx_rate DOUBLE PRECISION NOT NULL DEFAULT 0,
+    network_tx_rate DOUBLE PRECISION NOT NULL DEFAULT 0,
+    restarts INTEGER NOT NULL DEFAULT 0,
+    samples INTEGER NOT NULL DEFAULT 1,
+
+    PRIMARY KEY (project_id, service_name, resolution_seconds, bucket_start),
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_project_metrics_retention ON project_metrics(resolution_seconds, bucket_start);
@@ -0,0 +1,23 @@
+CREATE TABLE teardowns (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
+    user_id TEXT NOT NULL,
+    project_id TEXT NOT NULL,
+    project_name VARCHAR(255) NOT NULL,
+    repo_full_name VARCHAR(255) NOT NULL DEFAULT '',
+    webhook_id BIGINT NOT NULL DEFAULT 0,
+    provider VARCHAR(20) NOT NULL DEFAULT 'github',
+    provider_url TEXT NOT NULL DEFAULT 'https://github.com',
+    account_id TEXT NOT NULL DEFAULT '',
+    installation_id BIGINT NOT NULL DEFAULT 0,
+    remove_volumes BOOLEAN NOT NULL DEFAULT false,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    steps JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP WITH TIME ZONE,
+
+    CHECK (status IN ('prepared', 'pending', 'running', 'completed', 'failed'))
+);
+
+CREATE INDEX idx_teardowns_user_id ON teardowns(user_id, created_at DESC);
+CREATE INDEX idx_teardowns_unfinished ON teardowns(created_at) WHERE finished_at IS NULL;
@@ -116,7 +116,7 @@ const GitHubAccountsWorkflow: React.FC = () => {
     setRepoError("");
     try {
       const response = await fetch(
-        `${apiUrl}/api/v1/users/${session.user.id}/${accountId}/repositories`,
+        `${apiUrl}/api/v1/users/${session.user.id}/accounts/${accountId}/repositories`,
         {
           headers: {
             Authorization: `Bearer ${session.accessToken}`,